@@ -13,11 +13,16 @@ import (
 
 	"mangahub/database"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 
 	// gormdb "mangahub/internal/db" // removed — use database.OpenGorm()
 	"mangahub/internal/config"
+	"mangahub/internal/ingestion"
+	"mangahub/internal/ingestion/anilist"
+	"mangahub/internal/ingestion/mangadex"
+	"mangahub/internal/lifecycle"
+	"mangahub/internal/microservices/http-api/dto"
 	h "mangahub/internal/microservices/http-api/handler"
 	mid "mangahub/internal/microservices/http-api/middleware"
 	"mangahub/internal/microservices/http-api/models"
@@ -37,7 +42,21 @@ func main() {
 				p = pi
 			}
 		}
-		cfg = &config.Config{HTTPPort: p}
+		cfg = &config.Config{HTTPPort: p, StartupWaitTimeout: lifecycle.DefaultStartupWaitTimeout}
+	}
+
+	// Shutdown runs in a fixed order: stop the HTTP server, cancel background
+	// jobs and wait for them, then close Redis and the DB pools
+	lc := lifecycle.New(context.Background())
+
+	// Open GORM DB (used by repository), waiting up to STARTUP_WAIT_TIMEOUT
+	// for Postgres to accept connections
+	gdb, err := database.OpenGormWait(cfg.StartupWaitTimeout)
+	if err != nil {
+		log.Fatalf("failed to open gorm DB: %v", err)
+	}
+	if sqlDB, err := gdb.DB(); err == nil {
+		lc.OnClose("gorm pool", sqlDB.Close)
 	}
 
 	// Try to initialize optional pgx pool (used by some packages). Non-fatal.
@@ -45,92 +64,212 @@ func main() {
 		log.Printf("warning: pgx connect failed (continuing): %v", err)
 	} else {
 		log.Println("pgx pool connected")
-		defer database.Close()
+		lc.OnClose("pgx pool", func() error {
+			database.Close()
+			return nil
+		})
 	}
 
-	// Open GORM DB (used by repository)
-	gdb, err := database.OpenGorm()
-	if err != nil {
-		log.Fatalf("failed to open gorm DB: %v", err)
+	// Apply versioned SQL migrations
+	if err := database.Migrate(gdb); err != nil {
+		log.Fatalf("database migration failed: %v", err)
 	}
 
-	// Auto-migrate models
-	if err := gdb.AutoMigrate(
-		&models.Manga{},
-		&models.Genre{},
-		&models.MangaGenre{},
-		&models.User{},
-		&models.RefreshToken{},
-		&models.UserLibrary{},
-		&models.UserProgress{},
-		&models.Notification{},
-		&models.Rating{},
-		&models.Comment{},
-		&models.ChatMessage{},
-	); err != nil {
-		log.Printf("warning: auto-migrate failed (continuing): %v", err)
+	// GORM AutoMigrate is only for local development schemas
+	if cfg.AutoMigrate {
+		if err := gdb.AutoMigrate(
+			&models.Manga{},
+			&models.Genre{},
+			&models.MangaGenre{},
+			&models.User{},
+			&models.RefreshToken{},
+			&models.UserLibrary{},
+			&models.UserProgress{},
+			&models.Notification{},
+			&models.Rating{},
+			&models.Comment{},
+			&models.ChatMessage{},
+			&models.MetadataReport{},
+			&models.UserFollow{},
+			&models.Webhook{},
+		); err != nil {
+			log.Printf("warning: auto-migrate failed (continuing): %v", err)
+		}
 	}
 
 	// Wire repository, service, handler
 	mangaRepo := repo.NewMangaRepo(gdb)
 	mangaSvc := svc.NewMangaService(mangaRepo)
-	mangaHandler := h.NewMangaHandler(mangaSvc)
+
+	// recently viewed lists live in Redis; without it views just aren't tracked
+	var recentlyViewedSvc svc.RecentlyViewedService
+	if rdb, err := newRedisClient(cfg); err != nil {
+		log.Printf("warning: redis unavailable, recently viewed disabled: %v", err)
+	} else {
+		lc.OnClose("redis", rdb.Close)
+		recentlyViewedRepo := repo.NewRecentlyViewedRedisRepo(rdb, cfg.RecentlyViewedMax)
+		recentlyViewedSvc = svc.NewRecentlyViewedService(recentlyViewedRepo, mangaRepo)
+	}
+	mangaHandler := h.NewMangaHandlerWithViews(mangaSvc, recentlyViewedSvc)
+	// detail views are batched in memory and flushed to manga.view_count,
+	// which a read-only instance must not write
+	if !cfg.ReadOnly {
+		viewCounter := svc.NewViewCounter(mangaRepo, cfg.ViewCountFlushInterval)
+		lc.Go("view counter", viewCounter.Run)
+		mangaHandler.WithViewCounter(viewCounter)
+	}
+	recentlyViewedHandler := h.NewRecentlyViewedHandler(recentlyViewedSvc)
 
 	// genres repo/service/handler
 	genreRepo := repo.NewGenreRepo(gdb)
 	genreSvc := svc.NewGenreService(genreRepo)
 	genreHandler := h.NewGenreHandler(genreSvc)
+	// advanced search rejects genres that aren't in the genre table
+	mangaHandler.WithGenreValidation(genreSvc)
 
 	// auth and user setup
 	userRepo := repo.NewUserRepository(gdb)
 	refreshToken := repo.NewRefreshTokenRepository(gdb)
 	authSvc := svc.NewAuthService(userRepo, refreshToken, cfg)
 	authHandler := h.NewAuthHandler(authSvc)
+	userHandler := h.NewUserHandler(svc.NewUserService(userRepo))
+
+	dto.SetPageSizeLimits(cfg.DefaultPageSize, cfg.MaxPageSize)
+	dto.SetMaxSearchQueryLength(cfg.MaxSearchQueryLength)
+	mid.SetCatalogMaxAge(cfg.CatalogCacheMaxAge)
+	mid.SetReadOnly(cfg.ReadOnly, cfg.ReadOnlyLockUserData)
+
+	// maintenance mode freezes API writes; flip it with SIGHUP or the admin API
+	maintenance := mid.NewMaintenance(cfg.MaintenanceMode, cfg.MaintenanceRetryAfter)
+	maintenanceHandler := h.NewMaintenanceHandler(maintenance)
+	lc.Go("maintenance reload", func(ctx context.Context) {
+		reloadMaintenanceOnSIGHUP(ctx, maintenance)
+	})
 
 	// library setup
 	libraryRepo := repo.NewLibraryRepository(gdb)
-	librarySvc := svc.NewLibraryService(libraryRepo, mangaRepo)
+	progressRepo := repo.NewProgressRepository(gdb)
+	librarySvc := svc.NewLibraryServiceWithLimit(libraryRepo, mangaRepo, progressRepo, cfg.MaxLibrarySize)
 	libraryHandler := h.NewLibraryHandler(librarySvc)
 
 	// notification setup
 	notificationRepo := repo.NewNotificationRepository(gdb)
 	notificationSvc := svc.NewNotificationService(notificationRepo)
 	notificationHandler := h.NewNotificationHandler(notificationSvc)
+	// old notifications are pruned daily so the table stays bounded; a
+	// read-only instance leaves that to the primary
+	if cfg.NotificationRetentionDays > 0 && !cfg.ReadOnly {
+		retention := time.Duration(cfg.NotificationRetentionDays) * 24 * time.Hour
+		pruner := svc.NewNotificationPruner(notificationRepo, retention, cfg.NotificationPruneKeepUnread, svc.DefaultNotificationPruneInterval)
+		lc.Go("notification pruner", pruner.Run)
+	}
 
 	// ---progress repo/service/handler---
-	progressRepo := repo.NewProgressRepository(gdb)
 	progressSvc := svc.NewProgressService(progressRepo)
 	progressHandler := h.NewProgressHandler(progressSvc)
 
 	// rating setup
 	ratingRepo := repo.NewRatingRepository(gdb)
 	ratingSvc := svc.NewRatingService(ratingRepo, mangaRepo)
-	ratingHandler := h.NewRatingHandler(ratingSvc)
+	ratingHandler := h.NewRatingHandler(ratingSvc).WithCreateRateLimit(cfg.RatingRateLimit, cfg.RatingRateBurst)
 
 	// comment setup
 	commentRepo := repo.NewCommentRepository(gdb)
-	commentSvc := svc.NewCommentService(commentRepo, mangaRepo)
-	commentHandler := h.NewCommentHandler(commentSvc)
+	commentSvc := svc.NewCommentServiceWithPolicy(commentRepo, mangaRepo, svc.CommentPolicyFromConfig(cfg))
+	commentHandler := h.NewCommentHandler(commentSvc).WithCreateRateLimit(cfg.CommentRateLimit, cfg.CommentRateBurst)
+
+	// follows between users; public profiles gather a user's ratings,
+	// comments, library size and follow counts
+	followRepo := repo.NewFollowRepository(gdb)
+	followHandler := h.NewFollowHandler(svc.NewFollowService(userRepo, followRepo))
+	userHandler.WithPublicProfiles(svc.NewPublicProfileService(userRepo, ratingRepo, commentRepo, libraryRepo, followRepo))
+	// the feed shows followed users' recent activity, cached briefly per user
+	feedSvc := svc.NewFeedService(repo.NewFeedRepository(gdb), time.Duration(cfg.FeedLookbackDays)*24*time.Hour, cfg.FeedCacheTTL)
+	feedHandler := h.NewFeedHandler(feedSvc)
+
+	// webhooks: users register endpoints the UDP server POSTs notifications to
+	webhookHandler := h.NewWebhookHandler(svc.NewWebhookService(repo.NewWebhookRepository(gdb)))
+
+	// metadata reports: users flag wrong catalog data, admins review it
+	metadataReportSvc := svc.NewMetadataReportService(repo.NewMetadataReportRepository(gdb), mangaRepo)
+	metadataReportHandler := h.NewMetadataReportHandler(metadataReportSvc)
+
+	// single-title import reuses the ingestion sync services
+	udpURL := os.Getenv("UDP_SERVER_URL")
+	if udpURL == "" {
+		udpURL = "http://udp-server:8085"
+	}
+	sourcePriority, err := ingestion.ParseSourcePriority(os.Getenv("METADATA_SOURCE_PRIORITY"))
+	if err != nil {
+		log.Fatalf("METADATA_SOURCE_PRIORITY: %v", err)
+	}
+	importSvc := svc.NewImportService(mangaSvc, map[string]svc.MangaImporter{
+		svc.SourceMangaDex: mangadex.NewSyncService(mangadex.SyncConfig{
+			APIKey:         os.Getenv("MANGADX_API_KEY"),
+			UDPServerURL:   udpURL,
+			InternalToken:  cfg.InternalToken,
+			SourcePriority: sourcePriority,
+			Notify:         ingestion.NotifyConfig{SigningKey: cfg.InternalSigningKey},
+		}, gdb),
+		svc.SourceAniList: anilist.NewSyncService(anilist.SyncConfig{
+			UDPServerURL:   udpURL,
+			InternalToken:  cfg.InternalToken,
+			SourcePriority: sourcePriority,
+			Notify:         ingestion.NotifyConfig{SigningKey: cfg.InternalSigningKey},
+		}, gdb),
+	})
+	importHandler := h.NewImportHandler(importSvc)
+
+	// Create websocket hub with message repository and run it in a separate goroutine
+	chatMessageRepo := ws.NewChatMessageRepository(gdb)
+	wsHub := ws.NewHub(chatMessageRepo)
+	wsHub.Flood = ws.NewFloodGuard(ws.FloodConfig{
+		RatePerMinute: cfg.WSChatRatePerMinute,
+		Burst:         cfg.WSChatBurst,
+		MuteStrikes:   cfg.WSChatMuteStrikes,
+		MuteDuration:  cfg.WSChatMuteDuration,
+		MaxMute:       cfg.WSChatMaxMute,
+	})
+	wsHub.MaxConnections = cfg.WSMaxConnections
+	lc.Go("websocket hub", wsHub.RunContext)
+	roomHandler := h.NewRoomHandler(wsHub, mangaSvc)
 
 	// Gin setup
 	r := gin.New()
-	r.Use(gin.Logger())
+	r.Use(mid.Logger()) // redacts ?token= (WebSocket auth) from access logs
 	r.Use(gin.Recovery())
+	if cfg.GzipMinSize > 0 {
+		r.Use(mid.Gzip(cfg.GzipMinSize))
+	}
 
-	// CORS middleware
-	r.Use(cors.New(cors.Config{
-		AllowOrigins:     cfg.CORSOrigins,                                     //["http://localhost:3000"] //frontend origin
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}, //allowed methods
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"}, //allowed headers
-		ExposeHeaders:    []string{"Content-Length"},                          //exposed headers
-		AllowCredentials: true,                                                //allow cookies, authorization headers with CORS requests
-		MaxAge:           12 * time.Hour,                                      //preflight request cache duration
+	// Only believe X-Forwarded-For from our own proxies, or c.ClientIP() (and
+	// every IP-keyed limit) could be spoofed by any client
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("invalid TRUSTED_PROXIES: %v", err)
+	}
+
+	// Wrong method on a known path is a 405 with an Allow header, not a 404
+	r.HandleMethodNotAllowed = true
+	r.NoMethod(mid.MethodNotAllowed())
+	r.NoRoute(mid.NotFound())
+
+	// CORS: the frontends in CORS_ORIGINS may call everything, the sites in
+	// CORS_PUBLIC_ORIGINS only read the catalog groups
+	r.Use(mid.CORS(mid.CORSPolicy{
+		AuthenticatedOrigins: cfg.CORSOrigins,
+		PublicOrigins:        cfg.CORSPublicOrigins,
+		PublicPrefixes:       []string{"/api/manga", "/api/genres"},
 	}))
 
+	// Maintenance mode freezes every write except the switch itself, the
+	// session endpoints and the read-only POSTs
+	r.Use(maintenance.Middleware(mid.MaintenanceExemptPaths...))
+
 	// Public routes
-	auth := r.Group("/auth")
+	auth := r.Group("/auth", mid.CacheControl(mid.NoStore)) // responses carry tokens
 	{
 		auth.POST("/register", authHandler.Register)
+		auth.GET("/password-policy", authHandler.PasswordPolicy)
 		auth.POST("/login", authHandler.Login)
 		auth.POST("/refresh", authHandler.RefreshToken)
 		auth.POST("/revoke", authHandler.RevokeToken)
@@ -139,16 +278,33 @@ func main() {
 	// Protected routes
 	api := r.Group("/api")
 	api.Use(mid.AuthMiddleware(authSvc))
+	api.Use(mid.CacheControl(mid.NoStore)) // catalog reads opt in to caching per route
 	{
 		mangaGroup := api.Group("/manga")
 		mangaHandler.RegisterRoutes(mangaGroup)   // Register manga routes
 		ratingHandler.RegisterRoutes(mangaGroup)  // Register rating routes under manga group
 		commentHandler.RegisterRoutes(mangaGroup) // Register comment routes under manga group
+		progressHandler.RegisterMangaRoutes(mangaGroup)
+		genreHandler.RegisterMangaRoutes(mangaGroup)
+		metadataReportHandler.RegisterRoutes(mangaGroup)
 
 		genreHandler.RegisterRoutes(api.Group("/genres"))
-		libraryHandler.RegisterRoutes(api.Group("/library"))
-		progressHandler.RegisterRoutes(api.Group("/progress"))
-		notificationHandler.RegisterRoutes(api.Group("/notifications"))
+		libraryHandler.RegisterRoutes(api.Group("/library", mid.UserWrite()))
+		progressHandler.RegisterRoutes(api.Group("/progress", mid.UserWrite()))
+		notificationHandler.RegisterRoutes(api.Group("/notifications", mid.UserWrite()))
+		webhookHandler.RegisterRoutes(api.Group("/webhooks"))
+		importHandler.RegisterRoutes(api.Group("/admin"))
+		userHandler.RegisterAdminRoutes(api.Group("/admin"))
+		maintenanceHandler.RegisterRoutes(api.Group("/admin"))
+		metadataReportHandler.RegisterAdminRoutes(api.Group("/admin"))
+		commentHandler.RegisterAdminRoutes(api.Group("/admin"))
+		mangaHandler.RegisterAdminRoutes(api.Group("/admin"))
+		roomHandler.RegisterRoutes(api.Group("/rooms"))
+		userHandler.RegisterRoutes(api.Group("/users"))
+		followHandler.RegisterRoutes(api.Group("/users"))
+		feedHandler.RegisterRoutes(api.Group("/users"))
+		recentlyViewedHandler.RegisterRoutes(api.Group("/users"))
+		authHandler.RegisterSessionRoutes(api.Group("/users/me/sessions"))
 	}
 
 	// Health/readiness
@@ -172,23 +328,19 @@ func main() {
 		c.JSON(http.StatusOK, gin.H{"ok": true})
 	})
 
-	// Create websocket hub with message repository and run it in a separate goroutine
-	chatMessageRepo := ws.NewChatMessageRepository(gdb)
-	wsHub := ws.NewHub(chatMessageRepo)
-	go wsHub.Run()
-
 	// Register WebSocket route
-	r.GET("/ws", mid.AuthMiddleware(authSvc), ws.WSHandler(wsHub))
+	r.GET("/ws", mid.WSAuthMiddleware(authSvc), ws.WSHandler(wsHub))
 
 	addr := fmt.Sprintf("0.0.0.0:%d", cfg.HTTPPort)
 
 	srv := &http.Server{
 		Addr:         addr,
-		Handler:      r,
+		Handler:      mid.HeadAsGet(r), // HEAD is served by the GET routes
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	lc.OnStop("http server", srv.Shutdown)
 
 	// Start HTTPS server in a goroutine
 	go func() {
@@ -210,10 +362,54 @@ func main() {
 	<-quit
 	log.Println("shutting down server...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatalf("server forced to shutdown: %v", err)
+	if err := lc.Shutdown(cfg.ShutdownTimeout); err != nil {
+		log.Fatalf("unclean shutdown: %v", err)
 	}
 	log.Println("server stopped")
 }
+
+// reloadMaintenanceOnSIGHUP re-reads MAINTENANCE_MODE whenever the process
+// gets SIGHUP, so operators can freeze writes without a restart
+func reloadMaintenanceOnSIGHUP(ctx context.Context, m *mid.Maintenance) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+		}
+		enabled, err := config.ReloadMaintenanceMode()
+		if err != nil {
+			log.Printf("maintenance reload failed: %v", err)
+			continue
+		}
+		m.Set(enabled, "SIGHUP")
+	}
+}
+
+// newRedisClient connects to REDIS_URL, giving up if Redis isn't reachable
+// within STARTUP_WAIT_TIMEOUT
+func newRedisClient(cfg *config.Config) (*redis.Client, error) {
+	if cfg.RedisURL == "" {
+		return nil, fmt.Errorf("REDIS_URL is not set")
+	}
+	opts, err := redis.ParseURL(cfg.RedisURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse REDIS_URL: %w", err)
+	}
+	if cfg.RedisPassword != "" {
+		opts.Password = cfg.RedisPassword
+	}
+
+	rdb := redis.NewClient(opts)
+	err = lifecycle.WaitFor("redis", cfg.StartupWaitTimeout, func(ctx context.Context) error {
+		return rdb.Ping(ctx).Err()
+	})
+	if err != nil {
+		rdb.Close()
+		return nil, err
+	}
+	return rdb, nil
+}
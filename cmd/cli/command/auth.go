@@ -54,6 +54,7 @@ var loginCmd = &cobra.Command{
 		var c dto.LoginRequest
 		c.Username, _ = cmd.Flags().GetString("username")
 		c.Password, _ = cmd.Flags().GetString("password")
+		c.Scope, _ = cmd.Flags().GetString("scope")
 
 		// call API to login user
 		htppClient := client.NewHTTPClient(apiURL) // create new HTTP client
@@ -77,6 +78,9 @@ var loginCmd = &cobra.Command{
 
 		// return confirmation message
 		fmt.Println("✓ Successfully logged in!")
+		if c.Scope != "" {
+			fmt.Printf("Granted scopes: %s\n", response.Scope)
+		}
 		return nil
 	},
 }
@@ -179,6 +183,7 @@ func init() {
 	// add flags for login command
 	loginCmd.Flags().StringP("username", "u", "", "Username for the account")
 	loginCmd.Flags().StringP("password", "p", "", "Password for the account")
+	loginCmd.Flags().String("scope", "", "Request a subset of your scopes, e.g. \"read:manga read:library\" for a read-only session")
 	loginCmd.MarkFlagRequired("username")
 	loginCmd.MarkFlagRequired("password")
 
@@ -9,6 +9,8 @@ import (
 	"mangahub/cmd/cli/dto"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -55,8 +57,9 @@ type MangaResponse struct {
 }
 
 type GenreResponse struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	MangaCount *int64 `json:"manga_count,omitempty"`
 }
 
 type GenreIDsRequest struct {
@@ -87,6 +90,8 @@ type PaginatedMangaResponse struct {
 		PageSize   int   `json:"page_size"`
 		Total      int64 `json:"total"`
 		TotalPages int   `json:"total_pages"`
+		HasNext    bool  `json:"has_next"`
+		HasPrev    bool  `json:"has_previous"`
 	} `json:"pagination"`
 	Page       int   `json:"-"` // For backward compatibility
 	PageSize   int   `json:"-"`
@@ -94,6 +99,17 @@ type PaginatedMangaResponse struct {
 	TotalPages int   `json:"-"`
 }
 
+// AdvancedSearchParams holds the optional filters for /api/manga/advanced-search
+type AdvancedSearchParams struct {
+	Query     string
+	Genres    []string
+	Status    string
+	MinRating *float64
+	SortBy    string
+	Page      int
+	PageSize  int
+}
+
 // Rating-related request/response structures
 type CreateRatingRequest struct {
 	Rating int `json:"rating"`
@@ -381,6 +397,16 @@ func (c *HTTPClient) CreateManga(request *CreateMangaRequest) (*MangaResponse, e
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusConflict {
+		var conflict struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&conflict) == nil && conflict.Error != "" {
+			return nil, fmt.Errorf("%s", conflict.Error)
+		}
+		return nil, fmt.Errorf("manga already exists")
+	}
+
 	if resp.StatusCode != http.StatusCreated {
 		return nil, fmt.Errorf("failed to create manga: %s", resp.Status)
 	}
@@ -441,29 +467,34 @@ func (c *HTTPClient) DeleteManga(id int64) error {
 	return nil
 }
 
-// func (c *HTTPClient) GetMangaGenres(mangaID int64) ([]GenreResponse, error) {
-// 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/manga/%d/genres", c.baseURL, mangaID), nil)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	req.Header.Set("Authorization", "Bearer "+c.token)
+// GetMangaGenres lists a manga's genres; withCounts adds how many manga share each one
+func (c *HTTPClient) GetMangaGenres(mangaID int64, withCounts bool) ([]GenreResponse, error) {
+	url := fmt.Sprintf("%s/api/manga/%d/genres", c.baseURL, mangaID)
+	if withCounts {
+		url += "?counts=true"
+	}
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
 
-// 	resp, err := c.httpClient.Do(req)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	defer resp.Body.Close()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-// 	if resp.StatusCode != http.StatusOK {
-// 		return nil, fmt.Errorf("failed to get genres: %s", resp.Status)
-// 	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get genres: %s", resp.Status)
+	}
 
-// 	var result []GenreResponse
-// 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-// 		return nil, err
-// 	}
-// 	return result, nil
-// }
+	var result []GenreResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
 
 // func (c *HTTPClient) AddMangaGenres(mangaID int64, genreIDs []int64) error {
 // 	request := GenreIDsRequest{GenreIDs: genreIDs}
@@ -518,35 +549,37 @@ func (c *HTTPClient) DeleteManga(id int64) error {
 // }
 
 // Library methods
-func (c *HTTPClient) AddToLibrary(mangaID int64) error {
+
+// AddToLibrary makes sure the manga is in the library. added is false when
+// it was already there (the server answers 200 instead of 201).
+func (c *HTTPClient) AddToLibrary(mangaID int64) (added bool, err error) {
 	request := AddToLibraryRequest{MangaID: mangaID}
 	jsonData, err := json.Marshal(request)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	req, err := http.NewRequest("POST", c.baseURL+"/api/library", bytes.NewBuffer(jsonData))
 	if err != nil {
-		return err
+		return false, err
 	}
 	req.Header.Set("Authorization", "Bearer "+c.token)
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusConflict {
-		return fmt.Errorf("manga already in library")
-	}
-
-	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("failed to add to library: %s", resp.Status)
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return true, nil
+	case http.StatusOK:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to add to library: %s", resp.Status)
 	}
-
-	return nil
 }
 
 func (c *HTTPClient) GetLibrary() (*LibraryListResponse, error) {
@@ -915,7 +948,12 @@ func (c *HTTPClient) CreateGenre(name string) (*GenreResponse, error) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("permission denied: creating genres requires an admin account")
+	}
+
+	// 200 means an equivalent genre already existed and was returned instead
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		// Try to read the error message from response body
 		var errorResp map[string]interface{}
 		if err := json.NewDecoder(resp.Body).Decode(&errorResp); err == nil {
@@ -933,8 +971,21 @@ func (c *HTTPClient) CreateGenre(name string) (*GenreResponse, error) {
 	return &result, nil
 }
 
-func (c *HTTPClient) GetMangasByGenre(genreID int64) ([]MangaBasicResponse, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/genres/%d/mangas", c.baseURL, genreID), nil)
+// GetMangasByGenre retrieves one page of manga in a genre
+func (c *HTTPClient) GetMangasByGenre(genreID int64, page, pageSize int) (*PaginatedMangaResponse, error) {
+	// Set defaults
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	url := fmt.Sprintf("%s/api/genres/%d/mangas?page=%d&page_size=%d", c.baseURL, genreID, page, pageSize)
+	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -950,11 +1001,18 @@ func (c *HTTPClient) GetMangasByGenre(genreID int64) ([]MangaBasicResponse, erro
 		return nil, fmt.Errorf("failed to get mangas by genre: %s", resp.Status)
 	}
 
-	var result []MangaBasicResponse
+	var result PaginatedMangaResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return nil, err
 	}
-	return result, nil
+
+	// Copy nested pagination data to top level for easier access
+	result.Page = result.Pagination.Page
+	result.PageSize = result.Pagination.PageSize
+	result.Total = result.Pagination.Total
+	result.TotalPages = result.Pagination.TotalPages
+
+	return &result, nil
 }
 
 // Progress methods
@@ -1105,6 +1163,69 @@ func (c *HTTPClient) GetAllMangaPaginated(page, pageSize int) (*PaginatedMangaRe
 	return &result, nil
 }
 
+// AdvancedSearch searches manga by genres, status, minimum rating and sort order with pagination
+func (c *HTTPClient) AdvancedSearch(params *AdvancedSearchParams) (*PaginatedMangaResponse, error) {
+	query := url.Values{}
+	if params.Query != "" {
+		query.Set("q", params.Query)
+	}
+	if len(params.Genres) > 0 {
+		query.Set("genres", strings.Join(params.Genres, ","))
+	}
+	if params.Status != "" {
+		query.Set("status", params.Status)
+	}
+	if params.MinRating != nil {
+		query.Set("min_rating", strconv.FormatFloat(*params.MinRating, 'f', -1, 64))
+	}
+	if params.SortBy != "" {
+		query.Set("sort_by", params.SortBy)
+	}
+	if params.Page > 0 {
+		query.Set("page", strconv.Itoa(params.Page))
+	}
+	if params.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(params.PageSize))
+	}
+
+	fullURL := fmt.Sprintf("%s/api/manga/advanced-search?%s", c.baseURL, query.Encode())
+	req, err := http.NewRequest("GET", fullURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// The server explains invalid filters in the error body
+		var errorResp map[string]interface{}
+		if err := json.NewDecoder(resp.Body).Decode(&errorResp); err == nil {
+			if errMsg, ok := errorResp["error"]; ok {
+				return nil, fmt.Errorf("advanced search failed: %s - %v", resp.Status, errMsg)
+			}
+		}
+		return nil, fmt.Errorf("advanced search failed: %s", resp.Status)
+	}
+
+	var result PaginatedMangaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	// Copy nested pagination data to top level for easier access
+	result.Page = result.Pagination.Page
+	result.PageSize = result.Pagination.PageSize
+	result.Total = result.Pagination.Total
+	result.TotalPages = result.Pagination.TotalPages
+
+	return &result, nil
+}
+
 // GetAllManga retrieves all manga (backward compatibility, uses pagination)
 func (c *HTTPClient) GetAllManga() ([]MangaResponse, error) {
 	result, err := c.GetAllMangaPaginated(1, 100)
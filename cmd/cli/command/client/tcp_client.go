@@ -4,23 +4,42 @@ package client
 
 import (
 	"bufio"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"sync"
 	"time"
+
+	"github.com/google/uuid"
 )
 
+// AckTimeout is how long SendProgressUpdate waits for the server to confirm delivery
+const AckTimeout = 5 * time.Second
+
+// errMalformedMessage marks a line that was read but could not be decoded
+var errMalformedMessage = errors.New("malformed message")
+
 // TCPClient represents a TCP client for real-time sync
 type TCPClient struct {
 	serverAddr string
+	tlsConfig  *tls.Config // nil dials plaintext
 	conn       net.Conn
+	reader     *bufio.Reader
 	connected  bool
 	sessionID  string
 	stats      ConnectionStats
 	mu         sync.RWMutex
 	stopChan   chan struct{}
 	monitoring bool
+	lostChan   chan struct{} // closed when an established connection drops
+	reading    bool          // a background loop owns the reader and dispatches acks
+	pending    map[string]chan *TCPMessage
+
+	// set when the server announced a planned shutdown before closing
+	shutdownNotice bool
+	reconnectAfter time.Duration
 }
 
 // ConnectionStats holds connection statistics
@@ -36,19 +55,23 @@ type ConnectionStats struct {
 
 // SyncMessage represents a sync update message
 type SyncMessage struct {
-	Direction       string    // "incoming", "outgoing", "conflict"
+	Direction       string    // "incoming", "outgoing", "conflict", "failed"
 	Device          string    // Device name
 	MangaTitle      string    // Manga title
 	Chapter         int       // Chapter number
 	ConflictChapter int       // Conflicting chapter (for conflict type)
 	Timestamp       time.Time // Message timestamp
+	Error           string    // Failure reason (for failed type)
 }
 
 // TCPMessage represents the wire protocol message
 type TCPMessage struct {
 	Type      string                 `json:"type"`
+	ID        string                 `json:"id,omitempty"` // client-generated ID echoed in ack/error replies
 	Data      map[string]interface{} `json:"data"`
 	Timestamp time.Time              `json:"timestamp"`
+	Code      string                 `json:"code,omitempty"`    // error code (error replies only)
+	Message   string                 `json:"message,omitempty"` // error text (error replies only)
 }
 
 // NewTCPClient creates a new TCP client
@@ -56,25 +79,41 @@ func NewTCPClient(serverAddr string) *TCPClient {
 	return &TCPClient{
 		serverAddr: serverAddr,
 		stopChan:   make(chan struct{}),
+		pending:    make(map[string]chan *TCPMessage),
 		stats: ConnectionStats{
 			ConnectedAt: time.Now(),
 		},
 	}
 }
 
+// WithTLS makes Connect dial the server over TLS
+func (c *TCPClient) WithTLS(cfg *tls.Config) *TCPClient {
+	c.tlsConfig = cfg
+	return c
+}
+
 // Connect establishes connection to TCP server
 func (c *TCPClient) Connect(username, token string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// Establish TCP connection
-	conn, err := net.DialTimeout("tcp", c.serverAddr, 10*time.Second)
+	// Establish TCP connection, over TLS when configured
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var conn net.Conn
+	var err error
+	if c.tlsConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", c.serverAddr, c.tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", c.serverAddr)
+	}
 	if err != nil {
 		return fmt.Errorf("connection failed: %w", err)
 	}
 
 	c.conn = conn
+	c.reader = bufio.NewReader(conn)
 	c.connected = true
+	c.lostChan = make(chan struct{})
 	c.stats.ConnectedAt = time.Now()
 
 	// Send authentication message
@@ -149,6 +188,79 @@ func (c *TCPClient) Disconnect() error {
 	return nil
 }
 
+// Lost returns a channel that is closed when the connection drops unexpectedly.
+// It is only valid after a successful Connect.
+func (c *TCPClient) Lost() <-chan struct{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lostChan
+}
+
+// WatchConnection reads and discards incoming messages until the connection
+// fails, then marks the client as disconnected. Used by the background daemon,
+// which holds the connection open but does not display messages.
+func (c *TCPClient) WatchConnection() {
+	c.mu.Lock()
+	if c.conn == nil {
+		c.mu.Unlock()
+		return
+	}
+	c.reading = true
+	c.mu.Unlock()
+
+	for {
+		msg, err := c.readMessage()
+		if err != nil {
+			if errors.Is(err, errMalformedMessage) {
+				continue
+			}
+			c.markLost()
+			return
+		}
+		c.mu.Lock()
+		c.stats.MessagesReceived++
+		c.mu.Unlock()
+		c.noteShutdown(msg)
+		c.resolvePending(msg)
+	}
+}
+
+// noteShutdown records a server_shutdown notice so the connection loss that
+// follows can be told apart from a crash
+func (c *TCPClient) noteShutdown(msg *TCPMessage) {
+	if msg.Type != "server_shutdown" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shutdownNotice = true
+	c.reconnectAfter = time.Duration(getIntField(msg.Data, "reconnect_after", 0)) * time.Second
+}
+
+// ServerShutdown reports whether the server announced a planned shutdown,
+// and how long it suggested waiting before reconnecting
+func (c *TCPClient) ServerShutdown() (bool, time.Duration) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.shutdownNotice, c.reconnectAfter
+}
+
+// markLost flags the connection as dropped and wakes up anyone waiting on Lost
+func (c *TCPClient) markLost() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.connected {
+		return
+	}
+	c.connected = false
+	c.reading = false
+	if c.conn != nil {
+		c.conn.Close()
+	}
+	close(c.lostChan)
+}
+
 // IsConnected returns connection status
 func (c *TCPClient) IsConnected() bool {
 	c.mu.RLock()
@@ -175,17 +287,20 @@ func (c *TCPClient) GetStats() ConnectionStats {
 	return stats
 }
 
-// SendProgressUpdate sends a progress update to the server
+// SendProgressUpdate sends a progress update to the server and waits until the
+// server acknowledges it was saved. Returns an error if the server rejects the
+// update or does not answer within AckTimeout.
 func (c *TCPClient) SendProgressUpdate(mangaID int64, chapter int, status, userID string) error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 
 	if !c.connected {
+		c.mu.Unlock()
 		return fmt.Errorf("not connected")
 	}
 
 	msg := TCPMessage{
 		Type: "progress_update",
+		ID:   uuid.NewString(),
 		Data: map[string]any{
 			"user_id":  userID,
 			"manga_id": mangaID,
@@ -195,18 +310,98 @@ func (c *TCPClient) SendProgressUpdate(mangaID int64, chapter int, status, userI
 		Timestamp: time.Now(),
 	}
 
-	err := c.sendMessage(&msg)
-	if err == nil {
-		c.stats.MessagesSent++
-		c.stats.LastSync = time.Now()
+	ackChan := make(chan *TCPMessage, 1)
+	c.pending[msg.ID] = ackChan
+	reading := c.reading
+
+	if err := c.sendMessage(&msg); err != nil {
+		delete(c.pending, msg.ID)
+		c.mu.Unlock()
+		return err
+	}
+	c.stats.MessagesSent++
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, msg.ID)
+		c.mu.Unlock()
+	}()
+
+	// Without a background reader, read replies here until ours arrives
+	if !reading {
+		return c.awaitAck(msg.ID)
+	}
+
+	select {
+	case reply := <-ackChan:
+		return c.ackResult(reply)
+	case <-time.After(AckTimeout):
+		return fmt.Errorf("no delivery confirmation from server after %s", AckTimeout)
 	}
-	return err
+}
+
+// awaitAck reads replies directly from the connection until the one matching
+// msgID arrives or AckTimeout elapses
+func (c *TCPClient) awaitAck(msgID string) error {
+	deadline := time.Now().Add(AckTimeout)
+	c.conn.SetReadDeadline(deadline)
+	defer c.conn.SetReadDeadline(time.Time{})
+
+	for {
+		reply, err := c.readMessage()
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				return fmt.Errorf("no delivery confirmation from server after %s", AckTimeout)
+			}
+			if errors.Is(err, errMalformedMessage) {
+				continue
+			}
+			return fmt.Errorf("failed to read delivery confirmation: %w", err)
+		}
+		if reply.ID == msgID {
+			return c.ackResult(reply)
+		}
+	}
+}
+
+// ackResult converts a server reply into the outcome of the original request
+func (c *TCPClient) ackResult(reply *TCPMessage) error {
+	if reply.Type != "ack" {
+		return fmt.Errorf("server rejected update: %s (%s)", reply.Message, reply.Code)
+	}
+
+	c.mu.Lock()
+	c.stats.LastSync = time.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+// resolvePending hands an ack/error reply to the SendProgressUpdate call waiting on it
+func (c *TCPClient) resolvePending(msg *TCPMessage) bool {
+	if msg.ID == "" {
+		return false
+	}
+
+	c.mu.Lock()
+	ackChan, ok := c.pending[msg.ID]
+	c.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	select {
+	case ackChan <- msg:
+	default:
+	}
+	return true
 }
 
 // StartMonitoring starts monitoring for real-time updates
 func (c *TCPClient) StartMonitoring(msgChan chan<- SyncMessage) {
 	c.mu.Lock()
 	c.monitoring = true
+	c.reading = true
 	c.mu.Unlock()
 
 	for {
@@ -226,6 +421,10 @@ func (c *TCPClient) StartMonitoring(msgChan chan<- SyncMessage) {
 			c.stats.MessagesReceived++
 			c.mu.Unlock()
 
+			// Replies to our own requests also wake up the waiting sender
+			c.noteShutdown(msg)
+			c.resolvePending(msg)
+
 			// Parse and send to channel
 			syncMsg := c.parseMessage(msg)
 			if syncMsg != nil {
@@ -247,6 +446,7 @@ func (c *TCPClient) StopMonitoring() {
 	if c.monitoring {
 		close(c.stopChan)
 		c.monitoring = false
+		c.reading = false
 		c.stopChan = make(chan struct{}) // Reset for next monitoring session
 	}
 }
@@ -278,6 +478,11 @@ func (c *TCPClient) heartbeatLoop() {
 				c.stats.LastHeartbeat = time.Now()
 			}
 			c.mu.Unlock()
+
+			if err != nil {
+				c.markLost()
+				return
+			}
 		}
 	}
 }
@@ -298,15 +503,18 @@ func (c *TCPClient) sendMessage(msg *TCPMessage) error {
 
 // readMessage reads a message from the TCP connection
 func (c *TCPClient) readMessage() (*TCPMessage, error) {
-	reader := bufio.NewReader(c.conn)
-	line, err := reader.ReadString('\n')
+	// Reuse one buffered reader so bytes read ahead are not lost between calls
+	if c.reader == nil {
+		c.reader = bufio.NewReader(c.conn)
+	}
+	line, err := c.reader.ReadString('\n')
 	if err != nil {
 		return nil, err
 	}
 
 	var msg TCPMessage
 	if err := json.Unmarshal([]byte(line), &msg); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", errMalformedMessage, err)
 	}
 
 	return &msg, nil
@@ -338,6 +546,21 @@ func (c *TCPClient) parseMessage(msg *TCPMessage) *SyncMessage {
 			ConflictChapter: getIntField(msg.Data, "remote_chapter", 0),
 			Timestamp:       msg.Timestamp,
 		}
+	case "server_shutdown":
+		return &SyncMessage{
+			Direction: "shutdown",
+			Timestamp: msg.Timestamp,
+		}
+	case "error", "progress_error":
+		// Only replies to our own requests carry an ID
+		if msg.ID == "" {
+			return nil
+		}
+		return &SyncMessage{
+			Direction: "failed",
+			Error:     fmt.Sprintf("%s (%s)", msg.Message, msg.Code),
+			Timestamp: msg.Timestamp,
+		}
 	}
 	return nil
 }
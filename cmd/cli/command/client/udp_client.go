@@ -57,6 +57,7 @@ type FieldChange struct {
 type subscribeRequest struct {
 	Type   string `json:"type"`
 	UserID string `json:"user_id"`
+	Token  string `json:"token,omitempty"` // access token, verified by the server on SUBSCRIBE
 }
 
 // NewUDPClient creates a new UDP client
@@ -70,8 +71,9 @@ func NewUDPClient(serverAddr string) *UDPClient {
 	}
 }
 
-// Connect establishes connection to UDP server and subscribes
-func (c *UDPClient) Connect(userID string) error {
+// Connect establishes connection to UDP server and subscribes, proving the
+// user ID with the access token
+func (c *UDPClient) Connect(userID, accessToken string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -96,6 +98,7 @@ func (c *UDPClient) Connect(userID string) error {
 	sub := subscribeRequest{
 		Type:   "SUBSCRIBE",
 		UserID: userID,
+		Token:  accessToken,
 	}
 
 	subBytes, err := json.Marshal(sub)
@@ -205,6 +208,9 @@ func (c *UDPClient) displayNotification(n *UDPNotification) {
 	case "UNSUBSCRIBE":
 		fmt.Printf("  👋 %s\n", n.Message)
 
+	case "ERROR":
+		fmt.Printf("  ❌ Server rejected request: %s\n", n.Message)
+
 	case "NEW_MANGA":
 		fmt.Printf("  🆕 NEW MANGA ADDED!\n")
 		fmt.Printf("  📖 Title: %s\n", n.Title)
@@ -15,9 +15,10 @@ var commentCmd = &cobra.Command{
 }
 
 var createCommentCmd = &cobra.Command{
-	Use:   "create [manga-id] [content]",
-	Short: "Create a comment on a manga",
-	Args:  cobra.MinimumNArgs(2),
+	Use:     "create [manga-id] [content]",
+	Aliases: []string{"add"},
+	Short:   "Create a comment on a manga",
+	Args:    cobra.MinimumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		mangaID, err := strconv.ParseInt(args[0], 10, 64)
 		if err != nil {
@@ -9,9 +9,10 @@ import (
 )
 
 var genreCmd = &cobra.Command{
-	Use:   "genre",
-	Short: "Genre management commands",
-	Long:  `Manage genres: list all genres, create new genres, and find manga by genre`,
+	Use:     "genre",
+	Aliases: []string{"genres"},
+	Short:   "Genre management commands",
+	Long:    `Manage genres: list all genres, create new genres, and find manga by genre`,
 }
 
 var listGenresCmd = &cobra.Command{
@@ -41,7 +42,7 @@ var listGenresCmd = &cobra.Command{
 
 var createGenreCmd = &cobra.Command{
 	Use:   "create [name]",
-	Short: "Create a new genre",
+	Short: "Create a new genre (admin only)",
 	Args:  cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := strings.Join(args, " ")
@@ -71,25 +72,26 @@ var mangaByGenreCmd = &cobra.Command{
 			return fmt.Errorf("invalid genre ID: %w", err)
 		}
 
+		page, _ := cmd.Flags().GetInt("page")
+		pageSize, _ := cmd.Flags().GetInt("page-size")
+
 		httpClient := GetAuthenticatedClient()
 
-		mangas, err := httpClient.GetMangasByGenre(genreID)
+		result, err := httpClient.GetMangasByGenre(genreID, page, pageSize)
 		if err != nil {
 			return fmt.Errorf("failed to get manga by genre: %w", err)
 		}
 
-		if len(mangas) == 0 {
+		if len(result.Data) == 0 {
 			fmt.Printf("No manga found for genre ID %d.\n", genreID)
 			return nil
 		}
 
-		fmt.Printf("Manga in genre %d (%d total):\n\n", genreID, len(mangas))
-		for _, m := range mangas {
+		fmt.Printf("Found %d manga in genre %d (Page %d/%d, Total: %d):\n\n",
+			len(result.Data), genreID, result.Page, result.TotalPages, result.Total)
+		for _, m := range result.Data {
 			fmt.Printf("ID: %d\n", m.ID)
 			fmt.Printf("Title: %s\n", m.Title)
-			if m.Slug != nil {
-				fmt.Printf("Slug: %s\n", *m.Slug)
-			}
 			if m.Author != nil {
 				fmt.Printf("Author: %s\n", *m.Author)
 			}
@@ -102,6 +104,12 @@ var mangaByGenreCmd = &cobra.Command{
 			fmt.Println(strings.Repeat("-", 50))
 		}
 
+		// Show pagination info
+		fmt.Printf("\nPage %d of %d (Total: %d manga)\n", result.Page, result.TotalPages, result.Total)
+		if result.Page < result.TotalPages {
+			fmt.Printf("Use --page %d to see next page\n", result.Page+1)
+		}
+
 		return nil
 	},
 }
@@ -111,4 +119,8 @@ func init() {
 	genreCmd.AddCommand(listGenresCmd)
 	genreCmd.AddCommand(createGenreCmd)
 	genreCmd.AddCommand(mangaByGenreCmd)
+
+	// Mangas-by-genre flags
+	mangaByGenreCmd.Flags().Int("page", 1, "Page number (default: 1)")
+	mangaByGenreCmd.Flags().Int("page-size", 20, "Number of items per page (default: 20, max: 100)")
 }
@@ -26,10 +26,15 @@ var libraryAddCmd = &cobra.Command{
 
 		httpClient := GetAuthenticatedClient()
 
-		if err := httpClient.AddToLibrary(mangaID); err != nil {
+		added, err := httpClient.AddToLibrary(mangaID)
+		if err != nil {
 			fmt.Println("Failed to add manga to library:", err)
 			return
 		}
+		if !added {
+			fmt.Printf("Manga (ID: %d) is already in your library\n", mangaID)
+			return
+		}
 
 		fmt.Printf("✅ Successfully added manga (ID: %d) to your library\n", mangaID)
 	},
@@ -3,8 +3,12 @@ package command
 import (
 	"fmt"
 	"mangahub/cmd/cli/command/client"
+	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -83,34 +87,100 @@ var getMangaCmd = &cobra.Command{
 			return fmt.Errorf("failed to get manga: %w", err)
 		}
 
-		fmt.Printf("ID: %d\n", manga.ID)
-		fmt.Printf("Title: %s\n", manga.Title)
-		if manga.Slug != nil {
-			fmt.Printf("Slug: %s\n", *manga.Slug)
-		}
-		if manga.Author != nil {
-			fmt.Printf("Author: %s\n", *manga.Author)
-		}
-		if manga.Status != nil {
-			fmt.Printf("Status: %s\n", *manga.Status)
-		}
-		if manga.TotalChapters != nil {
-			fmt.Printf("Total Chapters: %d\n", *manga.TotalChapters)
-		}
-		if manga.Description != nil {
-			fmt.Printf("Description: %s\n", *manga.Description)
-		}
-		if manga.CoverURL != nil {
-			fmt.Printf("Cover URL: %s\n", *manga.CoverURL)
-		}
-		if manga.CreatedAt != nil {
-			fmt.Printf("Created At: %s\n", manga.CreatedAt.Format("2006-01-02 15:04:05"))
+		printMangaDetails(manga)
+
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			interval, _ := cmd.Flags().GetDuration("interval")
+			return watchManga(httpClient, manga, interval)
 		}
 
 		return nil
 	},
 }
 
+// printMangaDetails prints every available field of a manga
+func printMangaDetails(manga *client.MangaResponse) {
+	fmt.Printf("ID: %d\n", manga.ID)
+	fmt.Printf("Title: %s\n", manga.Title)
+	if manga.Slug != nil {
+		fmt.Printf("Slug: %s\n", *manga.Slug)
+	}
+	if manga.Author != nil {
+		fmt.Printf("Author: %s\n", *manga.Author)
+	}
+	if manga.Status != nil {
+		fmt.Printf("Status: %s\n", *manga.Status)
+	}
+	if manga.TotalChapters != nil {
+		fmt.Printf("Total Chapters: %d\n", *manga.TotalChapters)
+	}
+	if manga.Description != nil {
+		fmt.Printf("Description: %s\n", *manga.Description)
+	}
+	if manga.CoverURL != nil {
+		fmt.Printf("Cover URL: %s\n", *manga.CoverURL)
+	}
+	if manga.CreatedAt != nil {
+		fmt.Printf("Created At: %s\n", manga.CreatedAt.Format("2006-01-02 15:04:05"))
+	}
+}
+
+// watchManga polls a manga until interrupted and prints a line whenever its
+// chapter count or status changes. Poll errors are logged and retried.
+func watchManga(httpClient *client.HTTPClient, last *client.MangaResponse, interval time.Duration) error {
+	if interval < time.Second {
+		interval = time.Second
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	fmt.Printf("\nWatching manga %d every %s (Ctrl+C to stop)...\n", last.ID, interval)
+
+	for {
+		select {
+		case <-sigChan:
+			fmt.Println("\nStopped watching.")
+			return nil
+		case <-ticker.C:
+			current, err := httpClient.GetMangaByID(last.ID)
+			if err != nil {
+				fmt.Printf("[%s] poll failed: %v (retrying)\n", time.Now().Format("15:04:05"), err)
+				continue
+			}
+
+			oldChapters, newChapters := intValue(last.TotalChapters), intValue(current.TotalChapters)
+			if oldChapters != newChapters {
+				fmt.Printf("[%s] chapters: %d -> %d\n", time.Now().Format("15:04:05"), oldChapters, newChapters)
+			}
+			oldStatus, newStatus := stringValue(last.Status), stringValue(current.Status)
+			if oldStatus != newStatus {
+				fmt.Printf("[%s] status: %s -> %s\n", time.Now().Format("15:04:05"), oldStatus, newStatus)
+			}
+
+			last = current
+		}
+	}
+}
+
+func intValue(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func stringValue(v *string) string {
+	if v == nil {
+		return "unknown"
+	}
+	return *v
+}
+
 var searchMangaCmd = &cobra.Command{
 	Use:   "search [query]",
 	Short: "Search manga by title, author, or slug",
@@ -146,6 +216,71 @@ var searchMangaCmd = &cobra.Command{
 	},
 }
 
+var advancedSearchMangaCmd = &cobra.Command{
+	Use:   "advanced-search [query]",
+	Short: "Search manga with genre, status, rating and sort filters",
+	Long: `Search manga using the advanced search endpoint.
+Genres are comma-separated names, e.g. --genres action,fantasy`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		genres, _ := cmd.Flags().GetStringSlice("genres")
+		status, _ := cmd.Flags().GetString("status")
+		minRating, _ := cmd.Flags().GetFloat64("min-rating")
+		sortBy, _ := cmd.Flags().GetString("sort-by")
+		page, _ := cmd.Flags().GetInt("page")
+		pageSize, _ := cmd.Flags().GetInt("page-size")
+
+		params := &client.AdvancedSearchParams{
+			Query:    strings.Join(args, " "),
+			Genres:   genres,
+			Status:   status,
+			SortBy:   sortBy,
+			Page:     page,
+			PageSize: pageSize,
+		}
+		if cmd.Flags().Changed("min-rating") {
+			params.MinRating = &minRating
+		}
+
+		httpClient := GetAuthenticatedClient()
+
+		result, err := httpClient.AdvancedSearch(params)
+		if err != nil {
+			return fmt.Errorf("search failed: %w", err)
+		}
+
+		if len(result.Data) == 0 {
+			fmt.Println("No manga found matching your filters.")
+			return nil
+		}
+
+		fmt.Printf("Found %d manga (Page %d/%d, Total: %d):\n\n",
+			len(result.Data), result.Page, result.TotalPages, result.Total)
+
+		for _, m := range result.Data {
+			fmt.Printf("ID: %d\n", m.ID)
+			fmt.Printf("Title: %s\n", m.Title)
+			if m.Author != nil {
+				fmt.Printf("Author: %s\n", *m.Author)
+			}
+			if m.Status != nil {
+				fmt.Printf("Status: %s\n", *m.Status)
+			}
+			if m.TotalChapters != nil {
+				fmt.Printf("Chapters: %d\n", *m.TotalChapters)
+			}
+			fmt.Println(strings.Repeat("-", 50))
+		}
+
+		// Show pagination info
+		fmt.Printf("\nPage %d of %d (Total: %d manga)\n", result.Page, result.TotalPages, result.Total)
+		if result.Pagination.HasNext {
+			fmt.Printf("Use --page %d to see next page\n", result.Page+1)
+		}
+
+		return nil
+	},
+}
+
 var createMangaCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new manga",
@@ -278,36 +413,41 @@ var deleteMangaCmd = &cobra.Command{
 	},
 }
 
-// var genresCmd = &cobra.Command{
-// 	Use:   "genres [manga-id]",
-// 	Short: "Get genres for a manga",
-// 	Args:  cobra.ExactArgs(1),
-// 	RunE: func(cmd *cobra.Command, args []string) error {
-// 		id, err := strconv.ParseInt(args[0], 10, 64)
-// 		if err != nil {
-// 			return fmt.Errorf("invalid manga ID: %w", err)
-// 		}
+var genresCmd = &cobra.Command{
+	Use:   "genres [manga-id]",
+	Short: "Get genres for a manga",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid manga ID: %w", err)
+		}
 
-// 		httpClient := GetAuthenticatedClient()
+		withCounts, _ := cmd.Flags().GetBool("counts")
+		httpClient := GetAuthenticatedClient()
 
-// 		genres, err := httpClient.GetMangaGenres(id)
-// 		if err != nil {
-// 			return fmt.Errorf("failed to get genres: %w", err)
-// 		}
+		genres, err := httpClient.GetMangaGenres(id, withCounts)
+		if err != nil {
+			return fmt.Errorf("failed to get genres: %w", err)
+		}
 
-// 		if len(genres) == 0 {
-// 			fmt.Println("No genres found for this manga.")
-// 			return nil
-// 		}
+		if len(genres) == 0 {
+			fmt.Println("No genres found for this manga.")
+			return nil
+		}
 
-// 		fmt.Printf("Genres for manga %d:\n", id)
-// 		for _, g := range genres {
-// 			fmt.Printf("- %s (ID: %d)\n", g.Name, g.ID)
-// 		}
+		fmt.Printf("Genres for manga %d:\n", id)
+		for _, g := range genres {
+			if g.MangaCount != nil {
+				fmt.Printf("- %s (ID: %d, %d manga)\n", g.Name, g.ID, *g.MangaCount)
+				continue
+			}
+			fmt.Printf("- %s (ID: %d)\n", g.Name, g.ID)
+		}
 
-// 		return nil
-// 	},
-// }
+		return nil
+	},
+}
 
 // var addGenresCmd = &cobra.Command{
 // 	Use:   "add-genres [manga-id] [genre-ids...]",
@@ -376,14 +516,31 @@ func init() {
 	mangaCmd.AddCommand(listMangaCmd)
 	mangaCmd.AddCommand(getMangaCmd)
 	mangaCmd.AddCommand(searchMangaCmd)
+	mangaCmd.AddCommand(advancedSearchMangaCmd)
 	mangaCmd.AddCommand(createMangaCmd)
 	mangaCmd.AddCommand(updateMangaCmd)
 	mangaCmd.AddCommand(deleteMangaCmd)
+	mangaCmd.AddCommand(genresCmd)
 
 	// List command flags
 	listMangaCmd.Flags().Int("page", 1, "Page number (default: 1)")
 	listMangaCmd.Flags().Int("page-size", 20, "Number of items per page (default: 20, max: 100)")
 
+	// Get flags
+	getMangaCmd.Flags().Bool("watch", false, "Keep polling and print chapter/status changes until Ctrl+C")
+	getMangaCmd.Flags().Duration("interval", 30*time.Second, "Poll interval for --watch")
+
+	// Genres flags
+	genresCmd.Flags().Bool("counts", false, "Show how many manga share each genre")
+
+	// Advanced search flags
+	advancedSearchMangaCmd.Flags().StringSlice("genres", nil, "Comma-separated genre names")
+	advancedSearchMangaCmd.Flags().String("status", "", "Manga status (ongoing/completed/hiatus)")
+	advancedSearchMangaCmd.Flags().Float64("min-rating", 0, "Minimum average rating (0-10)")
+	advancedSearchMangaCmd.Flags().String("sort-by", "", "Sort order (popularity/rating/recent/title)")
+	advancedSearchMangaCmd.Flags().Int("page", 1, "Page number (default: 1)")
+	advancedSearchMangaCmd.Flags().Int("page-size", 20, "Number of items per page (default: 20, max: 100)")
+
 	// Create flags
 	createMangaCmd.Flags().String("title", "", "Manga title (required)")
 	createMangaCmd.Flags().String("author", "", "Manga author")
@@ -0,0 +1,60 @@
+package command
+
+import (
+	"fmt"
+	"mangahub/cmd/cli/command/client"
+
+	"github.com/spf13/cobra"
+)
+
+var notificationsServerAddr string
+
+// notificationsCmd groups commands for server notifications
+var notificationsCmd = &cobra.Command{
+	Use:   "notifications",
+	Short: "Server notification commands",
+	Long:  `Receive real-time notifications about new manga, new chapters and manga updates.`,
+}
+
+// notificationsWatchCmd tails notifications over UDP until Ctrl+C
+var notificationsWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch notifications as they arrive",
+	Long: `Subscribe to the UDP notification server with your stored login and print
+incoming notifications until Ctrl+C.
+
+Unlike 'mangahubCLI udp listen', the access token is refreshed first if it is
+about to expire, so the server accepts the subscription.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// The root command has already refreshed the access token if needed
+		userID := GetCurrentUserID()
+		if userID == "" {
+			return fmt.Errorf("no user ID stored, please run 'mangahubCLI auth login' again")
+		}
+
+		udpClient := client.NewUDPClient(notificationsServerAddr)
+
+		fmt.Println("🔌 Connecting to UDP notification server...")
+		fmt.Printf("   Server: %s\n", notificationsServerAddr)
+		fmt.Printf("   User: %s (ID: %s)\n\n", GetCurrentUsername(), userID)
+
+		if err := udpClient.Connect(userID, accessToken); err != nil {
+			return fmt.Errorf("failed to connect: %w", err)
+		}
+
+		// Blocks until Ctrl+C
+		if err := udpClient.StartListening(); err != nil {
+			return fmt.Errorf("error during listening: %w", err)
+		}
+
+		udpClient.PrintStats()
+		return nil
+	},
+}
+
+func init() {
+	notificationsServerAddr = defaultUDPAddress()
+	notificationsCmd.PersistentFlags().StringVar(&notificationsServerAddr, "server", notificationsServerAddr, "UDP server address (host:port)")
+
+	notificationsCmd.AddCommand(notificationsWatchCmd)
+}
@@ -2,7 +2,6 @@ package command
 
 import (
 	"fmt"
-	"mangahub/cmd/cli/command/client"
 	"mangahub/cmd/cli/dto"
 	"os"
 	"strconv"
@@ -271,7 +270,10 @@ var progressSyncCmd = &cobra.Command{
 				tcpServer = v
 			}
 
-			tempClient := client.NewTCPClient(tcpServer)
+			tempClient, err := newTCPClient(tcpServer)
+			if err != nil {
+				return err
+			}
 			username := GetCurrentUsername()
 			if username == "" {
 				return fmt.Errorf("not authenticated, please login first")
@@ -280,7 +282,7 @@ var progressSyncCmd = &cobra.Command{
 			// Get access token
 			token := accessToken
 
-			err := tempClient.Connect(username, token)
+			err = tempClient.Connect(username, token)
 			if err != nil {
 				return fmt.Errorf("failed to connect to TCP server: %w", err)
 			}
@@ -307,7 +309,7 @@ var progressSyncCmd = &cobra.Command{
 			return fmt.Errorf("failed to sync: %w", err)
 		}
 
-		fmt.Println("\n✓ Progress synced successfully via TCP!")
+		fmt.Println("\n✓ Progress synced via TCP (confirmed by server)!")
 		fmt.Println("\nNote: This updates the TCP sync server cache (Redis).")
 		fmt.Println("For persistent storage, use: mangahub progress update")
 
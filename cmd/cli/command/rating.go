@@ -15,9 +15,10 @@ var ratingCmd = &cobra.Command{
 }
 
 var rateCmd = &cobra.Command{
-	Use:   "rate [manga-id] [rating]",
-	Short: "Rate a manga (1-10)",
-	Args:  cobra.ExactArgs(2),
+	Use:     "rate [manga-id] [rating]",
+	Aliases: []string{"set"},
+	Short:   "Rate a manga (1-10)",
+	Args:    cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		mangaID, err := strconv.ParseInt(args[0], 10, 64)
 		if err != nil {
@@ -78,6 +78,7 @@ Use "mangahubCLI command -help" or "mangahubCLI command -h" to see all available
 				AccessToken:  refreshResp.AccessToken,
 				RefreshToken: refreshResp.RefreshToken,
 				Username:     creds.Username,
+				UserID:       creds.UserID,
 				ExpiresAt:    now + refreshResp.ExpiresIn,
 			})
 			if err != nil {
@@ -126,6 +127,7 @@ func init() {
 	rootCmd.AddCommand(genreCmd)
 	rootCmd.AddCommand(grpcCmd)
 	rootCmd.AddCommand(udpCmd)
+	rootCmd.AddCommand(notificationsCmd)
 }
 
 // GetAuthenticatedClient returns an HTTP client with the current access token
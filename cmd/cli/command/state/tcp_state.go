@@ -9,13 +9,22 @@ import (
 	"time"
 )
 
+// HeartbeatInterval is how often the sync daemon records it is alive.
+// A heartbeat older than HeartbeatStaleAfter means the daemon is hung.
+const (
+	HeartbeatInterval   = 15 * time.Second
+	HeartbeatStaleAfter = 4 * HeartbeatInterval
+)
+
 type TCPConnectionState struct {
-	Connected   bool      `json:"connected"`
-	Server      string    `json:"server"`
-	Username    string    `json:"username"`
-	SessionID   string    `json:"session_id"`
-	ConnectedAt time.Time `json:"connected_at"`
-	PID         int       `json:"pid"` // Process ID of connection holder
+	Connected     bool      `json:"connected"`
+	Server        string    `json:"server"`
+	Username      string    `json:"username"`
+	SessionID     string    `json:"session_id"`
+	ConnectedAt   time.Time `json:"connected_at"`
+	LastHeartbeat time.Time `json:"last_heartbeat"` // Last time the daemon reported it was alive
+	PID           int       `json:"pid"`            // Process ID of connection holder
+	Reconnects    int       `json:"reconnects"`     // Times the daemon re-established a dropped connection
 }
 
 func GetStateFilePath() string {
@@ -23,6 +32,15 @@ func GetStateFilePath() string {
 	return filepath.Join(homeDir, ".mangahub", "tcp_state.json")
 }
 
+// OpenDaemonLog opens (or creates) the sync daemon log file for appending
+func OpenDaemonLog() (*os.File, error) {
+	stateDir := filepath.Dir(GetStateFilePath())
+	if err := os.MkdirAll(stateDir, 0700); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(filepath.Join(stateDir, "sync_daemon.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+}
+
 func SaveConnectionState(state *TCPConnectionState) error {
 	stateDir := filepath.Dir(GetStateFilePath())
 	if err := os.MkdirAll(stateDir, 0700); err != nil {
@@ -84,6 +102,9 @@ func (s *TCPConnectionState) IsProcessRunning() bool {
 		// On Windows, this will always succeed if PID is valid
 		// So we need to trust the state was updated recently
 		// A better approach: check if state file is recent (< 60 seconds old)
+		if !s.LastHeartbeat.IsZero() {
+			return time.Since(s.LastHeartbeat) <= HeartbeatStaleAfter
+		}
 		if time.Since(s.ConnectedAt) > 5*time.Minute {
 			return false // Stale connection
 		}
@@ -94,3 +115,12 @@ func (s *TCPConnectionState) IsProcessRunning() bool {
 	err = process.Signal(syscall.Signal(0))
 	return err == nil
 }
+
+// IsHeartbeatStale reports whether a connected daemon has stopped updating its
+// heartbeat. States written before heartbeats existed are never considered stale.
+func (s *TCPConnectionState) IsHeartbeatStale() bool {
+	if !s.Connected || s.LastHeartbeat.IsZero() {
+		return false
+	}
+	return time.Since(s.LastHeartbeat) > HeartbeatStaleAfter
+}
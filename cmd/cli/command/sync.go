@@ -1,7 +1,10 @@
 package command
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"log"
 	"mangahub/cmd/cli/authentication"
 	"mangahub/cmd/cli/command/client"
 	"mangahub/cmd/cli/command/state"
@@ -9,6 +12,7 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -88,7 +92,7 @@ var syncDisconnectCmd = &cobra.Command{
 			return fmt.Errorf("failed to load state: %w", err)
 		}
 
-		if connState == nil || !connState.Connected {
+		if connState == nil || (!connState.Connected && !connState.IsProcessRunning()) {
 			return fmt.Errorf("not connected to sync server")
 		}
 
@@ -128,6 +132,14 @@ var syncStatusCmd = &cobra.Command{
 			return fmt.Errorf("failed to load state: %w", err)
 		}
 
+		if connState != nil && !connState.Connected && connState.IsProcessRunning() {
+			fmt.Println("  Connection: ⟳ Reconnecting")
+			fmt.Printf("  Server: %s\n", connState.Server)
+			fmt.Printf("  Daemon PID: %d\n", connState.PID)
+			fmt.Println("\nThe daemon lost its connection and is retrying in the background.")
+			return nil
+		}
+
 		if connState == nil || !connState.Connected {
 			fmt.Println("  Connection: ✗ Not connected")
 			fmt.Printf("  Server: %s\n", tcpServer)
@@ -146,6 +158,27 @@ var syncStatusCmd = &cobra.Command{
 			return nil
 		}
 
+		// Daemon is alive but its heartbeat stopped: treat as disconnected
+		if connState.IsHeartbeatStale() {
+			fmt.Printf("  Connection: ✗ Stale (last active %s ago)\n", formatDuration(time.Since(connState.LastHeartbeat)))
+			fmt.Printf("  Daemon PID: %d\n", connState.PID)
+
+			reconnect, _ := cmd.Flags().GetBool("reconnect")
+			if !reconnect {
+				fmt.Println("\nThe daemon is running but has stopped responding.")
+				fmt.Println("To restart it:")
+				fmt.Println("  mangahub sync status --reconnect")
+				return nil
+			}
+
+			if process, err := os.FindProcess(connState.PID); err == nil {
+				process.Kill()
+			}
+			state.ClearConnectionState()
+			fmt.Println("\nRestarting daemon...")
+			return syncConnectCmd.RunE(syncConnectCmd, nil)
+		}
+
 		// Connected
 		fmt.Println("  Connection: ✓ Active")
 		fmt.Printf("  Server: %s\n", connState.Server)
@@ -155,6 +188,12 @@ var syncStatusCmd = &cobra.Command{
 
 		uptime := time.Since(connState.ConnectedAt)
 		fmt.Printf("  Uptime: %s\n", formatDuration(uptime))
+		if !connState.LastHeartbeat.IsZero() {
+			fmt.Printf("  Last active: %s ago\n", formatDuration(time.Since(connState.LastHeartbeat)))
+		}
+		if connState.Reconnects > 0 {
+			fmt.Printf("  Reconnects: %d\n", connState.Reconnects)
+		}
 
 		return nil
 	},
@@ -179,7 +218,10 @@ var syncMonitorCmd = &cobra.Command{
 		fmt.Println("Monitoring real-time sync updates... (Press Ctrl+C to exit)")
 
 		// Create new temporary connection for monitoring
-		monitorClient := client.NewTCPClient(connState.Server)
+		monitorClient, err := newTCPClient(connState.Server)
+		if err != nil {
+			return err
+		}
 		username := GetCurrentUsername()
 
 		if err := monitorClient.Connect(username, accessToken); err != nil {
@@ -218,6 +260,12 @@ var syncMonitorCmd = &cobra.Command{
 				case "conflict":
 					fmt.Printf("[%s] ⚠ Conflict resolved: %s → Chapter %d (local) vs %d (remote)\n",
 						timestamp, msg.MangaTitle, msg.Chapter, msg.ConflictChapter)
+
+				case "failed":
+					fmt.Printf("[%s] ✗ Delivery failed: %s\n", timestamp, msg.Error)
+
+				case "shutdown":
+					fmt.Printf("[%s] ■ Server is shutting down for maintenance\n", timestamp)
 				}
 			}
 		}
@@ -233,12 +281,139 @@ var syncDaemonCmd = &cobra.Command{
 	},
 }
 
-// runDaemon keeps TCP connection alive in background
+// Reconnect backoff bounds for the sync daemon
+const (
+	daemonInitialBackoff = 1 * time.Second
+	daemonMaxBackoff     = 1 * time.Minute
+)
+
+// runDaemon keeps TCP connection alive in background and re-establishes it
+// with exponential backoff whenever it drops
 func runDaemon() error {
+	// Daemon has no terminal attached, so log to a file next to the state file
+	if logFile, err := state.OpenDaemonLog(); err == nil {
+		log.SetOutput(logFile)
+		defer logFile.Close()
+	}
+
+	// The first connection must succeed so 'sync connect' can report failures
+	tcpClient, username, err := connectDaemon()
+	if err != nil {
+		return err
+	}
+
+	connState := &state.TCPConnectionState{
+		Connected:     true,
+		Server:        tcpServer,
+		Username:      username,
+		SessionID:     tcpClient.GetSessionID(),
+		ConnectedAt:   time.Now(),
+		LastHeartbeat: time.Now(),
+		PID:           os.Getpid(),
+	}
+	if err := state.SaveConnectionState(connState); err != nil {
+		return err
+	}
+	log.Printf("connected to %s (session %s)", tcpServer, connState.SessionID)
+
+	// Handle signals for graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	// Periodically record liveness so 'sync status' can detect a hung daemon
+	heartbeat := time.NewTicker(state.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		go tcpClient.WatchConnection()
+
+	connected:
+		for {
+			select {
+			case <-sigChan:
+				// Cleanup
+				tcpClient.Disconnect()
+				state.ClearConnectionState()
+				return nil
+			case <-heartbeat.C:
+				saveHeartbeat(connState)
+			case <-tcpClient.Lost():
+				break connected
+			}
+		}
+
+		connState.Connected = false
+		state.SaveConnectionState(connState)
+
+		// A planned shutdown comes with a hint for when the server is back
+		backoff := daemonInitialBackoff
+		if planned, after := tcpClient.ServerShutdown(); planned {
+			log.Printf("server %s shut down", tcpServer)
+			if after > backoff {
+				backoff = after
+			}
+		} else {
+			log.Printf("connection to %s lost", tcpServer)
+		}
+		for attempt := 1; ; attempt++ {
+			log.Printf("reconnect attempt %d in %s", attempt, backoff)
+			// Keep the heartbeat going while waiting, so 'sync status' still
+			// sees a live (reconnecting) daemon during a long backoff
+			wait := time.NewTimer(backoff)
+		waiting:
+			for {
+				select {
+				case <-sigChan:
+					wait.Stop()
+					state.ClearConnectionState()
+					return nil
+				case <-heartbeat.C:
+					saveHeartbeat(connState)
+				case <-wait.C:
+					break waiting
+				}
+			}
+
+			tcpClient, username, err = connectDaemon()
+			if err == nil {
+				break
+			}
+			log.Printf("reconnect attempt %d failed: %v", attempt, err)
+
+			backoff *= 2
+			if backoff > daemonMaxBackoff {
+				backoff = daemonMaxBackoff
+			}
+		}
+
+		connState.Connected = true
+		connState.Username = username
+		connState.SessionID = tcpClient.GetSessionID()
+		connState.ConnectedAt = time.Now()
+		connState.LastHeartbeat = time.Now()
+		connState.Reconnects++
+		if err := state.SaveConnectionState(connState); err != nil {
+			log.Printf("failed to save connection state: %v", err)
+		}
+		log.Printf("reconnected to %s (session %s)", tcpServer, connState.SessionID)
+	}
+}
+
+// saveHeartbeat records that the daemon is still alive
+func saveHeartbeat(connState *state.TCPConnectionState) {
+	connState.LastHeartbeat = time.Now()
+	if err := state.SaveConnectionState(connState); err != nil {
+		log.Printf("failed to save heartbeat: %v", err)
+	}
+}
+
+// connectDaemon loads stored credentials, refreshes the access token if it is
+// about to expire, and opens an authenticated TCP connection
+func connectDaemon() (*client.TCPClient, string, error) {
 	// Load credentials from storage (since daemon runs as separate process)
 	creds, err := authentication.GetTokens()
 	if err != nil {
-		return fmt.Errorf("not authenticated: %w", err)
+		return nil, "", fmt.Errorf("not authenticated: %w", err)
 	}
 
 	// Check if token needs refresh
@@ -252,7 +427,7 @@ func runDaemon() error {
 
 		refreshResp, err := httpClient.RefreshToken(req)
 		if err != nil {
-			return fmt.Errorf("token refresh failed, please login again: %w", err)
+			return nil, "", fmt.Errorf("token refresh failed, please login again: %w", err)
 		}
 
 		// Store refreshed tokens
@@ -264,44 +439,49 @@ func runDaemon() error {
 			ExpiresAt:    now + refreshResp.ExpiresIn,
 		})
 		if err != nil {
-			return fmt.Errorf("failed to store refreshed tokens: %w", err)
+			return nil, "", fmt.Errorf("failed to store refreshed tokens: %w", err)
 		}
 
 		accessTokenToUse = refreshResp.AccessToken
 	}
 
-	// Create TCP client
-	tcpClient := client.NewTCPClient(tcpServer)
-
-	// Connect using fresh credentials
+	// Create TCP client and connect using fresh credentials
+	tcpClient, err := newTCPClient(tcpServer)
+	if err != nil {
+		return nil, "", err
+	}
 	if err := tcpClient.Connect(creds.Username, accessTokenToUse); err != nil {
-		return fmt.Errorf("TCP connection failed: %w", err)
+		return nil, "", fmt.Errorf("TCP connection failed: %w", err)
 	}
 
-	// Save state
-	connState := &state.TCPConnectionState{
-		Connected:   true,
-		Server:      tcpServer,
-		Username:    creds.Username,
-		SessionID:   tcpClient.GetSessionID(),
-		ConnectedAt: time.Now(),
-		PID:         os.Getpid(),
-	}
-	if err := state.SaveConnectionState(connState); err != nil {
-		return err
-	}
+	return tcpClient, creds.Username, nil
+}
 
-	// Handle signals for graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+// newTCPClient creates a client for addr that dials over TLS when
+// MANGAHUB_TCP_TLS=true or MANGAHUB_TCP_TLS_CA names a CA bundle to trust
+// (e.g. a self-signed dev certificate); otherwise it stays plaintext
+func newTCPClient(addr string) (*client.TCPClient, error) {
+	tcp := client.NewTCPClient(addr)
 
-	// Keep connection alive
-	<-sigChan
+	caFile := os.Getenv("MANGAHUB_TCP_TLS_CA")
+	useTLS, _ := strconv.ParseBool(os.Getenv("MANGAHUB_TCP_TLS"))
+	if !useTLS && caFile == "" {
+		return tcp, nil
+	}
 
-	// Cleanup
-	tcpClient.Disconnect()
-	state.ClearConnectionState()
-	return nil
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read MANGAHUB_TCP_TLS_CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+	return tcp.WithTLS(cfg), nil
 }
 
 // formatDuration formats a duration in a human-readable way
@@ -326,6 +506,8 @@ func init() {
 	syncCmd.AddCommand(syncMonitorCmd)
 	syncCmd.AddCommand(syncDaemonCmd)
 
+	syncStatusCmd.Flags().Bool("reconnect", false, "Restart the daemon if its heartbeat is stale")
+
 	// TCP server flag
 	defaultTCPServer := AddressServer + ":" + TCP_PORT
 	if v := os.Getenv("MANGAHUB_TCP_SERVER"); v != "" {
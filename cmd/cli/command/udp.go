@@ -51,7 +51,7 @@ Press Ctrl+C to stop listening and disconnect.`,
 		fmt.Printf("   User: %s (ID: %s)\n\n", creds.Username, creds.UserID)
 
 		// Connect and subscribe
-		if err := udpClient.Connect(creds.UserID); err != nil {
+		if err := udpClient.Connect(creds.UserID, creds.AccessToken); err != nil {
 			return fmt.Errorf("failed to connect: %w", err)
 		}
 
@@ -87,7 +87,7 @@ var udpTestCmd = &cobra.Command{
 		fmt.Printf("   User: %s (ID: %s)\n\n", creds.Username, creds.UserID)
 
 		// Connect and subscribe
-		if err := udpClient.Connect(creds.UserID); err != nil {
+		if err := udpClient.Connect(creds.UserID, creds.AccessToken); err != nil {
 			return fmt.Errorf("connection failed: %w", err)
 		}
 
@@ -122,12 +122,17 @@ var udpStatsCmd = &cobra.Command{
 	},
 }
 
-func init() {
-	// Get UDP server address from environment or use default
-	defaultUDPAddr := AddressServer + ":" + UDP_PORT
+// defaultUDPAddress returns MANGAHUB_UDP_ADDR, or the local UDP server
+func defaultUDPAddress() string {
 	if v := os.Getenv("MANGAHUB_UDP_ADDR"); v != "" {
-		defaultUDPAddr = v
+		return v
 	}
+	return AddressServer + ":" + UDP_PORT
+}
+
+func init() {
+	// Get UDP server address from environment or use default
+	defaultUDPAddr := defaultUDPAddress()
 	udpServerAddr = defaultUDPAddr
 
 	// Add flags
@@ -14,6 +14,7 @@ type LoginRequest struct {
 	Username string `json:"username"`
 	Email    string `json:"email"`
 	Password string `json:"password" binding:"required"`
+	Scope    string `json:"scope,omitempty"` // space-separated subset of the account's scopes
 }
 
 // AuthResponse: response payload after successful authentication
@@ -23,6 +24,7 @@ type AuthResponse struct {
 	UserID       string `json:"user_id"`
 	Username     string `json:"username"`
 	ExpiresIn    int64  `json:"expires_in"` // seconds
+	Scope        string `json:"scope"`      // granted scopes, space-separated
 }
 
 // RefreshTokenRequest: payload for refreshing access token
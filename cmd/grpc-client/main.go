@@ -17,7 +17,7 @@ func main() {
 	// Parse command line flags
 	serverAddr := flag.String("server", "localhost:8083", "gRPC server address")
 	action := flag.String("action", "get", "Action to perform: get, search")
-	mangaID := flag.Int64("id", 1, "Manga ID for get action")
+	mangaID := flag.Int64("id", 0, "Manga ID for get action (required)")
 	query := flag.String("query", "", "Search query for search action")
 	limit := flag.Int("limit", 10, "Limit for search results")
 	offset := flag.Int("offset", 0, "Offset for search results")
@@ -36,6 +36,9 @@ func main() {
 
 	switch *action {
 	case "get":
+		if *mangaID <= 0 {
+			log.Fatalf("-id must be a positive manga ID")
+		}
 		testGetManga(ctx, client, *mangaID)
 	case "search":
 		testSearchManga(ctx, client, *query, int32(*limit), int32(*offset))
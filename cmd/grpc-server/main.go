@@ -5,6 +5,7 @@ import (
 	"mangahub/database"
 	"mangahub/internal/config"
 	"mangahub/internal/microservices/grpc"
+	"mangahub/internal/microservices/http-api/dto"
 	"mangahub/internal/microservices/http-api/models"
 	rb "mangahub/internal/microservices/http-api/repository"
 	"strconv"
@@ -17,6 +18,13 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	// Open GORM DB (used by repository), waiting up to STARTUP_WAIT_TIMEOUT
+	// for Postgres to accept connections
+	gdb, err := database.OpenGormWait(cfg.StartupWaitTimeout)
+	if err != nil {
+		log.Fatalf("failed to open gorm DB: %v", err)
+	}
+
 	// Try to initialize optional pgx pool (used by some packages). Non-fatal.
 	if err := database.Connect(); err != nil {
 		log.Printf("warning: pgx connect failed (continuing): %v", err)
@@ -25,25 +33,28 @@ func main() {
 		defer database.Close()
 	}
 
-	// Open GORM DB (used by repository)
-	gdb, err := database.OpenGorm()
-	if err != nil {
-		log.Fatalf("failed to open gorm DB: %v", err)
+	// Apply versioned SQL migrations
+	if err := database.Migrate(gdb); err != nil {
+		log.Fatalf("database migration failed: %v", err)
 	}
 
-	// Auto-migrate models
-	if err := gdb.AutoMigrate(
-		&models.Manga{},
-		&models.Genre{},
-		&models.MangaGenre{},
-		&models.User{},
-		&models.RefreshToken{},
-		&models.UserLibrary{},
-		&models.UserProgress{},
-		&models.Notification{},
-	); err != nil {
-		log.Printf("warning: auto-migrate failed (continuing): %v", err)
+	// GORM AutoMigrate is only for local development schemas
+	if cfg.AutoMigrate {
+		if err := gdb.AutoMigrate(
+			&models.Manga{},
+			&models.Genre{},
+			&models.MangaGenre{},
+			&models.User{},
+			&models.RefreshToken{},
+			&models.UserLibrary{},
+			&models.UserProgress{},
+			&models.Notification{},
+		); err != nil {
+			log.Printf("warning: auto-migrate failed (continuing): %v", err)
+		}
 	}
+	dto.SetMaxSearchQueryLength(cfg.MaxSearchQueryLength)
+
 	port := cfg.GRPCPort
 	log.Printf("gRPC server starting on port %d", port)
 	portStr := ":" + strconv.Itoa(port)
@@ -52,7 +63,8 @@ func main() {
 	progressRepo := rb.NewProgressRepository(gdb)
 
 	// Start gRPC server
-	if err := grpc.StartGRPCServer(portStr, mangaRepo, progressRepo); err != nil {
+	readOnly := grpc.ReadOnlyMode{Enabled: cfg.ReadOnly, LockUserData: cfg.ReadOnlyLockUserData}
+	if err := grpc.StartGRPCServer(portStr, mangaRepo, progressRepo, cfg.GRPCDefaultDeadline, readOnly); err != nil {
 		log.Fatalf("gRPC server failed: %v", err)
 	}
 }
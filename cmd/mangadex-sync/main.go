@@ -3,13 +3,19 @@ package main
 import (
 	"context"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
+	_ "time/tzdata" // SCHEDULER_TIMEZONE must resolve in minimal images
 
 	"mangahub/database"
+	"mangahub/internal/ingestion"
 	"mangahub/internal/ingestion/mangadex"
+	"mangahub/internal/lifecycle"
 
 	"github.com/joho/godotenv"
 )
@@ -24,22 +30,64 @@ func main() {
 		log.Println("[Warning] .env file not found, using system environment variables")
 	}
 
-	// Initialize database connection
-	db, err := database.OpenGorm()
+	// Structured logging (LOG_LEVEL: debug|info|warn|error, LOG_FORMAT: text|json)
+	slog.SetDefault(ingestion.NewLogger(getEnv("LOG_LEVEL", "info"), getEnv("LOG_FORMAT", "text")))
+
+	// Initialize database connection, waiting up to STARTUP_WAIT_TIMEOUT for it
+	db, err := database.OpenGormWait(getEnvDuration("STARTUP_WAIT_TIMEOUT", lifecycle.DefaultStartupWaitTimeout))
 	if err != nil {
 		log.Fatalf("[Fatal] Failed to connect to database: %v", err)
 	}
 
 	log.Println("[Database] ✅ Connected successfully")
 
+	// Wall-clock schedule for the daily poll (MANGA_SYNC_POLL_AT=HH:MM in SCHEDULER_TIMEZONE)
+	schedulerLoc, err := ingestion.LoadSchedulerLocation(getEnv("SCHEDULER_TIMEZONE", "UTC"))
+	if err != nil {
+		log.Fatalf("[Fatal] %v", err)
+	}
+	pollAt, err := ingestion.ParseDailySchedule(getEnv("MANGA_SYNC_POLL_AT", ""), schedulerLoc)
+	if err != nil {
+		log.Fatalf("[Fatal] MANGA_SYNC_POLL_AT: %v", err)
+	}
+
+	// Which source wins when AniList also supplies a title
+	sourcePriority, err := ingestion.ParseSourcePriority(getEnv("METADATA_SOURCE_PRIORITY", ""))
+	if err != nil {
+		log.Fatalf("[Fatal] METADATA_SOURCE_PRIORITY: %v", err)
+	}
+	defaultStatus, err := ingestion.ParseDefaultStatus(getEnv("INGESTION_DEFAULT_STATUS", ""))
+	if err != nil {
+		log.Fatalf("[Fatal] INGESTION_DEFAULT_STATUS: %v", err)
+	}
+
 	// Get configuration from environment
 	config := mangadex.SyncConfig{
 		APIKey:           getEnv("MANGADX_API_KEY", ""),
 		DatabaseURL:      getEnv("DATABASE_URL", ""),
 		UDPServerURL:     getEnv("UDP_SERVER_URL", "http://udp-server:8085"),
+		InternalToken:    getEnv("INTERNAL_TOKEN", ""),
 		InitialSyncLimit: getEnvInt("MANGA_SYNC_INITIAL_COUNT", 150),
 		WorkerCount:      getEnvInt("MANGA_SYNC_WORKERS", 10),
 		RateConcurrency:  getEnvInt("MANGA_SYNC_RATE_CONCURRENCY", 5),
+		HTTP: ingestion.HTTPConfig{
+			Timeout:             getEnvDuration("MANGA_SYNC_HTTP_TIMEOUT", ingestion.DefaultHTTPTimeout),
+			DialTimeout:         getEnvDuration("MANGA_SYNC_DIAL_TIMEOUT", ingestion.DefaultDialTimeout),
+			TLSHandshakeTimeout: getEnvDuration("MANGA_SYNC_TLS_TIMEOUT", ingestion.DefaultTLSHandshakeTimeout),
+		},
+		Breaker: ingestion.BreakerConfig{
+			Threshold: getEnvInt("MANGA_SYNC_BREAKER_THRESHOLD", ingestion.DefaultBreakerThreshold),
+			Cooldown:  getEnvDuration("MANGA_SYNC_BREAKER_COOLDOWN", ingestion.DefaultBreakerCooldown),
+		},
+		Notify: ingestion.NotifyConfig{
+			Retries:    getEnvInt("MANGA_SYNC_NOTIFY_RETRIES", ingestion.DefaultNotifyRetries),
+			Timeout:    getEnvDuration("MANGA_SYNC_NOTIFY_TIMEOUT", ingestion.DefaultNotifyTimeout),
+			BufferSize: getEnvInt("MANGA_SYNC_NOTIFY_BUFFER", ingestion.DefaultNotifyBufferSize),
+			SigningKey: getEnv("INTERNAL_SIGNING_KEY", ""),
+		},
+		NewMangaPollAt: pollAt,
+		SourcePriority: sourcePriority,
+		DefaultStatus:  defaultStatus,
 	}
 
 	log.Println("[Config] Loaded configuration:")
@@ -48,11 +96,24 @@ func main() {
 	log.Printf("  - Initial Sync Limit: %d", config.InitialSyncLimit)
 	log.Printf("  - Worker Count: %d", config.WorkerCount)
 	log.Printf("  - Rate Concurrency: %d", config.RateConcurrency)
+	log.Printf("  - HTTP Timeout: %s", config.HTTP.Timeout)
 
 	// Create sync service
 	syncService := mangadex.NewSyncService(config, db)
 	log.Println("[SyncService] ✅ Service initialized")
 
+	// Expose sync status (sync states + circuit breaker) over HTTP
+	statusAddr := ":" + getEnv("MANGA_SYNC_STATUS_PORT", "8086")
+	go func() {
+		mux := ingestion.NewStatusMux(func(ctx context.Context, opts ingestion.StatusOptions) (any, error) {
+			return syncService.Status(ctx, opts)
+		})
+		log.Printf("[Status] Listening on %s/status", statusAddr)
+		if err := http.ListenAndServe(statusAddr, mux); err != nil {
+			log.Printf("[Status] Server error: %v", err)
+		}
+	}()
+
 	// Create context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -75,11 +136,16 @@ func main() {
 		log.Println("   Running Initial Sync")
 		log.Println("===========================================")
 
-		if err := syncService.RunInitialSync(ctx); err != nil {
+		result, err := syncService.RunInitialSync(ctx)
+		switch {
+		case err != nil:
 			log.Printf("[InitialSync] Error: %v", err)
-		} else {
+		case result.HasFailures():
+			log.Println("[InitialSync] ⚠️  Initial sync completed with failures")
+		default:
 			log.Println("[InitialSync] ✅ Initial sync completed successfully!")
 		}
+		logSyncResult("InitialSync", result)
 	} else {
 		log.Println("[InitialSync] Skipped (MANGA_SYNC_INITIAL=false)")
 	}
@@ -88,7 +154,11 @@ func main() {
 	log.Println("===========================================")
 	log.Println("   Starting Scheduled Pollers")
 	log.Println("===========================================")
-	log.Println("  • New Manga Poll: Every 24 hours")
+	if pollAt != nil {
+		log.Printf("  • New Manga Poll: Daily at %s", pollAt)
+	} else {
+		log.Println("  • New Manga Poll: Every 24 hours")
+	}
 	log.Println("  • Chapter Updates: Every 48 hours (2 days)")
 	log.Println("===========================================")
 
@@ -122,6 +192,15 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
 func getEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if value == "true" || value == "1" || value == "yes" {
@@ -143,3 +222,14 @@ func maskAPIKey(apiKey string) string {
 	}
 	return apiKey[:4] + "..." + apiKey[len(apiKey)-4:]
 }
+
+// logSyncResult prints a run's counts and the items that failed
+func logSyncResult(label string, result ingestion.SyncResult) {
+	log.Printf("[%s] Synced: %d, Failed: %d", label, result.Synced, result.Failed)
+	for _, e := range result.Errors {
+		log.Printf("  - %s: %s", e.Item, e.Error)
+	}
+	if omitted := result.Failed - len(result.Errors); omitted > 0 {
+		log.Printf("  ... and %d more", omitted)
+	}
+}
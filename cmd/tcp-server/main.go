@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
 	"fmt"
 	"log"
 	"log/slog"
 	"mangahub/internal/config"
+	"mangahub/internal/lifecycle"
 	"mangahub/internal/microservices/tcp"
 	"os"
 	"os/signal"
@@ -15,6 +17,7 @@ import (
 	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib" // PostgreSQL driver
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
@@ -31,6 +34,19 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	// Optional TLS; without a cert and key the server stays plaintext for local dev
+	var tlsConfig *tls.Config
+	if cfg.TCPTLSCert != "" || cfg.TCPTLSKey != "" {
+		if cfg.TCPTLSCert == "" || cfg.TCPTLSKey == "" {
+			log.Fatal("TCP_TLS_CERT and TCP_TLS_KEY must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.TCPTLSCert, cfg.TCPTLSKey)
+		if err != nil {
+			log.Fatalf("Failed to load TCP TLS certificate: %v", err)
+		}
+		tlsConfig = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	}
+
 	// Build TCP address from config
 	// Use 0.0.0.0 to accept connections from outside the container
 	tcpAddr := fmt.Sprintf("0.0.0.0:%d", cfg.TCPPort)
@@ -43,8 +59,18 @@ func main() {
 	logger.Info("starting_tcp_server",
 		"tcp_addr", tcpAddr,
 		"redis_addr", redisAddr,
+		"tls", tlsConfig != nil,
 	)
 
+	// Wait for Redis before the progress repository pings it once
+	if err := lifecycle.WaitFor("redis", cfg.StartupWaitTimeout, func(ctx context.Context) error {
+		rdb := redis.NewClient(&redis.Options{Addr: redisAddr})
+		defer rdb.Close()
+		return rdb.Ping(ctx).Err()
+	}); err != nil {
+		log.Fatalf("Failed to connect to Redis: %v", err)
+	}
+
 	// Connect to PostgreSQL for hybrid storage
 	dbURL := os.Getenv("DATABASE_URL")
 	var server *tcp.TCPServer
@@ -59,13 +85,10 @@ func main() {
 		}
 		defer db.Close()
 
-		// Test database connection
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		if err := db.PingContext(ctx); err != nil {
-			cancel()
+		// Test database connection, waiting for Postgres to come up
+		if err := lifecycle.WaitFor("postgres", cfg.StartupWaitTimeout, db.PingContext); err != nil {
 			log.Fatalf("Failed to ping database: %v", err)
 		}
-		cancel()
 
 		// Configure connection pool
 		db.SetMaxOpenConns(25)
@@ -85,6 +108,10 @@ func main() {
 	if server == nil {
 		log.Fatal("Failed to create TCP server")
 	}
+	server.MaxConnections = cfg.TCPMaxConnections
+	server.MetricsInterval = cfg.TCPMetricsInterval
+	server.TLSConfig = tlsConfig
+	logger.Info("connection_limit", "max_connections", cfg.TCPMaxConnections)
 
 	// Handle graceful shutdown
 	sigChan := make(chan os.Signal, 1)
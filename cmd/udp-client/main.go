@@ -36,6 +36,7 @@ type authResponse struct {
 type subscribeRequest struct {
 	Type   string `json:"type"`
 	UserID string `json:"user_id"`
+	Token  string `json:"token,omitempty"`
 }
 
 func main() {
@@ -83,7 +84,7 @@ func main() {
 	}
 	defer conn.Close()
 
-	sub := subscribeRequest{Type: "SUBSCRIBE", UserID: auth.UserID}
+	sub := subscribeRequest{Type: "SUBSCRIBE", UserID: auth.UserID, Token: auth.AccessToken}
 	subBytes, _ := json.Marshal(sub)
 
 	if _, err := conn.Write(subBytes); err != nil {
@@ -117,6 +118,27 @@ func main() {
 				continue
 			}
 
+			// Server dropped our subscription (e.g. missed pings): subscribe again
+			if notification["type"] == "RESUBSCRIBE" {
+				log.Println("subscription expired, re-subscribing")
+				_, _ = conn.Write(subBytes)
+				continue
+			}
+			if notification["type"] == "PONG" {
+				continue
+			}
+
+			// Batched datagrams carry several notifications
+			if notification["type"] == "BATCH" {
+				events, _ := notification["events"].([]interface{})
+				for _, event := range events {
+					if eventMap, ok := event.(map[string]interface{}); ok {
+						displayNotification(eventMap)
+					}
+				}
+				continue
+			}
+
 			// Display notification with clear formatting
 			displayNotification(notification)
 		}
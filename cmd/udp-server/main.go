@@ -9,8 +9,11 @@ import (
 	"time"
 
 	"mangahub/database"
+	"mangahub/internal/config"
 	"mangahub/internal/microservices/http-api/repository"
+	"mangahub/internal/microservices/http-api/service"
 	udp "mangahub/internal/microservices/udp-server"
+	"mangahub/internal/shared"
 )
 
 func main() {
@@ -33,8 +36,29 @@ func main() {
 	notificationRepo := repository.NewNotificationRepository(db)
 	userRepo := repository.NewUserRepository(db)
 
+	// Subscriptions not refreshed by a PING within the TTL are evicted
+	cfg := udp.Config{
+		SubscriptionTTL: getEnvDuration("UDP_SUBSCRIPTION_TTL", udp.DefaultSubscriptionTTL),
+		SweepInterval:   getEnvDuration("UDP_SWEEP_INTERVAL", udp.DefaultSweepInterval),
+	}
+	log.Printf("Subscription TTL %s, sweep every %s", cfg.SubscriptionTTL, cfg.SweepInterval)
+
+	// SUBSCRIBE requires an access token issued by the API server
+	appCfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	if err := appCfg.Validate(); err != nil {
+		log.Fatalf("Invalid config: %v", err)
+	}
+	cfg.Auth = service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(db), appCfg)
+
+	// Broadcasts are also POSTed to the webhooks users registered through the API
+	cfg.Webhooks = udp.NewWebhookDispatcher(repository.NewWebhookRepository(db),
+		appCfg.WebhookTimeout, appCfg.WebhookRetries, appCfg.WebhookMaxFailures, appCfg.WebhookAllowPrivate)
+
 	// Create and start UDP server
-	server, err := udp.NewServer(port, libraryRepo, notificationRepo, userRepo)
+	server, err := udp.NewServerWithConfig(port, cfg, libraryRepo, notificationRepo, userRepo)
 	if err != nil {
 		log.Fatalf("Failed to create UDP server: %v", err)
 	}
@@ -111,35 +135,27 @@ func main() {
 				return
 			}
 			var payload struct {
-				MangaID         int64         `json:"manga_id"`
-				Title           string        `json:"title"`
-				Changes         []string      `json:"changes"`
-				DetailedChanges []interface{} `json:"detailed_changes,omitempty"`
+				MangaID         int64             `json:"manga_id"`
+				Title           string            `json:"title"`
+				Changes         []string          `json:"changes"`
+				DetailedChanges []json.RawMessage `json:"detailed_changes,omitempty"`
 			}
 			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
+			// Reject malformed entries up front rather than trusting their shape
+			fieldChanges, err := shared.DecodeFieldChanges(payload.DetailedChanges)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
 
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
 
 			// Use detailed notification if available
-			if len(payload.DetailedChanges) > 0 {
-				// Convert to []udp.FieldChange
-				fieldChanges := make([]udp.FieldChange, 0, len(payload.DetailedChanges))
-				for _, dc := range payload.DetailedChanges {
-					if dcMap, ok := dc.(map[string]interface{}); ok {
-						fc := udp.FieldChange{
-							Field:    dcMap["field"].(string),
-							NewValue: dcMap["new_value"],
-						}
-						if oldVal, ok := dcMap["old_value"]; ok {
-							fc.OldValue = oldVal
-						}
-						fieldChanges = append(fieldChanges, fc)
-					}
-				}
+			if len(fieldChanges) > 0 {
 				if err := server.NotifyMangaUpdateWithDetails(ctx, payload.MangaID, payload.Title, fieldChanges); err != nil {
 					http.Error(w, err.Error(), http.StatusInternalServerError)
 					return
@@ -156,7 +172,21 @@ func main() {
 
 		addr := ":" + httpPort
 		log.Printf("HTTP trigger for UDP server listening on %s", addr)
-		if err := http.ListenAndServe(addr, mux); err != nil {
+		if appCfg.InternalToken == "" {
+			log.Printf("WARNING: INTERNAL_TOKEN not set, HTTP trigger is unauthenticated")
+		}
+		// Retried calls carrying an already-handled Idempotency-Key are not re-broadcast
+		idempotency := udp.NewIdempotencyCache(getEnvDuration("UDP_IDEMPOTENCY_TTL", udp.DefaultIdempotencyTTL))
+		handler := udp.Idempotent(idempotency, mux)
+
+		// Signed requests can't be tampered with or replayed
+		if appCfg.InternalSigningKey == "" {
+			log.Printf("WARNING: INTERNAL_SIGNING_KEY not set, HTTP trigger requests are not signature-checked")
+		}
+		handler = udp.RequireSignature(appCfg.InternalSigningKey, appCfg.InternalSignatureWindow, handler)
+
+		// Only services holding the shared secret may trigger broadcasts
+		if err := http.ListenAndServe(addr, udp.RequireInternalToken(appCfg.InternalToken, handler)); err != nil {
 			log.Fatalf("HTTP trigger server error: %v", err)
 		}
 	}()
@@ -166,3 +196,14 @@ func main() {
 		log.Fatalf("UDP server error: %v", err)
 	}
 }
+
+// getEnvDuration parses a duration env var like "90s" or "2m"
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return d
+		}
+		log.Printf("invalid %s=%q, using %s", key, value, defaultValue)
+	}
+	return defaultValue
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"time"
 
+	"mangahub/internal/lifecycle"
+
 	"github.com/jackc/pgx/v5/pgxpool"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -49,23 +51,46 @@ func Close() {
 
 // OpenGorm opens a gorm.DB from DATABASE_URL env and configures connection pool.
 func OpenGorm() (*gorm.DB, error) {
+	return OpenGormWait(0)
+}
+
+// OpenGormWait is OpenGorm for services started alongside the database: it
+// retries the first ping with backoff for up to timeout before giving up.
+func OpenGormWait(timeout time.Duration) (*gorm.DB, error) {
 	dsn := os.Getenv("DATABASE_URL")
 	if dsn == "" || dsn == "none" {
 		return nil, fmt.Errorf("DATABASE_URL not set")
 	}
 
+	// The ping is left to WaitFor so a failed attempt doesn't leak a pool
 	gormLogger := logger.Default.LogMode(logger.Silent)
 	gdb, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: gormLogger,
+		Logger:               gormLogger,
+		DisableAutomaticPing: true,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// SLOW_QUERY_THRESHOLD turns on logging of statements slower than it
+	threshold, err := SlowQueryThresholdFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if threshold > 0 {
+		if err := gdb.Use(&SlowQueryLogger{Threshold: threshold}); err != nil {
+			return nil, err
+		}
+	}
+
 	sqlDB, err := gdb.DB()
 	if err != nil {
 		return nil, err
 	}
+	if err := lifecycle.WaitFor("postgres", timeout, sqlDB.PingContext); err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
 	sqlDB.SetMaxOpenConns(25)
 	sqlDB.SetMaxIdleConns(5)
 	sqlDB.SetConnMaxLifetime(1 * time.Hour)
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Versioned migrations live in migrations/SQL as NNN_name.up.sql / NNN_name.down.sql.
+// Applied versions are recorded in the schema_migrations table.
+//
+//go:embed migrations/SQL/*.sql
+var migrationFiles embed.FS
+
+const migrationsDir = "migrations/SQL"
+
+// baselineVersion is the last migration that databases created before
+// versioning existed (via AutoMigrate or docker init scripts) already have.
+const baselineVersion = 2
+
+// SchemaMigration is a row of the schema_migrations table
+type SchemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null;default:now()"`
+}
+
+func (SchemaMigration) TableName() string { return "schema_migrations" }
+
+// migration is a single numbered migration loaded from the embedded files
+type migration struct {
+	Version int
+	Name    string
+	UpFile  string
+}
+
+// loadMigrations returns the embedded up migrations sorted by version
+func loadMigrations() ([]migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, migrationsDir)
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	seen := make(map[int]string)
+	var list []migration
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+
+		base := strings.TrimSuffix(name, ".up.sql")
+		prefix, label, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s: expected NNN_name.up.sql", name)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: invalid version: %w", name, err)
+		}
+		if other, dup := seen[version]; dup {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, other, name)
+		}
+		seen[version] = name
+
+		list = append(list, migration{Version: version, Name: label, UpFile: path.Join(migrationsDir, name)})
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+// Migrate applies every pending migration in version order, each inside its
+// own transaction. Databases that predate versioning are baselined: migrations
+// up to baselineVersion are recorded as applied without being run, since
+// 001_init would otherwise drop the existing tables.
+func Migrate(db *gorm.DB) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	migrator := db.Migrator()
+	fresh := !migrator.HasTable(&SchemaMigration{})
+	legacy := fresh && migrator.HasTable("users")
+
+	if err := migrator.AutoMigrate(&SchemaMigration{}); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	var applied []SchemaMigration
+	if err := db.Find(&applied).Error; err != nil {
+		return fmt.Errorf("load applied migrations: %w", err)
+	}
+	done := make(map[int]bool, len(applied))
+	for _, m := range applied {
+		done[m.Version] = true
+	}
+
+	for _, m := range migrations {
+		if done[m.Version] {
+			continue
+		}
+
+		if legacy && m.Version <= baselineVersion {
+			log.Printf("migrate: baselining %03d_%s (schema already present)", m.Version, m.Name)
+			if err := db.Create(&SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error; err != nil {
+				return fmt.Errorf("baseline migration %d: %w", m.Version, err)
+			}
+			continue
+		}
+
+		body, err := migrationFiles.ReadFile(m.UpFile)
+		if err != nil {
+			return fmt.Errorf("read migration %d: %w", m.Version, err)
+		}
+
+		log.Printf("migrate: applying %03d_%s", m.Version, m.Name)
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(string(body)).Error; err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("apply migration %03d_%s: %w", m.Version, m.Name, err)
+		}
+	}
+
+	return nil
+}
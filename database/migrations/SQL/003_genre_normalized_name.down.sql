@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_genres_normalized_name;
+ALTER TABLE genres DROP COLUMN IF EXISTS normalized_name;
@@ -0,0 +1,29 @@
+-- Case/accent-insensitive genre uniqueness.
+-- name stays the display name; normalized_name is the lowercased, accent-stripped,
+-- whitespace-collapsed key that must be unique (see models.NormalizeGenreName).
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+ALTER TABLE genres ADD COLUMN IF NOT EXISTS normalized_name TEXT;
+
+UPDATE genres
+SET normalized_name = lower(regexp_replace(btrim(unaccent(name)), '\s+', ' ', 'g'));
+
+-- Merge near-duplicates ("Action" / "action ") into the lowest id before enforcing uniqueness
+WITH ranked AS (
+    SELECT id, MIN(id) OVER (PARTITION BY normalized_name) AS keep_id
+    FROM genres
+)
+INSERT INTO manga_genres (manga_id, genre_id)
+SELECT mg.manga_id, r.keep_id
+FROM manga_genres mg
+JOIN ranked r ON r.id = mg.genre_id
+WHERE r.id <> r.keep_id
+ON CONFLICT (manga_id, genre_id) DO NOTHING;
+
+DELETE FROM genres g
+USING genres keep
+WHERE keep.normalized_name = g.normalized_name
+  AND keep.id < g.id;
+
+ALTER TABLE genres ALTER COLUMN normalized_name SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_genres_normalized_name ON genres(normalized_name);
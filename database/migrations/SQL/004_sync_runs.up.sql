@@ -0,0 +1,16 @@
+-- History of sync runs (one row per RunInitialSync / poll / chapter check),
+-- unlike sync_state which only holds the current status per sync type.
+CREATE TABLE IF NOT EXISTS sync_runs (
+    id BIGSERIAL PRIMARY KEY,
+    source TEXT NOT NULL,    -- 'mangadex', 'anilist'
+    sync_type TEXT NOT NULL, -- 'initial_sync', 'new_manga_poll', 'chapter_check'
+    status TEXT NOT NULL,    -- 'completed', 'error'
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    synced_count INTEGER NOT NULL DEFAULT 0,
+    error_count INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_runs_source_started ON sync_runs(source, started_at DESC);
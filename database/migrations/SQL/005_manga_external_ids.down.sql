@@ -0,0 +1,3 @@
+-- The columns and indexes are shared with 001_init and used by the ingestion
+-- services, so rolling back this migration leaves them in place.
+SELECT 1;
@@ -0,0 +1,8 @@
+-- External source IDs used by GET /api/manga/external/:source/:external_id.
+-- 001_init already creates these (no-op there); this covers databases that
+-- were built by AutoMigrate before the ingestion columns existed.
+ALTER TABLE manga ADD COLUMN IF NOT EXISTS mangadex_id UUID UNIQUE;
+ALTER TABLE manga ADD COLUMN IF NOT EXISTS anilist_id INTEGER UNIQUE;
+
+CREATE INDEX IF NOT EXISTS idx_manga_mangadex_id ON manga(mangadex_id);
+CREATE INDEX IF NOT EXISTS idx_manga_anilist_id ON manga(anilist_id);
@@ -0,0 +1,3 @@
+-- Scope narrowed at login (space-separated). Empty means the role's default
+-- scopes, which is what every existing token was issued with.
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS scope TEXT NOT NULL DEFAULT '';
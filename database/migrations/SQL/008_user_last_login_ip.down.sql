@@ -0,0 +1,2 @@
+-- last_login predates this migration and is left in place
+ALTER TABLE users DROP COLUMN IF EXISTS last_login_ip;
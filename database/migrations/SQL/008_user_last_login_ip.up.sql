@@ -0,0 +1,4 @@
+-- users.last_login already exists (001_init) but was never written; the API
+-- now sets it on each login alongside the client IP.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_login TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_login_ip TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,17 @@
+-- User reports of wrong manga metadata (usually from ingestion), reviewed by
+-- admins who either resolve or dismiss them.
+CREATE TABLE IF NOT EXISTS metadata_reports (
+    id BIGSERIAL PRIMARY KEY,
+    manga_id BIGINT NOT NULL REFERENCES manga(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    field TEXT NOT NULL,                 -- 'title', 'author', 'status', ...
+    reason TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'open', -- 'open', 'resolved', 'dismissed'
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_metadata_reports_status_created ON metadata_reports(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_metadata_reports_manga_id ON metadata_reports(manga_id);
@@ -0,0 +1,2 @@
+-- fails if any stored AniList ID no longer fits in INTEGER
+ALTER TABLE manga ALTER COLUMN anilist_id TYPE INTEGER;
@@ -0,0 +1,3 @@
+-- Manga IDs are BIGINT everywhere else; widen the AniList external ID to
+-- match the int64 used by the models and ingestion structs.
+ALTER TABLE manga ALTER COLUMN anilist_id TYPE BIGINT;
@@ -0,0 +1,3 @@
+-- The unique index may be 001_init's constraint, so it is left in place;
+-- deleted duplicate chapters are not restored.
+SELECT 1;
@@ -0,0 +1,11 @@
+-- Chapter storage upserts on (manga_id, chapter_number). 001_init declares
+-- this constraint, but schemas built by AutoMigrate don't have it, and
+-- re-run chapter checks could have stored the same chapter twice there.
+DELETE FROM chapters a
+    USING chapters b
+    WHERE a.manga_id = b.manga_id
+      AND a.chapter_number = b.chapter_number
+      AND a.id > b.id;
+
+-- Same name as 001_init's constraint index, so this is a no-op where it exists
+CREATE UNIQUE INDEX IF NOT EXISTS chapters_manga_id_chapter_number_key ON chapters(manga_id, chapter_number);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_manga_view_count;
+ALTER TABLE manga DROP COLUMN IF EXISTS view_count;
@@ -0,0 +1,4 @@
+-- Detail page views, incremented in batches by the API server
+ALTER TABLE manga ADD COLUMN IF NOT EXISTS view_count BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_manga_view_count ON manga(view_count DESC);
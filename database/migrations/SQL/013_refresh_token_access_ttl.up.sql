@@ -0,0 +1,3 @@
+-- Access-token lifetime a client asked for at login (token_ttl), in seconds.
+-- 0 means the server default; refreshed sessions keep the same hint.
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS access_token_ttl BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- Cancelled series have stopped for good; completed is the closest old status
+UPDATE manga SET status = 'completed' WHERE status = 'cancelled';
+ALTER TABLE manga DROP CONSTRAINT IF EXISTS manga_status_check;
+ALTER TABLE manga ADD CONSTRAINT manga_status_check CHECK (status IN ('ongoing', 'completed', 'hiatus'));
@@ -0,0 +1,4 @@
+-- MangaDex and AniList report cancelled series; allow it alongside the
+-- original statuses (models.MangaStatuses must match this list)
+ALTER TABLE manga DROP CONSTRAINT IF EXISTS manga_status_check;
+ALTER TABLE manga ADD CONSTRAINT manga_status_check CHECK (status IN ('ongoing', 'completed', 'hiatus', 'cancelled'));
@@ -0,0 +1,4 @@
+-- An unknown status is the same as no status at all
+UPDATE manga SET status = NULL WHERE status = 'unknown';
+ALTER TABLE manga DROP CONSTRAINT IF EXISTS manga_status_check;
+ALTER TABLE manga ADD CONSTRAINT manga_status_check CHECK (status IN ('ongoing', 'completed', 'hiatus', 'cancelled'));
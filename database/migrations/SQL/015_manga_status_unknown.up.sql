@@ -0,0 +1,4 @@
+-- Ingestion can store 'unknown' when a source sends a status we don't map
+-- (models.MangaStatuses must match this list)
+ALTER TABLE manga DROP CONSTRAINT IF EXISTS manga_status_check;
+ALTER TABLE manga ADD CONSTRAINT manga_status_check CHECK (status IN ('ongoing', 'completed', 'hiatus', 'cancelled', 'unknown'));
@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN IF EXISTS library_public;
+ALTER TABLE users DROP COLUMN IF EXISTS profile_public;
@@ -0,0 +1,4 @@
+-- Public profiles (GET /api/users/:username) are visible unless the user
+-- hides them; library size is shown only when the user opts in.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS profile_public BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS library_public BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_user_library_user_added;
+DROP INDEX IF EXISTS idx_comments_user_created;
+DROP INDEX IF EXISTS idx_ratings_user_updated;
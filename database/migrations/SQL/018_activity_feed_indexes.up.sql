@@ -0,0 +1,5 @@
+-- The activity feed reads each followed user's recent ratings, comments and
+-- library additions; these let it range-scan by time per user.
+CREATE INDEX IF NOT EXISTS idx_ratings_user_updated ON ratings(user_id, updated_at DESC);
+CREATE INDEX IF NOT EXISTS idx_comments_user_created ON comments(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_user_library_user_added ON user_library(user_id, added_at DESC);
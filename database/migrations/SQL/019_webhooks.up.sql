@@ -0,0 +1,18 @@
+-- User-registered endpoints the UDP server POSTs matching notifications to,
+-- signed with the webhook's secret. A webhook that keeps failing is
+-- deactivated until its owner re-enables it.
+CREATE TABLE IF NOT EXISTS webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    events TEXT NOT NULL,                    -- comma separated, e.g. 'NEW_CHAPTER,MANGA_UPDATE'
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    failure_count INT NOT NULL DEFAULT 0,    -- consecutive failed deliveries
+    last_error TEXT NOT NULL DEFAULT '',
+    last_failure_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_user_id ON webhooks(user_id);
@@ -9,11 +9,12 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"strings"
 	"sync"
 	"time"
 
 	"golang.org/x/time/rate"
+
+	mangaslug "mangahub/internal/shared/slug"
 )
 
 // MangaDex API Base URL
@@ -23,6 +24,16 @@ const (
 	GenreEndpoint   = "/manga/tag"
 )
 
+// httpClient is shared by all fetches so concurrent batches reuse connections
+var httpClient = &http.Client{
+	Timeout: 30 * time.Second,
+	Transport: &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	},
+}
+
 // MangaDex API response structures
 type MangaDexResponse struct {
 	Result   string      `json:"result"`
@@ -133,8 +144,7 @@ func scrapeGenres(apiKey string) ([]Genre, error) {
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch genres: %w", err)
 	}
@@ -279,8 +289,7 @@ func fetchMangaBatch(apiKey string, limit, offset int) ([]Manga, error) {
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch manga: %w", err)
 	}
@@ -374,7 +383,7 @@ func convertToManga(data MangaData) Manga {
 	}
 
 	// Generate slug from title
-	slug := generateSlug(title)
+	slug := mangaslug.WithFallback(title, "mangadex-"+data.ID)
 
 	// Cover URL
 	coverURL := fmt.Sprintf("https://mangadex.org/covers/%s.jpg", data.ID)
@@ -392,31 +401,6 @@ func convertToManga(data MangaData) Manga {
 	}
 }
 
-func generateSlug(title string) string {
-	// Convert to lowercase and replace spaces with hyphens
-	slug := strings.ToLower(title)
-	slug = strings.ReplaceAll(slug, " ", "-")
-
-	// Remove special characters
-	var result strings.Builder
-	for _, char := range slug {
-		if (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') || char == '-' {
-			result.WriteRune(char)
-		}
-	}
-
-	// Remove multiple consecutive hyphens
-	slug = result.String()
-	for strings.Contains(slug, "--") {
-		slug = strings.ReplaceAll(slug, "--", "-")
-	}
-
-	// Trim hyphens from start and end
-	slug = strings.Trim(slug, "-")
-
-	return slug
-}
-
 func saveToJSON(data ScrapedData, filename string) error {
 	file, err := os.Create(filename)
 	if err != nil {
@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	_ "github.com/lib/pq"
+
+	mangaslug "mangahub/internal/shared/slug"
 )
 
 // Structures matching the JSON file
@@ -171,7 +173,7 @@ func importMangas(tx *sql.Tx, mangas []Manga, genreIDMap map[string]int) (int, i
 		// Generate unique slug if needed
 		slug := manga.Slug
 		if slug == "" {
-			slug = generateSlug(manga.Title)
+			slug = mangaslug.WithFallback(manga.Title, "mangadex-"+manga.ID)
 		}
 
 		// Insert manga
@@ -221,28 +223,3 @@ func importMangas(tx *sql.Tx, mangas []Manga, genreIDMap map[string]int) (int, i
 
 	return mangaCount, relationCount, nil
 }
-
-func generateSlug(title string) string {
-	// Convert to lowercase and replace spaces with hyphens
-	slug := strings.ToLower(title)
-	slug = strings.ReplaceAll(slug, " ", "-")
-
-	// Remove special characters
-	var result strings.Builder
-	for _, char := range slug {
-		if (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') || char == '-' {
-			result.WriteRune(char)
-		}
-	}
-
-	// Remove multiple consecutive hyphens
-	slug = result.String()
-	for strings.Contains(slug, "--") {
-		slug = strings.ReplaceAll(slug, "--", "-")
-	}
-
-	// Trim hyphens from start and end
-	slug = strings.Trim(slug, "-")
-
-	return slug
-}
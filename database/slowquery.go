@@ -0,0 +1,91 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const slowQueryStartKey = "slow_query:start"
+
+// SlowQueryLogger is a GORM plugin that logs statements slower than
+// Threshold with their SQL and duration. Bind values are left out so
+// credentials and user data don't end up in the logs.
+type SlowQueryLogger struct {
+	Threshold time.Duration
+	Logger    *slog.Logger // nil uses slog.Default()
+}
+
+// SlowQueryThresholdFromEnv reads SLOW_QUERY_THRESHOLD (e.g. 200ms). Unset
+// or 0 disables slow-query logging.
+func SlowQueryThresholdFromEnv() (time.Duration, error) {
+	value := os.Getenv("SLOW_QUERY_THRESHOLD")
+	if value == "" {
+		return 0, nil
+	}
+	threshold, err := time.ParseDuration(value)
+	if err != nil || threshold < 0 {
+		return 0, fmt.Errorf("invalid SLOW_QUERY_THRESHOLD %q", value)
+	}
+	return threshold, nil
+}
+
+func (p *SlowQueryLogger) Name() string { return "slow_query_logger" }
+
+// Initialize times every create, query, update, delete, row and raw statement
+func (p *SlowQueryLogger) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("slow_query:before_create", p.start),
+		cb.Create().After("gorm:create").Register("slow_query:after_create", p.finish),
+		cb.Query().Before("gorm:query").Register("slow_query:before_query", p.start),
+		cb.Query().After("gorm:query").Register("slow_query:after_query", p.finish),
+		cb.Update().Before("gorm:update").Register("slow_query:before_update", p.start),
+		cb.Update().After("gorm:update").Register("slow_query:after_update", p.finish),
+		cb.Delete().Before("gorm:delete").Register("slow_query:before_delete", p.start),
+		cb.Delete().After("gorm:delete").Register("slow_query:after_delete", p.finish),
+		cb.Row().Before("gorm:row").Register("slow_query:before_row", p.start),
+		cb.Row().After("gorm:row").Register("slow_query:after_row", p.finish),
+		cb.Raw().Before("gorm:raw").Register("slow_query:before_raw", p.start),
+		cb.Raw().After("gorm:raw").Register("slow_query:after_raw", p.finish),
+	)
+}
+
+func (p *SlowQueryLogger) start(db *gorm.DB) {
+	db.InstanceSet(slowQueryStartKey, time.Now())
+}
+
+func (p *SlowQueryLogger) finish(db *gorm.DB) {
+	v, ok := db.InstanceGet(slowQueryStartKey)
+	if !ok {
+		return
+	}
+	started, ok := v.(time.Time)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(started)
+	if elapsed < p.Threshold {
+		return
+	}
+
+	logger := p.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	attrs := []any{
+		"duration_ms", elapsed.Milliseconds(),
+		"threshold_ms", p.Threshold.Milliseconds(),
+		"table", db.Statement.Table,
+		"rows", db.Statement.RowsAffected,
+		"sql", db.Statement.SQL.String(),
+	}
+	if db.Error != nil {
+		attrs = append(attrs, "error", db.Error)
+	}
+	logger.WarnContext(db.Statement.Context, "slow query", attrs...)
+}
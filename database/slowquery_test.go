@@ -0,0 +1,71 @@
+package database
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type slowQueryRow struct {
+	ID    int64
+	Email string
+}
+
+// dryRunDB builds statements without a server, so callbacks run but nothing executes
+func dryRunDB(t *testing.T, plugin *SlowQueryLogger) *gorm.DB {
+	t.Helper()
+	gdb, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=none"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, gdb.Use(plugin))
+	return gdb
+}
+
+func TestSlowQueryLogger_LogsStatementsOverThreshold(t *testing.T) {
+	var buf bytes.Buffer
+	gdb := dryRunDB(t, &SlowQueryLogger{Logger: slog.New(slog.NewTextHandler(&buf, nil))})
+
+	var rows []slowQueryRow
+	gdb.Where("email = ?", "reader@example.com").Find(&rows)
+
+	out := buf.String()
+	assert.Contains(t, out, "slow query")
+	assert.Contains(t, out, "slow_query_rows")
+	assert.NotContains(t, out, "reader@example.com", "bind values must not be logged")
+}
+
+func TestSlowQueryLogger_SkipsFastStatements(t *testing.T) {
+	var buf bytes.Buffer
+	gdb := dryRunDB(t, &SlowQueryLogger{Threshold: time.Hour, Logger: slog.New(slog.NewTextHandler(&buf, nil))})
+
+	var rows []slowQueryRow
+	gdb.Find(&rows)
+
+	assert.Empty(t, buf.String())
+}
+
+func TestSlowQueryThresholdFromEnv(t *testing.T) {
+	t.Setenv("SLOW_QUERY_THRESHOLD", "")
+	threshold, err := SlowQueryThresholdFromEnv()
+	assert.NoError(t, err)
+	assert.Zero(t, threshold)
+
+	t.Setenv("SLOW_QUERY_THRESHOLD", "250ms")
+	threshold, err = SlowQueryThresholdFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, 250*time.Millisecond, threshold)
+
+	t.Setenv("SLOW_QUERY_THRESHOLD", "soon")
+	_, err = SlowQueryThresholdFromEnv()
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strconv"
 	"strings"
@@ -21,17 +22,57 @@ type Config struct {
 	UDPPort  int `env:"UDP_PORT" default:"8082"`
 	GRPCPort int `env:"GRPC_PORT" default:"8083"`
 
+	// Deadline for gRPC calls whose client doesn't set one
+	GRPCDefaultDeadline time.Duration `env:"GRPC_DEFAULT_DEADLINE" default:"10s"`
+
+	// Connection caps for the real-time servers (0 = unlimited)
+	TCPMaxConnections int `env:"TCP_MAX_CONNECTIONS" default:"1000"`
+	WSMaxConnections  int `env:"WS_MAX_CONNECTIONS" default:"1000"`
+
+	// How often the TCP server logs its metrics (negative disables)
+	TCPMetricsInterval time.Duration `env:"TCP_METRICS_INTERVAL" default:"1m"`
+
+	// TLS for the TCP sync server; setting both enables it, neither keeps
+	// plaintext for local dev
+	TCPTLSCert string `env:"TCP_TLS_CERT" default:""`
+	TCPTLSKey  string `env:"TCP_TLS_KEY" default:""`
+
 	// Database
 	DatabaseURL string `env:"DATABASE_URL" default:"/app/data/mangahub.db"`
 	SQLitePath  string `env:"SQLITE_PATH" default:"/app/data/mangahub.db"` //(redundant now)
+	AutoMigrate bool   `env:"DB_AUTO_MIGRATE" default:"false"`             // GORM AutoMigrate on top of versioned migrations (local dev only)
 
 	// Authentication
 	JWTSecret string        `env:"JWT_SECRET" required:"true"`
 	JWTExpiry time.Duration `env:"JWT_EXPIRY" default:"24h"`
 
+	// Password policy for new accounts
+	PasswordMinLength      int  `env:"PASSWORD_MIN_LENGTH" default:"8"`
+	PasswordRequireDigit   bool `env:"PASSWORD_REQUIRE_DIGIT" default:"false"`
+	PasswordRequireUpper   bool `env:"PASSWORD_REQUIRE_UPPER" default:"false"`
+	PasswordRequireSpecial bool `env:"PASSWORD_REQUIRE_SPECIAL" default:"false"`
+
+	// Comment content rules. Length is counted in characters after trimming.
+	// Comments containing a COMMENT_BLOCKED_WORDS entry are rejected, or have
+	// the word masked with COMMENT_FILTER_MODE=mask.
+	CommentMinLength    int      `env:"COMMENT_MIN_LENGTH" default:"1"`
+	CommentMaxLength    int      `env:"COMMENT_MAX_LENGTH" default:"5000"`
+	CommentBlockedWords []string `env:"COMMENT_BLOCKED_WORDS"`
+	CommentFilterMode   string   `env:"COMMENT_FILTER_MODE" default:"reject"`
+
+	// Per-user limits on posting comments and ratings, in requests per
+	// minute with a short burst on top (0 disables). Ratings get more room
+	// since rating a run of manga back to back is normal.
+	CommentRateLimit int `env:"COMMENT_RATE_LIMIT" default:"5"`
+	CommentRateBurst int `env:"COMMENT_RATE_BURST" default:"3"`
+	RatingRateLimit  int `env:"RATING_RATE_LIMIT" default:"30"`
+	RatingRateBurst  int `env:"RATING_RATE_BURST" default:"10"`
+
 	// Token TTLs
 	AccessTokenTTL  time.Duration `env:"ACCESS_TOKEN_TTL" required:"true" default:"15m"`
 	RefreshTokenTTL time.Duration `env:"REFRESH_TOKEN_TTL" required:"true" default:"7day"`
+	// Upper bound for the token_ttl a client may ask for at login
+	AccessTokenMaxTTL time.Duration `env:"ACCESS_TOKEN_MAX_TTL" default:"24h"`
 
 	// Redis Cache
 	RedisURL      string `env:"REDIS_URL" default:"redis://redis:6379"`
@@ -51,17 +92,116 @@ type Config struct {
 	LogFormat   string   `env:"LOG_FORMAT" default:"text"`
 	CORSOrigins []string `env:"CORS_ORIGINS" default:"http://localhost:3000,http://localhost:8084"`
 
+	// Origins allowed to read the public catalog (manga and genres) without
+	// credentials, on top of CORS_ORIGINS which may call every route. Empty
+	// leaves CORS_ORIGINS as the only policy; "*" allows any site.
+	CORSPublicOrigins []string `env:"CORS_PUBLIC_ORIGINS"`
+
+	// Proxies (IPs or CIDRs) whose X-Forwarded-For / X-Real-IP headers are
+	// believed when resolving the client IP. The IP-keyed rate limits and
+	// session records depend on it; "none" trusts no proxy at all.
+	TrustedProxies []string `env:"TRUSTED_PROXIES" default:"127.0.0.1,::1"`
+
 	// File Storage
 	MangaDataPath string `env:"MANGA_DATA_PATH" default:"/app/data/manga"`
 	UserDataPath  string `env:"USER_DATA_PATH" default:"/app/data/users"`
 	UploadMaxSize string `env:"UPLOAD_MAX_SIZE" default:"10MB"`
 
+	// WebSocket chat flood protection (per user, per room)
+	WSChatRatePerMinute int           `env:"WS_CHAT_RATE_PER_MINUTE" default:"30"`
+	WSChatBurst         int           `env:"WS_CHAT_BURST" default:"5"`
+	WSChatMuteStrikes   int           `env:"WS_CHAT_MUTE_STRIKES" default:"5"`
+	WSChatMuteDuration  time.Duration `env:"WS_CHAT_MUTE_DURATION" default:"30s"`
+	WSChatMaxMute       time.Duration `env:"WS_CHAT_MAX_MUTE" default:"10m"`
+
+	// Internal APIs
+	InternalToken string `env:"INTERNAL_TOKEN"` // Shared secret for the UDP server's /notify/* trigger
+
+	// Trigger requests are HMAC-signed with INTERNAL_SIGNING_KEY when it is
+	// set; the UDP server then rejects unsigned, tampered or replayed ones and
+	// those whose timestamp is more than INTERNAL_SIGNATURE_WINDOW off.
+	InternalSigningKey      string        `env:"INTERNAL_SIGNING_KEY"`
+	InternalSignatureWindow time.Duration `env:"INTERNAL_SIGNATURE_WINDOW" default:"5m"`
+
+	// Pagination for list endpoints: page_size defaults to DefaultPageSize
+	// and larger requests are clamped to MaxPageSize
+	DefaultPageSize int `env:"DEFAULT_PAGE_SIZE" default:"20"`
+	MaxPageSize     int `env:"MAX_PAGE_SIZE" default:"100"`
+
+	// Longest accepted search query in characters (HTTP and gRPC search)
+	MaxSearchQueryLength int `env:"MAX_SEARCH_QUERY_LENGTH" default:"200"`
+
+	// Most manga one user may keep in their library (0 = unlimited)
+	MaxLibrarySize int `env:"MAX_LIBRARY_SIZE" default:"0"`
+
+	// Length of each user's recently viewed manga list (kept in Redis)
+	RecentlyViewedMax int `env:"RECENTLY_VIEWED_MAX" default:"20"`
+
+	// Upper bound on graceful shutdown: draining requests, stopping background
+	// jobs and closing pools
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" default:"15s"`
+
+	// How long startup keeps retrying the database and Redis, with backoff,
+	// before giving up; 0 fails on the first unsuccessful ping
+	StartupWaitTimeout time.Duration `env:"STARTUP_WAIT_TIMEOUT" default:"60s"`
+
+	// Response compression and catalog caching. Bodies smaller than
+	// HTTP_GZIP_MIN_SIZE bytes go out uncompressed; 0 turns gzip off.
+	// Catalog reads (manga lists, search, genres) may be reused by clients
+	// for CATALOG_CACHE_MAX_AGE; 0 marks them no-store like everything else.
+	GzipMinSize        int           `env:"HTTP_GZIP_MIN_SIZE" default:"1024"`
+	CatalogCacheMaxAge time.Duration `env:"CATALOG_CACHE_MAX_AGE" default:"30s"`
+
+	// Manga detail views are counted in memory and written to
+	// manga.view_count once per VIEW_COUNT_FLUSH_INTERVAL.
+	ViewCountFlushInterval time.Duration `env:"VIEW_COUNT_FLUSH_INTERVAL" default:"30s"`
+
+	// The activity feed only looks back FEED_LOOKBACK_DAYS, and each user's
+	// pages are cached for FEED_CACHE_TTL (0 disables the cache).
+	FeedLookbackDays int           `env:"FEED_LOOKBACK_DAYS" default:"30"`
+	FeedCacheTTL     time.Duration `env:"FEED_CACHE_TTL" default:"30s"`
+
+	// Notifications older than NOTIFICATION_RETENTION_DAYS are pruned daily;
+	// 0 keeps them forever. NOTIFICATION_PRUNE_KEEP_UNREAD spares unread ones.
+	NotificationRetentionDays   int  `env:"NOTIFICATION_RETENTION_DAYS" default:"90"`
+	NotificationPruneKeepUnread bool `env:"NOTIFICATION_PRUNE_KEEP_UNREAD" default:"true"`
+
+	// Webhooks registered by users get each matching notification POSTed by
+	// the UDP server, retried WEBHOOK_RETRIES times. A webhook that fails
+	// WEBHOOK_MAX_FAILURES deliveries in a row is disabled. Targets on
+	// private or loopback addresses are refused unless WEBHOOK_ALLOW_PRIVATE.
+	WebhookTimeout      time.Duration `env:"WEBHOOK_TIMEOUT" default:"5s"`
+	WebhookRetries      int           `env:"WEBHOOK_RETRIES" default:"3"`
+	WebhookMaxFailures  int           `env:"WEBHOOK_MAX_FAILURES" default:"10"`
+	WebhookAllowPrivate bool          `env:"WEBHOOK_ALLOW_PRIVATE" default:"false"`
+
+	// Read-only deployment (e.g. a public instance on a read replica): catalog
+	// writes are refused with 403 / PermissionDenied. READ_ONLY_LOCK_USER_DATA
+	// also refuses library, progress, rating and comment writes. Unlike
+	// maintenance mode this is fixed for the life of the process.
+	ReadOnly             bool `env:"READ_ONLY" default:"false"`
+	ReadOnlyLockUserData bool `env:"READ_ONLY_LOCK_USER_DATA" default:"false"`
+
+	// Maintenance mode: writes (including /auth) answer 503 while reads keep working.
+	// Re-read from .env / the environment on SIGHUP (see ReloadMaintenanceMode).
+	MaintenanceMode       bool          `env:"MAINTENANCE_MODE" default:"false"`
+	MaintenanceRetryAfter time.Duration `env:"MAINTENANCE_RETRY_AFTER" default:"2m"`
+
 	// TLS
 	TLSEnabled  bool   `env:"TLS_ENABLED" default:"false"`
 	TLSCertPath string `env:"TLS_CERT_PATH" default:"./cert/localhost+2.pem"`
 	TLSKeyPath  string `env:"TLS_KEY_PATH" default:"./cert/localhost+2-key.pem"`
 }
 
+// Headers used on internal service-to-service calls
+const (
+	InternalTokenHeader     = "X-Internal-Token"     // Shared secret (INTERNAL_TOKEN)
+	InternalTimestampHeader = "X-Internal-Timestamp" // Unix seconds the request was signed at
+	InternalNonceHeader     = "X-Internal-Nonce"     // Unique per signed request; a repeat is a replay
+	InternalSignatureHeader = "X-Internal-Signature" // Hex HMAC-SHA256 keyed with INTERNAL_SIGNING_KEY
+	IdempotencyKeyHeader    = "Idempotency-Key"      // Lets the UDP trigger drop retried notifications
+)
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (*Config, error) {
 	// Try to load .env file from project root (adjust path as needed)
@@ -95,6 +235,26 @@ func LoadConfig() (*Config, error) {
 	if err := loadEnvInt(&config.GRPCPort, "GRPC_PORT", 8083); err != nil {
 		return nil, err
 	}
+	if err := loadEnvDuration(&config.GRPCDefaultDeadline, "GRPC_DEFAULT_DEADLINE", 10*time.Second); err != nil {
+		return nil, err
+	}
+
+	// Connection caps
+	if err := loadEnvInt(&config.TCPMaxConnections, "TCP_MAX_CONNECTIONS", 1000); err != nil {
+		return nil, err
+	}
+	if err := loadEnvInt(&config.WSMaxConnections, "WS_MAX_CONNECTIONS", 1000); err != nil {
+		return nil, err
+	}
+	if err := loadEnvDuration(&config.TCPMetricsInterval, "TCP_METRICS_INTERVAL", time.Minute); err != nil {
+		return nil, err
+	}
+	if err := loadEnvString(&config.TCPTLSCert, "TCP_TLS_CERT", ""); err != nil {
+		return nil, err
+	}
+	if err := loadEnvString(&config.TCPTLSKey, "TCP_TLS_KEY", ""); err != nil {
+		return nil, err
+	}
 
 	// Database
 	if err := loadEnvString(&config.DatabaseURL, "DATABASE_URL", "/app/data/mangahub.db"); err != nil {
@@ -103,6 +263,9 @@ func LoadConfig() (*Config, error) {
 	if err := loadEnvString(&config.SQLitePath, "SQLITE_PATH", "/app/data/mangahub.db"); err != nil {
 		return nil, err
 	}
+	if err := loadEnvBool(&config.AutoMigrate, "DB_AUTO_MIGRATE", false); err != nil {
+		return nil, err
+	}
 
 	// Authentication
 	if err := loadEnvStringRequired(&config.JWTSecret, "JWT_SECRET"); err != nil {
@@ -112,6 +275,48 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	// Password policy
+	if err := loadEnvInt(&config.PasswordMinLength, "PASSWORD_MIN_LENGTH", 8); err != nil {
+		return nil, err
+	}
+	if err := loadEnvBool(&config.PasswordRequireDigit, "PASSWORD_REQUIRE_DIGIT", false); err != nil {
+		return nil, err
+	}
+	if err := loadEnvBool(&config.PasswordRequireUpper, "PASSWORD_REQUIRE_UPPER", false); err != nil {
+		return nil, err
+	}
+	if err := loadEnvBool(&config.PasswordRequireSpecial, "PASSWORD_REQUIRE_SPECIAL", false); err != nil {
+		return nil, err
+	}
+
+	// Comment content rules
+	if err := loadEnvInt(&config.CommentMinLength, "COMMENT_MIN_LENGTH", 1); err != nil {
+		return nil, err
+	}
+	if err := loadEnvInt(&config.CommentMaxLength, "COMMENT_MAX_LENGTH", 5000); err != nil {
+		return nil, err
+	}
+	if err := loadEnvStringSlice(&config.CommentBlockedWords, "COMMENT_BLOCKED_WORDS", nil); err != nil {
+		return nil, err
+	}
+	if err := loadEnvString(&config.CommentFilterMode, "COMMENT_FILTER_MODE", "reject"); err != nil {
+		return nil, err
+	}
+
+	// Comment and rating rate limits
+	if err := loadEnvInt(&config.CommentRateLimit, "COMMENT_RATE_LIMIT", 5); err != nil {
+		return nil, err
+	}
+	if err := loadEnvInt(&config.CommentRateBurst, "COMMENT_RATE_BURST", 3); err != nil {
+		return nil, err
+	}
+	if err := loadEnvInt(&config.RatingRateLimit, "RATING_RATE_LIMIT", 30); err != nil {
+		return nil, err
+	}
+	if err := loadEnvInt(&config.RatingRateBurst, "RATING_RATE_BURST", 10); err != nil {
+		return nil, err
+	}
+
 	// Token TTLs
 	if err := loadEnvDuration(&config.AccessTokenTTL, "ACCESS_TOKEN_TTL", 15*time.Minute); err != nil {
 		return nil, err
@@ -121,6 +326,10 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	if err := loadEnvDuration(&config.AccessTokenMaxTTL, "ACCESS_TOKEN_MAX_TTL", 24*time.Hour); err != nil {
+		return nil, err
+	}
+
 	// Redis
 	if err := loadEnvString(&config.RedisURL, "REDIS_URL", "redis://redis:6379"); err != nil {
 		return nil, err
@@ -158,6 +367,20 @@ func LoadConfig() (*Config, error) {
 	if err := loadEnvStringSlice(&config.CORSOrigins, "CORS_ORIGINS", []string{"http://localhost:3000", "http://localhost:8080"}); err != nil {
 		return nil, err
 	}
+	if err := loadEnvStringSlice(&config.CORSPublicOrigins, "CORS_PUBLIC_ORIGINS", nil); err != nil {
+		return nil, err
+	}
+
+	// Trusted proxies
+	if err := loadEnvStringSlice(&config.TrustedProxies, "TRUSTED_PROXIES", []string{"127.0.0.1", "::1"}); err != nil {
+		return nil, err
+	}
+	if len(config.TrustedProxies) == 1 && strings.EqualFold(config.TrustedProxies[0], "none") {
+		config.TrustedProxies = []string{}
+	}
+	if err := config.validateTrustedProxies(); err != nil {
+		return nil, err
+	}
 
 	// File Storage
 	if err := loadEnvString(&config.MangaDataPath, "MANGA_DATA_PATH", "/app/data/manga"); err != nil {
@@ -170,6 +393,129 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	// WebSocket chat flood protection
+	if err := loadEnvInt(&config.WSChatRatePerMinute, "WS_CHAT_RATE_PER_MINUTE", 30); err != nil {
+		return nil, err
+	}
+	if err := loadEnvInt(&config.WSChatBurst, "WS_CHAT_BURST", 5); err != nil {
+		return nil, err
+	}
+	if err := loadEnvInt(&config.WSChatMuteStrikes, "WS_CHAT_MUTE_STRIKES", 5); err != nil {
+		return nil, err
+	}
+	if err := loadEnvDuration(&config.WSChatMuteDuration, "WS_CHAT_MUTE_DURATION", 30*time.Second); err != nil {
+		return nil, err
+	}
+	if err := loadEnvDuration(&config.WSChatMaxMute, "WS_CHAT_MAX_MUTE", 10*time.Minute); err != nil {
+		return nil, err
+	}
+
+	// Internal APIs
+	if err := loadEnvString(&config.InternalToken, "INTERNAL_TOKEN", ""); err != nil {
+		return nil, err
+	}
+	if err := loadEnvString(&config.InternalSigningKey, "INTERNAL_SIGNING_KEY", ""); err != nil {
+		return nil, err
+	}
+	if err := loadEnvDuration(&config.InternalSignatureWindow, "INTERNAL_SIGNATURE_WINDOW", 5*time.Minute); err != nil {
+		return nil, err
+	}
+
+	// Pagination
+	if err := loadEnvInt(&config.DefaultPageSize, "DEFAULT_PAGE_SIZE", 20); err != nil {
+		return nil, err
+	}
+	if err := loadEnvInt(&config.MaxPageSize, "MAX_PAGE_SIZE", 100); err != nil {
+		return nil, err
+	}
+	if err := config.validatePageSizes(); err != nil {
+		return nil, err
+	}
+
+	// Search
+	if err := loadEnvInt(&config.MaxSearchQueryLength, "MAX_SEARCH_QUERY_LENGTH", 200); err != nil {
+		return nil, err
+	}
+
+	// Library
+	if err := loadEnvInt(&config.MaxLibrarySize, "MAX_LIBRARY_SIZE", 0); err != nil {
+		return nil, err
+	}
+
+	// Recently viewed
+	if err := loadEnvInt(&config.RecentlyViewedMax, "RECENTLY_VIEWED_MAX", 20); err != nil {
+		return nil, err
+	}
+
+	// Shutdown
+	if err := loadEnvDuration(&config.ShutdownTimeout, "SHUTDOWN_TIMEOUT", 15*time.Second); err != nil {
+		return nil, err
+	}
+
+	// Startup
+	if err := loadEnvDuration(&config.StartupWaitTimeout, "STARTUP_WAIT_TIMEOUT", 60*time.Second); err != nil {
+		return nil, err
+	}
+
+	// Compression and caching
+	if err := loadEnvInt(&config.GzipMinSize, "HTTP_GZIP_MIN_SIZE", 1024); err != nil {
+		return nil, err
+	}
+	if err := loadEnvDuration(&config.CatalogCacheMaxAge, "CATALOG_CACHE_MAX_AGE", 30*time.Second); err != nil {
+		return nil, err
+	}
+
+	// View counts
+	if err := loadEnvDuration(&config.ViewCountFlushInterval, "VIEW_COUNT_FLUSH_INTERVAL", 30*time.Second); err != nil {
+		return nil, err
+	}
+
+	// Activity feed
+	if err := loadEnvInt(&config.FeedLookbackDays, "FEED_LOOKBACK_DAYS", 30); err != nil {
+		return nil, err
+	}
+	if err := loadEnvDuration(&config.FeedCacheTTL, "FEED_CACHE_TTL", 30*time.Second); err != nil {
+		return nil, err
+	}
+
+	// Notification retention
+	if err := loadEnvInt(&config.NotificationRetentionDays, "NOTIFICATION_RETENTION_DAYS", 90); err != nil {
+		return nil, err
+	}
+	if err := loadEnvBool(&config.NotificationPruneKeepUnread, "NOTIFICATION_PRUNE_KEEP_UNREAD", true); err != nil {
+		return nil, err
+	}
+
+	// Webhook delivery
+	if err := loadEnvDuration(&config.WebhookTimeout, "WEBHOOK_TIMEOUT", 5*time.Second); err != nil {
+		return nil, err
+	}
+	if err := loadEnvInt(&config.WebhookRetries, "WEBHOOK_RETRIES", 3); err != nil {
+		return nil, err
+	}
+	if err := loadEnvInt(&config.WebhookMaxFailures, "WEBHOOK_MAX_FAILURES", 10); err != nil {
+		return nil, err
+	}
+	if err := loadEnvBool(&config.WebhookAllowPrivate, "WEBHOOK_ALLOW_PRIVATE", false); err != nil {
+		return nil, err
+	}
+
+	// Read-only deployment
+	if err := loadEnvBool(&config.ReadOnly, "READ_ONLY", false); err != nil {
+		return nil, err
+	}
+	if err := loadEnvBool(&config.ReadOnlyLockUserData, "READ_ONLY_LOCK_USER_DATA", false); err != nil {
+		return nil, err
+	}
+
+	// Maintenance mode
+	if err := loadEnvBool(&config.MaintenanceMode, "MAINTENANCE_MODE", false); err != nil {
+		return nil, err
+	}
+	if err := loadEnvDuration(&config.MaintenanceRetryAfter, "MAINTENANCE_RETRY_AFTER", 2*time.Minute); err != nil {
+		return nil, err
+	}
+
 	// TLS
 	if err := loadEnvBool(&config.TLSEnabled, "TLS_ENABLED", false); err != nil {
 		return nil, err
@@ -183,6 +529,26 @@ func LoadConfig() (*Config, error) {
 	return config, nil
 }
 
+// ReloadMaintenanceMode re-reads MAINTENANCE_MODE for a running process.
+// The .env file wins since a process can't see later changes to its own
+// environment; without an entry there the environment value is used.
+func ReloadMaintenanceMode() (bool, error) {
+	value := os.Getenv("MAINTENANCE_MODE")
+	if fileEnv, err := godotenv.Read(".env"); err == nil {
+		if v, ok := fileEnv["MAINTENANCE_MODE"]; ok {
+			value = v
+		}
+	}
+	if value == "" {
+		return false, nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid boolean value for MAINTENANCE_MODE: %v", err)
+	}
+	return enabled, nil
+}
+
 // Helper functions for type conversion and validation
 func loadEnvString(target *string, key, defaultValue string) error {
 	if value := os.Getenv(key); value != "" {
@@ -272,6 +638,93 @@ func (c *Config) Validate() error {
 		errors = append(errors, "GRPC_PORT must be between 1 and 65535")
 	}
 
+	if c.AccessTokenMaxTTL < c.AccessTokenTTL {
+		errors = append(errors, "ACCESS_TOKEN_MAX_TTL must not be shorter than ACCESS_TOKEN_TTL")
+	}
+
+	if c.GRPCDefaultDeadline <= 0 {
+		errors = append(errors, "GRPC_DEFAULT_DEADLINE must be positive")
+	}
+
+	if c.GzipMinSize < 0 {
+		errors = append(errors, "HTTP_GZIP_MIN_SIZE must not be negative")
+	}
+	if c.CatalogCacheMaxAge < 0 {
+		errors = append(errors, "CATALOG_CACHE_MAX_AGE must not be negative")
+	}
+	if c.ReadOnlyLockUserData && !c.ReadOnly {
+		errors = append(errors, "READ_ONLY_LOCK_USER_DATA requires READ_ONLY=true")
+	}
+	if c.ViewCountFlushInterval <= 0 {
+		errors = append(errors, "VIEW_COUNT_FLUSH_INTERVAL must be positive")
+	}
+	if c.FeedLookbackDays < 1 {
+		errors = append(errors, "FEED_LOOKBACK_DAYS must be at least 1")
+	}
+	if c.FeedCacheTTL < 0 {
+		errors = append(errors, "FEED_CACHE_TTL must not be negative")
+	}
+	if c.NotificationRetentionDays < 0 {
+		errors = append(errors, "NOTIFICATION_RETENTION_DAYS must not be negative")
+	}
+	if c.WebhookTimeout <= 0 {
+		errors = append(errors, "WEBHOOK_TIMEOUT must be positive")
+	}
+	if c.WebhookRetries < 0 {
+		errors = append(errors, "WEBHOOK_RETRIES must not be negative")
+	}
+	if c.WebhookMaxFailures < 1 {
+		errors = append(errors, "WEBHOOK_MAX_FAILURES must be at least 1")
+	}
+
+	if c.StartupWaitTimeout < 0 {
+		errors = append(errors, "STARTUP_WAIT_TIMEOUT must not be negative")
+	}
+
+	if c.TCPMaxConnections < 0 {
+		errors = append(errors, "TCP_MAX_CONNECTIONS must not be negative")
+	}
+	if (c.TCPTLSCert == "") != (c.TCPTLSKey == "") {
+		errors = append(errors, "TCP_TLS_CERT and TCP_TLS_KEY must be set together")
+	}
+	if c.WSMaxConnections < 0 {
+		errors = append(errors, "WS_MAX_CONNECTIONS must not be negative")
+	}
+
+	if err := c.validatePageSizes(); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := c.validateTrustedProxies(); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if c.MaxSearchQueryLength < 1 {
+		errors = append(errors, "MAX_SEARCH_QUERY_LENGTH must be at least 1")
+	}
+	if c.MaxLibrarySize < 0 {
+		errors = append(errors, "MAX_LIBRARY_SIZE must not be negative")
+	}
+	if c.PasswordMinLength < 1 {
+		errors = append(errors, "PASSWORD_MIN_LENGTH must be at least 1")
+	}
+	if c.CommentMinLength < 1 {
+		errors = append(errors, "COMMENT_MIN_LENGTH must be at least 1")
+	}
+	if c.CommentMaxLength < c.CommentMinLength {
+		errors = append(errors, "COMMENT_MAX_LENGTH must be at least COMMENT_MIN_LENGTH")
+	}
+	if c.CommentFilterMode != "reject" && c.CommentFilterMode != "mask" {
+		errors = append(errors, "COMMENT_FILTER_MODE must be reject or mask")
+	}
+	if c.CommentRateLimit < 0 || c.RatingRateLimit < 0 {
+		errors = append(errors, "COMMENT_RATE_LIMIT and RATING_RATE_LIMIT must not be negative")
+	}
+	if (c.CommentRateLimit > 0 && c.CommentRateBurst < 1) || (c.RatingRateLimit > 0 && c.RatingRateBurst < 1) {
+		errors = append(errors, "COMMENT_RATE_BURST and RATING_RATE_BURST must be at least 1 when their limit is set")
+	}
+	if c.RecentlyViewedMax < 1 {
+		errors = append(errors, "RECENTLY_VIEWED_MAX must be at least 1")
+	}
+
 	// Validate log level
 	validLogLevels := []string{"debug", "info", "warn", "error", "fatal", "panic"}
 	if !contains(validLogLevels, c.LogLevel) {
@@ -289,6 +742,17 @@ func (c *Config) Validate() error {
 		errors = append(errors, "JWT_SECRET should be at least 32 characters long")
 	}
 
+	// The notification trigger is only left open in development
+	if c.InternalToken == "" && !c.IsDevelopment() {
+		errors = append(errors, "INTERNAL_TOKEN is required outside development")
+	}
+	if c.InternalSigningKey != "" && len(c.InternalSigningKey) < 32 {
+		errors = append(errors, "INTERNAL_SIGNING_KEY should be at least 32 characters long")
+	}
+	if c.InternalSignatureWindow <= 0 {
+		errors = append(errors, "INTERNAL_SIGNATURE_WINDOW must be positive")
+	}
+
 	if len(errors) > 0 {
 		return fmt.Errorf("configuration validation failed: %s", strings.Join(errors, "; "))
 	}
@@ -296,6 +760,38 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// validatePageSizes checks the pagination limits. LoadConfig runs it too,
+// since a bad pair would break every list endpoint.
+func (c *Config) validatePageSizes() error {
+	if c.DefaultPageSize < 1 {
+		return fmt.Errorf("DEFAULT_PAGE_SIZE must be at least 1")
+	}
+	if c.MaxPageSize < c.DefaultPageSize {
+		return fmt.Errorf("MAX_PAGE_SIZE (%d) must be >= DEFAULT_PAGE_SIZE (%d)", c.MaxPageSize, c.DefaultPageSize)
+	}
+	return nil
+}
+
+// validateTrustedProxies checks each TRUSTED_PROXIES entry is an IP or CIDR.
+// Outside development it also refuses a range covering every address, since
+// any client could then forge its IP and dodge the IP-keyed rate limits.
+// LoadConfig runs it too, as Gin would otherwise reject the list at startup.
+func (c *Config) validateTrustedProxies() error {
+	for _, proxy := range c.TrustedProxies {
+		if net.ParseIP(proxy) != nil {
+			continue
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return fmt.Errorf("TRUSTED_PROXIES entry %q is not an IP address or CIDR", proxy)
+		}
+		if ones, _ := network.Mask.Size(); ones == 0 && !c.IsDevelopment() {
+			return fmt.Errorf("TRUSTED_PROXIES must not trust every address (%s) outside development", proxy)
+		}
+	}
+	return nil
+}
+
 // IsDevelopment returns true if the application is running in development mode
 func (c *Config) IsDevelopment() bool {
 	return c.GoEnv == "development"
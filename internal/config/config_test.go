@@ -0,0 +1,35 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		proxies []string
+		wantErr bool
+	}{
+		{"Loopback", "production", []string{"127.0.0.1", "::1"}, false},
+		{"PrivateRange", "production", []string{"10.0.0.0/8"}, false},
+		{"NoneTrusted", "production", []string{}, false},
+		{"NotAnAddress", "production", []string{"proxy.internal"}, true},
+		{"TrustEveryoneInProduction", "production", []string{"0.0.0.0/0"}, true},
+		{"TrustEveryoneIPv6", "production", []string{"::/0"}, true},
+		{"TrustEveryoneInDevelopment", "development", []string{"0.0.0.0/0"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{GoEnv: tt.env, TrustedProxies: tt.proxies}
+			err := cfg.validateTrustedProxies()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
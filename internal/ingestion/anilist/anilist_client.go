@@ -6,10 +6,12 @@ import (
     "encoding/json"
     "fmt"
     "io"
-    "log"
+    "log/slog"
     "net/http"
     "time"
 
+    "mangahub/internal/ingestion"
+
     "golang.org/x/time/rate"
 )
 
@@ -31,24 +33,34 @@ type AniListClient struct {
     apiURL      string
     httpClient  *http.Client
     rateLimiter *rate.Limiter
+    breaker     *ingestion.CircuitBreaker
+    logger      *slog.Logger
 }
 
-// NewClient creates a new AniList API client
-func NewClient() *AniListClient {
+// NewClient creates a new AniList API client. A nil httpClient uses the
+// default ingestion transport and a nil breaker uses the default thresholds.
+func NewClient(httpClient *http.Client, breaker *ingestion.CircuitBreaker) *AniListClient {
+    if httpClient == nil {
+        httpClient = ingestion.NewHTTPClient(ingestion.HTTPConfig{}, 0)
+    }
+    if breaker == nil {
+        breaker = ingestion.NewCircuitBreaker("anilist", ingestion.BreakerConfig{})
+    }
+
     return &AniListClient{
         apiURL:      apiURL,
         rateLimiter: rate.NewLimiter(rate.Limit(rateLimit), rateBurst),
-        httpClient: &http.Client{
-            Timeout: 30 * time.Second,
-            Transport: &http.Transport{
-                MaxIdleConns:        100,
-                MaxIdleConnsPerHost: 10,
-                IdleConnTimeout:     90 * time.Second,
-            },
-        },
+        httpClient:  httpClient,
+        breaker:     breaker,
+        logger:      slog.Default().With("source", "anilist", "component", "client"),
     }
 }
 
+// Breaker returns the state of the client's circuit breaker
+func (c *AniListClient) Breaker() ingestion.BreakerSnapshot {
+    return c.breaker.Snapshot()
+}
+
 // GraphQLRequest represents a GraphQL query request
 type GraphQLRequest struct {
     Query     string                 `json:"query"`
@@ -149,7 +161,7 @@ func (c *AniListClient) GetManga(ctx context.Context, page, perPage int) (*Manga
 }
 
 // GetMangaByID fetches a specific manga by ID
-func (c *AniListClient) GetMangaByID(ctx context.Context, id int) (*MediaResponse, error) {
+func (c *AniListClient) GetMangaByID(ctx context.Context, id int64) (*MediaResponse, error) {
     query := `
     query ($id: Int) {
         Media(id: $id, type: MANGA) {
@@ -313,13 +325,24 @@ func (c *AniListClient) doRequest(ctx context.Context, query string, variables m
         req.Header.Set("Content-Type", "application/json")
         req.Header.Set("Accept", "application/json")
 
+        // Short-circuit while AniList is considered down
+        if err := c.breaker.Allow(); err != nil {
+            return err
+        }
+
         // Execute request
         resp, err := c.httpClient.Do(req)
         if err != nil {
+            // Our own cancellation or deadline says nothing about the upstream
+            if ctx.Err() != nil {
+                c.breaker.Release()
+                return fmt.Errorf("request cancelled: %w", err)
+            }
+            c.breaker.Record(err)
             lastErr = err
             if attempt < maxRetries {
-                log.Printf("[AniList] Request failed (attempt %d/%d): %v, retrying in %v...",
-                    attempt+1, maxRetries, err, delay)
+                c.logger.Warn("request failed, retrying",
+                    "attempt", attempt+1, "max_retries", maxRetries, "delay", delay, "error", err)
                 time.Sleep(delay)
                 delay = minDuration(delay*2, maxDelay)
                 continue
@@ -332,14 +355,31 @@ func (c *AniListClient) doRequest(ctx context.Context, query string, variables m
         // Read response body
         respBody, err := io.ReadAll(resp.Body)
         if err != nil {
+            if ctx.Err() != nil {
+                c.breaker.Release()
+            } else {
+                c.breaker.Record(err)
+            }
             return fmt.Errorf("failed to read response: %w", err)
         }
 
         // Handle HTTP errors
         if resp.StatusCode != http.StatusOK {
+            httpErr := fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+            if resp.StatusCode == http.StatusNotFound {
+                httpErr = fmt.Errorf("%w: %s", ingestion.ErrNotFound, string(respBody))
+            }
+
+            // Only rate limiting and server errors count against the breaker
+            if shouldRetry(resp.StatusCode) {
+                c.breaker.Record(httpErr)
+            } else {
+                c.breaker.Record(nil)
+            }
+
             // Retry on rate limit or server errors
             if shouldRetry(resp.StatusCode) && attempt < maxRetries {
-                lastErr = fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+                lastErr = httpErr
 
                 // Check for Retry-After header
                 if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
@@ -348,16 +388,18 @@ func (c *AniListClient) doRequest(ctx context.Context, query string, variables m
                     }
                 }
 
-                log.Printf("[AniList] HTTP %d (attempt %d/%d), retrying in %v...",
-                    resp.StatusCode, attempt+1, maxRetries, delay)
+                c.logger.Warn("upstream error, retrying",
+                    "status", resp.StatusCode, "attempt", attempt+1, "max_retries", maxRetries, "delay", delay)
                 time.Sleep(delay)
                 delay = minDuration(delay*2, maxDelay)
                 continue
             }
 
-            return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+            return httpErr
         }
 
+        c.breaker.Record(nil)
+
         // Parse GraphQL response
         var gqlResp GraphQLResponse
         if err := json.Unmarshal(respBody, &gqlResp); err != nil {
@@ -1,28 +1,26 @@
 package anilist
 
 import (
-    "bytes"
     "context"
-    "encoding/json"
     "fmt"
-    "log"
-    "net/http"
-    "time"
+    "log/slog"
+
+    "mangahub/internal/ingestion"
 )
 
 // Notifier sends notifications to the UDP notification server
 type Notifier struct {
-    udpServerURL string
-    httpClient   *http.Client
+    delivery *ingestion.NotifyDelivery
+    logger   *slog.Logger
 }
 
-// NewNotifier creates a new notifier instance
-func NewNotifier(udpServerURL string) *Notifier {
+// NewNotifier creates a new notifier instance. Calls to the UDP server are
+// retried and, if it stays down, buffered as configured by cfg.
+func NewNotifier(udpServerURL, internalToken string, cfg ingestion.NotifyConfig) *Notifier {
+    logger := slog.Default().With("source", "anilist", "component", "notifier")
     return &Notifier{
-        udpServerURL: udpServerURL,
-        httpClient: &http.Client{
-            Timeout: 5 * time.Second,
-        },
+        delivery: ingestion.NewNotifyDelivery(udpServerURL, internalToken, cfg, logger),
+        logger:   logger,
     }
 }
 
@@ -43,11 +41,10 @@ func (n *Notifier) NotifyNewManga(mangaID int64, titleEN *string, titleRomaji *s
             "source":   "anilist",
         }
 
-        ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-        defer cancel()
+        ctx := context.Background()
 
-        if err := n.sendNotification(ctx, "/notify/new-manga", payload); err != nil {
-            log.Printf("[AniListNotifier] Failed to send new manga notification: %v", err)
+        if err := n.sendNotification(ctx, "/notify/new-manga", fmt.Sprintf("manga:%d", mangaID), payload); err != nil {
+            n.logger.Warn("failed to send new manga notification", "manga_id", mangaID, "title", title, "error", err)
         }
     }()
 }
@@ -64,11 +61,10 @@ func (n *Notifier) NotifyChapterUpdate(mangaID int64, title string, oldChapters,
             "source":       "anilist",
         }
 
-        ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-        defer cancel()
+        ctx := context.Background()
 
-        if err := n.sendNotification(ctx, "/notify/chapter-update", payload); err != nil {
-            log.Printf("[AniListNotifier] Failed to send chapter update notification: %v", err)
+        if err := n.sendNotification(ctx, "/notify/chapter-update", fmt.Sprintf("chapter:%d:%d", mangaID, newChapters), payload); err != nil {
+            n.logger.Warn("failed to send chapter update notification", "manga_id", mangaID, "title", title, "error", err)
         }
     }()
 }
@@ -83,39 +79,16 @@ func (n *Notifier) NotifyMangaUpdate(mangaID int64, title string) {
             "source":   "anilist",
         }
 
-        ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-        defer cancel()
+        ctx := context.Background()
 
-        if err := n.sendNotification(ctx, "/notify/manga-update", payload); err != nil {
-            log.Printf("[AniListNotifier] Failed to send manga update notification: %v", err)
+        if err := n.sendNotification(ctx, "/notify/manga-update", "", payload); err != nil {
+            n.logger.Warn("failed to send manga update notification", "manga_id", mangaID, "title", title, "error", err)
         }
     }()
 }
 
-// sendNotification sends HTTP POST request to UDP server
-func (n *Notifier) sendNotification(ctx context.Context, endpoint string, payload map[string]interface{}) error {
-    body, err := json.Marshal(payload)
-    if err != nil {
-        return fmt.Errorf("failed to marshal payload: %w", err)
-    }
-
-    url := n.udpServerURL + endpoint
-    req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
-    if err != nil {
-        return fmt.Errorf("failed to create request: %w", err)
-    }
-
-    req.Header.Set("Content-Type", "application/json")
-
-    resp, err := n.httpClient.Do(req)
-    if err != nil {
-        return fmt.Errorf("request failed: %w", err)
-    }
-    defer resp.Body.Close()
-
-    if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-        return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-    }
-
-    return nil
+// sendNotification sends HTTP POST request to UDP server. A non-empty
+// idempotencyKey lets the server drop duplicates when a call is retried.
+func (n *Notifier) sendNotification(ctx context.Context, endpoint, idempotencyKey string, payload map[string]interface{}) error {
+    return n.delivery.Send(ctx, endpoint, idempotencyKey, payload)
 }
\ No newline at end of file
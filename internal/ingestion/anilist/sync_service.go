@@ -3,11 +3,13 @@ package anilist
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"strconv"
 	"time"
 
+	"mangahub/internal/ingestion"
+
 	"gorm.io/gorm"
 )
 
@@ -16,44 +18,87 @@ type SyncService struct {
 	client   *AniListClient
 	db       *gorm.DB
 	notifier *Notifier
+	logger   *slog.Logger
 
 	// Configuration
 	initialSyncLimit int
 	workerCount      int
 	rateSemaphore    chan struct{} // Limits concurrent API calls
+	newMangaPollAt   *ingestion.DailySchedule
+	sourcePriority   []string // Which source wins when both supply a title
+	defaultStatus    string   // Stored for source statuses we can't map
+
+	runs ingestion.RunGuard // Keeps each sync from overlapping itself
 }
 
 // SyncConfig holds configuration for the sync service
 type SyncConfig struct {
 	DatabaseURL      string
 	UDPServerURL     string
+	InternalToken    string // Shared secret for the UDP server's HTTP trigger
 	InitialSyncLimit int
 	WorkerCount      int
 	RateConcurrency  int // Max concurrent API calls (default: 5)
+
+	// HTTP timeouts for upstream API requests (zero values use defaults)
+	HTTP ingestion.HTTPConfig
+
+	// Circuit breaker around upstream API calls (zero values use defaults)
+	Breaker ingestion.BreakerConfig
+
+	// Retries, timeout and buffering for notifications to the UDP server's
+	// HTTP trigger (zero values use defaults)
+	Notify ingestion.NotifyConfig
+
+	// Run the daily new manga poll at a fixed local time instead of every
+	// 24h from startup (nil keeps the interval)
+	NewMangaPollAt *ingestion.DailySchedule
+
+	// Source order for merging a title that both sources supply, highest
+	// first (nil uses ingestion.DefaultSourcePriority)
+	SourcePriority []string
+
+	// Status stored when the source reports one we can't map ("" uses
+	// ingestion.DefaultMangaStatus)
+	DefaultStatus string
 }
 
 // NewSyncService creates a new sync service instance
 func NewSyncService(config SyncConfig, db *gorm.DB) *SyncService {
-	client := NewClient()
-	notifier := NewNotifier(config.UDPServerURL)
-
 	workerCount := config.WorkerCount
 	if workerCount == 0 {
 		workerCount = 10 // Default
 	}
 
+	client := NewClient(ingestion.NewHTTPClient(config.HTTP, workerCount), ingestion.NewCircuitBreaker("anilist", config.Breaker))
+	notifier := NewNotifier(config.UDPServerURL, config.InternalToken, config.Notify)
+
 	rateConcurrency := config.RateConcurrency
 	if rateConcurrency == 0 {
 		rateConcurrency = 5 // Default
 	}
 
+	sourcePriority := config.SourcePriority
+	if len(sourcePriority) == 0 {
+		sourcePriority = ingestion.DefaultSourcePriority
+	}
+
+	defaultStatus := config.DefaultStatus
+	if defaultStatus == "" {
+		defaultStatus = ingestion.DefaultMangaStatus
+	}
+
 	return &SyncService{
 		client:           client,
 		db:               db,
 		notifier:         notifier,
+		logger:           slog.Default().With("source", "anilist"),
 		initialSyncLimit: config.InitialSyncLimit,
 		workerCount:      workerCount,
 		rateSemaphore:    make(chan struct{}, rateConcurrency),
+		newMangaPollAt:   config.NewMangaPollAt,
+		sourcePriority:   sourcePriority,
+		defaultStatus:    defaultStatus,
 	}
 }
 
@@ -63,15 +108,15 @@ func NewSyncService(config SyncConfig, db *gorm.DB) *SyncService {
 
 // SyncState represents a sync operation state in the database
 type SyncState struct {
-	ID            int    `gorm:"primaryKey"`
-	SyncType      string `gorm:"unique;not null"`
-	LastRunAt     *time.Time
-	LastSuccessAt *time.Time
-	LastCursor    string
-	Status        string
-	ErrorMessage  string
-	Metadata      string `gorm:"type:jsonb"`
-	UpdatedAt     time.Time
+	ID            int        `gorm:"primaryKey" json:"id"`
+	SyncType      string     `gorm:"unique;not null" json:"sync_type"`
+	LastRunAt     *time.Time `json:"last_run_at"`
+	LastSuccessAt *time.Time `json:"last_success_at"`
+	LastCursor    string     `json:"last_cursor"`
+	Status        string     `json:"status"`
+	ErrorMessage  string     `json:"error_message,omitempty"`
+	Metadata      string     `gorm:"type:jsonb" json:"-"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
 // TableName specifies the table name for SyncState
@@ -112,6 +157,45 @@ func (s *SyncService) getSyncState(syncType string) (*SyncState, error) {
 	return &state, nil
 }
 
+// SyncStatus is the payload served by the sync status endpoint
+type SyncStatus struct {
+	Source         string                    `json:"source"`
+	States         []SyncState               `json:"states"`
+	CircuitBreaker ingestion.BreakerSnapshot `json:"circuit_breaker"`
+	RecentRuns     []ingestion.SyncRun       `json:"recent_runs"`
+}
+
+// Status reports the stored sync states, the API circuit breaker and the
+// most recent sync runs
+func (s *SyncService) Status(ctx context.Context, opts ingestion.StatusOptions) (*SyncStatus, error) {
+	var states []SyncState
+	if err := s.db.WithContext(ctx).
+		Where("sync_type IN ?", []string{"anilist_initial_sync", "anilist_new_manga_poll", "anilist_chapter_check"}).
+		Order("sync_type").
+		Find(&states).Error; err != nil {
+		return nil, fmt.Errorf("failed to load sync states: %w", err)
+	}
+
+	runs, err := ingestion.RecentSyncRuns(ctx, s.db, "anilist", opts.Runs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sync runs: %w", err)
+	}
+
+	return &SyncStatus{
+		Source:         "anilist",
+		States:         states,
+		CircuitBreaker: s.client.Breaker(),
+		RecentRuns:     runs,
+	}, nil
+}
+
+// finishRun persists a sync run; failing to record history never fails the sync
+func (s *SyncService) finishRun(run *ingestion.RunTracker, err error) {
+	if _, saveErr := run.Finish(s.db, err); saveErr != nil {
+		s.logger.Warn("failed to record sync run", "error", saveErr)
+	}
+}
+
 // ============================================
 // DATABASE MODELS
 // ============================================
@@ -119,7 +203,8 @@ func (s *SyncService) getSyncState(syncType string) (*SyncState, error) {
 // Manga represents a manga entry in database
 type Manga struct {
 	ID                      int64   `gorm:"primaryKey;autoIncrement"`
-	AniListID               *int    `gorm:"column:anilist_id;unique"`
+	AniListID               *int64  `gorm:"column:anilist_id;unique"`
+	MangaDexID              *string `gorm:"column:mangadex_id;->"` // Set when MangaDex also supplies this title
 	Slug                    *string `gorm:"unique"`
 	Title                   string  `gorm:"not null"`
 	Author                  *string
@@ -139,29 +224,6 @@ func (Manga) TableName() string {
 	return "manga"
 }
 
-// Genre represents a genre in database
-type Genre struct {
-	ID   int    `gorm:"primaryKey;autoIncrement"`
-	Name string `gorm:"unique;not null"`
-}
-
-// TableName specifies the table name for Genre
-func (Genre) TableName() string {
-	return "genres"
-}
-
-// MangaGenre represents the many-to-many relationship
-type MangaGenre struct {
-	ID      int64 `gorm:"primaryKey;autoIncrement"`
-	MangaID int64 `gorm:"not null;index"`
-	GenreID int   `gorm:"not null;index"`
-}
-
-// TableName specifies the table name for MangaGenre
-func (MangaGenre) TableName() string {
-	return "manga_genres"
-}
-
 // ============================================
 // HELPER FUNCTIONS
 // ============================================
@@ -175,21 +237,27 @@ func (s *SyncService) storeManga(ctx context.Context, extracted *ExtractedManga)
 		}
 	}()
 
-	// Check if manga already exists (by AniList ID or slug)
+	// Check if manga already exists: by AniList ID, else a MangaDex row with
+	// the same slug that no AniList entry has claimed yet
 	var existingManga Manga
 	err := tx.Where("anilist_id = ?", extracted.AniListID).First(&existingManga).Error
+	if err == gorm.ErrRecordNotFound && extracted.Slug != "" {
+		err = tx.Where("slug = ? AND anilist_id IS NULL", extracted.Slug).First(&existingManga).Error
+	}
 
+	// Fields AniList sent as null stay nil, so Updates skips them rather
+	// than writing zeros over what is already stored
 	now := time.Now()
 	manga := Manga{
 		AniListID:           &extracted.AniListID,
 		Slug:                &extracted.Slug,
 		Title:               extracted.Title,
-		Author:              &extracted.Author,
-		Status:              &extracted.Status,
-		TotalChapters:       &extracted.TotalChapters,
-		Description:         &extracted.Description,
-		CoverURL:            &extracted.CoverURL,
-		AverageRating:       &extracted.AverageRating,
+		Author:              ingestion.NonEmpty(extracted.Author),
+		Status:              ingestion.NonEmpty(extracted.Status),
+		TotalChapters:       extracted.TotalChapters,
+		Description:         ingestion.NonEmpty(extracted.Description),
+		CoverURL:            ingestion.NonEmpty(extracted.CoverURL),
+		AverageRating:       extracted.AverageRating,
 		AniListLastSyncedAt: &now,
 	}
 
@@ -205,30 +273,19 @@ func (s *SyncService) storeManga(ctx context.Context, extracted *ExtractedManga)
 	} else {
 		// Update existing manga
 		manga.ID = existingManga.ID
+		if existingManga.MangaDexID != nil {
+			s.mergeWithMangaDex(&manga, existingManga)
+		}
 		if err := tx.Model(&manga).Updates(manga).Error; err != nil {
 			tx.Rollback()
 			return 0, fmt.Errorf("failed to update manga: %w", err)
 		}
 	}
 
-	// Store genres
-	for _, genreName := range extracted.Genres {
-		// Upsert genre
-		var genre Genre
-		err := tx.Where("name = ?", genreName).FirstOrCreate(&genre, Genre{Name: genreName}).Error
-		if err != nil {
-			continue
-		}
-
-		// Link manga to genre
-		var link MangaGenre
-		err = tx.Where("manga_id = ? AND genre_id = ?", manga.ID, genre.ID).FirstOrCreate(&link, MangaGenre{
-			MangaID: manga.ID,
-			GenreID: genre.ID,
-		}).Error
-		if err != nil {
-			log.Printf("[AniListSync] Failed to link genre '%s' to manga: %v", genreName, err)
-		}
+	// Store genres and link them to the manga
+	if err := ingestion.LinkGenres(tx, manga.ID, extracted.Genres); err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("failed to store genres: %w", err)
 	}
 
 	if err := tx.Commit().Error; err != nil {
@@ -238,6 +295,40 @@ func (s *SyncService) storeManga(ctx context.Context, extracted *ExtractedManga)
 	return manga.ID, nil
 }
 
+// mergeWithMangaDex resolves the fields of a title MangaDex also supplies
+// using the configured source priority, logging each field they disagree on
+func (s *SyncService) mergeWithMangaDex(manga *Manga, existing Manga) {
+	merged, conflicts := ingestion.MergeMetadata(s.sourcePriority,
+		existing.metadata(), ingestion.SourceMangaDex,
+		manga.metadata(), ingestion.SourceAniList)
+	manga.setMetadata(merged)
+
+	for _, c := range conflicts {
+		s.logger.Info("metadata conflict", "manga_id", manga.ID, "field", c.Field, "source", c.Source, "overridden", c.Overridden)
+	}
+}
+
+func (m Manga) metadata() ingestion.MetadataFields {
+	return ingestion.MetadataColumns{
+		Title:         m.Title,
+		Author:        m.Author,
+		Status:        m.Status,
+		Description:   m.Description,
+		CoverURL:      m.CoverURL,
+		TotalChapters: m.TotalChapters,
+	}.Fields()
+}
+
+func (m *Manga) setMetadata(f ingestion.MetadataFields) {
+	c := f.Columns()
+	m.Title = c.Title
+	m.Author = c.Author
+	m.Status = c.Status
+	m.Description = c.Description
+	m.CoverURL = c.CoverURL
+	m.TotalChapters = c.TotalChapters
+}
+
 // getInitialSyncLimit returns the initial sync limit from env or default
 func getInitialSyncLimit() int {
 	limitStr := os.Getenv("ANILIST_SYNC_INITIAL_COUNT")
@@ -253,25 +344,51 @@ func getInitialSyncLimit() int {
 	return limit
 }
 
-// processManga is a helper to extract and store a single manga
-func (s *SyncService) processManga(ctx context.Context, apiManga MediaData) error {
+// ImportManga fetches a single title from AniList by media ID and upserts it,
+// for on-demand imports outside the scheduled pollers. Imports are recorded
+// in sync run history like any other run.
+func (s *SyncService) ImportManga(ctx context.Context, externalID string) (mangaID int64, err error) {
+	id, err := ParseMediaID(externalID)
+	if err != nil {
+		return 0, err
+	}
+
+	run := ingestion.StartRun("anilist", "import")
+	defer func() { s.finishRun(run, err) }()
+
+	resp, err := s.client.GetMangaByID(ctx, id)
+	if err == nil {
+		mangaID, err = s.processManga(ctx, resp.Media)
+	}
+	if err != nil {
+		run.Failure(externalID, err)
+		return 0, err
+	}
+
+	run.Success()
+	return mangaID, nil
+}
+
+// processManga is a helper to extract and store a single manga.
+// It returns the internal manga ID.
+func (s *SyncService) processManga(ctx context.Context, apiManga MediaData) (int64, error) {
 	// Acquire rate semaphore
 	s.rateSemaphore <- struct{}{}
 	defer func() { <-s.rateSemaphore }()
 
 	// Extract metadata
-	extracted, err := ExtractMangaMetadata(apiManga)
+	extracted, err := ExtractMangaMetadata(apiManga, s.defaultStatus)
 	if err != nil {
-		return fmt.Errorf("failed to extract metadata: %w", err)
+		return 0, fmt.Errorf("failed to extract metadata: %w", err)
 	}
 
 	// Store in database
 	mangaID, err := s.storeManga(ctx, extracted)
 	if err != nil {
-		return fmt.Errorf("failed to store manga: %w", err)
+		return 0, fmt.Errorf("failed to store manga: %w", err)
 	}
 
-	log.Printf("[AniListSync] ✅ Synced: %s (ID: %d, AniList: %d)", extracted.Title, mangaID, extracted.AniListID)
+	s.logger.Debug("synced manga", "manga_id", mangaID, "anilist_id", extracted.AniListID, "title", extracted.Title)
 
-	return nil
+	return mangaID, nil
 }
@@ -3,9 +3,14 @@ package anilist
 import (
     "fmt"
     "html"
+    "math"
     "regexp"
+    "strconv"
     "strings"
     "time"
+
+    "mangahub/internal/microservices/http-api/models"
+    "mangahub/internal/shared/slug"
 )
 
 // ============================================
@@ -39,7 +44,7 @@ type MediaResponse struct {
 
 // MediaData represents a manga entry from AniList
 type MediaData struct {
-    ID           int          `json:"id"`
+    ID           int64        `json:"id"`
     IDMal        *int         `json:"idMal"`
     Title        TitleData    `json:"title"`
     Description  *string      `json:"description"`
@@ -108,17 +113,19 @@ type FuzzyDate struct {
 // EXTRACTED METADATA STRUCTURES
 // ============================================
 
-// ExtractedManga represents manga metadata ready for database
+// ExtractedManga represents manga metadata ready for database.
+// Empty strings and nil pointers mean AniList sent null for the field,
+// so storing it must leave the existing column alone.
 type ExtractedManga struct {
-    AniListID     int
+    AniListID     int64
     Title         string
     Slug          string
     Author        string
     Status        string
-    TotalChapters int
+    TotalChapters *int
     Description   string
     CoverURL      string
-    AverageRating float64
+    AverageRating *float64
     Genres        []string
     UpdatedAt     time.Time
 }
@@ -127,8 +134,9 @@ type ExtractedManga struct {
 // HELPER FUNCTIONS
 // ============================================
 
-// ExtractMangaMetadata extracts complete metadata from AniList API response
-func ExtractMangaMetadata(apiManga MediaData) (*ExtractedManga, error) {
+// ExtractMangaMetadata extracts complete metadata from AniList API response.
+// defaultStatus is used for a status AniList sends that we don't map.
+func ExtractMangaMetadata(apiManga MediaData, defaultStatus string) (*ExtractedManga, error) {
     extracted := &ExtractedManga{
         AniListID: apiManga.ID,
     }
@@ -147,7 +155,7 @@ func ExtractMangaMetadata(apiManga MediaData) (*ExtractedManga, error) {
     }
 
     // 2. Generate slug
-    extracted.Slug = GenerateSlug(extracted.Title)
+    extracted.Slug = slug.WithFallback(extracted.Title, fmt.Sprintf("anilist-%d", apiManga.ID))
 
     // 3. Description (clean HTML tags)
     if apiManga.Description != nil {
@@ -155,11 +163,14 @@ func ExtractMangaMetadata(apiManga MediaData) (*ExtractedManga, error) {
     }
 
     // 4. Status (map AniList status to our format)
-    extracted.Status = mapAniListStatus(apiManga.Status)
+    if apiManga.Status != "" {
+        extracted.Status = mapAniListStatus(apiManga.Status, defaultStatus)
+    }
 
-    // 5. Total chapters
-    if apiManga.Chapters != nil {
-        extracted.TotalChapters = *apiManga.Chapters
+    // 5. Total chapters (null while a series is still releasing)
+    if apiManga.Chapters != nil && *apiManga.Chapters > 0 {
+        chapters := *apiManga.Chapters
+        extracted.TotalChapters = &chapters
     }
 
     // 6. Extract author from staff (role="Story")
@@ -171,7 +182,7 @@ func ExtractMangaMetadata(apiManga MediaData) (*ExtractedManga, error) {
     }
 
     // 7. Cover URL
-    if apiManga.CoverImage.Large != nil {
+    if apiManga.CoverImage.Large != nil && *apiManga.CoverImage.Large != "" {
         extracted.CoverURL = *apiManga.CoverImage.Large
     } else if apiManga.CoverImage.Medium != nil {
         extracted.CoverURL = *apiManga.CoverImage.Medium
@@ -179,7 +190,8 @@ func ExtractMangaMetadata(apiManga MediaData) (*ExtractedManga, error) {
 
     // 8. Average rating (convert from 0-100 to 0-10)
     if apiManga.AverageScore != nil {
-        extracted.AverageRating = float64(*apiManga.AverageScore) / 10.0
+        rating := float64(*apiManga.AverageScore) / 10.0
+        extracted.AverageRating = &rating
     }
 
     // 9. Genres
@@ -193,21 +205,32 @@ func ExtractMangaMetadata(apiManga MediaData) (*ExtractedManga, error) {
     return extracted, nil
 }
 
-// mapAniListStatus converts AniList status to our format
-func mapAniListStatus(status string) string {
+// mapAniListStatus converts AniList status to our format, falling back to
+// defaultStatus for values we don't know
+func mapAniListStatus(status, defaultStatus string) string {
+    if mapped, ok := parseAniListStatus(status); ok {
+        return mapped
+    }
+    return defaultStatus
+}
+
+// parseAniListStatus converts AniList status to our format; ok is false for
+// values we don't know
+func parseAniListStatus(status string) (string, bool) {
     switch status {
     case "FINISHED":
-        return "completed"
+        return string(models.MangaStatusCompleted), true
     case "RELEASING":
-        return "ongoing"
+        return string(models.MangaStatusOngoing), true
     case "NOT_YET_RELEASED":
-        return "upcoming"
+        // announced titles are tracked like ongoing ones until they finish
+        return string(models.MangaStatusOngoing), true
     case "CANCELLED":
-        return "cancelled"
+        return string(models.MangaStatusCancelled), true
     case "HIATUS":
-        return "hiatus"
+        return string(models.MangaStatusHiatus), true
     default:
-        return "ongoing"
+        return "", false
     }
 }
 
@@ -226,26 +249,17 @@ func CleanDescription(desc string) string {
     return cleaned
 }
 
-// GenerateSlug creates a URL-friendly slug from title
-func GenerateSlug(title string) string {
-    slug := strings.ToLower(title)
-    slug = strings.ReplaceAll(slug, " ", "-")
+// MaxMediaID is the largest ID AniList's GraphQL API accepts: media IDs are
+// stored as int64 like every other manga ID, but the API's Int is 32-bit
+const MaxMediaID = math.MaxInt32
 
-    // Remove special characters, keep only alphanumeric and hyphens
-    var result strings.Builder
-    for _, char := range slug {
-        if (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') || char == '-' {
-            result.WriteRune(char)
-        }
-    }
-
-    // Remove multiple consecutive hyphens
-    slug = result.String()
-    for strings.Contains(slug, "--") {
-        slug = strings.ReplaceAll(slug, "--", "-")
+// ParseMediaID parses an AniList media ID, rejecting values the API can't take
+func ParseMediaID(s string) (int64, error) {
+    id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+    if err != nil || id <= 0 || id > MaxMediaID {
+        return 0, fmt.Errorf("invalid AniList ID %q", s)
     }
-
-    return strings.Trim(slug, "-")
+    return id, nil
 }
 
 // ToTime converts FuzzyDate to time.Time
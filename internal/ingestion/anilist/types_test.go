@@ -0,0 +1,70 @@
+package anilist
+
+import (
+	"testing"
+
+	"mangahub/internal/ingestion"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractMangaMetadata_NullFields(t *testing.T) {
+	title := "Berserk"
+	extracted, err := ExtractMangaMetadata(MediaData{ID: 30002, Title: TitleData{English: &title}}, ingestion.DefaultMangaStatus)
+	require.NoError(t, err)
+
+	// Nothing AniList left null may come back as a zero that would overwrite stored data
+	assert.Nil(t, extracted.TotalChapters)
+	assert.Nil(t, extracted.AverageRating)
+	assert.Empty(t, extracted.Status)
+	assert.Empty(t, extracted.Description)
+	assert.Empty(t, extracted.CoverURL)
+}
+
+func TestExtractMangaMetadata_PresentFields(t *testing.T) {
+	title, cover := "Berserk", "https://example.com/berserk.jpg"
+	chapters, score := 364, 93
+	extracted, err := ExtractMangaMetadata(MediaData{
+		ID:           30002,
+		Title:        TitleData{English: &title},
+		Status:       "RELEASING",
+		Chapters:     &chapters,
+		AverageScore: &score,
+		CoverImage:   CoverImage{Medium: &cover},
+	}, ingestion.DefaultMangaStatus)
+	require.NoError(t, err)
+
+	require.NotNil(t, extracted.TotalChapters)
+	assert.Equal(t, 364, *extracted.TotalChapters)
+	require.NotNil(t, extracted.AverageRating)
+	assert.InDelta(t, 9.3, *extracted.AverageRating, 0.001)
+	assert.Equal(t, "ongoing", extracted.Status)
+	assert.Equal(t, cover, extracted.CoverURL)
+}
+
+func TestExtractMangaMetadata_UnmappedStatus(t *testing.T) {
+	title := "Berserk"
+	media := MediaData{ID: 30002, Title: TitleData{English: &title}, Status: "ON_BREAK"}
+
+	extracted, err := ExtractMangaMetadata(media, "unknown")
+	require.NoError(t, err)
+	assert.Equal(t, "unknown", extracted.Status)
+
+	media.Status = "FINISHED"
+	extracted, err = ExtractMangaMetadata(media, "unknown")
+	require.NoError(t, err)
+	assert.Equal(t, "completed", extracted.Status)
+}
+
+func TestParseAniListStatus(t *testing.T) {
+	status, ok := parseAniListStatus("HIATUS")
+	assert.True(t, ok)
+	assert.Equal(t, "hiatus", status)
+
+	// Chapter checks only write statuses AniList actually reported
+	for _, raw := range []string{"", "ON_BREAK"} {
+		_, ok = parseAniListStatus(raw)
+		assert.False(t, ok, raw)
+	}
+}
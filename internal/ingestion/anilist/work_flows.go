@@ -2,26 +2,46 @@ package anilist
 
 import (
     "context"
+    "errors"
     "fmt"
-    "log"
+    "strconv"
     "time"
+
+    "mangahub/internal/ingestion"
 )
 
 // RunInitialSync performs one-time bulk import of manga
 // Fetches configured number of manga (default: 50) with complete metadata
-func (s *SyncService) RunInitialSync(ctx context.Context) error {
-    log.Println("[AniListSync] Starting initial sync...")
+// The result reports synced/failed counts even when the run itself errors
+func (s *SyncService) RunInitialSync(ctx context.Context) (result ingestion.SyncResult, err error) {
+    logger := s.logger.With("job", "initial_sync")
+    // Skip rather than overlap a run that is still going
+    done, ok := s.runs.TryStart("initial_sync")
+    if !ok {
+        logger.Info("previous run still in progress, skipping")
+        return result, ingestion.ErrRunInProgress
+    }
+    defer done()
+
+    logger.Info("starting initial sync")
+    start := time.Now()
 
     // Check if already completed
     state, err := s.getSyncState("anilist_initial_sync")
     if err == nil && state.Status == "completed" {
-        log.Println("[AniListSync] Initial sync already completed. Skipping.")
-        return nil
+        logger.Info("initial sync already completed, skipping")
+        return result, nil
     }
 
+    run := ingestion.StartRun("anilist", "initial_sync")
+    defer func() {
+        result = run.Result()
+        s.finishRun(run, err)
+    }()
+
     // Update status to running
     if err := s.updateSyncState("anilist_initial_sync", "running", "", nil); err != nil {
-        return fmt.Errorf("failed to update sync state: %w", err)
+        return result, fmt.Errorf("failed to update sync state: %w", err)
     }
 
     // Calculate pages needed
@@ -32,56 +52,52 @@ func (s *SyncService) RunInitialSync(ctx context.Context) error {
     }
 
     totalPages := (totalToFetch + perPage - 1) / perPage
-    log.Printf("[AniListSync] Fetching %d manga across %d pages...", totalToFetch, totalPages)
+    logger.Info("fetching manga", "limit", totalToFetch, "pages", totalPages)
 
     // Create worker pool
     pool := NewWorkerPool(s.workerCount)
     pool.Start()
 
-    successCount := 0
-    errorCount := 0
-
     // Fetch and process pages
     for page := 1; page <= totalPages; page++ {
         select {
         case <-ctx.Done():
-            log.Println("[AniListSync] Context cancelled, stopping sync...")
+            logger.Warn("context cancelled, stopping sync", "page", page)
             pool.Shutdown()
-            return ctx.Err()
+            return result, ctx.Err()
         default:
         }
 
-        log.Printf("[AniListSync] Fetching page %d/%d...", page, totalPages)
+        logger.Debug("fetching page", "page", page, "pages", totalPages)
 
         // Fetch manga list
         response, err := s.client.GetManga(ctx, page, perPage)
         if err != nil {
-            log.Printf("[AniListSync] ❌ Failed to fetch page %d: %v", page, err)
-            errorCount++
+            logger.Error("failed to fetch page", "page", page, "error", err)
+            run.Failure(fmt.Sprintf("page %d", page), err)
             continue
         }
 
-        log.Printf("[AniListSync] Processing %d manga from page %d...", len(response.Page.Media), page)
+        logger.Debug("processing page", "count", len(response.Page.Media), "page", page)
 
         // Submit tasks to worker pool
         for _, apiManga := range response.Page.Media {
             manga := apiManga // Capture for closure
-            pool.Submit(func(ctx context.Context) error {
-                if err := s.processManga(ctx, manga); err != nil {
-                    log.Printf("[AniListSync] ❌ Failed to process manga %d: %v", manga.ID, err)
-                    errorCount++
+            pool.Submit(run.Track(strconv.FormatInt(manga.ID, 10), func(ctx context.Context) error {
+                if _, err := s.processManga(ctx, manga); err != nil {
+                    logger.Error("failed to process manga", "anilist_id", manga.ID, "error", err)
                     return err
                 }
 
                 // Notify about new manga
                 s.notifier.NotifyNewManga(int64(manga.ID), manga.Title.English, manga.Title.Romaji)
-                successCount++
+                run.Success()
                 return nil
-            })
+            }))
         }
 
         // Don't fetch more than needed
-        if successCount >= totalToFetch {
+        if synced, _ := run.Counts(); synced >= totalToFetch {
             break
         }
 
@@ -92,24 +108,41 @@ func (s *SyncService) RunInitialSync(ctx context.Context) error {
     // Wait for all workers to complete
     pool.Wait()
 
-    log.Printf("[AniListSync] Initial sync completed: %d success, %d errors", successCount, errorCount)
+    synced, failed := run.Counts()
+    logger.Info("initial sync completed", "synced", synced, "errors", failed, "duration", time.Since(start))
 
     // Update sync state
     if err := s.updateSyncState("anilist_initial_sync", "completed", "", nil); err != nil {
-        return fmt.Errorf("failed to update sync state: %w", err)
+        return result, fmt.Errorf("failed to update sync state: %w", err)
     }
 
-    return nil
+    return result, nil
 }
 
 // PollNewManga checks for newly published manga on AniList
 // Runs every 24 hours, detects manga updated since last poll
-func (s *SyncService) PollNewManga(ctx context.Context) error {
-    log.Println("[AniListSync] Polling for new manga...")
+func (s *SyncService) PollNewManga(ctx context.Context) (result ingestion.SyncResult, err error) {
+    logger := s.logger.With("job", "new_manga_poll")
+    // Skip rather than overlap a run that is still going
+    done, ok := s.runs.TryStart("new_manga_poll")
+    if !ok {
+        logger.Info("previous run still in progress, skipping")
+        return result, ingestion.ErrRunInProgress
+    }
+    defer done()
+
+    logger.Info("polling for new manga")
+    start := time.Now()
+
+    run := ingestion.StartRun("anilist", "new_manga_poll")
+    defer func() {
+        result = run.Result()
+        s.finishRun(run, err)
+    }()
 
     // Update status to running
     if err := s.updateSyncState("anilist_new_manga_poll", "running", "", nil); err != nil {
-        return fmt.Errorf("failed to update sync state: %w", err)
+        return result, fmt.Errorf("failed to update sync state: %w", err)
     }
 
     // Get last cursor (timestamp)
@@ -122,14 +155,12 @@ func (s *SyncService) PollNewManga(ctx context.Context) error {
         lastUpdate = time.Now().Add(-24 * time.Hour).Unix()
     }
 
-    log.Printf("[AniListSync] Checking for manga updated after %s", time.Unix(lastUpdate, 0).Format(time.RFC3339))
+    logger.Info("checking for updated manga", "since", time.Unix(lastUpdate, 0).Format(time.RFC3339))
 
     // Create worker pool
     pool := NewWorkerPool(s.workerCount)
     pool.Start()
 
-    successCount := 0
-    errorCount := 0
     page := 1
     perPage := 50
 
@@ -137,39 +168,39 @@ func (s *SyncService) PollNewManga(ctx context.Context) error {
         select {
         case <-ctx.Done():
             pool.Shutdown()
-            return ctx.Err()
+            return result, ctx.Err()
         default:
         }
 
         // Fetch recently updated manga
         response, err := s.client.GetRecentlyUpdated(ctx, lastUpdate, page, perPage)
         if err != nil {
-            log.Printf("[AniListSync] ❌ Failed to fetch page %d: %v", page, err)
+            logger.Error("failed to fetch page", "page", page, "error", err)
+            run.Failure(fmt.Sprintf("page %d", page), err)
             break
         }
 
         if len(response.Page.Media) == 0 {
-            log.Println("[AniListSync] No more new manga found")
+            logger.Debug("no more new manga found", "page", page)
             break
         }
 
-        log.Printf("[AniListSync] Processing %d manga from page %d...", len(response.Page.Media), page)
+        logger.Debug("processing page", "count", len(response.Page.Media), "page", page)
 
         // Process manga
         for _, apiManga := range response.Page.Media {
             manga := apiManga
-            pool.Submit(func(ctx context.Context) error {
-                if err := s.processManga(ctx, manga); err != nil {
-                    log.Printf("[AniListSync] ❌ Failed to process manga %d: %v", manga.ID, err)
-                    errorCount++
+            pool.Submit(run.Track(strconv.FormatInt(manga.ID, 10), func(ctx context.Context) error {
+                if _, err := s.processManga(ctx, manga); err != nil {
+                    logger.Error("failed to process manga", "anilist_id", manga.ID, "error", err)
                     return err
                 }
 
                 // Notify about new manga
                 s.notifier.NotifyNewManga(int64(manga.ID), manga.Title.English, manga.Title.Romaji)
-                successCount++
+                run.Success()
                 return nil
-            })
+            }))
         }
 
         // Check if there are more pages
@@ -183,81 +214,95 @@ func (s *SyncService) PollNewManga(ctx context.Context) error {
 
     pool.Wait()
 
-    log.Printf("[AniListSync] Poll completed: %d new/updated manga, %d errors", successCount, errorCount)
+    synced, failed := run.Counts()
+    logger.Info("new manga poll completed", "synced", synced, "errors", failed, "duration", time.Since(start))
 
     // Update sync state with current timestamp as cursor
     newCursor := fmt.Sprintf("%d", time.Now().Unix())
     if err := s.updateSyncState("anilist_new_manga_poll", "completed", newCursor, nil); err != nil {
-        return fmt.Errorf("failed to update sync state: %w", err)
+        return result, fmt.Errorf("failed to update sync state: %w", err)
     }
 
-    return nil
+    return result, nil
 }
 
 // CheckChapterUpdates checks for chapter count updates for tracked manga
 // Runs every 48 hours, checks manga that haven't been checked recently
-func (s *SyncService) CheckChapterUpdates(ctx context.Context) error {
-    log.Println("[AniListSync] Checking for chapter updates...")
+func (s *SyncService) CheckChapterUpdates(ctx context.Context) (result ingestion.SyncResult, err error) {
+    logger := s.logger.With("job", "chapter_check")
+    // Skip rather than overlap a run that is still going
+    done, ok := s.runs.TryStart("chapter_check")
+    if !ok {
+        logger.Info("previous run still in progress, skipping")
+        return result, ingestion.ErrRunInProgress
+    }
+    defer done()
+
+    logger.Info("checking for chapter updates")
+    start := time.Now()
+
+    run := ingestion.StartRun("anilist", "chapter_check")
+    defer func() {
+        result = run.Result()
+        s.finishRun(run, err)
+    }()
 
     // Update status to running
     if err := s.updateSyncState("anilist_chapter_check", "running", "", nil); err != nil {
-        return fmt.Errorf("failed to update sync state: %w", err)
+        return result, fmt.Errorf("failed to update sync state: %w", err)
     }
 
     // Get manga that haven't been checked in 48 hours
     var mangaList []Manga
     checkThreshold := time.Now().Add(-48 * time.Hour)
 
-    err := s.db.Where("anilist_id IS NOT NULL").
+    err = s.db.Where("anilist_id IS NOT NULL").
         Where("anilist_last_chapter_check IS NULL OR anilist_last_chapter_check < ?", checkThreshold).
         Limit(100). // Limit to avoid overwhelming the API
         Find(&mangaList).Error
 
     if err != nil {
-        return fmt.Errorf("failed to fetch manga for update check: %w", err)
+        return result, fmt.Errorf("failed to fetch manga for update check: %w", err)
     }
 
     if len(mangaList) == 0 {
-        log.Println("[AniListSync] No manga need chapter updates")
+        logger.Info("no manga need chapter updates", "duration", time.Since(start))
         if err := s.updateSyncState("anilist_chapter_check", "completed", "", nil); err != nil {
-            return err
+            return result, err
         }
-        return nil
+        return result, nil
     }
 
-    log.Printf("[AniListSync] Checking chapter updates for %d manga...", len(mangaList))
+    logger.Info("checking manga for chapter updates", "count", len(mangaList))
 
     // Create worker pool
     pool := NewWorkerPool(s.workerCount)
     pool.Start()
 
-    successCount := 0
-    errorCount := 0
-
     // Process each manga
     for _, manga := range mangaList {
         m := manga
-        pool.Submit(func(ctx context.Context) error {
+        pool.Submit(run.Track(fmt.Sprintf("manga %d", m.ID), func(ctx context.Context) error {
             if err := s.checkMangaChapters(ctx, &m); err != nil {
-                log.Printf("[AniListSync] ❌ Failed to check chapters for manga %d: %v", m.ID, err)
-                errorCount++
+                logger.Error("failed to check chapters", "manga_id", m.ID, "error", err)
                 return err
             }
-            successCount++
+            run.Success()
             return nil
-        })
+        }))
     }
 
     pool.Wait()
 
-    log.Printf("[AniListSync] Chapter check completed: %d checked, %d errors", successCount, errorCount)
+    checked, failed := run.Counts()
+    logger.Info("chapter check completed", "checked", checked, "errors", failed, "duration", time.Since(start))
 
     // Update sync state
     if err := s.updateSyncState("anilist_chapter_check", "completed", "", nil); err != nil {
-        return fmt.Errorf("failed to update sync state: %w", err)
+        return result, fmt.Errorf("failed to update sync state: %w", err)
     }
 
-    return nil
+    return result, nil
 }
 
 // checkMangaChapters checks a single manga for chapter count updates
@@ -295,9 +340,9 @@ func (s *SyncService) checkMangaChapters(ctx context.Context, manga *Manga) erro
             "anilist_last_synced_at":       &now,
         }
 
-        // Update other metadata if changed
-        if response.Media.Status != "" {
-            status := mapAniListStatus(response.Media.Status)
+        // Update other metadata if changed; an unmapped status would only
+        // overwrite a known one with the fallback
+        if status, ok := parseAniListStatus(response.Media.Status); ok {
             updates["status"] = &status
         }
 
@@ -310,7 +355,9 @@ func (s *SyncService) checkMangaChapters(ctx context.Context, manga *Manga) erro
             return fmt.Errorf("failed to update manga: %w", err)
         }
 
-        log.Printf("[AniListSync] 📖 Chapter update: %s (%d → %d chapters)", manga.Title, oldChapters, newChapters)
+        s.logger.Info("chapter update",
+            "job", "chapter_check", "manga_id", manga.ID, "title", manga.Title,
+            "old_chapters", oldChapters, "new_chapters", newChapters)
 
         // Notify about chapter update
         s.notifier.NotifyChapterUpdate(manga.ID, manga.Title, oldChapters, newChapters)
@@ -325,21 +372,35 @@ func (s *SyncService) checkMangaChapters(ctx context.Context, manga *Manga) erro
 
 // StartPollers starts all scheduled pollers in goroutines
 func (s *SyncService) StartPollers(ctx context.Context) {
-    log.Println("[AniListSync] Starting scheduled pollers...")
+    logger := s.logger.With("component", "pollers")
+    // A run that overlaps the previous one logs its own skip, so
+    // ErrRunInProgress isn't reported as a failure here
+    logger.Info("starting scheduled pollers")
 
-    // Poll for new manga every 24 hours
+    // Poll for new manga every 24 hours, or daily at newMangaPollAt
     go func() {
+        if s.newMangaPollAt != nil {
+            logger.Info("new manga poller scheduled", "daily_at", s.newMangaPollAt.String())
+            s.newMangaPollAt.Run(ctx, func() {
+                if _, err := s.PollNewManga(ctx); err != nil && !errors.Is(err, ingestion.ErrRunInProgress) {
+                    logger.Error("new manga poll failed", "error", err)
+                }
+            })
+            logger.Info("new manga poller stopped")
+            return
+        }
+
         ticker := time.NewTicker(24 * time.Hour)
         defer ticker.Stop()
 
         for {
             select {
             case <-ctx.Done():
-                log.Println("[AniListSync] New manga poller stopped")
+                logger.Info("new manga poller stopped")
                 return
             case <-ticker.C:
-                if err := s.PollNewManga(ctx); err != nil {
-                    log.Printf("[AniListSync] ❌ New manga poll failed: %v", err)
+                if _, err := s.PollNewManga(ctx); err != nil && !errors.Is(err, ingestion.ErrRunInProgress) {
+                    logger.Error("new manga poll failed", "error", err)
                 }
             }
         }
@@ -353,15 +414,15 @@ func (s *SyncService) StartPollers(ctx context.Context) {
         for {
             select {
             case <-ctx.Done():
-                log.Println("[AniListSync] Chapter check poller stopped")
+                logger.Info("chapter check poller stopped")
                 return
             case <-ticker.C:
-                if err := s.CheckChapterUpdates(ctx); err != nil {
-                    log.Printf("[AniListSync] ❌ Chapter check failed: %v", err)
+                if _, err := s.CheckChapterUpdates(ctx); err != nil && !errors.Is(err, ingestion.ErrRunInProgress) {
+                    logger.Error("chapter check failed", "error", err)
                 }
             }
         }
     }()
 
-    log.Println("[AniListSync] All pollers started")
+    logger.Info("all pollers started")
 }
\ No newline at end of file
@@ -0,0 +1,32 @@
+package anilist
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"mangahub/internal/ingestion"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflows_SkipOverlappingRun(t *testing.T) {
+	s := &SyncService{logger: slog.New(slog.DiscardHandler)}
+	runs := map[string]func(context.Context) (ingestion.SyncResult, error){
+		"initial_sync":   s.RunInitialSync,
+		"new_manga_poll": s.PollNewManga,
+		"chapter_check":  s.CheckChapterUpdates,
+	}
+	for name, run := range runs {
+		t.Run(name, func(t *testing.T) {
+			done, ok := s.runs.TryStart(name)
+			assert.True(t, ok)
+			defer done()
+
+			// Skipped before touching the database or the API
+			result, err := run(context.Background())
+			assert.ErrorIs(t, err, ingestion.ErrRunInProgress)
+			assert.Zero(t, result)
+		})
+	}
+}
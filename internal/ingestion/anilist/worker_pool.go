@@ -2,8 +2,11 @@ package anilist
 
 import (
     "context"
-    "log"
+    "errors"
+    "log/slog"
     "sync"
+
+    "mangahub/internal/ingestion"
 )
 
 // Task represents a unit of work
@@ -18,6 +21,7 @@ type WorkerPool struct {
     cancel      context.CancelFunc
     closed      bool
     closeMux    sync.Mutex
+    logger      *slog.Logger
 }
 
 // NewWorkerPool creates a pool with specified number of workers
@@ -28,6 +32,7 @@ func NewWorkerPool(workerCount int) *WorkerPool {
         taskQueue:   make(chan Task, workerCount*2),
         ctx:         ctx,
         cancel:      cancel,
+        logger:      slog.Default().With("source", "anilist", "component", "worker_pool"),
     }
 }
 
@@ -37,7 +42,7 @@ func (wp *WorkerPool) Start() {
         wp.wg.Add(1)
         go wp.worker(i)
     }
-    log.Printf("[AniListWorkerPool] Started %d workers", wp.workerCount)
+    wp.logger.Debug("started workers", "workers", wp.workerCount)
 }
 
 // Submit adds a task to the queue
@@ -45,7 +50,7 @@ func (wp *WorkerPool) Submit(task Task) {
     select {
     case wp.taskQueue <- task:
     case <-wp.ctx.Done():
-        log.Println("[AniListWorkerPool] Pool is shutting down, task rejected")
+        wp.logger.Warn("pool is shutting down, task rejected")
     }
 }
 
@@ -59,12 +64,12 @@ func (wp *WorkerPool) Wait() {
     wp.closeMux.Unlock()
 
     wp.wg.Wait()
-    log.Println("[AniListWorkerPool] All workers completed")
+    wp.logger.Debug("all workers completed")
 }
 
 // Shutdown cancels all workers
 func (wp *WorkerPool) Shutdown() {
-    log.Println("[AniListWorkerPool] Shutting down...")
+    wp.logger.Info("shutting down")
     wp.cancel()
     wp.Wait()
 }
@@ -80,8 +85,13 @@ func (wp *WorkerPool) worker(id int) {
                 return
             }
 
-            if err := task(wp.ctx); err != nil {
-                log.Printf("[AniListWorker-%d] Task failed: %v", id, err)
+            if err := ingestion.RunRecovered(wp.ctx, task); err != nil {
+                var pe *ingestion.PanicError
+                if errors.As(err, &pe) {
+                    wp.logger.Error("task panicked", "worker", id, "error", err, "stack", string(pe.Stack))
+                } else {
+                    wp.logger.Warn("task failed", "worker", id, "error", err)
+                }
             }
 
         case <-wp.ctx.Done():
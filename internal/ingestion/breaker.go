@@ -0,0 +1,156 @@
+package ingestion
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Circuit breaker defaults
+const (
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 1 * time.Minute
+)
+
+// BreakerState is the state of a CircuitBreaker
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// ErrCircuitOpen is returned while the breaker is short-circuiting calls
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// BreakerConfig configures a CircuitBreaker. Zero values use the defaults above.
+type BreakerConfig struct {
+	Threshold int           // Consecutive failures before the breaker opens
+	Cooldown  time.Duration // How long the breaker stays open before probing
+}
+
+// BreakerSnapshot is a point-in-time view of a breaker for status reporting
+type BreakerSnapshot struct {
+	Name                string       `json:"name"`
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	Threshold           int          `json:"threshold"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+	RetryAt             *time.Time   `json:"retry_at,omitempty"`
+}
+
+// CircuitBreaker stops calls to an upstream API after repeated failures.
+// Closed lets every call through. After Threshold consecutive failures it
+// opens and rejects calls with ErrCircuitOpen for Cooldown, then goes
+// half-open and lets a single probe through: success closes it again,
+// failure re-opens it for another cool-down.
+type CircuitBreaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a closed breaker
+func NewCircuitBreaker(name string, cfg BreakerConfig) *CircuitBreaker {
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = DefaultBreakerThreshold
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = DefaultBreakerCooldown
+	}
+
+	return &CircuitBreaker{
+		name:      name,
+		threshold: cfg.Threshold,
+		cooldown:  cfg.Cooldown,
+		now:       time.Now,
+		state:     BreakerClosed,
+	}
+}
+
+// Allow reports whether a call may proceed. Every call that is allowed must
+// be followed by exactly one Record.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return fmt.Errorf("%s: %w", b.name, ErrCircuitOpen)
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return fmt.Errorf("%s: %w", b.name, ErrCircuitOpen)
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Record reports the outcome of an allowed call. A nil error counts as a
+// success and any other error, including a client timeout, as a failure.
+// Callers whose own context is done should use Release instead.
+func (b *CircuitBreaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbe := b.probing
+	b.probing = false
+
+	if err == nil {
+		b.failures = 0
+		b.state = BreakerClosed
+		return
+	}
+
+	b.failures++
+	if wasProbe || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// Release ends an allowed call without recording an outcome. It is for calls
+// abandoned because the caller's context was cancelled or timed out, which
+// says nothing about the upstream's health.
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// Snapshot returns the breaker's current state
+func (b *CircuitBreaker) Snapshot() BreakerSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snap := BreakerSnapshot{
+		Name:                b.name,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		Threshold:           b.threshold,
+	}
+	if b.state != BreakerClosed {
+		openedAt := b.openedAt
+		retryAt := openedAt.Add(b.cooldown)
+		snap.OpenedAt = &openedAt
+		snap.RetryAt = &retryAt
+	}
+	return snap
+}
@@ -0,0 +1,94 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBreaker(threshold int, cooldown time.Duration) (*CircuitBreaker, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := NewCircuitBreaker("test", BreakerConfig{Threshold: threshold, Cooldown: cooldown})
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	b, _ := newTestBreaker(3, time.Minute)
+	upstreamErr := errors.New("HTTP 503")
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, b.Allow())
+		b.Record(upstreamErr)
+	}
+
+	assert.Equal(t, BreakerOpen, b.Snapshot().State)
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	b, _ := newTestBreaker(3, time.Minute)
+
+	b.Record(errors.New("timeout"))
+	b.Record(errors.New("timeout"))
+	b.Record(nil)
+	b.Record(errors.New("timeout"))
+
+	snap := b.Snapshot()
+	assert.Equal(t, BreakerClosed, snap.State)
+	assert.Equal(t, 1, snap.ConsecutiveFailures)
+}
+
+func TestCircuitBreaker_HalfOpenProbe(t *testing.T) {
+	b, now := newTestBreaker(1, time.Minute)
+
+	b.Record(errors.New("connection refused"))
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+
+	*now = now.Add(time.Minute)
+
+	// Only one probe goes through while half-open
+	assert.NoError(t, b.Allow())
+	assert.Equal(t, BreakerHalfOpen, b.Snapshot().State)
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+
+	// A failed probe re-opens for another cool-down
+	b.Record(errors.New("connection refused"))
+	assert.Equal(t, BreakerOpen, b.Snapshot().State)
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+
+	*now = now.Add(time.Minute)
+	assert.NoError(t, b.Allow())
+	b.Record(nil)
+	assert.Equal(t, BreakerClosed, b.Snapshot().State)
+}
+
+func TestCircuitBreaker_ReleaseKeepsState(t *testing.T) {
+	b, now := newTestBreaker(1, time.Minute)
+
+	b.Record(errors.New("HTTP 500"))
+	*now = now.Add(time.Minute)
+
+	assert.NoError(t, b.Allow())
+	b.Release()
+
+	// The probe slot is released without changing state
+	assert.Equal(t, BreakerHalfOpen, b.Snapshot().State)
+	assert.NoError(t, b.Allow())
+}
+
+func TestCircuitBreaker_CountsTimeouts(t *testing.T) {
+	b, _ := newTestBreaker(2, time.Minute)
+
+	assert.NoError(t, b.Allow())
+	b.Record(context.DeadlineExceeded)
+	assert.NoError(t, b.Allow())
+	b.Record(fmt.Errorf("Post \"https://graphql.anilist.co\": %w", context.DeadlineExceeded))
+
+	assert.Equal(t, BreakerOpen, b.Snapshot().State)
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+}
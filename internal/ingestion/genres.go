@@ -0,0 +1,63 @@
+// Package ingestion holds helpers shared by the MangaDex and AniList sync services.
+package ingestion
+
+import (
+	"fmt"
+
+	"mangahub/internal/microservices/http-api/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LinkGenres get-or-creates each genre by normalized name and links it to the
+// manga. It runs on the caller's transaction and is safe to call from
+// concurrent workers: inserts use ON CONFLICT DO NOTHING, so a genre created
+// by another worker in the meantime is simply looked up instead of aborting
+// the transaction with a unique-constraint error.
+func LinkGenres(tx *gorm.DB, mangaID int64, names []string) error {
+	for _, name := range uniqueGenreNames(names) {
+		genre, err := getOrCreateGenre(tx, name)
+		if err != nil {
+			return err
+		}
+
+		link := models.MangaGenre{MangaID: mangaID, GenreID: genre.ID}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&link).Error; err != nil {
+			return fmt.Errorf("link genre %q to manga %d: %w", name, mangaID, err)
+		}
+	}
+	return nil
+}
+
+// getOrCreateGenre returns the genre matching name's normalized key, creating it if needed
+func getOrCreateGenre(tx *gorm.DB, name string) (*models.Genre, error) {
+	genre := models.Genre{Name: name}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&genre).Error; err != nil {
+		return nil, fmt.Errorf("create genre %q: %w", name, err)
+	}
+
+	// Nothing inserted: the genre already existed (possibly just created by another worker)
+	if genre.ID == 0 {
+		if err := tx.Where("normalized_name = ?", models.NormalizeGenreName(name)).First(&genre).Error; err != nil {
+			return nil, fmt.Errorf("find genre %q: %w", name, err)
+		}
+	}
+	return &genre, nil
+}
+
+// uniqueGenreNames drops blank names and names that normalize to one already seen,
+// keeping the first spelling
+func uniqueGenreNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		key := models.NormalizeGenreName(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, models.CleanGenreName(name))
+	}
+	return out
+}
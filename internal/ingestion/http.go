@@ -0,0 +1,72 @@
+package ingestion
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ErrNotFound is returned by the source clients when the upstream API has no
+// such resource (HTTP 404)
+var ErrNotFound = errors.New("not found at source")
+
+// Default HTTP settings shared by the MangaDex and AniList clients
+const (
+	DefaultHTTPTimeout         = 30 * time.Second
+	DefaultDialTimeout         = 10 * time.Second
+	DefaultTLSHandshakeTimeout = 10 * time.Second
+	DefaultIdleConnTimeout     = 90 * time.Second
+
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 10
+)
+
+// HTTPConfig holds the timeouts used for upstream API requests.
+// Zero values fall back to the defaults above.
+type HTTPConfig struct {
+	Timeout             time.Duration // Whole request, including reading the body
+	DialTimeout         time.Duration
+	TLSHandshakeTimeout time.Duration
+	IdleConnTimeout     time.Duration
+}
+
+// NewHTTPClient builds an HTTP client with a pooled transport. Idle connections
+// per host scale with workerCount so concurrent workers reuse connections
+// instead of re-dialing the API on every request.
+func NewHTTPClient(cfg HTTPConfig, workerCount int) *http.Client {
+	idlePerHost := defaultMaxIdleConnsPerHost
+	if workerCount > idlePerHost {
+		idlePerHost = workerCount
+	}
+
+	maxIdle := defaultMaxIdleConns
+	if idlePerHost > maxIdle {
+		maxIdle = idlePerHost
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   orDefault(cfg.DialTimeout, DefaultDialTimeout),
+		KeepAlive: 30 * time.Second,
+	}
+
+	return &http.Client{
+		Timeout: orDefault(cfg.Timeout, DefaultHTTPTimeout),
+		Transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        maxIdle,
+			MaxIdleConnsPerHost: idlePerHost,
+			IdleConnTimeout:     orDefault(cfg.IdleConnTimeout, DefaultIdleConnTimeout),
+			TLSHandshakeTimeout: orDefault(cfg.TLSHandshakeTimeout, DefaultTLSHandshakeTimeout),
+		},
+	}
+}
+
+func orDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
@@ -0,0 +1,34 @@
+package ingestion
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// NewLogger builds the sync services' logger from LOG_LEVEL / LOG_FORMAT
+// values. Format "json" emits one JSON object per line for log aggregation;
+// anything else uses slog's key=value text format.
+func NewLogger(level, format string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(level)}
+
+	if strings.EqualFold(format, "json") {
+		return slog.New(slog.NewJSONHandler(os.Stdout, opts))
+	}
+	return slog.New(slog.NewTextHandler(os.Stdout, opts))
+}
+
+// parseLevel maps the config's log levels onto slog levels.
+// fatal and panic have no slog equivalent and are treated as error.
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error", "fatal", "panic":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,25 @@
+package ingestion
+
+import (
+	"fmt"
+
+	"mangahub/internal/microservices/http-api/models"
+)
+
+// DefaultMangaStatus is stored for a source status the sync services can't
+// map when INGESTION_DEFAULT_STATUS is unset. It stays "ongoing" for
+// compatibility; "unknown" keeps unmapped series apart for admins to review.
+const DefaultMangaStatus = string(models.MangaStatusOngoing)
+
+// ParseDefaultStatus parses INGESTION_DEFAULT_STATUS, which may be any manga
+// status; an empty string gives DefaultMangaStatus
+func ParseDefaultStatus(s string) (string, error) {
+	if s == "" {
+		return DefaultMangaStatus, nil
+	}
+	status, ok := models.ParseMangaStatus(s)
+	if !ok {
+		return "", fmt.Errorf("unknown status %q (want one of: %s)", s, models.MangaStatusList())
+	}
+	return string(status), nil
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"time"
 
+	"mangahub/internal/ingestion"
+
 	"golang.org/x/time/rate"
 )
 
@@ -32,25 +34,35 @@ type MangaDexClient struct {
 	apiKey      string
 	httpClient  *http.Client
 	rateLimiter *rate.Limiter
+	breaker     *ingestion.CircuitBreaker
+	logger      *slog.Logger
 }
 
-// NewClient creates a new MangaDex API client
-func NewClient(apiKey string) *MangaDexClient {
+// NewClient creates a new MangaDex API client. A nil httpClient uses the
+// default ingestion transport and a nil breaker uses the default thresholds.
+func NewClient(apiKey string, httpClient *http.Client, breaker *ingestion.CircuitBreaker) *MangaDexClient {
+	if httpClient == nil {
+		httpClient = ingestion.NewHTTPClient(ingestion.HTTPConfig{}, 0)
+	}
+	if breaker == nil {
+		breaker = ingestion.NewCircuitBreaker("mangadex", ingestion.BreakerConfig{})
+	}
+
 	return &MangaDexClient{
 		baseURL:     baseURL,
 		apiKey:      apiKey,
 		rateLimiter: rate.NewLimiter(rate.Limit(rateLimit), rateBurst),
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 10,
-				IdleConnTimeout:     90 * time.Second,
-			},
-		},
+		httpClient:  httpClient,
+		breaker:     breaker,
+		logger:      slog.Default().With("source", "mangadex", "component", "client"),
 	}
 }
 
+// Breaker returns the state of the client's circuit breaker
+func (c *MangaDexClient) Breaker() ingestion.BreakerSnapshot {
+	return c.breaker.Snapshot()
+}
+
 // GetManga fetches a list of manga with pagination and filters
 func (c *MangaDexClient) GetManga(ctx context.Context, params url.Values) (*MangaListResponse, error) {
 	endpoint := "/manga"
@@ -71,6 +83,23 @@ func (c *MangaDexClient) GetManga(ctx context.Context, params url.Values) (*Mang
 	return &response, nil
 }
 
+// GetMangaByID fetches a single manga with complete metadata
+func (c *MangaDexClient) GetMangaByID(ctx context.Context, mangaID string) (*MangaResponse, error) {
+	endpoint := fmt.Sprintf("/manga/%s", url.PathEscape(mangaID))
+
+	params := url.Values{}
+	params.Add("includes[]", "author")
+	params.Add("includes[]", "cover_art")
+	params.Add("includes[]", "artist")
+
+	var response MangaResponse
+	if err := c.doRequest(ctx, "GET", endpoint, params, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch manga %s: %w", mangaID, err)
+	}
+
+	return &response, nil
+}
+
 // GetMangaFeed fetches chapters for a specific manga
 func (c *MangaDexClient) GetMangaFeed(ctx context.Context, mangaID string, params url.Values) (*ChapterListResponse, error) {
 	endpoint := fmt.Sprintf("/manga/%s/feed", mangaID)
@@ -123,13 +152,24 @@ func (c *MangaDexClient) doRequest(ctx context.Context, method, endpoint string,
 			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
 		}
 
+		// Short-circuit while MangaDex is considered down
+		if err := c.breaker.Allow(); err != nil {
+			return err
+		}
+
 		// Execute request
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
+			// Our own cancellation or deadline says nothing about the upstream
+			if ctx.Err() != nil {
+				c.breaker.Release()
+				return fmt.Errorf("request cancelled: %w", err)
+			}
+			c.breaker.Record(err)
 			lastErr = err
 			if attempt < maxRetries {
-				log.Printf("[MangaDex] Request failed (attempt %d/%d): %v, retrying in %v...",
-					attempt+1, maxRetries, err, delay)
+				c.logger.Warn("request failed, retrying",
+					"endpoint", endpoint, "attempt", attempt+1, "max_retries", maxRetries, "delay", delay, "error", err)
 				time.Sleep(delay)
 				delay = minDuration(delay*2, maxDelay)
 				continue
@@ -143,10 +183,21 @@ func (c *MangaDexClient) doRequest(ctx context.Context, method, endpoint string,
 		if resp.StatusCode != http.StatusOK {
 			bodyBytes, _ := io.ReadAll(resp.Body)
 			bodyStr := string(bodyBytes)
+			httpErr := fmt.Errorf("HTTP %d: %s", resp.StatusCode, bodyStr)
+			if resp.StatusCode == http.StatusNotFound {
+				httpErr = fmt.Errorf("%w: %s", ingestion.ErrNotFound, bodyStr)
+			}
+
+			// Only rate limiting and server errors count against the breaker
+			if shouldRetry(resp.StatusCode) {
+				c.breaker.Record(httpErr)
+			} else {
+				c.breaker.Record(nil)
+			}
 
 			// Retry on rate limit or server errors
 			if shouldRetry(resp.StatusCode) && attempt < maxRetries {
-				lastErr = fmt.Errorf("HTTP %d: %s", resp.StatusCode, bodyStr)
+				lastErr = httpErr
 
 				// Check for Retry-After header
 				if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
@@ -155,16 +206,18 @@ func (c *MangaDexClient) doRequest(ctx context.Context, method, endpoint string,
 					}
 				}
 
-				log.Printf("[MangaDex] HTTP %d (attempt %d/%d), retrying in %v...",
-					resp.StatusCode, attempt+1, maxRetries, delay)
+				c.logger.Warn("upstream error, retrying",
+					"endpoint", endpoint, "status", resp.StatusCode, "attempt", attempt+1, "max_retries", maxRetries, "delay", delay)
 				time.Sleep(delay)
 				delay = minDuration(delay*2, maxDelay)
 				continue
 			}
 
-			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, bodyStr)
+			return httpErr
 		}
 
+		c.breaker.Record(nil)
+
 		// Parse response
 		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
 			return fmt.Errorf("failed to parse response: %w", err)
@@ -0,0 +1,62 @@
+package mangadex
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mangahub/internal/ingestion"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newStallingServer(t *testing.T) *httptest.Server {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+	return srv
+}
+
+func TestDoRequest_ClientTimeoutCountsAsFailure(t *testing.T) {
+	srv := newStallingServer(t)
+
+	breaker := ingestion.NewCircuitBreaker("mangadex", ingestion.BreakerConfig{Threshold: 1, Cooldown: time.Minute})
+	c := NewClient("", &http.Client{Timeout: 50 * time.Millisecond}, breaker)
+	c.baseURL = srv.URL
+
+	var result map[string]interface{}
+	err := c.doRequest(context.Background(), http.MethodGet, "/manga", nil, &result)
+
+	// The retry after the timeout is short-circuited by the open breaker
+	assert.ErrorIs(t, err, ingestion.ErrCircuitOpen)
+	snap := c.Breaker()
+	assert.Equal(t, ingestion.BreakerOpen, snap.State)
+	assert.Equal(t, 1, snap.ConsecutiveFailures)
+}
+
+func TestDoRequest_CallerCancellationIsNotRecorded(t *testing.T) {
+	srv := newStallingServer(t)
+
+	breaker := ingestion.NewCircuitBreaker("mangadex", ingestion.BreakerConfig{Threshold: 1, Cooldown: time.Minute})
+	c := NewClient("", &http.Client{}, breaker)
+	c.baseURL = srv.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var result map[string]interface{}
+	err := c.doRequest(ctx, http.MethodGet, "/manga", nil, &result)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	snap := c.Breaker()
+	assert.Equal(t, ingestion.BreakerClosed, snap.State)
+	assert.Equal(t, 0, snap.ConsecutiveFailures)
+}
@@ -1,46 +1,43 @@
 package mangadex
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"log"
-	"net/http"
-	"time"
+	"log/slog"
+
+	"mangahub/internal/ingestion"
 )
 
 // Notifier sends notifications to the UDP notification server
 type Notifier struct {
-	udpServerURL string // http://localhost:8085 or http://udp-server:8085
-	httpClient   *http.Client
+	delivery *ingestion.NotifyDelivery
+	logger   *slog.Logger
 }
 
-// NewNotifier creates a new notifier instance
-func NewNotifier(udpServerURL string) *Notifier {
+// NewNotifier creates a new notifier instance. Calls to the UDP server are
+// retried and, if it stays down, buffered as configured by cfg.
+func NewNotifier(udpServerURL, internalToken string, cfg ingestion.NotifyConfig) *Notifier {
+	logger := slog.Default().With("source", "mangadex", "component", "notifier")
 	return &Notifier{
-		udpServerURL: udpServerURL,
-		httpClient: &http.Client{
-			Timeout: 5 * time.Second,
-		},
+		delivery: ingestion.NewNotifyDelivery(udpServerURL, internalToken, cfg, logger),
+		logger:   logger,
 	}
 }
 
 // NotifyNewManga sends notification for a newly discovered manga (async, non-blocking)
 func (n *Notifier) NotifyNewManga(mangaID int64, title string) {
 	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := context.Background()
 
 		payload := map[string]interface{}{
 			"manga_id": mangaID,
 			"title":    title,
 		}
 
-		if err := n.sendNotification(ctx, "/notify/new-manga", payload); err != nil {
-			log.Printf("[Notifier] Failed to send new manga notification for '%s': %v", title, err)
+		if err := n.sendNotification(ctx, "/notify/new-manga", fmt.Sprintf("manga:%d", mangaID), payload); err != nil {
+			n.logger.Warn("failed to send new manga notification", "manga_id", mangaID, "title", title, "error", err)
 		} else {
-			log.Printf("[Notifier] ✅ Sent new manga notification: %s (ID: %d)", title, mangaID)
+			n.logger.Debug("sent new manga notification", "manga_id", mangaID, "title", title)
 		}
 	}()
 }
@@ -48,8 +45,7 @@ func (n *Notifier) NotifyNewManga(mangaID int64, title string) {
 // NotifyNewChapter sends notification for a new chapter (async, non-blocking)
 func (n *Notifier) NotifyNewChapter(mangaID int64, title string, chapter int) {
 	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := context.Background()
 
 		payload := map[string]interface{}{
 			"manga_id": mangaID,
@@ -57,10 +53,10 @@ func (n *Notifier) NotifyNewChapter(mangaID int64, title string, chapter int) {
 			"chapter":  chapter,
 		}
 
-		if err := n.sendNotification(ctx, "/notify/new-chapter", payload); err != nil {
-			log.Printf("[Notifier] Failed to send chapter notification for '%s' ch.%d: %v", title, chapter, err)
+		if err := n.sendNotification(ctx, "/notify/new-chapter", fmt.Sprintf("chapter:%d:%d", mangaID, chapter), payload); err != nil {
+			n.logger.Warn("failed to send chapter notification", "manga_id", mangaID, "title", title, "chapter", chapter, "error", err)
 		} else {
-			log.Printf("[Notifier] ✅ Sent new chapter notification: %s - Chapter %d (ID: %d)", title, chapter, mangaID)
+			n.logger.Debug("sent new chapter notification", "manga_id", mangaID, "title", title, "chapter", chapter)
 		}
 	}()
 }
@@ -68,8 +64,7 @@ func (n *Notifier) NotifyNewChapter(mangaID int64, title string, chapter int) {
 // NotifyNewChapterWithPrevious sends notification with previous chapter info for comparison
 func (n *Notifier) NotifyNewChapterWithPrevious(mangaID int64, title string, oldChapter, newChapter int) {
 	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := context.Background()
 
 		payload := map[string]interface{}{
 			"manga_id":    mangaID,
@@ -78,10 +73,10 @@ func (n *Notifier) NotifyNewChapterWithPrevious(mangaID int64, title string, old
 			"old_chapter": oldChapter,
 		}
 
-		if err := n.sendNotification(ctx, "/notify/new-chapter", payload); err != nil {
-			log.Printf("[Notifier] Failed to send chapter notification for '%s' ch.%d: %v", title, newChapter, err)
+		if err := n.sendNotification(ctx, "/notify/new-chapter", fmt.Sprintf("chapter:%d:%d", mangaID, newChapter), payload); err != nil {
+			n.logger.Warn("failed to send chapter notification", "manga_id", mangaID, "title", title, "chapter", newChapter, "error", err)
 		} else {
-			log.Printf("[Notifier] ✅ Sent new chapter notification: %s - Chapter %d→%d (ID: %d)", title, oldChapter, newChapter, mangaID)
+			n.logger.Debug("sent new chapter notification", "manga_id", mangaID, "title", title, "old_chapter", oldChapter, "chapter", newChapter)
 		}
 	}()
 }
@@ -89,8 +84,7 @@ func (n *Notifier) NotifyNewChapterWithPrevious(mangaID int64, title string, old
 // NotifyMangaUpdate sends notification for manga metadata update (async, non-blocking)
 func (n *Notifier) NotifyMangaUpdate(mangaID int64, title string) {
 	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := context.Background()
 
 		payload := map[string]interface{}{
 			"manga_id": mangaID,
@@ -98,40 +92,18 @@ func (n *Notifier) NotifyMangaUpdate(mangaID int64, title string) {
 			"changes":  []string{"metadata"}, // Generic update from sync
 		}
 
-		if err := n.sendNotification(ctx, "/notify/manga-update", payload); err != nil {
-			log.Printf("[Notifier] Failed to send manga update notification for '%s': %v", title, err)
+		if err := n.sendNotification(ctx, "/notify/manga-update", "", payload); err != nil {
+			n.logger.Warn("failed to send manga update notification", "manga_id", mangaID, "title", title, "error", err)
 		} else {
-			log.Printf("[Notifier] ✅ Sent manga update notification: %s (ID: %d)", title, mangaID)
+			n.logger.Debug("sent manga update notification", "manga_id", mangaID, "title", title)
 		}
 	}()
 }
 
-// sendNotification sends HTTP POST request to UDP server
-func (n *Notifier) sendNotification(ctx context.Context, endpoint string, payload map[string]interface{}) error {
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %w", err)
-	}
-
-	url := n.udpServerURL + endpoint
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := n.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	return nil
+// sendNotification sends HTTP POST request to UDP server. A non-empty
+// idempotencyKey lets the server drop duplicates when a call is retried.
+func (n *Notifier) sendNotification(ctx context.Context, endpoint, idempotencyKey string, payload map[string]interface{}) error {
+	return n.delivery.Send(ctx, endpoint, idempotencyKey, payload)
 }
 
 // NotifyBatch sends multiple notifications in batch (for efficiency)
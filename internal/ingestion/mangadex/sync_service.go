@@ -3,11 +3,13 @@ package mangadex
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"strconv"
 	"time"
 
+	"mangahub/internal/ingestion"
+
 	"gorm.io/gorm"
 )
 
@@ -16,11 +18,17 @@ type SyncService struct {
 	client   *MangaDexClient
 	db       *gorm.DB
 	notifier *Notifier
+	logger   *slog.Logger
 
 	// Configuration
 	initialSyncLimit int
 	workerCount      int
 	rateSemaphore    chan struct{} // Limits concurrent API calls
+	newMangaPollAt   *ingestion.DailySchedule
+	sourcePriority   []string // Which source wins when both supply a title
+	defaultStatus    string   // Stored for source statuses we can't map
+
+	runs ingestion.RunGuard // Keeps each sync from overlapping itself
 }
 
 // SyncConfig holds configuration for the sync service
@@ -28,33 +36,70 @@ type SyncConfig struct {
 	APIKey           string
 	DatabaseURL      string
 	UDPServerURL     string
+	InternalToken    string // Shared secret for the UDP server's HTTP trigger
 	InitialSyncLimit int
 	WorkerCount      int
 	RateConcurrency  int // Max concurrent API calls (default: 5)
+
+	// HTTP timeouts for upstream API requests (zero values use defaults)
+	HTTP ingestion.HTTPConfig
+
+	// Circuit breaker around upstream API calls (zero values use defaults)
+	Breaker ingestion.BreakerConfig
+
+	// Retries, timeout and buffering for notifications to the UDP server's
+	// HTTP trigger (zero values use defaults)
+	Notify ingestion.NotifyConfig
+
+	// Run the daily new manga poll at a fixed local time instead of every
+	// 24h from startup (nil keeps the interval)
+	NewMangaPollAt *ingestion.DailySchedule
+
+	// Source order for merging a title that both sources supply, highest
+	// first (nil uses ingestion.DefaultSourcePriority)
+	SourcePriority []string
+
+	// Status stored when the source reports one we can't map ("" uses
+	// ingestion.DefaultMangaStatus)
+	DefaultStatus string
 }
 
 // NewSyncService creates a new sync service instance
 func NewSyncService(config SyncConfig, db *gorm.DB) *SyncService {
-	client := NewClient(config.APIKey)
-	notifier := NewNotifier(config.UDPServerURL)
-
 	workerCount := config.WorkerCount
 	if workerCount == 0 {
 		workerCount = 10 // Default
 	}
 
+	client := NewClient(config.APIKey, ingestion.NewHTTPClient(config.HTTP, workerCount), ingestion.NewCircuitBreaker("mangadex", config.Breaker))
+	notifier := NewNotifier(config.UDPServerURL, config.InternalToken, config.Notify)
+
 	rateConcurrency := config.RateConcurrency
 	if rateConcurrency == 0 {
 		rateConcurrency = 5 // Default (MangaDex limit)
 	}
 
+	sourcePriority := config.SourcePriority
+	if len(sourcePriority) == 0 {
+		sourcePriority = ingestion.DefaultSourcePriority
+	}
+
+	defaultStatus := config.DefaultStatus
+	if defaultStatus == "" {
+		defaultStatus = ingestion.DefaultMangaStatus
+	}
+
 	return &SyncService{
 		client:           client,
 		db:               db,
 		notifier:         notifier,
+		logger:           slog.Default().With("source", "mangadex"),
 		initialSyncLimit: config.InitialSyncLimit,
 		workerCount:      workerCount,
 		rateSemaphore:    make(chan struct{}, rateConcurrency),
+		newMangaPollAt:   config.NewMangaPollAt,
+		sourcePriority:   sourcePriority,
+		defaultStatus:    defaultStatus,
 	}
 }
 
@@ -64,15 +109,15 @@ func NewSyncService(config SyncConfig, db *gorm.DB) *SyncService {
 
 // SyncState represents a sync operation state in the database
 type SyncState struct {
-	ID            int    `gorm:"primaryKey"`
-	SyncType      string `gorm:"unique;not null"`
-	LastRunAt     *time.Time
-	LastSuccessAt *time.Time
-	LastCursor    string
-	Status        string
-	ErrorMessage  string
-	Metadata      string `gorm:"type:jsonb"`
-	UpdatedAt     time.Time
+	ID            int        `gorm:"primaryKey" json:"id"`
+	SyncType      string     `gorm:"unique;not null" json:"sync_type"`
+	LastRunAt     *time.Time `json:"last_run_at"`
+	LastSuccessAt *time.Time `json:"last_success_at"`
+	LastCursor    string     `json:"last_cursor"`
+	Status        string     `json:"status"`
+	ErrorMessage  string     `json:"error_message,omitempty"`
+	Metadata      string     `gorm:"type:jsonb" json:"-"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
 // TableName specifies the table name for SyncState
@@ -113,6 +158,45 @@ func (s *SyncService) getSyncState(syncType string) (*SyncState, error) {
 	return &state, nil
 }
 
+// SyncStatus is the payload served by the sync status endpoint
+type SyncStatus struct {
+	Source         string                    `json:"source"`
+	States         []SyncState               `json:"states"`
+	CircuitBreaker ingestion.BreakerSnapshot `json:"circuit_breaker"`
+	RecentRuns     []ingestion.SyncRun       `json:"recent_runs"`
+}
+
+// Status reports the stored sync states, the API circuit breaker and the
+// most recent sync runs
+func (s *SyncService) Status(ctx context.Context, opts ingestion.StatusOptions) (*SyncStatus, error) {
+	var states []SyncState
+	if err := s.db.WithContext(ctx).
+		Where("sync_type IN ?", []string{"initial_sync", "new_manga_poll", "chapter_check"}).
+		Order("sync_type").
+		Find(&states).Error; err != nil {
+		return nil, fmt.Errorf("failed to load sync states: %w", err)
+	}
+
+	runs, err := ingestion.RecentSyncRuns(ctx, s.db, "mangadex", opts.Runs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sync runs: %w", err)
+	}
+
+	return &SyncStatus{
+		Source:         "mangadex",
+		States:         states,
+		CircuitBreaker: s.client.Breaker(),
+		RecentRuns:     runs,
+	}, nil
+}
+
+// finishRun persists a sync run; failing to record history never fails the sync
+func (s *SyncService) finishRun(run *ingestion.RunTracker, err error) {
+	if _, saveErr := run.Finish(s.db, err); saveErr != nil {
+		s.logger.Warn("failed to record sync run", "error", saveErr)
+	}
+}
+
 // ============================================
 // DATABASE MODELS
 // ============================================
@@ -121,6 +205,7 @@ func (s *SyncService) getSyncState(syncType string) (*SyncState, error) {
 type Manga struct {
 	ID               int64   `gorm:"primaryKey;autoIncrement"`
 	MangaDexID       *string `gorm:"column:mangadex_id;type:uuid;unique"`
+	AniListID        *int64  `gorm:"column:anilist_id;->"` // Set when AniList also supplies this title
 	Slug             *string `gorm:"unique"`
 	Title            string  `gorm:"not null"`
 	Author           *string
@@ -159,29 +244,6 @@ func (Chapter) TableName() string {
 	return "chapters"
 }
 
-// Genre represents a genre in database
-type Genre struct {
-	ID   int    `gorm:"primaryKey;autoIncrement"`
-	Name string `gorm:"unique;not null"`
-}
-
-// TableName specifies the table name for Genre
-func (Genre) TableName() string {
-	return "genres"
-}
-
-// MangaGenre represents the many-to-many relationship
-type MangaGenre struct {
-	ID      int64 `gorm:"primaryKey;autoIncrement"`
-	MangaID int64 `gorm:"not null;index"`
-	GenreID int   `gorm:"not null;index"`
-}
-
-// TableName specifies the table name for MangaGenre
-func (MangaGenre) TableName() string {
-	return "manga_genres"
-}
-
 // ============================================
 // HELPER FUNCTIONS
 // ============================================
@@ -195,22 +257,30 @@ func (s *SyncService) storeManga(ctx context.Context, extracted *ExtractedManga)
 		}
 	}()
 
-	// Check if manga already exists
+	// Check if manga already exists: by MangaDex ID, else an AniList row with
+	// the same slug that no MangaDex entry has claimed yet
 	var existingManga Manga
 	err := tx.Where("mangadex_id = ?", extracted.MangaDexID).First(&existingManga).Error
+	if err == gorm.ErrRecordNotFound && extracted.Slug != "" {
+		err = tx.Where("slug = ? AND mangadex_id IS NULL", extracted.Slug).First(&existingManga).Error
+	}
 
+	// Fields MangaDex left empty stay nil, so Updates skips them rather
+	// than writing blanks over what is already stored
 	now := time.Now()
 	manga := Manga{
-		MangaDexID:    &extracted.MangaDexID,
-		Slug:          &extracted.Slug,
-		Title:         extracted.Title,
-		Author:        &extracted.Author,
-		Status:        &extracted.Status,
-		TotalChapters: &extracted.TotalChapters,
-		Description:   &extracted.Description,
-		CoverURL:      &extracted.CoverURL,
-		LastSyncedAt:  &now,
+		MangaDexID:   &extracted.MangaDexID,
+		Slug:         &extracted.Slug,
+		LastSyncedAt: &now,
 	}
+	manga.setMetadata(ingestion.MetadataFields{
+		Title:         extracted.Title,
+		Author:        extracted.Author,
+		Status:        extracted.Status,
+		Description:   extracted.Description,
+		CoverURL:      extracted.CoverURL,
+		TotalChapters: extracted.TotalChapters,
+	})
 
 	if err == gorm.ErrRecordNotFound {
 		// Create new manga
@@ -224,30 +294,19 @@ func (s *SyncService) storeManga(ctx context.Context, extracted *ExtractedManga)
 	} else {
 		// Update existing manga
 		manga.ID = existingManga.ID
+		if existingManga.AniListID != nil {
+			s.mergeWithAniList(&manga, existingManga)
+		}
 		if err := tx.Model(&manga).Updates(manga).Error; err != nil {
 			tx.Rollback()
 			return 0, fmt.Errorf("failed to update manga: %w", err)
 		}
 	}
 
-	// Store genres
-	for _, genreName := range extracted.Genres {
-		// Upsert genre
-		var genre Genre
-		err := tx.Where("name = ?", genreName).FirstOrCreate(&genre, Genre{Name: genreName}).Error
-		if err != nil {
-			continue
-		}
-
-		// Link manga to genre
-		var link MangaGenre
-		err = tx.Where("manga_id = ? AND genre_id = ?", manga.ID, genre.ID).FirstOrCreate(&link, MangaGenre{
-			MangaID: manga.ID,
-			GenreID: genre.ID,
-		}).Error
-		if err != nil {
-			log.Printf("[SyncService] Failed to link genre '%s' to manga: %v", genreName, err)
-		}
+	// Store genres and link them to the manga
+	if err := ingestion.LinkGenres(tx, manga.ID, extracted.Genres); err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("failed to store genres: %w", err)
 	}
 
 	if err := tx.Commit().Error; err != nil {
@@ -257,27 +316,64 @@ func (s *SyncService) storeManga(ctx context.Context, extracted *ExtractedManga)
 	return manga.ID, nil
 }
 
-// storeChapter stores extracted chapter metadata in database
-func (s *SyncService) storeChapter(ctx context.Context, mangaID int64, extracted *ExtractedChapter) error {
-	chapter := Chapter{
-		MangaID:           mangaID,
-		MangaDexChapterID: &extracted.MangaDexChapterID,
-		ChapterNumber:     extracted.ChapterNumber,
-		Title:             extracted.Title,
-		Volume:            extracted.Volume,
-		Pages:             extracted.Pages,
-		PublishedAt:       &extracted.PublishedAt,
+// mergeWithAniList resolves the fields of a title AniList also supplies
+// using the configured source priority, logging each field they disagree on
+func (s *SyncService) mergeWithAniList(manga *Manga, existing Manga) {
+	merged, conflicts := ingestion.MergeMetadata(s.sourcePriority,
+		existing.metadata(), ingestion.SourceAniList,
+		manga.metadata(), ingestion.SourceMangaDex)
+	manga.setMetadata(merged)
+
+	for _, c := range conflicts {
+		s.logger.Info("metadata conflict", "manga_id", manga.ID, "field", c.Field, "source", c.Source, "overridden", c.Overridden)
 	}
+}
 
-	// Upsert chapter (insert or update on conflict)
-	err := s.db.Where("manga_id = ? AND chapter_number = ?", mangaID, extracted.ChapterNumber).
-		FirstOrCreate(&chapter).Error
+func (m Manga) metadata() ingestion.MetadataFields {
+	return ingestion.MetadataColumns{
+		Title:         m.Title,
+		Author:        m.Author,
+		Status:        m.Status,
+		Description:   m.Description,
+		CoverURL:      m.CoverURL,
+		TotalChapters: m.TotalChapters,
+	}.Fields()
+}
 
+func (m *Manga) setMetadata(f ingestion.MetadataFields) {
+	c := f.Columns()
+	m.Title = c.Title
+	m.Author = c.Author
+	m.Status = c.Status
+	m.Description = c.Description
+	m.CoverURL = c.CoverURL
+	m.TotalChapters = c.TotalChapters
+}
+
+// storeChapter upserts extracted chapter metadata keyed by (manga_id,
+// chapter_number), so a re-run chapter check updates the existing row instead
+// of adding another. It reports whether the chapter was new.
+func (s *SyncService) storeChapter(ctx context.Context, mangaID int64, extracted *ExtractedChapter) (created bool, err error) {
+	var result struct{ Inserted bool }
+	err = s.db.WithContext(ctx).Raw(`
+		INSERT INTO chapters (manga_id, mangadex_chapter_id, chapter_number, title, volume, pages, published_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+		ON CONFLICT (manga_id, chapter_number) DO UPDATE SET
+			mangadex_chapter_id = EXCLUDED.mangadex_chapter_id,
+			title = EXCLUDED.title,
+			volume = EXCLUDED.volume,
+			pages = EXCLUDED.pages,
+			published_at = EXCLUDED.published_at,
+			updated_at = NOW()
+		RETURNING (xmax = 0) AS inserted`,
+		mangaID, extracted.MangaDexChapterID, extracted.ChapterNumber,
+		extracted.Title, extracted.Volume, extracted.Pages, extracted.PublishedAt,
+	).Scan(&result).Error
 	if err != nil {
-		return fmt.Errorf("failed to store chapter: %w", err)
+		return false, fmt.Errorf("failed to store chapter: %w", err)
 	}
 
-	return nil
+	return result.Inserted, nil
 }
 
 // getInitialSyncLimit returns the initial sync limit from env or default
@@ -295,25 +391,46 @@ func getInitialSyncLimit() int {
 	return limit
 }
 
-// processManga is a helper to extract and store a single manga
-func (s *SyncService) processManga(ctx context.Context, apiManga MangaData) error {
+// ImportManga fetches a single title from MangaDex by UUID and upserts it,
+// for on-demand imports outside the scheduled pollers. Imports are recorded
+// in sync run history like any other run.
+func (s *SyncService) ImportManga(ctx context.Context, externalID string) (mangaID int64, err error) {
+	run := ingestion.StartRun("mangadex", "import")
+	defer func() { s.finishRun(run, err) }()
+
+	resp, err := s.client.GetMangaByID(ctx, externalID)
+	if err == nil {
+		mangaID, err = s.processManga(ctx, resp.Data)
+	}
+	if err != nil {
+		run.Failure(externalID, err)
+		return 0, err
+	}
+
+	run.Success()
+	return mangaID, nil
+}
+
+// processManga is a helper to extract and store a single manga.
+// It returns the internal manga ID.
+func (s *SyncService) processManga(ctx context.Context, apiManga MangaData) (int64, error) {
 	// Acquire rate semaphore
 	s.rateSemaphore <- struct{}{}
 	defer func() { <-s.rateSemaphore }()
 
 	// Extract metadata
-	extracted, err := ExtractMangaMetadata(apiManga)
+	extracted, err := ExtractMangaMetadata(apiManga, s.defaultStatus)
 	if err != nil {
-		return fmt.Errorf("failed to extract metadata: %w", err)
+		return 0, fmt.Errorf("failed to extract metadata: %w", err)
 	}
 
 	// Store in database
 	mangaID, err := s.storeManga(ctx, extracted)
 	if err != nil {
-		return fmt.Errorf("failed to store manga: %w", err)
+		return 0, fmt.Errorf("failed to store manga: %w", err)
 	}
 
-	log.Printf("[SyncService] ✅ Synced: %s (ID: %d, MangaDex: %s)", extracted.Title, mangaID, extracted.MangaDexID)
+	s.logger.Debug("synced manga", "manga_id", mangaID, "mangadex_id", extracted.MangaDexID, "title", extracted.Title)
 
-	return nil
+	return mangaID, nil
 }
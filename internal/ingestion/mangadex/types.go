@@ -2,8 +2,10 @@ package mangadex
 
 import (
 	"fmt"
-	"strings"
 	"time"
+
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/shared/slug"
 )
 
 // ============================================
@@ -20,6 +22,13 @@ type MangaListResponse struct {
 	Total    int         `json:"total"`
 }
 
+// MangaResponse represents the response from GET /manga/{id}
+type MangaResponse struct {
+	Result   string    `json:"result"`
+	Response string    `json:"response"`
+	Data     MangaData `json:"data"`
+}
+
 // MangaData represents a single manga entry from the API
 type MangaData struct {
 	ID            string          `json:"id"`
@@ -127,8 +136,9 @@ type ExtractedChapter struct {
 // HELPER FUNCTIONS
 // ============================================
 
-// ExtractMangaMetadata extracts complete metadata from MangaDex API response
-func ExtractMangaMetadata(apiManga MangaData) (*ExtractedManga, error) {
+// ExtractMangaMetadata extracts complete metadata from MangaDex API response.
+// defaultStatus is used when MangaDex sends no status or one we don't know.
+func ExtractMangaMetadata(apiManga MangaData, defaultStatus string) (*ExtractedManga, error) {
 	extracted := &ExtractedManga{
 		MangaDexID: apiManga.ID,
 	}
@@ -149,7 +159,7 @@ func ExtractMangaMetadata(apiManga MangaData) (*ExtractedManga, error) {
 	}
 
 	// 2. Generate slug
-	extracted.Slug = GenerateSlug(extracted.Title)
+	extracted.Slug = slug.WithFallback(extracted.Title, "mangadex-"+apiManga.ID)
 
 	// 3. Description (prefer English)
 	if enDesc, ok := apiManga.Attributes.Description["en"]; ok {
@@ -161,10 +171,10 @@ func ExtractMangaMetadata(apiManga MangaData) (*ExtractedManga, error) {
 		}
 	}
 
-	// 4. Status
-	extracted.Status = apiManga.Attributes.Status
-	if extracted.Status == "" {
-		extracted.Status = "ongoing"
+	// 4. Status (anything we don't know, e.g. a new MangaDex value, gets the default)
+	extracted.Status = defaultStatus
+	if status, ok := models.ParseMangaStatus(apiManga.Attributes.Status); ok && status != models.MangaStatusUnknown {
+		extracted.Status = string(status)
 	}
 
 	// 5. Total chapters (parse lastChapter field)
@@ -243,28 +253,6 @@ func ExtractChapterMetadata(apiChapter ChapterData) (*ExtractedChapter, error) {
 	return extracted, nil
 }
 
-// GenerateSlug creates a URL-friendly slug from title
-func GenerateSlug(title string) string {
-	slug := strings.ToLower(title)
-	slug = strings.ReplaceAll(slug, " ", "-")
-
-	// Remove special characters, keep only alphanumeric and hyphens
-	var result strings.Builder
-	for _, char := range slug {
-		if (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') || char == '-' {
-			result.WriteRune(char)
-		}
-	}
-
-	// Remove multiple consecutive hyphens
-	slug = result.String()
-	for strings.Contains(slug, "--") {
-		slug = strings.ReplaceAll(slug, "--", "-")
-	}
-
-	return strings.Trim(slug, "-")
-}
-
 // GetPreferredTitle extracts title preferring English
 func GetPreferredTitle(titleMap map[string]string) string {
 	if enTitle, ok := titleMap["en"]; ok {
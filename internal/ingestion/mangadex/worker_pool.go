@@ -2,8 +2,11 @@ package mangadex
 
 import (
 	"context"
-	"log"
+	"errors"
+	"log/slog"
 	"sync"
+
+	"mangahub/internal/ingestion"
 )
 
 // Task represents a unit of work to be processed by the worker pool
@@ -18,6 +21,7 @@ type WorkerPool struct {
 	cancel      context.CancelFunc
 	closed      bool
 	closeMux    sync.Mutex
+	logger      *slog.Logger
 }
 
 // NewWorkerPool creates a pool with specified number of workers
@@ -28,6 +32,7 @@ func NewWorkerPool(workerCount int) *WorkerPool {
 		taskQueue:   make(chan Task, workerCount*2), // Buffered channel
 		ctx:         ctx,
 		cancel:      cancel,
+		logger:      poolLogger(),
 	}
 }
 
@@ -37,7 +42,7 @@ func (wp *WorkerPool) Start() {
 		wp.wg.Add(1)
 		go wp.worker(i)
 	}
-	log.Printf("[WorkerPool] Started %d workers", wp.workerCount)
+	wp.logger.Debug("started workers", "workers", wp.workerCount)
 }
 
 // Submit adds a task to the queue (non-blocking with context check)
@@ -47,7 +52,7 @@ func (wp *WorkerPool) Submit(task Task) {
 		// Task submitted successfully
 	case <-wp.ctx.Done():
 		// Pool is shutting down
-		log.Println("[WorkerPool] Pool shutting down, task not submitted")
+		wp.logger.Warn("pool shutting down, task not submitted")
 	}
 }
 
@@ -61,12 +66,12 @@ func (wp *WorkerPool) Wait() {
 	wp.closeMux.Unlock()
 
 	wp.wg.Wait() // Wait for workers to finish
-	log.Println("[WorkerPool] All workers completed")
+	wp.logger.Debug("all workers completed")
 }
 
 // Shutdown cancels all workers and waits for completion
 func (wp *WorkerPool) Shutdown() {
-	log.Println("[WorkerPool] Shutting down...")
+	wp.logger.Info("shutting down")
 	wp.cancel()
 	wp.Wait()
 }
@@ -79,14 +84,19 @@ func (wp *WorkerPool) worker(id int) {
 		// Check if context is cancelled
 		select {
 		case <-wp.ctx.Done():
-			log.Printf("[Worker %d] Context cancelled, stopping", id)
+			wp.logger.Debug("context cancelled, stopping", "worker", id)
 			return
 		default:
 		}
 
-		// Execute task
-		if err := task(wp.ctx); err != nil {
-			log.Printf("[Worker %d] Task error: %v", id, err)
+		// Execute task; a panic fails only this task and the worker carries on
+		if err := ingestion.RunRecovered(wp.ctx, task); err != nil {
+			var pe *ingestion.PanicError
+			if errors.As(err, &pe) {
+				wp.logger.Error("task panicked", "worker", id, "error", err, "stack", string(pe.Stack))
+			} else {
+				wp.logger.Warn("task failed", "worker", id, "error", err)
+			}
 		}
 	}
 }
@@ -99,5 +109,10 @@ func WorkerPoolWithContext(ctx context.Context, workerCount int) *WorkerPool {
 		taskQueue:   make(chan Task, workerCount*2),
 		ctx:         poolCtx,
 		cancel:      cancel,
+		logger:      poolLogger(),
 	}
 }
+
+func poolLogger() *slog.Logger {
+	return slog.Default().With("source", "mangadex", "component", "worker_pool")
+}
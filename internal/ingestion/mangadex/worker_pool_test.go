@@ -0,0 +1,33 @@
+package mangadex
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool_SurvivesPanickingTask(t *testing.T) {
+	pool := NewWorkerPool(2)
+	pool.Start()
+
+	var done atomic.Int32
+	for i := 0; i < 10; i++ {
+		if i == 3 {
+			pool.Submit(func(ctx context.Context) error {
+				var m *Manga
+				_ = m.Title // nil pointer dereference
+				return nil
+			})
+			continue
+		}
+		pool.Submit(func(ctx context.Context) error {
+			done.Add(1)
+			return nil
+		})
+	}
+	pool.Wait()
+
+	assert.Equal(t, int32(9), done.Load())
+}
@@ -2,9 +2,11 @@ package mangadex
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"time"
+
+	"mangahub/internal/ingestion"
 )
 
 // formatMangaDexDate formats time to MangaDex API format (YYYY-MM-DDTHH:MM:SS)
@@ -28,19 +30,36 @@ func parseMangaDexDate(dateStr string) (time.Time, error) {
 // RunInitialSync performs one-time bulk import of manga
 // Fetches configured number of manga (default: 150) with complete metadata
 // Does NOT fetch historical chapters - only stores baseline total_chapters
-func (s *SyncService) RunInitialSync(ctx context.Context) error {
-	log.Println("[InitialSync] Starting initial sync...")
+// The result reports synced/failed counts even when the run itself errors
+func (s *SyncService) RunInitialSync(ctx context.Context) (result ingestion.SyncResult, err error) {
+	logger := s.logger.With("job", "initial_sync")
+	// Skip rather than overlap a run that is still going
+	done, ok := s.runs.TryStart("initial_sync")
+	if !ok {
+		logger.Info("previous run still in progress, skipping")
+		return result, ingestion.ErrRunInProgress
+	}
+	defer done()
+
+	logger.Info("starting initial sync")
+	start := time.Now()
 
 	// Check if already completed
 	state, err := s.getSyncState("initial_sync")
 	if err == nil && state.Status == "completed" {
-		log.Println("[InitialSync] Already completed, skipping")
-		return nil
+		logger.Info("initial sync already completed, skipping")
+		return result, nil
 	}
 
+	run := ingestion.StartRun("mangadex", "initial_sync")
+	defer func() {
+		result = run.Result()
+		s.finishRun(run, err)
+	}()
+
 	// Update status to running
 	if err := s.updateSyncState("initial_sync", "running", "", nil); err != nil {
-		return fmt.Errorf("failed to update sync state: %w", err)
+		return result, fmt.Errorf("failed to update sync state: %w", err)
 	}
 
 	limit := s.initialSyncLimit
@@ -48,7 +67,7 @@ func (s *SyncService) RunInitialSync(ctx context.Context) error {
 		limit = getInitialSyncLimit()
 	}
 
-	log.Printf("[InitialSync] Fetching %d manga with concurrent processing (%d workers)...", limit, s.workerCount)
+	logger.Info("fetching manga", "limit", limit, "workers", s.workerCount)
 
 	// Create worker pool
 	pool := NewWorkerPool(s.workerCount)
@@ -57,7 +76,6 @@ func (s *SyncService) RunInitialSync(ctx context.Context) error {
 
 	offset := 0
 	batchSize := 100
-	totalSynced := 0
 
 	for offset < limit {
 		// Fetch batch
@@ -65,23 +83,23 @@ func (s *SyncService) RunInitialSync(ctx context.Context) error {
 		resp, err := s.client.GetManga(ctx, params)
 		if err != nil {
 			s.updateSyncState("initial_sync", "error", "", err)
-			return fmt.Errorf("failed to fetch manga batch: %w", err)
+			return result, fmt.Errorf("failed to fetch manga batch: %w", err)
 		}
 
-		log.Printf("[InitialSync] Fetched batch: %d manga (offset %d)", len(resp.Data), offset)
+		logger.Debug("fetched batch", "count", len(resp.Data), "offset", offset)
 
 		// Process each manga concurrently
 		for _, apiManga := range resp.Data {
 			apiManga := apiManga // Capture loop variable
 
-			pool.Submit(func(ctx context.Context) error {
-				if err := s.processManga(ctx, apiManga); err != nil {
-					log.Printf("[InitialSync] Failed to process manga: %v", err)
+			pool.Submit(run.Track(apiManga.ID, func(ctx context.Context) error {
+				if _, err := s.processManga(ctx, apiManga); err != nil {
+					logger.Error("failed to process manga", "mangadex_id", apiManga.ID, "error", err)
 					return err
 				}
-				totalSynced++
+				run.Success()
 				return nil
-			})
+			}))
 		}
 
 		offset += batchSize
@@ -98,21 +116,38 @@ func (s *SyncService) RunInitialSync(ctx context.Context) error {
 	// Update sync state
 	now := time.Now()
 	if err := s.updateSyncState("initial_sync", "completed", formatMangaDexDate(now), nil); err != nil {
-		return fmt.Errorf("failed to update sync state: %w", err)
+		return result, fmt.Errorf("failed to update sync state: %w", err)
 	}
 
-	log.Printf("[InitialSync] ✅ Completed! Synced %d manga", totalSynced)
-	return nil
+	synced, failed := run.Counts()
+	logger.Info("initial sync completed", "synced", synced, "errors", failed, "duration", time.Since(start))
+	return result, nil
 }
 
 // PollNewManga checks for newly published manga on MangaDex
 // Runs every 24 hours, detects manga created since last poll
-func (s *SyncService) PollNewManga(ctx context.Context) error {
-	log.Println("[NewMangaPoll] Starting new manga detection...")
+func (s *SyncService) PollNewManga(ctx context.Context) (result ingestion.SyncResult, err error) {
+	logger := s.logger.With("job", "new_manga_poll")
+	// Skip rather than overlap a run that is still going
+	done, ok := s.runs.TryStart("new_manga_poll")
+	if !ok {
+		logger.Info("previous run still in progress, skipping")
+		return result, ingestion.ErrRunInProgress
+	}
+	defer done()
+
+	logger.Info("starting new manga detection")
+	start := time.Now()
+
+	run := ingestion.StartRun("mangadex", "new_manga_poll")
+	defer func() {
+		result = run.Result()
+		s.finishRun(run, err)
+	}()
 
 	// Update status to running
 	if err := s.updateSyncState("new_manga_poll", "running", "", nil); err != nil {
-		return fmt.Errorf("failed to update sync state: %w", err)
+		return result, fmt.Errorf("failed to update sync state: %w", err)
 	}
 
 	// Get last cursor (timestamp)
@@ -125,7 +160,7 @@ func (s *SyncService) PollNewManga(ctx context.Context) error {
 		cursor = formatMangaDexDate(time.Now().Add(-24 * time.Hour))
 	}
 
-	log.Printf("[NewMangaPoll] Checking for manga created since: %s", cursor)
+	logger.Info("checking for new manga", "since", cursor)
 
 	// Fetch new manga
 	params := BuildMangaQueryParams(100, 0, cursor)
@@ -134,29 +169,28 @@ func (s *SyncService) PollNewManga(ctx context.Context) error {
 	resp, err := s.client.GetManga(ctx, params)
 	if err != nil {
 		s.updateSyncState("new_manga_poll", "error", cursor, err)
-		return fmt.Errorf("failed to fetch new manga: %w", err)
+		return result, fmt.Errorf("failed to fetch new manga: %w", err)
 	}
 
 	if len(resp.Data) == 0 {
-		log.Println("[NewMangaPoll] No new manga found")
+		logger.Info("no new manga found", "duration", time.Since(start))
 		s.updateSyncState("new_manga_poll", "completed", cursor, nil)
-		return nil
+		return result, nil
 	}
 
-	log.Printf("[NewMangaPoll] Found %d new manga", len(resp.Data))
+	logger.Info("found new manga", "count", len(resp.Data))
 
 	// Create worker pool
 	pool := NewWorkerPool(s.workerCount)
 	pool.Start()
 	defer pool.Wait()
 
-	newCount := 0
 	lastCreatedAt := cursor
 
 	for _, apiManga := range resp.Data {
 		apiManga := apiManga // Capture loop variable
 
-		pool.Submit(func(ctx context.Context) error {
+		pool.Submit(run.Track(apiManga.ID, func(ctx context.Context) error {
 			// Check if already exists
 			var existing Manga
 			err := s.db.Where("mangadex_id = ?", apiManga.ID).First(&existing).Error
@@ -167,12 +201,12 @@ func (s *SyncService) PollNewManga(ctx context.Context) error {
 			}
 
 			// Process new manga
-			if err := s.processManga(ctx, apiManga); err != nil {
+			if _, err := s.processManga(ctx, apiManga); err != nil {
 				return err
 			}
 
 			// Extract metadata for notification
-			extracted, err := ExtractMangaMetadata(apiManga)
+			extracted, err := ExtractMangaMetadata(apiManga, s.defaultStatus)
 			if err != nil {
 				return err
 			}
@@ -186,9 +220,9 @@ func (s *SyncService) PollNewManga(ctx context.Context) error {
 			// Send notification (async)
 			s.notifier.NotifyNewManga(manga.ID, extracted.Title)
 
-			newCount++
+			run.Success()
 			return nil
-		})
+		}))
 
 		// Update cursor to last manga's createdAt
 		if apiManga.Attributes.CreatedAt != "" {
@@ -200,26 +234,43 @@ func (s *SyncService) PollNewManga(ctx context.Context) error {
 
 	// Update sync state
 	if err := s.updateSyncState("new_manga_poll", "completed", lastCreatedAt, nil); err != nil {
-		return fmt.Errorf("failed to update sync state: %w", err)
+		return result, fmt.Errorf("failed to update sync state: %w", err)
 	}
 
-	log.Printf("[NewMangaPoll] ✅ Completed! Found %d new manga", newCount)
-	return nil
+	synced, failed := run.Counts()
+	logger.Info("new manga poll completed", "new", synced, "errors", failed, "duration", time.Since(start))
+	return result, nil
 }
 
 // CheckChapterUpdates checks for new chapters for tracked manga
 // Runs every 48 hours (2 days), only stores chapters > baseline
-func (s *SyncService) CheckChapterUpdates(ctx context.Context) error {
-	log.Println("[ChapterCheck] Starting chapter update detection...")
+func (s *SyncService) CheckChapterUpdates(ctx context.Context) (result ingestion.SyncResult, err error) {
+	logger := s.logger.With("job", "chapter_check")
+	// Skip rather than overlap a run that is still going
+	done, ok := s.runs.TryStart("chapter_check")
+	if !ok {
+		logger.Info("previous run still in progress, skipping")
+		return result, ingestion.ErrRunInProgress
+	}
+	defer done()
+
+	logger.Info("starting chapter update detection")
+	start := time.Now()
+
+	run := ingestion.StartRun("mangadex", "chapter_check")
+	defer func() {
+		result = run.Result()
+		s.finishRun(run, err)
+	}()
 
 	// Update status to running
 	if err := s.updateSyncState("chapter_check", "running", "", nil); err != nil {
-		return fmt.Errorf("failed to update sync state: %w", err)
+		return result, fmt.Errorf("failed to update sync state: %w", err)
 	}
 
 	// Get manga that haven't been checked in 48 hours
 	var mangaList []Manga
-	err := s.db.Where("mangadex_id IS NOT NULL").
+	err = s.db.Where("mangadex_id IS NOT NULL").
 		Where("last_chapter_check IS NULL OR last_chapter_check < ?", time.Now().Add(-48*time.Hour)).
 		Order("last_chapter_check ASC NULLS FIRST").
 		Limit(50). // Check 50 manga per run
@@ -227,45 +278,47 @@ func (s *SyncService) CheckChapterUpdates(ctx context.Context) error {
 
 	if err != nil {
 		s.updateSyncState("chapter_check", "error", "", err)
-		return fmt.Errorf("failed to fetch manga list: %w", err)
+		return result, fmt.Errorf("failed to fetch manga list: %w", err)
 	}
 
 	if len(mangaList) == 0 {
-		log.Println("[ChapterCheck] No manga to check")
+		logger.Info("no manga to check", "duration", time.Since(start))
 		s.updateSyncState("chapter_check", "completed", "", nil)
-		return nil
+		return result, nil
 	}
 
-	log.Printf("[ChapterCheck] Checking %d manga for chapter updates...", len(mangaList))
+	logger.Info("checking manga for chapter updates", "count", len(mangaList))
 
 	// Create worker pool
 	pool := NewWorkerPool(s.workerCount)
 	pool.Start()
 	defer pool.Wait()
 
-	updateCount := 0
-
 	for _, manga := range mangaList {
 		manga := manga // Capture loop variable
 
-		pool.Submit(func(ctx context.Context) error {
-			return s.checkMangaChapters(ctx, &manga, &updateCount)
-		})
+		pool.Submit(run.Track(fmt.Sprintf("manga %d", manga.ID), func(ctx context.Context) error {
+			return s.checkMangaChapters(ctx, &manga, run)
+		}))
 	}
 
 	pool.Wait()
 
 	// Update sync state
 	if err := s.updateSyncState("chapter_check", "completed", "", nil); err != nil {
-		return fmt.Errorf("failed to update sync state: %w", err)
+		return result, fmt.Errorf("failed to update sync state: %w", err)
 	}
 
-	log.Printf("[ChapterCheck] ✅ Completed! Found updates for %d manga", updateCount)
-	return nil
+	updated, failed := run.Counts()
+	logger.Info("chapter check completed", "updated", updated, "errors", failed, "duration", time.Since(start))
+	return result, nil
 }
 
-// checkMangaChapters checks a single manga for new chapters
-func (s *SyncService) checkMangaChapters(ctx context.Context, manga *Manga, updateCount *int) error {
+// checkMangaChapters checks a single manga for new chapters, counting it as
+// synced on run when new chapters were stored
+func (s *SyncService) checkMangaChapters(ctx context.Context, manga *Manga, run *ingestion.RunTracker) error {
+	logger := s.logger.With("job", "chapter_check", "manga_id", manga.ID, "title", manga.Title)
+
 	// Acquire rate semaphore
 	s.rateSemaphore <- struct{}{}
 	defer func() { <-s.rateSemaphore }()
@@ -274,7 +327,7 @@ func (s *SyncService) checkMangaChapters(ctx context.Context, manga *Manga, upda
 	params := BuildChapterQueryParams(100, "desc")
 	resp, err := s.client.GetMangaFeed(ctx, *manga.MangaDexID, params)
 	if err != nil {
-		log.Printf("[ChapterCheck] Failed to fetch chapters for %s: %v", manga.Title, err)
+		logger.Error("failed to fetch chapters", "error", err)
 		return err
 	}
 
@@ -283,9 +336,11 @@ func (s *SyncService) checkMangaChapters(ctx context.Context, manga *Manga, upda
 		baseline = *manga.TotalChapters
 	}
 
-	// Filter for new chapters (chapter_number > baseline)
+	// Filter for new chapters (chapter_number > baseline). The feed lists a
+	// chapter once per scanlation group, so keep the first of each number.
 	var newChapters []ChapterData
 	highestChapter := baseline
+	seen := make(map[float64]bool)
 
 	for _, apiChapter := range resp.Data {
 		extracted, err := ExtractChapterMetadata(apiChapter)
@@ -296,7 +351,8 @@ func (s *SyncService) checkMangaChapters(ctx context.Context, manga *Manga, upda
 		chapterNum := int(extracted.ChapterNumber)
 
 		// Only process chapters after baseline
-		if chapterNum > baseline {
+		if chapterNum > baseline && !seen[extracted.ChapterNumber] {
+			seen[extracted.ChapterNumber] = true
 			newChapters = append(newChapters, apiChapter)
 
 			if chapterNum > highestChapter {
@@ -313,7 +369,7 @@ func (s *SyncService) checkMangaChapters(ctx context.Context, manga *Manga, upda
 		return nil
 	}
 
-	log.Printf("[ChapterCheck] Found %d new chapters for %s (baseline: %d)", len(newChapters), manga.Title, baseline)
+	logger.Info("found new chapters", "count", len(newChapters), "baseline", baseline)
 
 	// Store new chapters and send notifications
 	for _, apiChapter := range newChapters {
@@ -322,9 +378,14 @@ func (s *SyncService) checkMangaChapters(ctx context.Context, manga *Manga, upda
 			continue
 		}
 
-		// Store chapter
-		if err := s.storeChapter(ctx, manga.ID, extracted); err != nil {
-			log.Printf("[ChapterCheck] Failed to store chapter: %v", err)
+		// Store chapter; one already stored by an earlier, interrupted run
+		// was announced then and isn't notified again
+		created, err := s.storeChapter(ctx, manga.ID, extracted)
+		if err != nil {
+			logger.Error("failed to store chapter", "chapter", extracted.ChapterNumber, "error", err)
+			continue
+		}
+		if !created {
 			continue
 		}
 
@@ -335,33 +396,49 @@ func (s *SyncService) checkMangaChapters(ctx context.Context, manga *Manga, upda
 	// Update manga's total_chapters to highest found
 	s.db.Model(&manga).Update("total_chapters", highestChapter)
 
-	*updateCount++
+	run.Success()
 	return nil
 }
 
 // StartPollers starts all scheduled pollers in goroutines
 func (s *SyncService) StartPollers(ctx context.Context) {
-	// New manga poller: every 24 hours
+	logger := s.logger.With("component", "pollers")
+	// A run that overlaps the previous one logs its own skip, so
+	// ErrRunInProgress isn't reported as a failure here
+
+	// New manga poller: every 24 hours, or daily at NewMangaPollAt
 	go func() {
+		// Run immediately on start
+		if _, err := s.PollNewManga(ctx); err != nil && !errors.Is(err, ingestion.ErrRunInProgress) {
+			logger.Error("new manga poll failed", "error", err)
+		}
+
+		if s.newMangaPollAt != nil {
+			logger.Info("new manga poller started", "daily_at", s.newMangaPollAt.String())
+			s.newMangaPollAt.Run(ctx, func() {
+				logger.Debug("running new manga poll")
+				if _, err := s.PollNewManga(ctx); err != nil && !errors.Is(err, ingestion.ErrRunInProgress) {
+					logger.Error("new manga poll failed", "error", err)
+				}
+			})
+			logger.Info("new manga poller stopped")
+			return
+		}
+
 		ticker := time.NewTicker(24 * time.Hour)
 		defer ticker.Stop()
 
-		log.Println("[Pollers] New manga poller started (interval: 24 hours)")
-
-		// Run immediately on start
-		if err := s.PollNewManga(ctx); err != nil {
-			log.Printf("[Pollers] New manga poll error: %v", err)
-		}
+		logger.Info("new manga poller started", "interval", 24*time.Hour)
 
 		for {
 			select {
 			case <-ticker.C:
-				log.Println("[Pollers] Running new manga poll...")
-				if err := s.PollNewManga(ctx); err != nil {
-					log.Printf("[Pollers] New manga poll error: %v", err)
+				logger.Debug("running new manga poll")
+				if _, err := s.PollNewManga(ctx); err != nil && !errors.Is(err, ingestion.ErrRunInProgress) {
+					logger.Error("new manga poll failed", "error", err)
 				}
 			case <-ctx.Done():
-				log.Println("[Pollers] New manga poller stopped")
+				logger.Info("new manga poller stopped")
 				return
 			}
 		}
@@ -372,24 +449,24 @@ func (s *SyncService) StartPollers(ctx context.Context) {
 		ticker := time.NewTicker(48 * time.Hour)
 		defer ticker.Stop()
 
-		log.Println("[Pollers] Chapter update poller started (interval: 48 hours)")
+		logger.Info("chapter update poller started", "interval", 48*time.Hour)
 
 		// Wait 1 hour before first run (let initial sync complete)
 		time.Sleep(1 * time.Hour)
 
-		if err := s.CheckChapterUpdates(ctx); err != nil {
-			log.Printf("[Pollers] Chapter check error: %v", err)
+		if _, err := s.CheckChapterUpdates(ctx); err != nil && !errors.Is(err, ingestion.ErrRunInProgress) {
+			logger.Error("chapter check failed", "error", err)
 		}
 
 		for {
 			select {
 			case <-ticker.C:
-				log.Println("[Pollers] Running chapter update check...")
-				if err := s.CheckChapterUpdates(ctx); err != nil {
-					log.Printf("[Pollers] Chapter check error: %v", err)
+				logger.Debug("running chapter update check")
+				if _, err := s.CheckChapterUpdates(ctx); err != nil && !errors.Is(err, ingestion.ErrRunInProgress) {
+					logger.Error("chapter check failed", "error", err)
 				}
 			case <-ctx.Done():
-				log.Println("[Pollers] Chapter update poller stopped")
+				logger.Info("chapter update poller stopped")
 				return
 			}
 		}
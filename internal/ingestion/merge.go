@@ -0,0 +1,188 @@
+package ingestion
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"mangahub/internal/microservices/http-api/models"
+)
+
+// Ingestion sources
+const (
+	SourceAniList  = "anilist"
+	SourceMangaDex = "mangadex"
+)
+
+// DefaultSourcePriority is used when METADATA_SOURCE_PRIORITY is unset
+var DefaultSourcePriority = []string{SourceAniList, SourceMangaDex}
+
+// ParseSourcePriority parses a comma-separated source list, highest priority
+// first (e.g. "anilist,mangadex"). Known sources left out rank last in their
+// default order; an empty string gives DefaultSourcePriority.
+func ParseSourcePriority(s string) ([]string, error) {
+	var priority []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ",") {
+		source := strings.ToLower(strings.TrimSpace(part))
+		if source == "" {
+			continue
+		}
+		if source != SourceAniList && source != SourceMangaDex {
+			return nil, fmt.Errorf("unknown source %q (want %s or %s)", source, SourceAniList, SourceMangaDex)
+		}
+		if seen[source] {
+			return nil, fmt.Errorf("source %q listed twice", source)
+		}
+		seen[source] = true
+		priority = append(priority, source)
+	}
+
+	for _, source := range DefaultSourcePriority {
+		if !seen[source] {
+			priority = append(priority, source)
+		}
+	}
+	return priority, nil
+}
+
+// MetadataFields are the manga columns both sources write
+type MetadataFields struct {
+	Title         string
+	Author        string
+	Status        string
+	Description   string
+	CoverURL      string
+	TotalChapters int
+}
+
+// MetadataColumns are MetadataFields as a manga row stores them, where NULL
+// means no source has supplied the value
+type MetadataColumns struct {
+	Title         string
+	Author        *string
+	Status        *string
+	Description   *string
+	CoverURL      *string
+	TotalChapters *int
+}
+
+// Fields reads the columns, with NULL as the empty value
+func (c MetadataColumns) Fields() MetadataFields {
+	return MetadataFields{
+		Title:         c.Title,
+		Author:        Deref(c.Author),
+		Status:        Deref(c.Status),
+		Description:   Deref(c.Description),
+		CoverURL:      Deref(c.CoverURL),
+		TotalChapters: Deref(c.TotalChapters),
+	}
+}
+
+// Columns converts the fields back for writing. Empty values become nil so a
+// GORM Updates skips them instead of overwriting NULL with "" or 0.
+func (f MetadataFields) Columns() MetadataColumns {
+	c := MetadataColumns{
+		Title:       f.Title,
+		Author:      NonEmpty(f.Author),
+		Status:      NonEmpty(f.Status),
+		Description: NonEmpty(f.Description),
+		CoverURL:    NonEmpty(f.CoverURL),
+	}
+	if f.TotalChapters > 0 {
+		c.TotalChapters = &f.TotalChapters
+	}
+	return c
+}
+
+// NonEmpty returns nil for "", so an absent field isn't written
+func NonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// Deref returns the value p points to, or the zero value for nil
+func Deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+// FieldConflict records a field the two sources disagreed on
+type FieldConflict struct {
+	Field      string
+	Source     string // source whose value was kept
+	Overridden string // source whose value was dropped
+}
+
+// MergeMetadata merges incoming metadata into a row that another source
+// already filled. For each field the higher-priority source wins, but an
+// empty value never replaces a non-empty one; the chapter count always takes
+// the larger of the two. The row doesn't record which source wrote each
+// column, so existing values are attributed to existingSource.
+func MergeMetadata(priority []string, existing MetadataFields, existingSource string, incoming MetadataFields, incomingSource string) (MetadataFields, []FieldConflict) {
+	incomingWins := rank(priority, incomingSource) < rank(priority, existingSource)
+
+	var conflicts []FieldConflict
+	pick := func(field, current, next string) string {
+		switch {
+		case next == "" || next == current:
+			return current
+		case current == "":
+			return next
+		case incomingWins:
+			conflicts = append(conflicts, FieldConflict{Field: field, Source: incomingSource, Overridden: existingSource})
+			return next
+		default:
+			conflicts = append(conflicts, FieldConflict{Field: field, Source: existingSource, Overridden: incomingSource})
+			return current
+		}
+	}
+
+	merged := MetadataFields{
+		Title:         pick("title", existing.Title, incoming.Title),
+		Author:        pick("author", existing.Author, incoming.Author),
+		Status:        pick("status", knownStatus(existing.Status), knownStatus(incoming.Status)),
+		Description:   pick("description", existing.Description, incoming.Description),
+		CoverURL:      pick("cover_url", existing.CoverURL, incoming.CoverURL),
+		TotalChapters: max(existing.TotalChapters, incoming.TotalChapters),
+	}
+
+	// Both unmapped: keep it marked unknown rather than blank
+	if merged.Status == "" {
+		merged.Status = cmp.Or(existing.Status, incoming.Status)
+	}
+
+	if existing.TotalChapters != incoming.TotalChapters && existing.TotalChapters > 0 && incoming.TotalChapters > 0 {
+		c := FieldConflict{Field: "total_chapters", Source: existingSource, Overridden: incomingSource}
+		if incoming.TotalChapters > existing.TotalChapters {
+			c.Source, c.Overridden = incomingSource, existingSource
+		}
+		conflicts = append(conflicts, c)
+	}
+
+	return merged, conflicts
+}
+
+// knownStatus treats an unmapped status like a missing one, so it never
+// replaces a status the other source did know
+func knownStatus(status string) string {
+	if status == string(models.MangaStatusUnknown) {
+		return ""
+	}
+	return status
+}
+
+// rank is a source's position in priority; unlisted sources rank last
+func rank(priority []string, source string) int {
+	for i, s := range priority {
+		if s == source {
+			return i
+		}
+	}
+	return len(priority)
+}
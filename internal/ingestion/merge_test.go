@@ -0,0 +1,120 @@
+package ingestion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSourcePriority(t *testing.T) {
+	got, err := ParseSourcePriority("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{SourceAniList, SourceMangaDex}, got)
+
+	got, err = ParseSourcePriority(" MangaDex , anilist")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{SourceMangaDex, SourceAniList}, got)
+
+	got, err = ParseSourcePriority("mangadex")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{SourceMangaDex, SourceAniList}, got)
+
+	_, err = ParseSourcePriority("anilist,kitsu")
+	assert.Error(t, err)
+
+	_, err = ParseSourcePriority("anilist,anilist")
+	assert.Error(t, err)
+}
+
+func TestMergeMetadata(t *testing.T) {
+	mangadex := MetadataFields{
+		Title:         "Sousou no Frieren",
+		Author:        "Yamada Kanehito",
+		Status:        "ongoing",
+		Description:   "",
+		CoverURL:      "https://mangadex.example/cover.jpg",
+		TotalChapters: 120,
+	}
+	anilist := MetadataFields{
+		Title:         "Frieren: Beyond Journey's End",
+		Author:        "Yamada Kanehito",
+		Status:        "ongoing",
+		Description:   "An elf mage outlives her party.",
+		CoverURL:      "",
+		TotalChapters: 0,
+	}
+
+	t.Run("IncomingHasPriority", func(t *testing.T) {
+		merged, conflicts := MergeMetadata([]string{SourceAniList, SourceMangaDex}, mangadex, SourceMangaDex, anilist, SourceAniList)
+
+		assert.Equal(t, "Frieren: Beyond Journey's End", merged.Title)
+		assert.Equal(t, "An elf mage outlives her party.", merged.Description) // fills a blank
+		assert.Equal(t, "https://mangadex.example/cover.jpg", merged.CoverURL) // blank never wins
+		assert.Equal(t, 120, merged.TotalChapters)
+		assert.Equal(t, []FieldConflict{{Field: "title", Source: SourceAniList, Overridden: SourceMangaDex}}, conflicts)
+	})
+
+	t.Run("ExistingHasPriority", func(t *testing.T) {
+		merged, conflicts := MergeMetadata([]string{SourceMangaDex, SourceAniList}, mangadex, SourceMangaDex, anilist, SourceAniList)
+
+		assert.Equal(t, "Sousou no Frieren", merged.Title)
+		assert.Equal(t, "An elf mage outlives her party.", merged.Description)
+		assert.Equal(t, []FieldConflict{{Field: "title", Source: SourceMangaDex, Overridden: SourceAniList}}, conflicts)
+	})
+
+	t.Run("ChapterCountTakesMax", func(t *testing.T) {
+		incoming := anilist
+		incoming.TotalChapters = 125
+
+		merged, conflicts := MergeMetadata([]string{SourceMangaDex, SourceAniList}, mangadex, SourceMangaDex, incoming, SourceAniList)
+
+		assert.Equal(t, 125, merged.TotalChapters)
+		assert.Contains(t, conflicts, FieldConflict{Field: "total_chapters", Source: SourceAniList, Overridden: SourceMangaDex})
+	})
+}
+
+func TestParseDefaultStatus(t *testing.T) {
+	got, err := ParseDefaultStatus("")
+	assert.NoError(t, err)
+	assert.Equal(t, "ongoing", got)
+
+	got, err = ParseDefaultStatus(" Unknown ")
+	assert.NoError(t, err)
+	assert.Equal(t, "unknown", got)
+
+	_, err = ParseDefaultStatus("paused")
+	assert.Error(t, err)
+}
+
+func TestMergeMetadata_UnknownStatusNeverWins(t *testing.T) {
+	existing := MetadataFields{Title: "Berserk", Status: "hiatus"}
+	incoming := MetadataFields{Title: "Berserk", Status: "unknown"}
+
+	merged, conflicts := MergeMetadata([]string{SourceAniList, SourceMangaDex}, existing, SourceMangaDex, incoming, SourceAniList)
+	assert.Equal(t, "hiatus", merged.Status)
+	assert.Empty(t, conflicts)
+
+	existing.Status = "unknown"
+	merged, _ = MergeMetadata([]string{SourceAniList, SourceMangaDex}, existing, SourceMangaDex, incoming, SourceAniList)
+	assert.Equal(t, "unknown", merged.Status)
+}
+
+func TestMetadataColumns_EmptyValuesStayNull(t *testing.T) {
+	author := "Oda"
+	chapters := 1100
+	stored := MetadataColumns{Title: "One Piece", Author: &author, TotalChapters: &chapters}
+
+	f := stored.Fields()
+	assert.Equal(t, MetadataFields{Title: "One Piece", Author: "Oda", TotalChapters: 1100}, f)
+
+	// Round-tripping keeps NULL columns NULL instead of writing "" or 0
+	c := f.Columns()
+	assert.Equal(t, "One Piece", c.Title)
+	assert.Equal(t, &author, c.Author)
+	assert.Equal(t, &chapters, c.TotalChapters)
+	assert.Nil(t, c.Status)
+	assert.Nil(t, c.Description)
+	assert.Nil(t, c.CoverURL)
+
+	assert.Nil(t, MetadataFields{Title: "Blank"}.Columns().TotalChapters)
+}
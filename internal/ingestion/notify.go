@@ -0,0 +1,217 @@
+package ingestion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"mangahub/internal/config"
+	"mangahub/internal/shared"
+)
+
+// Notification delivery defaults
+const (
+	DefaultNotifyRetries    = 3
+	DefaultNotifyTimeout    = 5 * time.Second
+	DefaultNotifyBackoff    = 500 * time.Millisecond
+	DefaultNotifyBufferSize = 100
+)
+
+// NotifyConfig configures how the sync services post notifications to the
+// UDP server's HTTP trigger. Zero values use the defaults above.
+type NotifyConfig struct {
+	Retries    int           // Extra attempts after the first one fails (negative disables retries)
+	Timeout    time.Duration // Per attempt
+	Backoff    time.Duration // Wait before the first retry, doubled for each one after
+	BufferSize int           // Failed notifications kept to resend once the server is back
+	SigningKey string        // HMAC key for signing requests (INTERNAL_SIGNING_KEY); empty sends them unsigned
+}
+
+// errRejected marks a notification the trigger refused (4xx); resending it
+// won't help, so it is neither retried nor buffered
+var errRejected = errors.New("notification rejected")
+
+// pendingNotification is a notification whose retries all failed
+type pendingNotification struct {
+	endpoint       string
+	idempotencyKey string
+	body           []byte
+}
+
+// NotifyDelivery posts notifications to the UDP server's HTTP trigger. A call
+// that fails is retried with backoff; if the server stays unreachable the
+// notification is buffered and resent after the next successful delivery,
+// so a brief UDP server restart doesn't drop it. It is safe for concurrent use.
+type NotifyDelivery struct {
+	baseURL       string // http://localhost:8085 or http://udp-server:8085
+	internalToken string // Sent as X-Internal-Token; the trigger rejects requests without it
+	signingKey    string // Signs each attempt; see shared.SignTriggerRequest
+	httpClient    *http.Client
+	logger        *slog.Logger
+
+	retries    int
+	timeout    time.Duration
+	backoff    time.Duration
+	bufferSize int
+
+	mu      sync.Mutex
+	pending []pendingNotification // Oldest first
+}
+
+// NewNotifyDelivery creates a delivery for the trigger at baseURL
+func NewNotifyDelivery(baseURL, internalToken string, cfg NotifyConfig, logger *slog.Logger) *NotifyDelivery {
+	retries := cfg.Retries
+	if retries < 0 {
+		retries = 0
+	} else if retries == 0 {
+		retries = DefaultNotifyRetries
+	}
+	bufferSize := cfg.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = DefaultNotifyBufferSize
+	}
+
+	return &NotifyDelivery{
+		baseURL:       baseURL,
+		internalToken: internalToken,
+		signingKey:    cfg.SigningKey,
+		httpClient:    &http.Client{},
+		logger:        logger,
+		retries:       retries,
+		timeout:       orDefault(cfg.Timeout, DefaultNotifyTimeout),
+		backoff:       orDefault(cfg.Backoff, DefaultNotifyBackoff),
+		bufferSize:    bufferSize,
+	}
+}
+
+// Send posts payload to endpoint, retrying failed attempts. A non-empty
+// idempotencyKey lets the server drop duplicates when a call is retried.
+// If every attempt fails the notification is buffered and the last error
+// is returned; one the server rejects outright is not buffered.
+func (d *NotifyDelivery) Send(ctx context.Context, endpoint, idempotencyKey string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	n := pendingNotification{endpoint: endpoint, idempotencyKey: idempotencyKey, body: body}
+
+	backoff := d.backoff
+	for attempt := 0; ; attempt++ {
+		err = d.post(ctx, n)
+		if err == nil {
+			d.flush(ctx)
+			return nil
+		}
+		if errors.Is(err, errRejected) {
+			return err
+		}
+		if attempt == d.retries || ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	d.buffer(n)
+	return err
+}
+
+// Pending returns how many notifications are waiting to be resent
+func (d *NotifyDelivery) Pending() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.pending)
+}
+
+// buffer keeps n for a later resend, dropping the oldest when full
+func (d *NotifyDelivery) buffer(n pendingNotification) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.pending) >= d.bufferSize {
+		dropped := d.pending[0]
+		d.pending = d.pending[1:]
+		d.logger.Warn("notification buffer full, dropping oldest", "endpoint", dropped.endpoint, "idempotency_key", dropped.idempotencyKey)
+	}
+	d.pending = append(d.pending, n)
+}
+
+// flush resends buffered notifications once each, oldest first. It stops at
+// the first failure and puts the rest back in the buffer.
+func (d *NotifyDelivery) flush(ctx context.Context) {
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = nil
+	d.mu.Unlock()
+
+	for i, n := range pending {
+		err := d.post(ctx, n)
+		if errors.Is(err, errRejected) {
+			d.logger.Warn("buffered notification rejected, dropping", "endpoint", n.endpoint, "idempotency_key", n.idempotencyKey, "error", err)
+			continue
+		}
+		if err != nil {
+			d.mu.Lock()
+			d.pending = append(append([]pendingNotification(nil), pending[i:]...), d.pending...)
+			d.mu.Unlock()
+			return
+		}
+		d.logger.Debug("resent buffered notification", "endpoint", n.endpoint, "idempotency_key", n.idempotencyKey)
+	}
+}
+
+// post makes a single attempt, bounded by the per-attempt timeout
+func (d *NotifyDelivery) post(ctx context.Context, n pendingNotification) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.baseURL+n.endpoint, bytes.NewReader(n.body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if n.idempotencyKey != "" {
+		req.Header.Set(config.IdempotencyKeyHeader, n.idempotencyKey)
+	}
+	if d.internalToken != "" {
+		req.Header.Set(config.InternalTokenHeader, d.internalToken)
+	}
+	// Each attempt is signed afresh, with its own nonce, so retries and
+	// resends of buffered notifications are neither stale nor replays
+	if d.signingKey != "" {
+		nonce, err := shared.NewTriggerNonce()
+		if err != nil {
+			return fmt.Errorf("failed to create nonce: %w", err)
+		}
+		timestamp := time.Now().Unix()
+		req.Header.Set(config.InternalTimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(config.InternalNonceHeader, nonce)
+		req.Header.Set(config.InternalSignatureHeader, shared.SignTriggerRequest(d.signingKey, timestamp, nonce, req.URL.Path, n.body))
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return fmt.Errorf("%w: status code %d", errRejected, resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
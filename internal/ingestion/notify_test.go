@@ -0,0 +1,107 @@
+package ingestion
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"mangahub/internal/config"
+	"mangahub/internal/shared"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyTrigger fails the first failures calls with 503, then accepts
+func flakyTrigger(t *testing.T, failures int32) (*httptest.Server, *atomic.Int32, *[]string) {
+	t.Helper()
+	var calls atomic.Int32
+	var delivered []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		delivered = append(delivered, r.Header.Get(config.IdempotencyKeyHeader))
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls, &delivered
+}
+
+func newTestDelivery(url string, retries int) *NotifyDelivery {
+	return NewNotifyDelivery(url, "", NotifyConfig{Retries: retries, Backoff: time.Millisecond, BufferSize: 2}, slog.Default())
+}
+
+func TestNotifyDelivery_RetriesUntilDelivered(t *testing.T) {
+	srv, calls, delivered := flakyTrigger(t, 2)
+	d := newTestDelivery(srv.URL, 3)
+
+	err := d.Send(context.Background(), "/notify/new-manga", "manga:1", map[string]any{"manga_id": 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, []string{"manga:1"}, *delivered)
+	assert.Equal(t, 0, d.Pending())
+}
+
+func TestNotifyDelivery_BuffersAndResends(t *testing.T) {
+	srv, _, delivered := flakyTrigger(t, 2)
+	d := newTestDelivery(srv.URL, -1) // no retries: each failed call is buffered
+
+	assert.Error(t, d.Send(context.Background(), "/notify/new-manga", "manga:1", nil))
+	assert.Error(t, d.Send(context.Background(), "/notify/new-manga", "manga:2", nil))
+	assert.Equal(t, 2, d.Pending())
+
+	// The server is back: the new one goes out, then the buffered ones in order
+	assert.NoError(t, d.Send(context.Background(), "/notify/new-manga", "manga:3", nil))
+	assert.Equal(t, []string{"manga:3", "manga:1", "manga:2"}, *delivered)
+	assert.Equal(t, 0, d.Pending())
+}
+
+func TestNotifyDelivery_BufferDropsOldest(t *testing.T) {
+	srv, _, _ := flakyTrigger(t, 100)
+	d := newTestDelivery(srv.URL, -1)
+
+	for _, key := range []string{"manga:1", "manga:2", "manga:3"} {
+		assert.Error(t, d.Send(context.Background(), "/notify/new-manga", key, nil))
+	}
+	assert.Equal(t, 2, d.Pending())
+	assert.Equal(t, "manga:2", d.pending[0].idempotencyKey)
+}
+
+func TestNotifyDelivery_RejectedIsNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	d := newTestDelivery(srv.URL, 3)
+
+	assert.Error(t, d.Send(context.Background(), "/notify/new-manga", "manga:1", nil))
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, 0, d.Pending())
+}
+
+func TestNotifyDelivery_SignsRequests(t *testing.T) {
+	var verified atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestamp, err := strconv.ParseInt(r.Header.Get(config.InternalTimestampHeader), 10, 64)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(r.Body)
+		nonce := r.Header.Get(config.InternalNonceHeader)
+		verified.Store(shared.VerifyTriggerSignature("signing-key", timestamp, nonce, r.URL.Path, body, r.Header.Get(config.InternalSignatureHeader)))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+	d := NewNotifyDelivery(srv.URL, "", NotifyConfig{SigningKey: "signing-key"}, slog.Default())
+
+	assert.NoError(t, d.Send(context.Background(), "/notify/new-manga", "", map[string]any{"manga_id": 1}))
+	assert.True(t, verified.Load())
+}
@@ -0,0 +1,28 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is a worker task's panic turned into an error, so one bad
+// record fails its own item instead of taking down the whole sync
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("task panicked: %v", e.Value)
+}
+
+// RunRecovered runs task, returning a *PanicError if it panics
+func RunRecovered(ctx context.Context, task func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return task(ctx)
+}
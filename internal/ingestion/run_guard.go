@@ -0,0 +1,45 @@
+package ingestion
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrRunInProgress is returned by a sync that was skipped because a previous
+// run of it hasn't finished
+var ErrRunInProgress = errors.New("sync run already in progress")
+
+// RunGuard keeps runs of the same sync from overlapping, e.g. a poll that
+// outlasts its interval and a manual trigger. The sync_state "running" status
+// is only informational; this is what actually prevents the overlap.
+// The zero value is ready to use.
+type RunGuard struct {
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+// TryStart marks the sync named name as running. It returns false, and a
+// no-op done, if a run of it is already in progress; the caller should skip
+// and return ErrRunInProgress.
+// Otherwise the caller must call done when the run ends.
+func (g *RunGuard) TryStart(name string) (done func(), ok bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.running[name] {
+		return func() {}, false
+	}
+	if g.running == nil {
+		g.running = make(map[string]bool)
+	}
+	g.running[name] = true
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			g.mu.Lock()
+			delete(g.running, name)
+			g.mu.Unlock()
+		})
+	}, true
+}
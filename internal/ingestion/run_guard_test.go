@@ -0,0 +1,29 @@
+package ingestion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunGuard_SkipsOverlappingRun(t *testing.T) {
+	var g RunGuard
+
+	done, ok := g.TryStart("new_manga_poll")
+	assert.True(t, ok)
+
+	_, ok = g.TryStart("new_manga_poll")
+	assert.False(t, ok, "second run of the same sync must be skipped")
+
+	// Other syncs are independent
+	otherDone, ok := g.TryStart("chapter_check")
+	assert.True(t, ok)
+	otherDone()
+
+	done()
+	done() // calling done twice is harmless
+
+	again, ok := g.TryStart("new_manga_poll")
+	assert.True(t, ok, "a finished run frees the name")
+	again()
+}
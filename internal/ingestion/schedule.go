@@ -0,0 +1,78 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DailySchedule fires once a day at a wall-clock time in Location, so
+// "09:00" with Europe/Paris means 9am Paris time across DST changes
+type DailySchedule struct {
+	Hour     int
+	Minute   int
+	Location *time.Location
+}
+
+// LoadSchedulerLocation resolves a SCHEDULER_TIMEZONE value. Empty means UTC.
+func LoadSchedulerLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scheduler timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// ParseDailySchedule parses an "HH:MM" clock time in the given location.
+// An empty clock returns nil, meaning the job keeps its plain interval.
+func ParseDailySchedule(clock string, loc *time.Location) (*DailySchedule, error) {
+	if clock == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return nil, fmt.Errorf("invalid daily time %q, expected HH:MM: %w", clock, err)
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &DailySchedule{Hour: t.Hour(), Minute: t.Minute(), Location: loc}, nil
+}
+
+// Next returns the first scheduled time strictly after after
+func (d *DailySchedule) Next(after time.Time) time.Time {
+	local := after.In(d.Location)
+	next := time.Date(local.Year(), local.Month(), local.Day(), d.Hour, d.Minute, 0, 0, d.Location)
+	if !next.After(local) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+1, d.Hour, d.Minute, 0, 0, d.Location)
+	}
+	return next
+}
+
+// Wait blocks until the next scheduled time. It returns ctx.Err() if the
+// context is cancelled first.
+func (d *DailySchedule) Wait(ctx context.Context) error {
+	timer := time.NewTimer(time.Until(d.Next(time.Now())))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Run calls fn at every scheduled time until ctx is cancelled
+func (d *DailySchedule) Run(ctx context.Context, fn func()) {
+	for d.Wait(ctx) == nil {
+		fn()
+	}
+}
+
+func (d *DailySchedule) String() string {
+	return fmt.Sprintf("%02d:%02d %s", d.Hour, d.Minute, d.Location)
+}
@@ -0,0 +1,56 @@
+package ingestion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailySchedule_Next(t *testing.T) {
+	tokyo, err := LoadSchedulerLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	sched, err := ParseDailySchedule("09:00", tokyo)
+	require.NoError(t, err)
+
+	// 23:00 UTC is 08:00 the next day in Tokyo: the run is an hour away
+	now := time.Date(2024, 3, 1, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), sched.Next(now).UTC())
+
+	// Exactly on the hour rolls over to the next day
+	onTime := time.Date(2024, 3, 2, 9, 0, 0, 0, tokyo)
+	assert.Equal(t, time.Date(2024, 3, 3, 9, 0, 0, 0, tokyo), sched.Next(onTime))
+}
+
+func TestDailySchedule_NextAcrossDST(t *testing.T) {
+	paris, err := LoadSchedulerLocation("Europe/Paris")
+	require.NoError(t, err)
+	sched, err := ParseDailySchedule("09:00", paris)
+	require.NoError(t, err)
+
+	// Clocks go forward on 2024-03-31: 09:00 local moves from 08:00 to 07:00 UTC
+	before := time.Date(2024, 3, 30, 12, 0, 0, 0, paris)
+	next := sched.Next(before)
+	assert.Equal(t, 9, next.Hour())
+	assert.Equal(t, time.Date(2024, 3, 31, 7, 0, 0, 0, time.UTC), next.UTC())
+}
+
+func TestParseDailySchedule(t *testing.T) {
+	sched, err := ParseDailySchedule("", time.UTC)
+	assert.NoError(t, err)
+	assert.Nil(t, sched)
+
+	_, err = ParseDailySchedule("9am", time.UTC)
+	assert.Error(t, err)
+
+	_, err = ParseDailySchedule("24:00", time.UTC)
+	assert.Error(t, err)
+
+	_, err = LoadSchedulerLocation("Mars/Olympus_Mons")
+	assert.Error(t, err)
+
+	loc, err := LoadSchedulerLocation("")
+	assert.NoError(t, err)
+	assert.Equal(t, time.UTC, loc)
+}
@@ -0,0 +1,59 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Limits for the number of recent runs returned by the status endpoint
+const (
+	DefaultStatusRuns = 10
+	MaxStatusRuns     = 100
+)
+
+// StatusOptions are the query options of the status endpoint
+type StatusOptions struct {
+	Runs int // Number of recent sync runs to include
+}
+
+// StatusFunc reports the current state of a sync service
+type StatusFunc func(ctx context.Context, opts StatusOptions) (any, error)
+
+// NewStatusMux serves the sync status endpoint:
+//
+//	GET /status?runs=N -> JSON produced by status, with the last N sync runs
+func NewStatusMux(status StatusFunc) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		opts := StatusOptions{Runs: DefaultStatusRuns}
+		if v := r.URL.Query().Get("runs"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				http.Error(w, "runs must be a non-negative integer", http.StatusBadRequest)
+				return
+			}
+			opts.Runs = min(n, MaxStatusRuns)
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+
+		body, err := status(ctx, opts)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(body)
+	})
+	return mux
+}
@@ -0,0 +1,162 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Sync run statuses
+const (
+	RunCompleted = "completed"
+	RunError     = "error"
+)
+
+// maxErrorSummaries caps how many per-item errors a run keeps for its result
+const maxErrorSummaries = 50
+
+// SyncRun is one row of sync_runs: the outcome of a single sync or poll
+type SyncRun struct {
+	ID          int64     `gorm:"primaryKey" json:"id"`
+	Source      string    `gorm:"not null" json:"source"`
+	SyncType    string    `gorm:"not null" json:"sync_type"`
+	Status      string    `gorm:"not null" json:"status"`
+	StartedAt   time.Time `gorm:"not null" json:"started_at"`
+	FinishedAt  time.Time `gorm:"not null" json:"finished_at"`
+	DurationMs  int64     `json:"duration_ms"`
+	SyncedCount int       `json:"synced_count"`
+	ErrorCount  int       `json:"error_count"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+func (SyncRun) TableName() string { return "sync_runs" }
+
+// ErrorSummary describes one item that failed during a run
+type ErrorSummary struct {
+	Item  string `json:"item"`
+	Error string `json:"error"`
+}
+
+// SyncResult is what a sync or poll reports back to its caller, so a run
+// where half the items failed can be told apart from a clean one
+type SyncResult struct {
+	Synced int            `json:"synced"`
+	Failed int            `json:"failed"`
+	Errors []ErrorSummary `json:"errors,omitempty"` // First maxErrorSummaries failures
+}
+
+// HasFailures reports whether any item failed
+func (r SyncResult) HasFailures() bool { return r.Failed > 0 }
+
+// RunTracker accumulates counts for a sync run while its workers are running.
+// It is safe for concurrent use.
+type RunTracker struct {
+	mu     sync.Mutex
+	run    SyncRun
+	errors []ErrorSummary
+}
+
+// StartRun begins tracking a run of syncType for source
+func StartRun(source, syncType string) *RunTracker {
+	return &RunTracker{run: SyncRun{
+		Source:    source,
+		SyncType:  syncType,
+		StartedAt: time.Now(),
+	}}
+}
+
+// Success counts one synced item
+func (t *RunTracker) Success() {
+	t.mu.Lock()
+	t.run.SyncedCount++
+	t.mu.Unlock()
+}
+
+// Failure counts one failed item, identified by item (an external ID, page
+// number, ...), and keeps its error as the most recent one
+func (t *RunTracker) Failure(item string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.run.ErrorCount++
+	if err == nil {
+		return
+	}
+	t.run.LastError = err.Error()
+	if len(t.errors) < maxErrorSummaries {
+		t.errors = append(t.errors, ErrorSummary{Item: item, Error: err.Error()})
+	}
+}
+
+// Track wraps a worker task for item so a returned error, or a panic, is
+// counted as a failure. A panic comes back as a *PanicError naming item.
+func (t *RunTracker) Track(item string, task func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		err := RunRecovered(ctx, task)
+		if err != nil {
+			t.Failure(item, err)
+		}
+		if pe, ok := err.(*PanicError); ok {
+			return fmt.Errorf("%s: %w", item, pe)
+		}
+		return err
+	}
+}
+
+// Counts returns the synced and failed item counts so far
+func (t *RunTracker) Counts() (synced, failed int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.run.SyncedCount, t.run.ErrorCount
+}
+
+// Result returns the run's outcome so far
+func (t *RunTracker) Result() SyncResult {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return SyncResult{
+		Synced: t.run.SyncedCount,
+		Failed: t.run.ErrorCount,
+		Errors: append([]ErrorSummary(nil), t.errors...),
+	}
+}
+
+// Finish closes the run and persists it. A non-nil err marks the whole run
+// as failed and becomes its last error.
+func (t *RunTracker) Finish(db *gorm.DB, err error) (SyncRun, error) {
+	t.mu.Lock()
+	run := t.run
+	t.mu.Unlock()
+
+	run.FinishedAt = time.Now()
+	run.DurationMs = run.FinishedAt.Sub(run.StartedAt).Milliseconds()
+	run.Status = RunCompleted
+	if err != nil {
+		run.Status = RunError
+		run.LastError = err.Error()
+	}
+
+	// Use a fresh context so cancelled runs are still recorded
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if saveErr := db.WithContext(ctx).Create(&run).Error; saveErr != nil {
+		return run, saveErr
+	}
+	return run, nil
+}
+
+// RecentSyncRuns returns the latest runs for source, newest first
+func RecentSyncRuns(ctx context.Context, db *gorm.DB, source string, limit int) ([]SyncRun, error) {
+	var runs []SyncRun
+	err := db.WithContext(ctx).
+		Where("source = ?", source).
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&runs).Error
+	return runs, err
+}
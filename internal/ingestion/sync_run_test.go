@@ -0,0 +1,77 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunTracker_CountsConcurrentTasks(t *testing.T) {
+	run := StartRun("mangadex", "initial_sync")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			task := run.Track(fmt.Sprintf("item-%d", i), func(ctx context.Context) error {
+				if i%4 == 0 {
+					return errors.New("store failed")
+				}
+				run.Success()
+				return nil
+			})
+			task(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	synced, failed := run.Counts()
+	assert.Equal(t, 15, synced)
+	assert.Equal(t, 5, failed)
+	assert.Equal(t, "store failed", run.run.LastError)
+
+	result := run.Result()
+	assert.Equal(t, 15, result.Synced)
+	assert.Equal(t, 5, result.Failed)
+	assert.Len(t, result.Errors, 5)
+	assert.True(t, result.HasFailures())
+}
+
+func TestRunTracker_CapsErrorSummaries(t *testing.T) {
+	run := StartRun("anilist", "new_manga_poll")
+
+	for i := 0; i < maxErrorSummaries+10; i++ {
+		run.Failure(fmt.Sprintf("page %d", i), errors.New("HTTP 500"))
+	}
+
+	result := run.Result()
+	assert.Equal(t, maxErrorSummaries+10, result.Failed)
+	assert.Len(t, result.Errors, maxErrorSummaries)
+	assert.Equal(t, ErrorSummary{Item: "page 0", Error: "HTTP 500"}, result.Errors[0])
+}
+
+func TestRunTracker_TrackRecoversPanic(t *testing.T) {
+	run := StartRun("anilist", "initial_sync")
+
+	task := run.Track("30013", func(ctx context.Context) error {
+		var m map[string]int
+		m["chapters"] = 1 // nil map write
+		return nil
+	})
+	err := task(context.Background())
+
+	var pe *PanicError
+	assert.ErrorAs(t, err, &pe)
+	assert.Contains(t, err.Error(), "30013")
+	assert.NotEmpty(t, pe.Stack)
+
+	result := run.Result()
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, "30013", result.Errors[0].Item)
+	assert.Contains(t, result.Errors[0].Error, "task panicked")
+}
@@ -0,0 +1,147 @@
+// Package lifecycle waits for a server's dependencies at startup and shuts
+// them down in a fixed order: stop taking new work, cancel background jobs
+// and wait for them, then close the connection pools they were using.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultShutdownTimeout bounds a whole Shutdown when none is given
+const DefaultShutdownTimeout = 15 * time.Second
+
+type stopper struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+type closer struct {
+	name string
+	fn   func() error
+}
+
+// Manager owns the root context handed to background jobs and the hooks run
+// on shutdown. The zero value is not usable; create one with New.
+type Manager struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	jobs   sync.WaitGroup
+
+	mu       sync.Mutex
+	stoppers []stopper
+	closers  []closer
+	running  map[string]int
+}
+
+// New creates a Manager whose root context is derived from parent
+func New(parent context.Context) *Manager {
+	ctx, cancel := context.WithCancel(parent)
+	return &Manager{ctx: ctx, cancel: cancel, running: make(map[string]int)}
+}
+
+// Context is the root context for background jobs. It is cancelled once the
+// stop hooks have run.
+func (m *Manager) Context() context.Context { return m.ctx }
+
+// Go runs fn in a goroutine that Shutdown waits for. fn must return soon
+// after ctx is cancelled.
+func (m *Manager) Go(name string, fn func(ctx context.Context)) {
+	m.mu.Lock()
+	m.running[name]++
+	m.mu.Unlock()
+
+	m.jobs.Add(1)
+	go func() {
+		defer func() {
+			m.mu.Lock()
+			m.running[name]--
+			m.mu.Unlock()
+			m.jobs.Done()
+		}()
+		fn(m.ctx)
+	}()
+}
+
+// OnStop registers a hook that stops new work from arriving, e.g. an HTTP
+// server's Shutdown. Stop hooks run first, in registration order.
+func (m *Manager) OnStop(name string, fn func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stoppers = append(m.stoppers, stopper{name: name, fn: fn})
+}
+
+// OnClose registers a hook that releases a resource, e.g. a DB pool. Close
+// hooks run last, in reverse registration order, so something opened early
+// is closed after everything that was built on it.
+func (m *Manager) OnClose(name string, fn func() error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closers = append(m.closers, closer{name: name, fn: fn})
+}
+
+// Shutdown runs the stop hooks, cancels the root context, waits for the
+// background jobs and then runs the close hooks. timeout bounds the first
+// three steps together; close hooks always run so pools aren't leaked, even
+// when a job overstays the deadline. Every hook error is returned joined.
+func (m *Manager) Shutdown(timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	m.mu.Lock()
+	stoppers := append([]stopper(nil), m.stoppers...)
+	closers := append([]closer(nil), m.closers...)
+	m.mu.Unlock()
+
+	var errs []error
+	for _, s := range stoppers {
+		log.Printf("shutdown: stopping %s", s.name)
+		if err := s.fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stop %s: %w", s.name, err))
+		}
+	}
+
+	m.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		m.jobs.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("background jobs still running after %s: %v", timeout, m.stillRunning()))
+	}
+
+	for i := len(closers) - 1; i >= 0; i-- {
+		log.Printf("shutdown: closing %s", closers[i].name)
+		if err := closers[i].fn(); err != nil {
+			errs = append(errs, fmt.Errorf("close %s: %w", closers[i].name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stillRunning names the jobs that haven't returned yet
+func (m *Manager) stillRunning() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var names []string
+	for name, n := range m.running {
+		if n > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,75 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdown_Order(t *testing.T) {
+	m := New(context.Background())
+
+	var mu sync.Mutex
+	var steps []string
+	record := func(step string) {
+		mu.Lock()
+		defer mu.Unlock()
+		steps = append(steps, step)
+	}
+
+	m.OnClose("db", func() error { record("close db"); return nil })
+	m.OnClose("redis", func() error { record("close redis"); return nil })
+	m.OnStop("http", func(ctx context.Context) error { record("stop http"); return nil })
+	m.Go("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		record("worker done")
+	})
+
+	assert.NoError(t, m.Shutdown(time.Second))
+	assert.Equal(t, []string{"stop http", "worker done", "close redis", "close db"}, steps)
+}
+
+func TestShutdown_StopHooksRunBeforeCancel(t *testing.T) {
+	m := New(context.Background())
+	var ctxErrDuringStop error
+	m.OnStop("http", func(ctx context.Context) error {
+		ctxErrDuringStop = m.Context().Err()
+		return nil
+	})
+
+	assert.NoError(t, m.Shutdown(time.Second))
+	assert.NoError(t, ctxErrDuringStop)
+	assert.ErrorIs(t, m.Context().Err(), context.Canceled)
+}
+
+func TestShutdown_DeadlineStillCloses(t *testing.T) {
+	m := New(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+
+	closed := false
+	m.OnClose("db", func() error { closed = true; return nil })
+	m.Go("stuck", func(ctx context.Context) { <-release })
+
+	err := m.Shutdown(20 * time.Millisecond)
+
+	assert.ErrorContains(t, err, "stuck")
+	assert.True(t, closed)
+}
+
+func TestShutdown_JoinsErrors(t *testing.T) {
+	m := New(context.Background())
+	stopErr := errors.New("listener busy")
+	closeErr := errors.New("pool closed twice")
+	m.OnStop("http", func(ctx context.Context) error { return stopErr })
+	m.OnClose("db", func() error { return closeErr })
+
+	err := m.Shutdown(time.Second)
+
+	assert.ErrorIs(t, err, stopErr)
+	assert.ErrorIs(t, err, closeErr)
+}
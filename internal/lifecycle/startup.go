@@ -0,0 +1,53 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// DefaultStartupWaitTimeout bounds how long a service waits for its
+// dependencies to come up when none is given
+const DefaultStartupWaitTimeout = 60 * time.Second
+
+// Startup check pacing: each attempt gets at most startupAttemptTimeout, and
+// the wait between attempts doubles from startupBackoff up to startupMaxBackoff
+var (
+	startupAttemptTimeout = 5 * time.Second
+	startupBackoff        = 500 * time.Millisecond
+	startupMaxBackoff     = 5 * time.Second
+)
+
+// WaitFor runs check until it succeeds or timeout has passed, backing off
+// between attempts, so a service started alongside its database or Redis
+// doesn't exit while they are still booting. A timeout of 0 or less makes a
+// single attempt. The returned error wraps the last failure.
+func WaitFor(name string, timeout time.Duration, check func(ctx context.Context) error) error {
+	start := time.Now()
+	deadline := start.Add(timeout)
+	backoff := startupBackoff
+
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), startupAttemptTimeout)
+		err := check(ctx)
+		cancel()
+		if err == nil {
+			if attempt > 1 {
+				log.Printf("%s ready after %d attempts (%s)", name, attempt, time.Since(start).Round(time.Millisecond))
+			}
+			return nil
+		}
+
+		if time.Now().Add(backoff).After(deadline) {
+			return fmt.Errorf("%s not ready after %d attempts: %w", name, attempt, err)
+		}
+		log.Printf("waiting for %s (attempt %d, retrying in %s): %v", name, attempt, backoff, err)
+		time.Sleep(backoff)
+
+		backoff *= 2
+		if backoff > startupMaxBackoff {
+			backoff = startupMaxBackoff
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func withFastBackoff(t *testing.T) {
+	t.Helper()
+	prevBackoff, prevMax := startupBackoff, startupMaxBackoff
+	startupBackoff, startupMaxBackoff = time.Millisecond, 4*time.Millisecond
+	t.Cleanup(func() { startupBackoff, startupMaxBackoff = prevBackoff, prevMax })
+}
+
+func TestWaitFor_RetriesUntilReady(t *testing.T) {
+	withFastBackoff(t)
+
+	calls := 0
+	err := WaitFor("db", time.Second, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestWaitFor_GivesUpAfterTimeout(t *testing.T) {
+	withFastBackoff(t)
+
+	refused := errors.New("connection refused")
+	calls := 0
+	start := time.Now()
+	err := WaitFor("db", 30*time.Millisecond, func(ctx context.Context) error {
+		calls++
+		return refused
+	})
+
+	assert.ErrorIs(t, err, refused)
+	assert.Greater(t, calls, 1)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestWaitFor_ZeroTimeoutTriesOnce(t *testing.T) {
+	withFastBackoff(t)
+
+	calls := 0
+	err := WaitFor("redis", 0, func(ctx context.Context) error {
+		calls++
+		return errors.New("connection refused")
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWaitFor_AttemptHasDeadline(t *testing.T) {
+	err := WaitFor("redis", 0, func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		return nil
+	})
+	assert.NoError(t, err)
+}
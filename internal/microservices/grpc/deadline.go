@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultRequestDeadline applies to calls whose client set no deadline
+const DefaultRequestDeadline = 10 * time.Second
+
+// deadlineInterceptor gives every unary call a deadline, using fallback when
+// the client didn't send one, so an abandoned call can't hold a DB connection
+// indefinitely. Handlers pass ctx down to their queries; when it expires or
+// the client cancels, the resulting error is reported as DeadlineExceeded or
+// Canceled rather than Unknown.
+func deadlineInterceptor(fallback time.Duration) grpc.UnaryServerInterceptor {
+	if fallback <= 0 {
+		fallback = DefaultRequestDeadline
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, fallback)
+			defer cancel()
+		}
+
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if _, isStatus := status.FromError(err); isStatus {
+			return nil, err
+		}
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		case errors.Is(err, context.Canceled):
+			return nil, status.Error(codes.Canceled, err.Error())
+		}
+		return nil, err
+	}
+}
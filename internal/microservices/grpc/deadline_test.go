@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testInfo = &grpc.UnaryServerInfo{FullMethod: "/manga.MangaService/SearchManga"}
+
+func TestDeadlineInterceptor_AppliesFallback(t *testing.T) {
+	interceptor := deadlineInterceptor(50 * time.Millisecond)
+
+	var deadline time.Time
+	var hasDeadline bool
+	_, err := interceptor(context.Background(), nil, testInfo, func(ctx context.Context, req any) (any, error) {
+		deadline, hasDeadline = ctx.Deadline()
+		return nil, nil
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(50*time.Millisecond), deadline, 50*time.Millisecond)
+}
+
+func TestDeadlineInterceptor_KeepsClientDeadline(t *testing.T) {
+	interceptor := deadlineInterceptor(time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	want, _ := ctx.Deadline()
+
+	_, err := interceptor(ctx, nil, testInfo, func(ctx context.Context, req any) (any, error) {
+		got, _ := ctx.Deadline()
+		assert.Equal(t, want, got)
+		return nil, nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestDeadlineInterceptor_MapsContextErrors(t *testing.T) {
+	interceptor := deadlineInterceptor(10 * time.Millisecond)
+
+	_, err := interceptor(context.Background(), nil, testInfo, func(ctx context.Context, req any) (any, error) {
+		<-ctx.Done()
+		// repositories wrap the driver error
+		return nil, fmt.Errorf("search manga by title/author: %w", ctx.Err())
+	})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	_, err = interceptor(context.Background(), nil, testInfo, func(ctx context.Context, req any) (any, error) {
+		return nil, fmt.Errorf("query: %w", context.Canceled)
+	})
+	assert.Equal(t, codes.Canceled, status.Code(err))
+}
+
+func TestDeadlineInterceptor_PassesOtherErrors(t *testing.T) {
+	interceptor := deadlineInterceptor(time.Second)
+	boom := errors.New("boom")
+	invalid := status.Error(codes.InvalidArgument, "bad query")
+
+	_, err := interceptor(context.Background(), nil, testInfo, func(ctx context.Context, req any) (any, error) {
+		return nil, boom
+	})
+	assert.ErrorIs(t, err, boom)
+
+	_, err = interceptor(context.Background(), nil, testInfo, func(ctx context.Context, req any) (any, error) {
+		return nil, invalid
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,33 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "mangahub/proto/pb"
+)
+
+// ReadOnlyMode mirrors the API server's READ_ONLY settings
+type ReadOnlyMode struct {
+	Enabled      bool // Refuse catalog writes
+	LockUserData bool // Also refuse per-user writes such as progress updates
+}
+
+// userWriteMethods change a single user's data; the service has no catalog
+// writes yet, so LockUserData is what matters here
+var userWriteMethods = map[string]bool{
+	pb.MangaService_UpdateProgress_FullMethodName: true,
+}
+
+// readOnlyInterceptor answers PermissionDenied for writes the mode forbids
+func readOnlyInterceptor(mode ReadOnlyMode) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if mode.Enabled && mode.LockUserData && userWriteMethods[info.FullMethod] {
+			return nil, status.Error(codes.PermissionDenied, "this instance is read-only")
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "mangahub/proto/pb"
+)
+
+func TestReadOnlyInterceptor(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   ReadOnlyMode
+		method string
+		want   codes.Code
+	}{
+		{"Off", ReadOnlyMode{}, pb.MangaService_UpdateProgress_FullMethodName, codes.OK},
+		{"CatalogOnlyAllowsProgress", ReadOnlyMode{Enabled: true}, pb.MangaService_UpdateProgress_FullMethodName, codes.OK},
+		{"LockedProgress", ReadOnlyMode{Enabled: true, LockUserData: true}, pb.MangaService_UpdateProgress_FullMethodName, codes.PermissionDenied},
+		{"LockedReadsStillWork", ReadOnlyMode{Enabled: true, LockUserData: true}, pb.MangaService_GetManga_FullMethodName, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+			_, err := readOnlyInterceptor(tt.mode)(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.want, status.Code(err))
+		})
+	}
+}
@@ -8,9 +8,12 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "mangahub/proto/pb"
 
+	dto "mangahub/internal/microservices/http-api/dto"
 	models "mangahub/internal/microservices/http-api/models"
 	rp "mangahub/internal/microservices/http-api/repository"
 	search "mangahub/internal/search"
@@ -77,6 +80,9 @@ func (s *MangaServiceServer) GetManga(ctx context.Context, req *pb.GetMangaReque
 		return nil, fmt.Errorf("empty request")
 	}
 	mangaID := req.GetMangaId()
+	if mangaID <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "manga_id must be positive")
+	}
 	manga, err := s.mangaRepo.GetByID(ctx, mangaID)
 	if err != nil {
 		return nil, err
@@ -91,7 +97,10 @@ func (s *MangaServiceServer) SearchManga(ctx context.Context, req *pb.SearchRequ
 	if req == nil {
 		return nil, fmt.Errorf("empty request")
 	}
-	query := req.GetQuery()
+	query, err := dto.NormalizeSearchQuery(req.GetQuery())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	limit := int(req.GetLimit())
 	offset := int(req.GetOffset())
 	if limit <= 0 || limit > 20 {
@@ -121,6 +130,11 @@ func (s *MangaServiceServer) SearchManga(ctx context.Context, req *pb.SearchRequ
 		localPB = append(localPB, pm)
 	}
 
+	// Don't start the external fetch for a caller that has already gone
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
 	// 2) Always fetch external to ensure links are included
 	externals := search.FetchExternalSources(ctx, query, limit)
 
@@ -195,13 +209,18 @@ func (s *MangaServiceServer) UpdateProgress(ctx context.Context, req *pb.UpdateP
 	}, nil
 }
 
-// StartGRPCServer starts the gRPC server
-func StartGRPCServer(addr string, mangaRepo *rp.MangaRepo, progressRepo rp.ProgressRepository) error {
+// StartGRPCServer starts the gRPC server. Calls without a client deadline get
+// defaultDeadline (DefaultRequestDeadline when zero); readOnly refuses writes
+// on a read-only deployment.
+func StartGRPCServer(addr string, mangaRepo *rp.MangaRepo, progressRepo rp.ProgressRepository, defaultDeadline time.Duration, readOnly ReadOnlyMode) error {
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		return err
 	}
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		readOnlyInterceptor(readOnly),
+		deadlineInterceptor(defaultDeadline),
+	))
 	srv := NewMangaServiceServer(mangaRepo, progressRepo)
 	pb.RegisterMangaServiceServer(grpcServer, srv)
 	log.Printf("gRPC listening on %s", addr)
@@ -1,19 +1,38 @@
 package dto
 
+import (
+	"mangahub/internal/microservices/http-api/models"
+	"time"
+)
+
 // Data Transfer Objects for authentication requests and responses
 
 // RegisterRequest: payload for user registration
 type RegisterRequest struct {
-	Username string `json:"username" binding:"required,min=3,max=50"`
-	Password string `json:"password" binding:"required,min=8"`
+	Username string `json:"username" binding:"required,min=3,max=50,excludes=@"` // "@" is reserved so login can tell emails apart
+	Password string `json:"password" binding:"required"` // strength is checked against the configurable password policy
 	Email    string `json:"email" binding:"required,email"`
 }
 
+// PasswordPolicyResponse: the rules new passwords must satisfy
+type PasswordPolicyResponse struct {
+	MinLength      int  `json:"min_length"`
+	RequireDigit   bool `json:"require_digit"`
+	RequireUpper   bool `json:"require_upper"`
+	RequireSpecial bool `json:"require_special"`
+}
+
 // LoginRequest: payload for user login
 type LoginRequest struct {
 	Username string `json:"username"`
 	Email    string `json:"email"`
 	Password string `json:"password" binding:"required"`
+	Scope    string `json:"scope,omitempty"` // optional space-separated subset of the role's scopes, e.g. "read:manga read:library"
+	// DeviceName labels the session in GET /api/users/me/sessions; derived from the user agent when empty
+	DeviceName string `json:"device_name,omitempty" binding:"max=100"`
+	// TokenTTL asks for a different access-token lifetime in seconds, e.g. shorter
+	// for a CLI daemon; the server clamps it and reports the result in expires_in
+	TokenTTL int64 `json:"token_ttl,omitempty" binding:"omitempty,min=1"`
 }
 
 // AuthResponse: response payload after successful authentication
@@ -23,6 +42,7 @@ type AuthResponse struct {
 	UserID       string `json:"user_id"`
 	Username     string `json:"username"`
 	ExpiresIn    int64  `json:"expires_in"` // seconds
+	Scope        string `json:"scope"`      // granted scopes, space-separated
 }
 
 // RefreshTokenRequest: payload for refreshing access token
@@ -52,6 +72,30 @@ type RevokeTokenResponse struct {
 	Message string `json:"message"`
 }
 
+// SessionResponse: a signed-in session (one active refresh token)
+type SessionResponse struct {
+	ID         string     `json:"id"`
+	DeviceName string     `json:"device_name"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+}
+
+// NewSessionResponse converts a refresh token into a SessionResponse
+func NewSessionResponse(token models.RefreshToken) SessionResponse {
+	return SessionResponse{
+		ID:         token.ID,
+		DeviceName: token.DeviceName,
+		UserAgent:  token.UserAgent,
+		IPAddress:  token.IPAddress,
+		CreatedAt:  token.CreatedAt,
+		LastUsedAt: token.LastUsedAt,
+		ExpiresAt:  token.ExpiresAt,
+	}
+}
+
 // OAuth2.1 DTOs
 // OAuthTokenRequest: payload for OAuth2.1 token request
 type OAuthTokenRequest struct {
@@ -6,14 +6,15 @@ import (
 	"mangahub/internal/microservices/http-api/models"
 )
 
-// CreateCommentDTO for creating a comment
+// CreateCommentDTO for creating a comment. Length limits are configurable
+// and checked by the comment service.
 type CreateCommentDTO struct {
-	Content string `json:"content" binding:"required,min=1,max=5000"`
+	Content string `json:"content" binding:"required"`
 }
 
 // UpdateCommentDTO for updating a comment
 type UpdateCommentDTO struct {
-	Content string `json:"content" binding:"required,min=1,max=5000"`
+	Content string `json:"content" binding:"required"`
 }
 
 // CommentResponse for returning comment information (for list view - without IDs)
@@ -41,14 +42,14 @@ type PaginatedCommentResponse struct {
 	PageSize   int               `json:"page_size"`
 	Total      int               `json:"total"`
 	TotalPages int               `json:"total_pages"`
+	HasNext    bool              `json:"has_next"`
+	HasPrev    bool              `json:"has_prev"`
 }
 
 // NewPaginatedCommentResponse creates a paginated comment response
 func NewPaginatedCommentResponse(data []CommentResponse, total, page, pageSize int) *PaginatedCommentResponse {
-	totalPages := total / pageSize
-	if total%pageSize != 0 {
-		totalPages++
-	}
+	totalPages := TotalPages(int64(total), pageSize)
+	hasNext, hasPrev := PageFlags(page, totalPages)
 
 	return &PaginatedCommentResponse{
 		Data:       data,
@@ -56,5 +57,61 @@ func NewPaginatedCommentResponse(data []CommentResponse, total, page, pageSize i
 		PageSize:   pageSize,
 		Total:      total,
 		TotalPages: totalPages,
+		HasNext:    hasNext,
+		HasPrev:    hasPrev,
+	}
+}
+
+// AdminCommentResponse is a comment in the moderation feed, with enough
+// context to act on it without further lookups
+type AdminCommentResponse struct {
+	ID         int64     `json:"id"`
+	MangaID    int64     `json:"manga_id"`
+	MangaTitle string    `json:"manga_title"`
+	UserID     string    `json:"user_id"`
+	Username   string    `json:"username"`
+	Content    string    `json:"content"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// FromModelToAdminCommentResponse converts a Comment with its User and Manga loaded
+func FromModelToAdminCommentResponse(comment *models.Comment) AdminCommentResponse {
+	return AdminCommentResponse{
+		ID:         comment.ID,
+		MangaID:    comment.MangaID,
+		MangaTitle: comment.Manga.Title,
+		UserID:     comment.UserID,
+		Username:   comment.User.Username,
+		Content:    comment.Content,
+		CreatedAt:  comment.CreatedAt,
+		UpdatedAt:  comment.UpdatedAt,
+	}
+}
+
+// PaginatedAdminCommentResponse for returning a page of the moderation feed
+type PaginatedAdminCommentResponse struct {
+	Data       []AdminCommentResponse `json:"data"`
+	Page       int                    `json:"page"`
+	PageSize   int                    `json:"page_size"`
+	Total      int                    `json:"total"`
+	TotalPages int                    `json:"total_pages"`
+	HasNext    bool                   `json:"has_next"`
+	HasPrev    bool                   `json:"has_prev"`
+}
+
+// NewPaginatedAdminCommentResponse creates a paginated moderation feed response
+func NewPaginatedAdminCommentResponse(data []AdminCommentResponse, total, page, pageSize int) *PaginatedAdminCommentResponse {
+	totalPages := TotalPages(int64(total), pageSize)
+	hasNext, hasPrev := PageFlags(page, totalPages)
+
+	return &PaginatedAdminCommentResponse{
+		Data:       data,
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    hasNext,
+		HasPrev:    hasPrev,
 	}
 }
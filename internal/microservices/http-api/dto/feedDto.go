@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+// ActivityResponse is one entry in the activity feed
+type ActivityResponse struct {
+	Type       string    `json:"type"` // rating, comment or library_add
+	Username   string    `json:"username"`
+	MangaID    int64     `json:"manga_id"`
+	MangaTitle string    `json:"manga_title"`
+	Rating     *int      `json:"rating,omitempty"`
+	Content    *string   `json:"content,omitempty"`
+	At         time.Time `json:"at"`
+}
+
+// FeedResponse is a page of the activity feed. Pass NextCursor as ?cursor=
+// to get the next page; it is empty on the last one.
+type FeedResponse struct {
+	Data       []ActivityResponse `json:"data"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+	HasNext    bool               `json:"has_next"`
+}
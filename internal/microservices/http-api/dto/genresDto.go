@@ -7,9 +7,15 @@ type CreateGenreDTO struct {
 	Name string `json:"name" binding:"required"`
 }
 
+// GenreMangasDTO for POST and DELETE /api/genres/:id/mangas
+type GenreMangasDTO struct {
+	MangaIDs []int64 `json:"manga_ids" binding:"required,min=1,max=500,dive,gt=0"`
+}
+
 type GenreResponse struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	MangaCount *int64 `json:"manga_count,omitempty"` // only with ?counts=true on GET /api/manga/:manga_id/genres
 }
 
 func GenreFromModel(g models.Genre) GenreResponse {
@@ -18,3 +24,20 @@ func GenreFromModel(g models.Genre) GenreResponse {
 		Name: g.Name,
 	}
 }
+
+// GenreLinkResponse reports a bulk POST /api/genres/:id/mangas
+type GenreLinkResponse struct {
+	GenreID       int64   `json:"genre_id"`
+	Linked        []int64 `json:"linked"`
+	AlreadyLinked []int64 `json:"already_linked"`
+	NotFound      []int64 `json:"not_found"` // No manga with these ids
+}
+
+// GenreUnlinkResponse reports a bulk DELETE /api/genres/:id/mangas
+type GenreUnlinkResponse struct {
+	GenreID   int64   `json:"genre_id"`
+	Unlinked  []int64 `json:"unlinked"`
+	NotLinked []int64 `json:"not_linked"`
+	NotFound  []int64 `json:"not_found"`
+	SoleGenre []int64 `json:"sole_genre"` // Skipped: the genre is their only one
+}
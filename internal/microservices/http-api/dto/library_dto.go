@@ -7,6 +7,41 @@ type AddToLibraryRequest struct {
     MangaID int64 `json:"manga_id" binding:"required"`
 }
 
+// BulkRemoveFromLibraryRequest: payload to remove several manga at once
+type BulkRemoveFromLibraryRequest struct {
+    MangaIDs []int64 `json:"manga_ids" binding:"required,min=1,max=100,dive,gt=0"`
+}
+
+// BulkRemoveFromLibraryResponse: outcome of a bulk remove
+type BulkRemoveFromLibraryResponse struct {
+    Removed     int     `json:"removed"`
+    NotFound    int     `json:"not_found"`
+    RemovedIDs  []int64 `json:"removed_ids"`
+    NotFoundIDs []int64 `json:"not_found_ids"`
+}
+
+// CatchUpResponse: outcome of marking the whole library caught up
+type CatchUpResponse struct {
+    Updated          int     `json:"updated"`
+    AlreadyCaughtUp  int     `json:"already_caught_up"`
+    Skipped          int     `json:"skipped"`
+    Excluded         int     `json:"excluded"` // Dropped or plan_to_read entries, left as they are
+    UpdatedMangaIDs  []int64 `json:"updated_manga_ids"`
+    SkippedMangaIDs  []int64 `json:"skipped_manga_ids"`
+    ExcludedMangaIDs []int64 `json:"excluded_manga_ids"`
+}
+
+// LibraryStatsResponse: counts by reading status for the profile dashboard
+type LibraryStatsResponse struct {
+    Reading           int64   `json:"reading"`
+    Completed         int64   `json:"completed"`
+    PlanToRead        int64   `json:"plan_to_read"`
+    Dropped           int64   `json:"dropped"`
+    Total             int64   `json:"total"`
+    TotalChapters     int64   `json:"total_chapters"`
+    CompletionPercent float64 `json:"completion_percent"`
+}
+
 // LibraryResponse: response for a library item
 type LibraryResponse struct {
     ID        int64         `json:"id"`
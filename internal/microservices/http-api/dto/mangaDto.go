@@ -7,13 +7,14 @@ import (
 
 // SearchFilters for advanced manga search
 type SearchFilters struct {
-	Query     string   `form:"q"`                                                                // Full-text search query
-	Genres    []string `form:"genres"`                                                           // Genre names or IDs (comma-separated)
-	Status    string   `form:"status" binding:"omitempty,oneof=ongoing completed hiatus"`        // ongoing, completed, hiatus
-	MinRating *float64 `form:"min_rating" binding:"omitempty,min=0,max=10"`                      // Minimum average rating (0-10)
-	SortBy    string   `form:"sort_by" binding:"omitempty,oneof=popularity rating recent title"` // Sort order
-	Page      int      `form:"page" binding:"omitempty,min=1"`                                   // Page number (default: 1)
-	PageSize  int      `form:"page_size" binding:"omitempty,min=1,max=100"`                      // Items per page (default: 20, max: 100)
+	Query     string   `form:"q"`                                                                      // Full-text search query
+	Genres    []string `form:"genres"`                                                                 // Genre names or IDs (comma-separated)
+	GenreIDs  []int64  `form:"genre_ids"`                                                              // Genre IDs only (comma-separated)
+	Status    string   `form:"status" binding:"omitempty,manga_status"`                                // see models.MangaStatuses
+	MinRating *float64 `form:"min_rating" binding:"omitempty,min=0,max=10"`                            // Minimum average rating (0-10)
+	SortBy    string   `form:"sort_by" binding:"omitempty,oneof=popularity rating recent title views"` // Sort order
+	Page      int      `form:"page" binding:"omitempty,min=1"`                                         // Page number (default: 1)
+	PageSize  int      `form:"page_size" binding:"omitempty,min=1"`                                    // Items per page (default: DEFAULT_PAGE_SIZE, clamped to MAX_PAGE_SIZE)
 }
 
 // CreateMangaDTO used for POST /api/manga
@@ -21,23 +22,43 @@ type CreateMangaDTO struct {
 	Slug          *string `json:"slug,omitempty"`
 	Title         string  `json:"title" binding:"required"`
 	Author        *string `json:"author,omitempty"`
-	Status        *string `json:"status,omitempty" binding:"omitempty,oneof=ongoing completed hiatus"`
+	Status        *string `json:"status,omitempty" binding:"omitempty,manga_status"`
 	TotalChapters *int    `json:"total_chapters,omitempty" binding:"omitempty,min=0"`
 	Description   *string `json:"description,omitempty"`
 	CoverURL      *string `json:"cover_url,omitempty"`
 	GenreIDs      []int64 `json:"genre_ids,omitempty"`
 }
 
-// UpdateMangaDTO used for PUT /api/manga/:id (partial updates allowed)
+// UpdateMangaDTO used for PUT and PATCH /api/manga/:id. Omitted fields are
+// left alone; ClearFields names nullable fields to set back to null, which
+// an omitted pointer can't express.
 type UpdateMangaDTO struct {
-	Title         *string `json:"title,omitempty"`
-	Author        *string `json:"author,omitempty"`
-	Status        *string `json:"status,omitempty" binding:"omitempty,oneof=ongoing completed hiatus"`
-	TotalChapters *int    `json:"total_chapters,omitempty" binding:"omitempty,min=0"`
-	Description   *string `json:"description,omitempty"`
-	CoverURL      *string `json:"cover_url,omitempty"`
-	Slug          *string `json:"slug,omitempty"`
-	GenreIDs      []int64 `json:"genre_ids,omitempty"`
+	Title         *string  `json:"title,omitempty"`
+	Author        *string  `json:"author,omitempty"`
+	Status        *string  `json:"status,omitempty" binding:"omitempty,manga_status"`
+	TotalChapters *int     `json:"total_chapters,omitempty" binding:"omitempty,min=0"`
+	Description   *string  `json:"description,omitempty"`
+	CoverURL      *string  `json:"cover_url,omitempty"`
+	Slug          *string  `json:"slug,omitempty"`
+	GenreIDs      []int64  `json:"genre_ids,omitempty"`
+	ClearFields   []string `json:"clear_fields,omitempty" binding:"omitempty,dive,oneof=author status total_chapters description cover_url"`
+}
+
+// ImportMangaRequest used for POST /api/admin/manga/import
+type ImportMangaRequest struct {
+	Source     string `json:"source" binding:"required,oneof=mangadex anilist"`
+	ExternalID string `json:"external_id" binding:"required"`
+}
+
+// MangaBySlugsRequest used for POST /api/manga/slugs (at most 100 slugs)
+type MangaBySlugsRequest struct {
+	Slugs []string `json:"slugs" binding:"required,min=1,max=100,dive,required,max=255"`
+}
+
+// MangaBySlugsResponse lists the manga found and the slugs that matched nothing
+type MangaBySlugsResponse struct {
+	Data     []MangaResponse `json:"data"`
+	NotFound []string        `json:"not_found"`
 }
 
 // MangaBasicResponse DTO for list view (basic info only)
@@ -49,6 +70,76 @@ type MangaBasicResponse struct {
 	TotalChapters *int     `json:"total_chapters,omitempty"`
 	CoverURL      *string  `json:"cover_url,omitempty"`
 	AverageRating *float64 `json:"average_rating,omitempty"`
+	ViewCount     int64    `json:"view_count"`
+}
+
+// MangaSearchResult DTO for search results: the basic view plus which fields
+// matched the query and, for description matches, a highlighted excerpt
+type MangaSearchResult struct {
+	MangaBasicResponse
+	MatchedFields []string `json:"matched_fields,omitempty"`
+	Highlight     string   `json:"highlight,omitempty"` // HTML-escaped; matches wrapped in <em>
+}
+
+// MangaUserSearchResult is a search result annotated with the caller's
+// library entry and reading progress, for ?with_user_context=true. The
+// progress fields are null when they haven't started the manga.
+type MangaUserSearchResult struct {
+	MangaSearchResult
+	InLibrary      bool    `json:"in_library"`
+	CurrentChapter *int    `json:"current_chapter"`
+	ReadingStatus  *string `json:"reading_status"`
+}
+
+// MangaProjectionFields lists the manga fields a client may pick with
+// ?fields=. Names are both the JSON keys and the database columns.
+var MangaProjectionFields = map[string]bool{
+	"id":             true,
+	"slug":           true,
+	"title":          true,
+	"author":         true,
+	"status":         true,
+	"total_chapters": true,
+	"description":    true,
+	"cover_url":      true,
+	"average_rating": true,
+	"created_at":     true,
+}
+
+// ProjectManga returns only the requested fields of m, keyed by JSON name.
+// Unset optional fields are omitted like in the full responses.
+func ProjectManga(m models.Manga, fields []string) map[string]any {
+	out := make(map[string]any, len(fields))
+	set := func(key string, v any, present bool) {
+		if present {
+			out[key] = v
+		}
+	}
+	for _, f := range fields {
+		switch f {
+		case "id":
+			set(f, m.ID, true)
+		case "slug":
+			set(f, m.Slug, m.Slug != nil)
+		case "title":
+			set(f, m.Title, true)
+		case "author":
+			set(f, m.Author, m.Author != nil)
+		case "status":
+			set(f, m.Status, m.Status != nil)
+		case "total_chapters":
+			set(f, m.TotalChapters, m.TotalChapters != nil)
+		case "description":
+			set(f, m.Description, m.Description != nil)
+		case "cover_url":
+			set(f, m.CoverURL, m.CoverURL != nil)
+		case "average_rating":
+			set(f, m.AverageRating, m.AverageRating != nil)
+		case "created_at":
+			set(f, m.CreatedAt, m.CreatedAt != nil)
+		}
+	}
+	return out
 }
 
 // MangaResponse DTO for detailed responses (all attributes)
@@ -62,8 +153,11 @@ type MangaResponse struct {
 	Description   *string    `json:"description,omitempty"`
 	CoverURL      *string    `json:"cover_url,omitempty"`
 	AverageRating *float64   `json:"average_rating,omitempty"`
+	ViewCount     int64      `json:"view_count"`
 	CreatedAt     *time.Time `json:"created_at,omitempty"`
 	Genres        []string   `json:"genres,omitempty"`
+	MangaDexID    *string    `json:"mangadex_id,omitempty"`
+	AniListID     *int64     `json:"anilist_id,omitempty"`
 }
 
 // Converters
@@ -119,8 +213,11 @@ func FromModelToResponse(m models.Manga) MangaResponse {
 		Description:   m.Description,
 		CoverURL:      m.CoverURL,
 		AverageRating: m.AverageRating,
+		ViewCount:     m.ViewCount,
 		CreatedAt:     m.CreatedAt,
 		Genres:        genreNames,
+		MangaDexID:    m.MangaDexID,
+		AniListID:     m.AniListID,
 	}
 }
 
@@ -133,5 +230,48 @@ func FromModelToBasicResponse(m models.Manga) MangaBasicResponse {
 		TotalChapters: m.TotalChapters,
 		CoverURL:      m.CoverURL,
 		AverageRating: m.AverageRating,
+		ViewCount:     m.ViewCount,
+	}
+}
+
+// MangaSyncInfoResponse reports a manga's ingestion state. Fields are null
+// rather than omitted when a source doesn't apply, so clients see the full shape.
+type MangaSyncInfoResponse struct {
+	MangaID          int64      `json:"manga_id"`
+	MangaDexID       *string    `json:"mangadex_id"`
+	AniListID        *int64     `json:"anilist_id"`
+	LastSyncedAt     *time.Time `json:"last_synced_at"`
+	LastChapterCheck *time.Time `json:"last_chapter_check"`
+	LastUpdatedBy    *string    `json:"last_updated_by"` // "mangadex" or "anilist"
+}
+
+// FromSyncInfoModel reports the most recent sync and chapter check across
+// both sources, and which source did the latest sync
+func FromSyncInfoModel(info models.MangaSyncInfo) MangaSyncInfoResponse {
+	resp := MangaSyncInfoResponse{
+		MangaID:          info.ID,
+		MangaDexID:       info.MangaDexID,
+		AniListID:        info.AniListID,
+		LastChapterCheck: latest(info.MangaDexLastChapterCheck, info.AniListLastChapterCheck),
+	}
+
+	source := ""
+	switch {
+	case info.AniListLastSyncedAt != nil && (info.MangaDexLastSyncedAt == nil || info.AniListLastSyncedAt.After(*info.MangaDexLastSyncedAt)):
+		resp.LastSyncedAt, source = info.AniListLastSyncedAt, "anilist"
+	case info.MangaDexLastSyncedAt != nil:
+		resp.LastSyncedAt, source = info.MangaDexLastSyncedAt, "mangadex"
+	}
+	if source != "" {
+		resp.LastUpdatedBy = &source
+	}
+	return resp
+}
+
+// latest returns the later of two optional timestamps
+func latest(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.After(*a)) {
+		return b
 	}
+	return a
 }
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"mangahub/internal/microservices/http-api/models"
+)
+
+// CreateMetadataReportRequest is the body of POST /api/manga/:manga_id/report
+type CreateMetadataReportRequest struct {
+	Field  string `json:"field" binding:"required,oneof=title author status description total_chapters genres cover other"`
+	Reason string `json:"reason" binding:"required,min=1,max=1000"`
+}
+
+// CloseMetadataReportRequest is the body of PUT /api/admin/metadata-reports/:id
+type CloseMetadataReportRequest struct {
+	Status string `json:"status" binding:"required,oneof=resolved dismissed"`
+}
+
+// MetadataReportResponse is a metadata report as shown to admins
+type MetadataReportResponse struct {
+	ID         int64      `json:"id"`
+	MangaID    int64      `json:"manga_id"`
+	MangaTitle string     `json:"manga_title,omitempty"`
+	UserID     string     `json:"user_id"`
+	Username   string     `json:"username,omitempty"`
+	Field      string     `json:"field"`
+	Reason     string     `json:"reason"`
+	Status     string     `json:"status"`
+	ResolvedBy *string    `json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func NewMetadataReportResponse(r models.MetadataReport) MetadataReportResponse {
+	return MetadataReportResponse{
+		ID:         r.ID,
+		MangaID:    r.MangaID,
+		MangaTitle: r.Manga.Title,
+		UserID:     r.UserID,
+		Username:   r.User.Username,
+		Field:      r.Field,
+		Reason:     r.Reason,
+		Status:     r.Status,
+		ResolvedBy: r.ResolvedBy,
+		ResolvedAt: r.ResolvedAt,
+		CreatedAt:  r.CreatedAt,
+	}
+}
@@ -1,11 +1,90 @@
 package dto
 
+// Page size limits shared by every paginated endpoint. They default to 20
+// and 100 and are overridden from config at startup via SetPageSizeLimits.
+var (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// SetPageSizeLimits sets the default and maximum page size. It is meant to be
+// called once at startup; invalid pairs are ignored since config validation
+// already rejects them.
+func SetPageSizeLimits(defaultSize, maxSize int) {
+	if defaultSize < 1 || maxSize < defaultSize {
+		return
+	}
+	defaultPageSize = defaultSize
+	maxPageSize = maxSize
+}
+
+// DefaultPageSize is the page size used when a request doesn't give one
+func DefaultPageSize() int { return defaultPageSize }
+
+// MaxPageSize is the largest page size a request may ask for
+func MaxPageSize() int { return maxPageSize }
+
+// NormalizePagination defaults page to 1 and pageSize to DefaultPageSize when
+// they aren't positive, and clamps pageSize to MaxPageSize
+func NormalizePagination(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
+
+// TotalPages is how many pages of pageSize it takes to hold total items
+func TotalPages(total int64, pageSize int) int {
+	if pageSize < 1 {
+		return 0
+	}
+	return int((total + int64(pageSize) - 1) / int64(pageSize))
+}
+
+// PageFlags reports whether there are pages after and before page, so
+// clients needn't work it out from the totals
+func PageFlags(page, totalPages int) (hasNext, hasPrev bool) {
+	return page < totalPages, page > 1
+}
+
+// Pagination is the page metadata list endpoints return under "pagination"
+type Pagination struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+	HasNext    bool  `json:"has_next"`
+	HasPrev    bool  `json:"has_prev"`
+}
+
+// NewPagination builds the page metadata for page of a total-item list
+func NewPagination(page, pageSize int, total int64) Pagination {
+	totalPages := TotalPages(total, pageSize)
+	hasNext, hasPrev := PageFlags(page, totalPages)
+	return Pagination{
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    hasNext,
+		HasPrev:    hasPrev,
+	}
+}
+
 type PaginatedMangaResponse struct {
 	Data       []MangaResponse `json:"data"`
 	Page       int             `json:"page"`
 	PageSize   int             `json:"page_size"`
 	Total      int64           `json:"total"`
 	TotalPages int             `json:"total_pages"`
+	HasNext    bool            `json:"has_next"`
+	HasPrev    bool            `json:"has_prev"`
 }
 
 type PaginatedMangaBasicResponse struct {
@@ -14,13 +93,13 @@ type PaginatedMangaBasicResponse struct {
 	PageSize   int                  `json:"page_size"`
 	Total      int64                `json:"total"`
 	TotalPages int                  `json:"total_pages"`
+	HasNext    bool                 `json:"has_next"`
+	HasPrev    bool                 `json:"has_prev"`
 }
 
 func NewPaginatedMangaResponse(data []MangaResponse, page, pageSize int, total int64) PaginatedMangaResponse {
-	totalPages := int(total) / pageSize
-	if int(total)%pageSize != 0 {
-		totalPages++
-	}
+	totalPages := TotalPages(total, pageSize)
+	hasNext, hasPrev := PageFlags(page, totalPages)
 
 	return PaginatedMangaResponse{
 		Data:       data,
@@ -28,14 +107,14 @@ func NewPaginatedMangaResponse(data []MangaResponse, page, pageSize int, total i
 		PageSize:   pageSize,
 		Total:      total,
 		TotalPages: totalPages,
+		HasNext:    hasNext,
+		HasPrev:    hasPrev,
 	}
 }
 
 func NewPaginatedMangaBasicResponse(data []MangaBasicResponse, page, pageSize int, total int64) PaginatedMangaBasicResponse {
-	totalPages := int(total) / pageSize
-	if int(total)%pageSize != 0 {
-		totalPages++
-	}
+	totalPages := TotalPages(total, pageSize)
+	hasNext, hasPrev := PageFlags(page, totalPages)
 
 	return PaginatedMangaBasicResponse{
 		Data:       data,
@@ -43,5 +122,7 @@ func NewPaginatedMangaBasicResponse(data []MangaBasicResponse, page, pageSize in
 		PageSize:   pageSize,
 		Total:      total,
 		TotalPages: totalPages,
+		HasNext:    hasNext,
+		HasPrev:    hasPrev,
 	}
 }
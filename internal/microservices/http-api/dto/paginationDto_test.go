@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPagination(t *testing.T) {
+	tests := []struct {
+		name             string
+		page, pageSize   int
+		total            int64
+		totalPages       int
+		hasNext, hasPrev bool
+	}{
+		{"empty", 1, 20, 0, 0, false, false},
+		{"single page", 1, 20, 20, 1, false, false},
+		{"first of several", 1, 20, 41, 3, true, false},
+		{"middle", 2, 20, 41, 3, true, true},
+		{"last", 3, 20, 41, 3, false, true},
+		{"past the end", 5, 20, 41, 3, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPagination(tt.page, tt.pageSize, tt.total)
+			assert.Equal(t, tt.totalPages, p.TotalPages)
+			assert.Equal(t, tt.hasNext, p.HasNext)
+			assert.Equal(t, tt.hasPrev, p.HasPrev)
+		})
+	}
+}
+
+func TestNewPaginatedCommentResponse_PageFlags(t *testing.T) {
+	resp := NewPaginatedCommentResponse(nil, 45, 2, 20)
+	assert.Equal(t, 3, resp.TotalPages)
+	assert.True(t, resp.HasNext)
+	assert.True(t, resp.HasPrev)
+}
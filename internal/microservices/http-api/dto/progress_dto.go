@@ -14,11 +14,6 @@ type UpdateProgressRequest struct {
 	Status     string `json:"status" binding:"required,oneof=reading completed plan_to_read dropped"`
 }
 
-type DeleteProgressRequest struct {
-	UserID  string `json:"user_id" binding:"required,uuid"`
-	MangaID int64  `json:"manga_id" binding:"required,uuid"`
-}
-
 type ProgressResponse struct {
 	UserID     string `json:"user_id"`
 	MangaID    int64  `json:"manga_id"`
@@ -32,3 +27,14 @@ type ProgressHistoryResponse struct {
 	History []ProgressResponse `json:"history"`
 	Total   int                `json:"total"`
 }
+
+// ReaderRankResponse tells a reader how their progress compares to others.
+// AheadOfPercent is null until the manga has enough readers to rank.
+type ReaderRankResponse struct {
+	MangaID        int64  `json:"manga_id"`
+	Chapter        int    `json:"chapter"`
+	Readers        int64  `json:"readers"`
+	Ranked         bool   `json:"ranked"`
+	AheadOfPercent *int   `json:"ahead_of_percent"`
+	Message        string `json:"message"`
+}
@@ -43,14 +43,14 @@ type PaginatedRatingResponse struct {
 	PageSize   int              `json:"page_size"`
 	Total      int              `json:"total"`
 	TotalPages int              `json:"total_pages"`
+	HasNext    bool             `json:"has_next"`
+	HasPrev    bool             `json:"has_prev"`
 }
 
 // NewPaginatedRatingResponse creates a paginated rating response
 func NewPaginatedRatingResponse(data []RatingResponse, total, page, pageSize int) *PaginatedRatingResponse {
-	totalPages := total / pageSize
-	if total%pageSize != 0 {
-		totalPages++
-	}
+	totalPages := TotalPages(int64(total), pageSize)
+	hasNext, hasPrev := PageFlags(page, totalPages)
 
 	return &PaginatedRatingResponse{
 		Data:       data,
@@ -58,5 +58,23 @@ func NewPaginatedRatingResponse(data []RatingResponse, total, page, pageSize int
 		PageSize:   pageSize,
 		Total:      total,
 		TotalPages: totalPages,
+		HasNext:    hasNext,
+		HasPrev:    hasPrev,
 	}
 }
+
+// RatingAveragesRequest for looking up the averages of several manga at once (at most 100)
+type RatingAveragesRequest struct {
+	MangaIDs []int64 `json:"manga_ids" binding:"required,min=1,max=100,dive,gt=0"`
+}
+
+// RatingAverage is the average rating and number of ratings for one manga
+type RatingAverage struct {
+	Average float64 `json:"average"`
+	Count   int64   `json:"count"`
+}
+
+// RatingAveragesResponse maps each requested manga ID to its rating average
+type RatingAveragesResponse struct {
+	Averages map[int64]RatingAverage `json:"averages"`
+}
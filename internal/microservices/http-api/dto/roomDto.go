@@ -0,0 +1,9 @@
+package dto
+
+// RoomSummary is an active WebSocket chat room. Rooms are keyed by manga ID.
+type RoomSummary struct {
+	RoomID      int64  `json:"room_id"`
+	MangaID     int64  `json:"manga_id"`
+	Title       string `json:"title,omitempty"`
+	MemberCount int    `json:"member_count"`
+}
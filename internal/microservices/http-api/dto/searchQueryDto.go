@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrSearchQueryTooLong is returned by NormalizeSearchQuery for queries over
+// the configured limit
+var ErrSearchQueryTooLong = errors.New("search query too long")
+
+// maxSearchQueryLength caps search queries, in characters. It defaults to 200
+// and is overridden from config at startup via SetMaxSearchQueryLength.
+var maxSearchQueryLength = 200
+
+// SetMaxSearchQueryLength sets the longest accepted search query. Values
+// below 1 are ignored since config validation already rejects them.
+func SetMaxSearchQueryLength(n int) {
+	if n < 1 {
+		return
+	}
+	maxSearchQueryLength = n
+}
+
+// MaxSearchQueryLength is the longest search query a request may send
+func MaxSearchQueryLength() int { return maxSearchQueryLength }
+
+// NormalizeSearchQuery trims the query and collapses runs of whitespace to a
+// single space. Queries longer than MaxSearchQueryLength after normalizing
+// fail with ErrSearchQueryTooLong.
+func NormalizeSearchQuery(q string) (string, error) {
+	q = strings.Join(strings.Fields(q), " ")
+	if utf8.RuneCountInString(q) > maxSearchQueryLength {
+		return "", fmt.Errorf("%w: must be at most %d characters", ErrSearchQueryTooLong, maxSearchQueryLength)
+	}
+	return q, nil
+}
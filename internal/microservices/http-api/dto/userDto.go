@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"mangahub/internal/microservices/http-api/models"
+	"time"
+)
+
+// UserProfileResponse is the current user's profile, also used for the admin user list
+type UserProfileResponse struct {
+	ID          string     `json:"id"`
+	Username    string     `json:"username"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	LastLoginIP string     `json:"last_login_ip,omitempty"`
+
+	ProfilePublic bool `json:"profile_public"`
+	LibraryPublic bool `json:"library_public"`
+}
+
+func NewUserProfileResponse(u models.User) UserProfileResponse {
+	return UserProfileResponse{
+		ID:          u.ID,
+		Username:    u.Username,
+		Email:       u.Email,
+		Role:        u.Role,
+		CreatedAt:   u.CreatedAt,
+		LastLoginAt: u.LastLogin,
+		LastLoginIP: u.LastLoginIP,
+
+		ProfilePublic: u.ProfilePublic,
+		LibraryPublic: u.LibraryPublic,
+	}
+}
+
+// UpdatePrivacyRequest changes the public profile settings; omitted fields
+// are left as they are
+type UpdatePrivacyRequest struct {
+	ProfilePublic *bool `json:"profile_public"`
+	LibraryPublic *bool `json:"library_public"`
+}
+
+// PublicProfileResponse is a user's profile as other users see it. It is
+// built field by field so nothing private (email, role, login details)
+// can leak through.
+type PublicProfileResponse struct {
+	Username       string                  `json:"username"`
+	JoinedAt       time.Time               `json:"joined_at"`
+	RatingCount    int64                   `json:"rating_count"`
+	RecentComments []PublicCommentResponse `json:"recent_comments"`
+	LibrarySize    *int64                  `json:"library_size,omitempty"` // Absent unless the user shares it
+	FollowerCount  int64                   `json:"follower_count"`
+	FollowingCount int64                   `json:"following_count"`
+}
+
+// PublicCommentResponse is a comment shown on its author's public profile
+type PublicCommentResponse struct {
+	ID         int64     `json:"id"`
+	MangaID    int64     `json:"manga_id"`
+	MangaTitle string    `json:"manga_title"`
+	Content    string    `json:"content"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func NewPublicProfileResponse(u models.User, ratingCount int64, comments []models.Comment, librarySize *int64, followers, following int64) PublicProfileResponse {
+	recent := make([]PublicCommentResponse, 0, len(comments))
+	for _, c := range comments {
+		recent = append(recent, PublicCommentResponse{
+			ID:         c.ID,
+			MangaID:    c.MangaID,
+			MangaTitle: c.Manga.Title,
+			Content:    c.Content,
+			CreatedAt:  c.CreatedAt,
+		})
+	}
+
+	return PublicProfileResponse{
+		Username:       u.Username,
+		JoinedAt:       u.CreatedAt,
+		RatingCount:    ratingCount,
+		RecentComments: recent,
+		LibrarySize:    librarySize,
+		FollowerCount:  followers,
+		FollowingCount: following,
+	}
+}
+
+// FollowResponse is a user in a following or followers list
+type FollowResponse struct {
+	Username   string    `json:"username"`
+	FollowedAt time.Time `json:"followed_at"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+// FieldError describes one request field that failed validation
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationErrorResponse is the 400 body for requests that fail validation
+type ValidationErrorResponse struct {
+	Error   string       `json:"error"`
+	Details []FieldError `json:"details"`
+}
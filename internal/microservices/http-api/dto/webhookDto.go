@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"mangahub/internal/microservices/http-api/models"
+)
+
+// CreateWebhookRequest registers a webhook. Secret is optional; one is
+// generated when it's left out.
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required"`
+	Events []string `json:"events" binding:"required"`
+	Secret string   `json:"secret"`
+}
+
+// UpdateWebhookRequest changes a webhook; omitted fields are left as they are
+type UpdateWebhookRequest struct {
+	URL    *string  `json:"url"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active"`
+}
+
+// WebhookResponse describes a webhook without its secret
+type WebhookResponse struct {
+	ID            int64      `json:"id"`
+	URL           string     `json:"url"`
+	Events        []string   `json:"events"`
+	Active        bool       `json:"active"`
+	FailureCount  int        `json:"failure_count"`
+	LastError     string     `json:"last_error,omitempty"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// CreatedWebhookResponse is returned once, on creation, with the secret
+// used to sign deliveries
+type CreatedWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+func NewWebhookResponse(w models.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:            w.ID,
+		URL:           w.URL,
+		Events:        w.EventList(),
+		Active:        w.Active,
+		FailureCount:  w.FailureCount,
+		LastError:     w.LastError,
+		LastFailureAt: w.LastFailureAt,
+		CreatedAt:     w.CreatedAt,
+		UpdatedAt:     w.UpdatedAt,
+	}
+}
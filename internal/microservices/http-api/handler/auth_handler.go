@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"mangahub/internal/microservices/http-api/dto"
 	"mangahub/internal/microservices/http-api/service"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,16 +23,28 @@ func NewAuthHandler(authService service.AuthService) *AuthHandler {
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req dto.RegisterRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
 	user, err := h.authService.Register(req.Username, req.Password, req.Email)
+	var policyErr *service.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		details := make([]dto.FieldError, 0, len(policyErr.Violations))
+		for _, v := range policyErr.Violations {
+			details = append(details, dto.FieldError{Field: "password", Rule: v.Rule, Message: v.Message})
+		}
+		abortWithFieldErrors(c, details...)
+		return
+	}
 	if err == service.ErrNameInUse || err == service.ErrEmailInUse {
 		c.JSON(http.StatusConflict, gin.H{"error": "Account creation failed"})
 		return
 	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Account creation failed"})
+		return
+	}
 	c.JSON(http.StatusCreated, gin.H{
 		"user_id":  user.ID,
 		"username": user.Username,
@@ -37,6 +52,18 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	})
 }
 
+// PasswordPolicy lists the password rules so clients can show them up front
+// GET /auth/password-policy
+func (h *AuthHandler) PasswordPolicy(c *gin.Context) {
+	policy := h.authService.PasswordPolicy()
+	c.JSON(http.StatusOK, dto.PasswordPolicyResponse{
+		MinLength:      policy.MinLength,
+		RequireDigit:   policy.RequireDigit,
+		RequireUpper:   policy.RequireUpper,
+		RequireSpecial: policy.RequireSpecial,
+	})
+}
+
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
 
@@ -45,7 +72,16 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, user, err := h.authService.Login(req.Username, req.Password, req.Email)
+	accessToken, refreshToken, expiresIn, user, scopes, err := h.authService.Login(req.Username, req.Password, req.Email, strings.Fields(req.Scope), service.SessionInfo{
+		UserAgent:      c.Request.UserAgent(),
+		IPAddress:      c.ClientIP(),
+		DeviceName:     req.DeviceName,
+		AccessTokenTTL: time.Duration(req.TokenTTL) * time.Second,
+	})
+	if errors.Is(err, service.ErrInvalidScope) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
@@ -56,7 +92,8 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		RefreshToken: refreshToken,
 		UserID:       user.ID,
 		Username:     user.Username,
-		ExpiresIn:    9000, // 15 minutes in seconds
+		ExpiresIn:    int64(expiresIn.Seconds()),
+		Scope:        strings.Join(scopes, " "),
 	})
 }
 
@@ -71,7 +108,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	newAccessToken, newRefreshToken, err := h.authService.RefreshAccessToken(req.RefreshToken)
+	newAccessToken, newRefreshToken, expiresIn, err := h.authService.RefreshAccessToken(req.RefreshToken)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
@@ -81,7 +118,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		RefreshToken: newRefreshToken,
 		AccessToken:  newAccessToken,
 		TokenType:    "Bearer",
-		ExpiresIn:    900, // 15 minutes in seconds
+		ExpiresIn:    int64(expiresIn.Seconds()),
 	})
 }
 
@@ -102,3 +139,53 @@ func (h *AuthHandler) RevokeToken(c *gin.Context) {
 		Message: "Refresh token revoked successfully",
 	})
 }
+
+// RegisterSessionRoutes registers the current user's session management routes
+func (h *AuthHandler) RegisterSessionRoutes(router *gin.RouterGroup) {
+	router.GET("", h.ListSessions)
+	router.DELETE("/:id", h.RevokeSession)
+}
+
+// ListSessions lists where the current user is signed in
+// GET /api/users/me/sessions
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	tokens, err := h.authService.ListSessions(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list sessions"})
+		return
+	}
+
+	sessions := make([]dto.SessionResponse, 0, len(tokens))
+	for _, token := range tokens {
+		sessions = append(sessions, dto.NewSessionResponse(token))
+	}
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions, "total": len(sessions)})
+}
+
+// RevokeSession signs one of the current user's sessions out
+// DELETE /api/users/me/sessions/:id
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	err := h.authService.RevokeSession(userID.(string), c.Param("id"))
+	if errors.Is(err, service.ErrSessionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -29,14 +30,18 @@ func (m *MockAuthService) Register(username, password, email string) (*models.Us
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockAuthService) Login(username, password, email string) (string, string, *models.User, error) {
-	args := m.Called(username, password, email)
-	return args.String(0), args.String(1), args.Get(2).(*models.User), args.Error(3)
+func (m *MockAuthService) Login(username, password, email string, requestedScopes []string, session service.SessionInfo) (string, string, time.Duration, *models.User, []string, error) {
+	args := m.Called(username, password, email, requestedScopes, session)
+	var scopes []string
+	if args.Get(4) != nil {
+		scopes = args.Get(4).([]string)
+	}
+	return args.String(0), args.String(1), args.Get(2).(time.Duration), args.Get(3).(*models.User), scopes, args.Error(5)
 }
 
-func (m *MockAuthService) RefreshAccessToken(refreshToken string) (string, string, error) {
+func (m *MockAuthService) RefreshAccessToken(refreshToken string) (string, string, time.Duration, error) {
 	args := m.Called(refreshToken)
-	return args.String(0), args.String(1), args.Error(2)
+	return args.String(0), args.String(1), args.Get(2).(time.Duration), args.Error(3)
 }
 
 func (m *MockAuthService) ValidateToken(tokenString string) (*service.Claims, error) {
@@ -52,6 +57,23 @@ func (m *MockAuthService) RevokeToken(refreshToken string) error {
 	return args.Error(0)
 }
 
+func (m *MockAuthService) ListSessions(userID string) ([]models.RefreshToken, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.RefreshToken), args.Error(1)
+}
+
+func (m *MockAuthService) RevokeSession(userID, sessionID string) error {
+	args := m.Called(userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) PasswordPolicy() service.PasswordPolicy {
+	return m.Called().Get(0).(service.PasswordPolicy)
+}
+
 func setupRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	return gin.New()
@@ -95,6 +117,74 @@ func TestRegister_Success(t *testing.T) {
 	mockAuthService.AssertExpectations(t)
 }
 
+func TestRegister_WeakPassword(t *testing.T) {
+	mockAuthService := new(MockAuthService)
+	handler := NewAuthHandler(mockAuthService)
+	router := setupRouter()
+	router.POST("/register", handler.Register)
+
+	mockAuthService.On("Register", "testuser", "short", "test@example.com").
+		Return(nil, &service.PasswordPolicyError{Violations: []service.PasswordViolation{
+			{Rule: "min_length", Message: "must be at least 8 characters"},
+			{Rule: "digit", Message: "must contain a digit"},
+		}})
+
+	body, _ := json.Marshal(dto.RegisterRequest{Username: "testuser", Password: "short", Email: "test@example.com"})
+	req, _ := http.NewRequest("POST", "/register", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response dto.ValidationErrorResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, []dto.FieldError{
+		{Field: "password", Rule: "min_length", Message: "must be at least 8 characters"},
+		{Field: "password", Rule: "digit", Message: "must contain a digit"},
+	}, response.Details)
+}
+
+func TestRegister_UsernameWithAt(t *testing.T) {
+	mockAuthService := new(MockAuthService)
+	handler := NewAuthHandler(mockAuthService)
+	router := setupRouter()
+	router.POST("/register", handler.Register)
+
+	body, _ := json.Marshal(dto.RegisterRequest{Username: "alice@example.com", Password: "password123", Email: "mallory@example.com"})
+	req, _ := http.NewRequest("POST", "/register", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response dto.ValidationErrorResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, []dto.FieldError{
+		{Field: "username", Rule: "excludes", Message: `must not contain "@"`},
+	}, response.Details)
+	mockAuthService.AssertNotCalled(t, "Register", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	mockAuthService := new(MockAuthService)
+	handler := NewAuthHandler(mockAuthService)
+	router := setupRouter()
+	router.GET("/password-policy", handler.PasswordPolicy)
+
+	mockAuthService.On("PasswordPolicy").Return(service.PasswordPolicy{MinLength: 12, RequireUpper: true})
+
+	req, _ := http.NewRequest("GET", "/password-policy", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dto.PasswordPolicyResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, dto.PasswordPolicyResponse{MinLength: 12, RequireUpper: true}, response)
+}
+
 func TestRegister_UsernameInUse(t *testing.T) {
 	mockAuthService := new(MockAuthService)
 	handler := NewAuthHandler(mockAuthService)
@@ -179,8 +269,9 @@ func TestLogin_Success(t *testing.T) {
 		Email:    "johndoe@example.com",
 	}
 
-	mockAuthService.On("Login", "manCity", "mcfc1213", "").
-		Return("access-token", "refresh-token", user, nil)
+	accessToken := "access-token"
+	mockAuthService.On("Login", "manCity", "mcfc1213", "", []string{}, mock.AnythingOfType("service.SessionInfo")).
+		Return(accessToken, "refresh-token", 15*time.Minute, user, []string{"read:manga", "write:library"}, nil)
 
 	reqBody := dto.LoginRequest{
 		Username: "manCity",
@@ -198,12 +289,38 @@ func TestLogin_Success(t *testing.T) {
 
 	var response dto.AuthResponse
 	json.Unmarshal(w.Body.Bytes(), &response)
-	assert.Equal(t, "access-token", response.AccessToken)
+	assert.Equal(t, accessToken, response.AccessToken)
 	assert.Equal(t, "refresh-token", response.RefreshToken)
 	assert.Equal(t, "68f3b8be-5bd8-4c6c-9919-a4614b2731b3", response.UserID)
 	assert.Equal(t, "manCity", response.Username)
-	assert.Equal(t, int64(9000), response.ExpiresIn)
+	assert.Equal(t, int64(900), response.ExpiresIn)
+	assert.Equal(t, "read:manga write:library", response.Scope)
+
+	mockAuthService.AssertExpectations(t)
+}
 
+func TestLogin_TokenTTLHint(t *testing.T) {
+	mockAuthService := new(MockAuthService)
+	handler := NewAuthHandler(mockAuthService)
+	router := setupRouter()
+	router.POST("/login", handler.Login)
+
+	user := &models.User{ID: "user-id", Username: "daemon"}
+	// The service clamps the hint; expires_in reports what was actually issued
+	mockAuthService.On("Login", "daemon", "password123", "", []string{}, mock.MatchedBy(func(s service.SessionInfo) bool {
+		return s.AccessTokenTTL == 5*time.Minute
+	})).Return("access-token", "refresh-token", 5*time.Minute, user, []string{"read:manga"}, nil)
+
+	body, _ := json.Marshal(dto.LoginRequest{Username: "daemon", Password: "password123", TokenTTL: 300})
+	req, _ := http.NewRequest("POST", "/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dto.AuthResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, int64(300), response.ExpiresIn)
 	mockAuthService.AssertExpectations(t)
 }
 
@@ -213,8 +330,8 @@ func TestLogin_InvalidCredentials(t *testing.T) {
 	router := setupRouter()
 	router.POST("/login", handler.Login)
 
-	mockAuthService.On("Login", "testuser", "wrongpassword", "").
-		Return("", "", (*models.User)(nil), service.ErrInvalidCredentials)
+	mockAuthService.On("Login", "testuser", "wrongpassword", "", []string{}, mock.AnythingOfType("service.SessionInfo")).
+		Return("", "", time.Duration(0), (*models.User)(nil), nil, service.ErrInvalidCredentials)
 
 	reqBody := dto.LoginRequest{
 		Username: "testuser",
@@ -232,6 +349,55 @@ func TestLogin_InvalidCredentials(t *testing.T) {
 	mockAuthService.AssertExpectations(t)
 }
 
+func TestLogin_RequestedScopes(t *testing.T) {
+	mockAuthService := new(MockAuthService)
+	handler := NewAuthHandler(mockAuthService)
+	router := setupRouter()
+	router.POST("/login", handler.Login)
+
+	user := &models.User{ID: "user-123", Username: "reader"}
+	mockAuthService.On("Login", "reader", "password123", "", []string{"read:manga", "read:library"}, mock.AnythingOfType("service.SessionInfo")).
+		Return("access-token", "refresh-token", 15*time.Minute, user, []string{"read:manga", "read:library"}, nil)
+
+	body, _ := json.Marshal(dto.LoginRequest{
+		Username: "reader",
+		Password: "password123",
+		Scope:    "read:manga  read:library",
+	})
+	req, _ := http.NewRequest("POST", "/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dto.AuthResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "read:manga read:library", response.Scope)
+	mockAuthService.AssertExpectations(t)
+}
+
+func TestLogin_InvalidScope(t *testing.T) {
+	mockAuthService := new(MockAuthService)
+	handler := NewAuthHandler(mockAuthService)
+	router := setupRouter()
+	router.POST("/login", handler.Login)
+
+	mockAuthService.On("Login", "reader", "password123", "", []string{"admin:users"}, mock.AnythingOfType("service.SessionInfo")).
+		Return("", "", time.Duration(0), (*models.User)(nil), nil, service.ErrInvalidScope)
+
+	body, _ := json.Marshal(dto.LoginRequest{Username: "reader", Password: "password123", Scope: "admin:users"})
+	req, _ := http.NewRequest("POST", "/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockAuthService.AssertExpectations(t)
+}
+
 func TestLogin_InvalidJSON(t *testing.T) {
 	mockAuthService := new(MockAuthService)
 	handler := NewAuthHandler(mockAuthService)
@@ -253,8 +419,9 @@ func TestRefreshToken_Success(t *testing.T) {
 	router := setupRouter()
 	router.POST("/refresh", handler.RefreshToken)
 
+	newAccessToken := "new-access-token"
 	mockAuthService.On("RefreshAccessToken", "old-refresh-token").
-		Return("new-access-token", "new-refresh-token", nil)
+		Return(newAccessToken, "new-refresh-token", 15*time.Minute, nil)
 
 	reqBody := dto.RefreshTokenRequest{
 		RefreshToken: "old-refresh-token",
@@ -271,7 +438,7 @@ func TestRefreshToken_Success(t *testing.T) {
 
 	var response dto.RefreshResponse
 	json.Unmarshal(w.Body.Bytes(), &response)
-	assert.Equal(t, "new-access-token", response.AccessToken)
+	assert.Equal(t, newAccessToken, response.AccessToken)
 	assert.Equal(t, "new-refresh-token", response.RefreshToken)
 	assert.Equal(t, "Bearer", response.TokenType)
 	assert.EqualValues(t, 900, response.ExpiresIn)
@@ -286,7 +453,7 @@ func TestRefreshToken_InvalidToken(t *testing.T) {
 	router.POST("/refresh", handler.RefreshToken)
 
 	mockAuthService.On("RefreshAccessToken", "invalid-token").
-		Return("", "", errors.New("invalid refresh token"))
+		Return("", "", time.Duration(0), errors.New("invalid refresh token"))
 
 	reqBody := dto.RefreshTokenRequest{
 		RefreshToken: "invalid-token",
@@ -384,3 +551,78 @@ func TestRevokeToken_InvalidJSON(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
+
+func TestLogin_CapturesSessionInfo(t *testing.T) {
+	mockAuthService := new(MockAuthService)
+	handler := NewAuthHandler(mockAuthService)
+	router := setupRouter()
+	router.POST("/login", handler.Login)
+
+	user := &models.User{ID: "user-123", Username: "reader"}
+	session := service.SessionInfo{UserAgent: "mangahub-cli/1.0", IPAddress: "203.0.113.7", DeviceName: "work laptop"}
+	mockAuthService.On("Login", "reader", "password123", "", []string{}, session).
+		Return("access-token", "refresh-token", 15*time.Minute, user, []string{"read:manga"}, nil)
+
+	body, _ := json.Marshal(dto.LoginRequest{Username: "reader", Password: "password123", DeviceName: "work laptop"})
+	req, _ := http.NewRequest("POST", "/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "mangahub-cli/1.0")
+	req.RemoteAddr = "203.0.113.7:51234"
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockAuthService.AssertExpectations(t)
+}
+
+func withUser(userID string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Next()
+	}
+}
+
+func TestListSessions(t *testing.T) {
+	mockAuthService := new(MockAuthService)
+	handler := NewAuthHandler(mockAuthService)
+	router := setupRouter()
+	handler.RegisterSessionRoutes(router.Group("/sessions", withUser("user-123")))
+
+	mockAuthService.On("ListSessions", "user-123").Return([]models.RefreshToken{
+		{ID: "s1", Token: "secret-token", DeviceName: "Firefox on Linux", IPAddress: "203.0.113.7"},
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/sessions", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "secret-token")
+
+	var response struct {
+		Sessions []dto.SessionResponse `json:"sessions"`
+		Total    int                   `json:"total"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, 1, response.Total)
+	assert.Equal(t, "s1", response.Sessions[0].ID)
+	assert.Equal(t, "Firefox on Linux", response.Sessions[0].DeviceName)
+	mockAuthService.AssertExpectations(t)
+}
+
+func TestRevokeSession_NotFound(t *testing.T) {
+	mockAuthService := new(MockAuthService)
+	handler := NewAuthHandler(mockAuthService)
+	router := setupRouter()
+	handler.RegisterSessionRoutes(router.Group("/sessions", withUser("user-123")))
+
+	mockAuthService.On("RevokeSession", "user-123", "s9").Return(service.ErrSessionNotFound)
+
+	req, _ := http.NewRequest("DELETE", "/sessions/s9", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockAuthService.AssertExpectations(t)
+}
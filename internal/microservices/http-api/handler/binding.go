@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// The validator is shared by every gin binding, so the field names and
+// custom rules are set up once when the package loads rather than on the
+// first bindJSON call; a ShouldBindQuery that ran first would otherwise
+// see an unregistered manga_status rule
+func init() {
+	useJSONFieldNames()
+	useCustomRules()
+}
+
+// bindJSON binds the request body into obj. On failure it writes a 400 that
+// lists each failing field and returns false; the handler should just return.
+func bindJSON(c *gin.Context, obj any) bool {
+	err := c.ShouldBindJSON(obj)
+	if err == nil {
+		return true
+	}
+
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &validationErrs):
+		details := make([]dto.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			details = append(details, dto.FieldError{
+				Field:   fe.Field(),
+				Rule:    fe.Tag(),
+				Message: validationMessage(fe),
+			})
+		}
+		abortWithFieldErrors(c, details...)
+	case errors.As(err, &typeErr):
+		abortWithFieldErrors(c, dto.FieldError{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: fmt.Sprintf("must be a %s", typeErr.Type.Kind()),
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+	return false
+}
+
+// abortWithFieldErrors writes the structured 400 used for validation failures
+func abortWithFieldErrors(c *gin.Context, details ...dto.FieldError) {
+	c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{
+		Error:   "validation failed",
+		Details: details,
+	})
+}
+
+// useJSONFieldNames makes validator report fields by their json (or form)
+// tag, so clients see "title" rather than "CreateMangaDTO.Title"
+func useJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return f.Name
+	})
+}
+
+// useCustomRules registers the repo's own binding rules:
+// manga_status accepts the values in models.MangaStatuses
+func useCustomRules() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	if err := v.RegisterValidation("manga_status", func(fl validator.FieldLevel) bool {
+		return models.MangaStatus(fl.Field().String()).IsValid()
+	}); err != nil {
+		panic(fmt.Sprintf("register manga_status rule: %v", err))
+	}
+}
+
+// validationMessage turns a failed rule into a short human-readable message
+func validationMessage(fe validator.FieldError) string {
+	isString := fe.Kind() == reflect.String
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "uuid":
+		return "must be a valid UUID"
+	case "manga_status":
+		return "must be one of: " + models.MangaStatusList()
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "min":
+		if isString {
+			return fmt.Sprintf("must be at least %s characters", fe.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "max":
+		if isString {
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	case "excludes":
+		return fmt.Sprintf("must not contain %q", fe.Param())
+	default:
+		return fmt.Sprintf("failed the %s rule", fe.Tag())
+	}
+}
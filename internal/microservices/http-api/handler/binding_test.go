@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mangahub/internal/microservices/http-api/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func bindRequest(t *testing.T, body string, obj any) (*httptest.ResponseRecorder, bool) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return w, bindJSON(c, obj)
+}
+
+func decodeValidation(t *testing.T, w *httptest.ResponseRecorder) map[string]dto.FieldError {
+	t.Helper()
+	var resp dto.ValidationErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "validation failed", resp.Error)
+
+	byField := make(map[string]dto.FieldError, len(resp.Details))
+	for _, d := range resp.Details {
+		byField[d.Field] = d
+	}
+	return byField
+}
+
+func TestBindJSON_ValidationDetails(t *testing.T) {
+	var req dto.RegisterRequest
+	w, ok := bindRequest(t, `{"username":"ab","email":"not-an-email"}`, &req)
+
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	details := decodeValidation(t, w)
+	assert.Equal(t, dto.FieldError{Field: "username", Rule: "min", Message: "must be at least 3 characters"}, details["username"])
+	assert.Equal(t, dto.FieldError{Field: "password", Rule: "required", Message: "is required"}, details["password"])
+	assert.Equal(t, "email", details["email"].Rule)
+}
+
+func TestBindJSON_EnumRule(t *testing.T) {
+	var in dto.CreateMangaDTO
+	w, ok := bindRequest(t, `{"title":"Berserk","status":"dropped"}`, &in)
+
+	assert.False(t, ok)
+	details := decodeValidation(t, w)
+	assert.Equal(t, dto.FieldError{Field: "status", Rule: "manga_status", Message: "must be one of: ongoing, completed, hiatus, cancelled, unknown"}, details["status"])
+}
+
+func TestShouldBindQuery_EnumRule(t *testing.T) {
+	// The rule is registered at startup, not by the first bindJSON call
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/?status=dropped", nil)
+
+	var filters dto.SearchFilters
+	err := c.ShouldBindQuery(&filters)
+
+	var validationErrs validator.ValidationErrors
+	if assert.ErrorAs(t, err, &validationErrs) {
+		assert.Equal(t, "status", validationErrs[0].Field())
+		assert.Equal(t, "manga_status", validationErrs[0].Tag())
+	}
+}
+
+func TestBindJSON_CancelledStatus(t *testing.T) {
+	var in dto.UpdateMangaDTO
+	_, ok := bindRequest(t, `{"status":"cancelled"}`, &in)
+
+	assert.True(t, ok)
+	assert.Equal(t, "cancelled", *in.Status)
+}
+
+func TestBindJSON_TypeMismatch(t *testing.T) {
+	var in dto.CreateRatingDTO
+	w, ok := bindRequest(t, `{"rating":"ten"}`, &in)
+
+	assert.False(t, ok)
+	details := decodeValidation(t, w)
+	assert.Equal(t, "type", details["rating"].Rule)
+}
+
+func TestBindJSON_MalformedBody(t *testing.T) {
+	var in dto.CreateRatingDTO
+	w, ok := bindRequest(t, `{"rating":`, &in)
+
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"error"`)
+}
+
+func TestBindJSON_Valid(t *testing.T) {
+	var in dto.CreateRatingDTO
+	_, ok := bindRequest(t, `{"rating":8}`, &in)
+
+	assert.True(t, ok)
+	assert.Equal(t, 8, in.Rating)
+}
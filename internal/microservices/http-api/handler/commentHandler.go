@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/middleware"
 	"mangahub/internal/microservices/http-api/service"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +15,7 @@ import (
 
 type CommentHandler struct {
 	commentService service.CommentService
+	createLimit    gin.HandlerFunc // optional; nil leaves posting unthrottled
 }
 
 func NewCommentHandler(commentService service.CommentService) *CommentHandler {
@@ -20,20 +24,35 @@ func NewCommentHandler(commentService service.CommentService) *CommentHandler {
 	}
 }
 
+// WithCreateRateLimit limits each user to limit new comments a minute with
+// bursts of up to burst; a limit of 0 leaves posting unthrottled. Call it
+// before RegisterRoutes.
+func (h *CommentHandler) WithCreateRateLimit(limit, burst int) *CommentHandler {
+	if limit > 0 {
+		h.createLimit = middleware.RateLimitPerUser(limit, time.Minute, burst)
+	}
+	return h
+}
+
 // RegisterRoutes registers comment-related routes
 func (h *CommentHandler) RegisterRoutes(router *gin.RouterGroup) {
+	create := []gin.HandlerFunc{h.Create}
+	if h.createLimit != nil {
+		create = append([]gin.HandlerFunc{h.createLimit}, create...)
+	}
+
 	// Manga comments
-	mangaComments := router.Group("/:manga_id/comments")
+	mangaComments := router.Group("/:manga_id/comments", middleware.UserWrite())
 	{
 		// Public routes
 		mangaComments.GET("", h.ListByManga) // Get all comments for a manga
 
 		// Write routes (already authenticated by parent middleware)
-		mangaComments.POST("", h.Create) // Create a comment
+		mangaComments.POST("", create...) // Create a comment
 	}
 
 	// Comment operations (already authenticated by parent middleware)
-	comments := router.Group("/comments")
+	comments := router.Group("/comments", middleware.UserWrite())
 	{
 		comments.GET("/:id", h.GetByID)          // Get a specific comment
 		comments.PUT("/:id", h.Update)           // Update a comment (user's own)
@@ -42,6 +61,11 @@ func (h *CommentHandler) RegisterRoutes(router *gin.RouterGroup) {
 	}
 }
 
+// RegisterAdminRoutes registers the moderation feed under /api/admin
+func (h *CommentHandler) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	rg.GET("/comments", middleware.RequireScopes("admin:comments"), middleware.RequireAdmin(), h.ListRecent)
+}
+
 // Create creates a new comment for a manga
 // POST /api/manga/:manga_id/comments
 func (h *CommentHandler) Create(c *gin.Context) {
@@ -65,6 +89,9 @@ func (h *CommentHandler) Create(c *gin.Context) {
 
 	comment, err := h.commentService.CreateComment(userID.(string), mangaID, req.Content)
 	if err != nil {
+		if abortOnCommentContent(c, err) {
+			return
+		}
 		if err.Error() == "manga not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -99,6 +126,9 @@ func (h *CommentHandler) Update(c *gin.Context) {
 
 	comment, err := h.commentService.UpdateComment(commentID, userID.(string), req.Content)
 	if err != nil {
+		if abortOnCommentContent(c, err) {
+			return
+		}
 		if err.Error() == "comment not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -172,14 +202,9 @@ func (h *CommentHandler) ListByManga(c *gin.Context) {
 		return
 	}
 
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
-
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
 	}
 
 	comments, err := h.commentService.GetMangaComments(mangaID, page, pageSize)
@@ -204,17 +229,39 @@ func (h *CommentHandler) ListByCurrentUser(c *gin.Context) {
 		return
 	}
 
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
 
-	if page < 1 {
-		page = 1
+	comments, err := h.commentService.GetUserComments(userID.(string), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// ListRecent retrieves the newest comments across all manga for moderation
+// GET /api/admin/comments?page=1&page_size=20&since=2024-05-01T00:00:00Z
+func (h *CommentHandler) ListRecent(c *gin.Context) {
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
+
+	var since *time.Time
+	if raw := c.Query("since"); raw != "" {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			abortWithFieldErrors(c, dto.FieldError{Field: "since", Rule: "datetime", Message: "must be an RFC 3339 timestamp"})
+			return
+		}
+		since = &t
 	}
 
-	comments, err := h.commentService.GetUserComments(userID.(string), page, pageSize)
+	comments, err := h.commentService.GetRecentComments(since, page, pageSize)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -222,3 +269,13 @@ func (h *CommentHandler) ListByCurrentUser(c *gin.Context) {
 
 	c.JSON(http.StatusOK, comments)
 }
+
+// abortOnCommentContent answers 400 if err is a comment content rule failure
+func abortOnCommentContent(c *gin.Context, err error) bool {
+	var contentErr *service.CommentContentError
+	if !errors.As(err, &contentErr) {
+		return false
+	}
+	abortWithFieldErrors(c, dto.FieldError{Field: "content", Rule: contentErr.Rule, Message: contentErr.Message})
+	return true
+}
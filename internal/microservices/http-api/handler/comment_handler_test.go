@@ -1,9 +1,19 @@
 package handler_test
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/handler"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestCommentHandlerStructure(t *testing.T) {
@@ -12,5 +22,71 @@ func TestCommentHandlerStructure(t *testing.T) {
 	})
 }
 
-// Full handler tests require proper service mocking
-// See auth_handler_test.go and mangaHandler_test.go for working examples
+// MockCommentService implements only the moderation feed; the other
+// methods panic on the nil embedded interface
+type MockCommentService struct {
+	service.CommentService
+	mock.Mock
+}
+
+func (m *MockCommentService) GetRecentComments(since *time.Time, page, pageSize int) (*dto.PaginatedAdminCommentResponse, error) {
+	args := m.Called(since, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.PaginatedAdminCommentResponse), args.Error(1)
+}
+
+func commentAdminRouter(svc service.CommentService, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-1")
+		c.Set("role", role)
+		c.Set("scopes", []string{"admin:*"})
+		c.Next()
+	})
+	handler.NewCommentHandler(svc).RegisterAdminRoutes(r.Group("/api/admin"))
+	return r
+}
+
+func TestCommentHandler_ListRecent(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockSvc := new(MockCommentService)
+		since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+		mockSvc.On("GetRecentComments", &since, 2, 10).Return(&dto.PaginatedAdminCommentResponse{
+			Data: []dto.AdminCommentResponse{
+				{ID: 9, MangaID: 7, MangaTitle: "One Piece", Username: "luffy", Content: "Gear 5!"},
+			},
+			Page: 2, PageSize: 10, Total: 11, TotalPages: 2,
+		}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/admin/comments?page=2&page_size=10&since=2024-05-01T00:00:00Z", nil)
+		w := httptest.NewRecorder()
+		commentAdminRouter(mockSvc, "admin").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response dto.PaginatedAdminCommentResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "One Piece", response.Data[0].MangaTitle)
+		assert.Equal(t, "luffy", response.Data[0].Username)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("InvalidSince", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/admin/comments?since=yesterday", nil)
+		w := httptest.NewRecorder()
+		commentAdminRouter(new(MockCommentService), "admin").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"since"`)
+	})
+
+	t.Run("NotAdmin", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/admin/comments", nil)
+		w := httptest.NewRecorder()
+		commentAdminRouter(new(MockCommentService), "user").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
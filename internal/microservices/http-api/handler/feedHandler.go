@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/middleware"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedHandler struct {
+	svc service.FeedService
+}
+
+func NewFeedHandler(svc service.FeedService) *FeedHandler {
+	return &FeedHandler{svc: svc}
+}
+
+// RegisterRoutes registers the activity feed under /api/users
+func (h *FeedHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/me/feed", middleware.RequireScopes("read:manga"), h.Feed)
+}
+
+// Feed handles GET /api/users/me/feed?cursor=&limit=, the recent ratings,
+// comments and library additions of the users the caller follows
+func (h *FeedHandler) Feed(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	limit, ok := positiveQueryInt(c, "limit", dto.DefaultPageSize())
+	if !ok {
+		return
+	}
+	limit = min(limit, dto.MaxPageSize())
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	page, err := h.svc.Feed(ctx, userID.(string), c.Query("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidFeedCursor) {
+			abortWithFieldErrors(c, dto.FieldError{Field: "cursor", Rule: "cursor", Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := dto.FeedResponse{
+		Data:       make([]dto.ActivityResponse, 0, len(page.Activities)),
+		NextCursor: page.NextCursor,
+		HasNext:    page.NextCursor != "",
+	}
+	for _, a := range page.Activities {
+		resp.Data = append(resp.Data, dto.ActivityResponse{
+			Type:       a.Kind,
+			Username:   a.Username,
+			MangaID:    a.MangaID,
+			MangaTitle: a.MangaTitle,
+			Rating:     a.Rating,
+			Content:    a.Content,
+			At:         a.At,
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,96 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/handler"
+	"mangahub/internal/microservices/http-api/repository"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFeedService struct {
+	mock.Mock
+}
+
+func (m *MockFeedService) Feed(ctx context.Context, userID, cursor string, limit int) (*service.FeedPage, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.FeedPage), args.Error(1)
+}
+
+func feedRouter(svc service.FeedService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-1")
+		c.Set("scopes", []string{"read:manga"})
+		c.Next()
+	})
+	handler.NewFeedHandler(svc).RegisterRoutes(r.Group("/api/users"))
+	return r
+}
+
+func TestFeedHandler_Feed(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		at := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+		rating := 9
+		svc := new(MockFeedService)
+		svc.On("Feed", mock.Anything, "user-1", "abc", 2).Return(&service.FeedPage{
+			Activities: []repository.Activity{
+				{Kind: repository.ActivityRating, ID: 4, UserID: "user-2", Username: "bob", MangaID: 7, MangaTitle: "Berserk", Rating: &rating, At: at},
+			},
+			NextCursor: "next",
+		}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/users/me/feed?cursor=abc&limit=2", nil)
+		feedRouter(svc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "user-2")
+
+		var resp dto.FeedResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "next", resp.NextCursor)
+		assert.True(t, resp.HasNext)
+		assert.Equal(t, "rating", resp.Data[0].Type)
+		assert.Equal(t, "bob", resp.Data[0].Username)
+		assert.Equal(t, 9, *resp.Data[0].Rating)
+		assert.Nil(t, resp.Data[0].Content)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("InvalidCursor", func(t *testing.T) {
+		svc := new(MockFeedService)
+		svc.On("Feed", mock.Anything, "user-1", "junk", dto.DefaultPageSize()).Return(nil, service.ErrInvalidFeedCursor)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/users/me/feed?cursor=junk", nil)
+		feedRouter(svc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("InvalidLimit", func(t *testing.T) {
+		svc := new(MockFeedService)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/users/me/feed?limit=0", nil)
+		feedRouter(svc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		svc.AssertNotCalled(t, "Feed", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/middleware"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FollowHandler struct {
+	svc service.FollowService
+}
+
+func NewFollowHandler(svc service.FollowService) *FollowHandler {
+	return &FollowHandler{svc: svc}
+}
+
+// RegisterRoutes registers the follow routes under /api/users
+func (h *FollowHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/me/following", h.Following)
+	rg.GET("/me/followers", h.Followers)
+	rg.POST("/:username/follow", middleware.RequireScopes("write:profile"), middleware.UserWrite(), h.Follow)
+	rg.DELETE("/:username/follow", middleware.RequireScopes("write:profile"), middleware.UserWrite(), h.Unfollow)
+}
+
+// Follow handles POST /api/users/:username/follow. Following someone already
+// followed is not an error.
+func (h *FollowHandler) Follow(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.svc.Follow(ctx, userID.(string), c.Param("username")); err != nil {
+		writeFollowError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Unfollow handles DELETE /api/users/:username/follow
+func (h *FollowHandler) Unfollow(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.svc.Unfollow(ctx, userID.(string), c.Param("username")); err != nil {
+		writeFollowError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Following handles GET /api/users/me/following
+func (h *FollowHandler) Following(c *gin.Context) {
+	h.list(c, h.svc.Following, func(f models.UserFollow) models.User { return f.Followee })
+}
+
+// Followers handles GET /api/users/me/followers
+func (h *FollowHandler) Followers(c *gin.Context) {
+	h.list(c, h.svc.Followers, func(f models.UserFollow) models.User { return f.Follower })
+}
+
+// list serves a page of the current user's follows, showing the user on
+// the other side of each
+func (h *FollowHandler) list(c *gin.Context,
+	fetch func(ctx context.Context, userID string, page, pageSize int) ([]models.UserFollow, int64, error),
+	other func(models.UserFollow) models.User) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	follows, total, err := fetch(ctx, userID.(string), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.FollowResponse, 0, len(follows))
+	for _, f := range follows {
+		resp = append(resp, dto.FollowResponse{Username: other(f).Username, FollowedAt: f.CreatedAt})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       resp,
+		"pagination": dto.NewPagination(page, pageSize, total),
+	})
+}
+
+func writeFollowError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrSelfFollow):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrProfilePrivate):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,119 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/handler"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFollowService struct {
+	mock.Mock
+}
+
+func (m *MockFollowService) Follow(ctx context.Context, followerID, username string) error {
+	return m.Called(ctx, followerID, username).Error(0)
+}
+
+func (m *MockFollowService) Unfollow(ctx context.Context, followerID, username string) error {
+	return m.Called(ctx, followerID, username).Error(0)
+}
+
+func (m *MockFollowService) Following(ctx context.Context, userID string, page, pageSize int) ([]models.UserFollow, int64, error) {
+	args := m.Called(ctx, userID, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]models.UserFollow), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockFollowService) Followers(ctx context.Context, userID string, page, pageSize int) ([]models.UserFollow, int64, error) {
+	args := m.Called(ctx, userID, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]models.UserFollow), args.Get(1).(int64), args.Error(2)
+}
+
+func followRouter(svc service.FollowService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-1")
+		c.Set("scopes", []string{"write:profile"})
+		c.Next()
+	})
+	handler.NewFollowHandler(svc).RegisterRoutes(r.Group("/api/users"))
+	return r
+}
+
+func TestFollowHandler_Follow(t *testing.T) {
+	cases := []struct {
+		name   string
+		method string
+		err    error
+		status int
+	}{
+		{"Follow", http.MethodPost, nil, http.StatusNoContent},
+		{"Unfollow", http.MethodDelete, nil, http.StatusNoContent},
+		{"Self", http.MethodPost, service.ErrSelfFollow, http.StatusBadRequest},
+		{"Private", http.MethodPost, service.ErrProfilePrivate, http.StatusForbidden},
+		{"NotFound", http.MethodPost, service.ErrUserNotFound, http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := new(MockFollowService)
+			method := "Follow"
+			if tc.method == http.MethodDelete {
+				method = "Unfollow"
+			}
+			svc.On(method, mock.Anything, "user-1", "bob").Return(tc.err)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tc.method, "/api/users/bob/follow", nil)
+			followRouter(svc).ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+			svc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFollowHandler_Lists(t *testing.T) {
+	followedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	svc := new(MockFollowService)
+	svc.On("Following", mock.Anything, "user-1", 1, 20).Return([]models.UserFollow{
+		{FollowerID: "user-1", FolloweeID: "user-2", CreatedAt: followedAt, Followee: models.User{Username: "bob", Email: "bob@example.com"}},
+	}, int64(1), nil)
+	svc.On("Followers", mock.Anything, "user-1", 1, 20).Return([]models.UserFollow{
+		{FollowerID: "user-3", FolloweeID: "user-1", CreatedAt: followedAt, Follower: models.User{Username: "carol"}},
+	}, int64(1), nil)
+
+	for path, want := range map[string]string{"/api/users/me/following": "bob", "/api/users/me/followers": "carol"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path+"?page=1&page_size=20", nil)
+		followRouter(svc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "example.com")
+
+		var resp struct {
+			Data []dto.FollowResponse `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, []dto.FollowResponse{{Username: want, FollowedAt: followedAt}}, resp.Data)
+	}
+	svc.AssertExpectations(t)
+}
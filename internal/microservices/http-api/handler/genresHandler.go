@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -23,11 +24,21 @@ func NewGenreHandler(svc service.GenreService) *GenreHandler {
 }
 
 func (h *GenreHandler) RegisterRoutes(rg *gin.RouterGroup) {
-	rg.GET("/", middleware.RequireScopes("read:genre"), h.List)
-	rg.POST("/", middleware.RequireScopes("write:genre"), middleware.RequireAdmin(), h.Create)
+	rg.GET("/", middleware.RequireScopes("read:genre"), middleware.CatalogCache(), h.List)
+	rg.POST("/", middleware.RequireScopes("write:genre"), middleware.RequireAdmin(), middleware.CatalogWrite(), h.Create)
+	rg.DELETE("/:id", middleware.RequireScopes("delete:genre"), middleware.RequireAdmin(), middleware.CatalogWrite(), h.Delete)
 
 	// new route: GET /api/genres/:id/mangas
-	rg.GET("/:id/mangas", middleware.RequireScopes("read:manga"), h.GetMangasByGenre)
+	rg.GET("/:id/mangas", middleware.RequireScopes("read:manga"), middleware.CatalogCache(), h.GetMangasByGenre)
+
+	// bulk tagging: POST/DELETE /api/genres/:id/mangas
+	rg.POST("/:id/mangas", middleware.RequireScopes("write:genre"), middleware.RequireAdmin(), middleware.CatalogWrite(), h.LinkMangas)
+	rg.DELETE("/:id/mangas", middleware.RequireScopes("write:genre"), middleware.RequireAdmin(), middleware.CatalogWrite(), h.UnlinkMangas)
+}
+
+// RegisterMangaRoutes registers the genre routes nested under /api/manga
+func (h *GenreHandler) RegisterMangaRoutes(rg *gin.RouterGroup) {
+	rg.GET("/:manga_id/genres", middleware.RequireScopes("read:genre"), middleware.CatalogCache(), h.ListForManga)
 }
 
 func (h *GenreHandler) List(c *gin.Context) {
@@ -46,6 +57,41 @@ func (h *GenreHandler) List(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// ListForManga handles GET /api/manga/:manga_id/genres[?counts=true]. A manga
+// without genres gets an empty array; counts adds how many manga share each genre.
+func (h *GenreHandler) ListForManga(c *gin.Context) {
+	mangaID, err := strconv.ParseInt(c.Param("manga_id"), 10, 64)
+	if err != nil || mangaID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga id"})
+		return
+	}
+	withCounts := c.Query("counts") == "true"
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	genres, counts, err := h.svc.GetForManga(ctx, mangaID, withCounts)
+	if err != nil {
+		if errors.Is(err, service.ErrMangaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.GenreResponse, 0, len(genres))
+	for _, g := range genres {
+		item := dto.GenreFromModel(g)
+		if withCounts {
+			count := counts[g.ID]
+			item.MangaCount = &count
+		}
+		resp = append(resp, item)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
 func (h *GenreHandler) Create(c *gin.Context) {
 	var in dto.CreateGenreDTO
 	if err := c.ShouldBindJSON(&in); err != nil {
@@ -56,13 +102,58 @@ func (h *GenreHandler) Create(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	if err := h.svc.Create(ctx, &model); err != nil {
+	created, err := h.svc.Create(ctx, &model)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	// A genre differing only by case/accents/spacing already exists
+	if !created {
+		c.JSON(http.StatusOK, dto.GenreFromModel(model))
+		return
+	}
 	c.JSON(http.StatusCreated, dto.GenreFromModel(model))
 }
 
+// Delete handles DELETE /api/genres/:id[?force=true]. Without force, a genre
+// that is the sole genre of some manga is not deleted and the response lists
+// those manga so they can be reassigned first.
+func (h *GenreHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid genre id"})
+		return
+	}
+	force, _ := strconv.ParseBool(c.Query("force"))
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	affected, err := h.svc.Delete(ctx, id, force)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrGenreSoleGenre):
+			c.JSON(http.StatusConflict, gin.H{
+				"error":              err.Error(),
+				"affected_manga_ids": affected,
+				"hint":               "reassign genres for these manga or retry with ?force=true",
+			})
+		case errors.Is(err, service.ErrGenreNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	// Forced deletes report the manga now left without any genre
+	if len(affected) > 0 {
+		c.JSON(http.StatusOK, gin.H{"deleted": id, "orphaned_manga_ids": affected})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
 // GetMangasByGenre handles GET /api/genres/:id/mangas
 func (h *GenreHandler) GetMangasByGenre(c *gin.Context) {
 	idStr := c.Param("id")
@@ -72,10 +163,16 @@ func (h *GenreHandler) GetMangasByGenre(c *gin.Context) {
 		return
 	}
 
+	// Parse pagination parameters
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	list, err := h.svc.GetMangasByGenre(ctx, id)
+	list, total, err := h.svc.GetMangasByGenre(ctx, id, page, pageSize)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -86,5 +183,82 @@ func (h *GenreHandler) GetMangasByGenre(c *gin.Context) {
 	for _, m := range list {
 		resp = append(resp, dto.FromModelToBasicResponse(m))
 	}
-	c.JSON(http.StatusOK, resp)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       resp,
+		"pagination": dto.NewPagination(page, pageSize, total),
+	})
+}
+
+// LinkMangas handles POST /api/genres/:id/mangas, tagging every listed manga
+// with the genre. Manga already tagged or that don't exist are reported.
+func (h *GenreHandler) LinkMangas(c *gin.Context) {
+	id, in, ok := parseGenreMangas(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	result, err := h.svc.LinkMangas(ctx, id, in.MangaIDs)
+	if err != nil {
+		abortOnGenreError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.GenreLinkResponse{
+		GenreID:       id,
+		Linked:        result.Changed,
+		AlreadyLinked: result.Unchanged,
+		NotFound:      result.Missing,
+	})
+}
+
+// UnlinkMangas handles DELETE /api/genres/:id/mangas, removing the genre from
+// every listed manga. Manga without it, that don't exist or that would be
+// left with no genre are reported.
+func (h *GenreHandler) UnlinkMangas(c *gin.Context) {
+	id, in, ok := parseGenreMangas(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	result, err := h.svc.UnlinkMangas(ctx, id, in.MangaIDs)
+	if err != nil {
+		abortOnGenreError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.GenreUnlinkResponse{
+		GenreID:   id,
+		Unlinked:  result.Changed,
+		NotLinked: result.Unchanged,
+		NotFound:  result.Missing,
+		SoleGenre: result.SoleGenre,
+	})
+}
+
+// parseGenreMangas reads the genre id and manga_ids body of a bulk request,
+// writing a 400 and returning false if either is invalid
+func parseGenreMangas(c *gin.Context) (int64, dto.GenreMangasDTO, bool) {
+	var in dto.GenreMangasDTO
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid genre id"})
+		return 0, in, false
+	}
+	if !bindJSON(c, &in) {
+		return 0, in, false
+	}
+	return id, in, true
+}
+
+func abortOnGenreError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrGenreNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 }
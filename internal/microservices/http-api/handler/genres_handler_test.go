@@ -0,0 +1,269 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"mangahub/internal/microservices/http-api/handler"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGenreService struct {
+	mock.Mock
+}
+
+func (m *MockGenreService) GetAll(ctx context.Context) ([]models.Genre, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.Genre), args.Error(1)
+}
+
+func (m *MockGenreService) Create(ctx context.Context, g *models.Genre) (bool, error) {
+	args := m.Called(ctx, g)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGenreService) GetForManga(ctx context.Context, mangaID int64, withCounts bool) ([]models.Genre, map[int64]int64, error) {
+	args := m.Called(ctx, mangaID, withCounts)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	var counts map[int64]int64
+	if args.Get(1) != nil {
+		counts = args.Get(1).(map[int64]int64)
+	}
+	return args.Get(0).([]models.Genre), counts, args.Error(2)
+}
+
+func (m *MockGenreService) GetMangasByGenre(ctx context.Context, genreID int64, page, pageSize int) ([]models.Manga, int64, error) {
+	args := m.Called(ctx, genreID, page, pageSize)
+	return args.Get(0).([]models.Manga), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockGenreService) Delete(ctx context.Context, id int64, force bool) ([]int64, error) {
+	args := m.Called(ctx, id, force)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *MockGenreService) ResolveGenres(ctx context.Context, names []string, ids []int64) ([]models.Genre, []string, error) {
+	args := m.Called(ctx, names, ids)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]models.Genre), args.Get(1).([]string), args.Error(2)
+}
+
+func (m *MockGenreService) LinkMangas(ctx context.Context, genreID int64, mangaIDs []int64) (*repository.GenreLinkResult, error) {
+	args := m.Called(ctx, genreID, mangaIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.GenreLinkResult), args.Error(1)
+}
+
+func (m *MockGenreService) UnlinkMangas(ctx context.Context, genreID int64, mangaIDs []int64) (*repository.GenreLinkResult, error) {
+	args := m.Called(ctx, genreID, mangaIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.GenreLinkResult), args.Error(1)
+}
+
+func setupGenreRouter(mockService *MockGenreService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h := handler.NewGenreHandler(mockService)
+	r.DELETE("/api/genres/:id", h.Delete)
+	r.GET("/api/manga/:manga_id/genres", h.ListForManga)
+	r.POST("/api/genres/:id/mangas", h.LinkMangas)
+	r.DELETE("/api/genres/:id/mangas", h.UnlinkMangas)
+	return r
+}
+
+func TestGenreHandler_Delete(t *testing.T) {
+	t.Run("Deleted", func(t *testing.T) {
+		mockService := new(MockGenreService)
+		r := setupGenreRouter(mockService)
+		mockService.On("Delete", mock.Anything, int64(4), false).Return([]int64{}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodDelete, "/api/genres/4", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("SoleGenreConflict", func(t *testing.T) {
+		mockService := new(MockGenreService)
+		r := setupGenreRouter(mockService)
+		mockService.On("Delete", mock.Anything, int64(4), false).
+			Return([]int64{10, 12}, service.ErrGenreSoleGenre).Once()
+
+		req, _ := http.NewRequest(http.MethodDelete, "/api/genres/4", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		var response struct {
+			AffectedMangaIDs []int64 `json:"affected_manga_ids"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, []int64{10, 12}, response.AffectedMangaIDs)
+	})
+
+	t.Run("Forced", func(t *testing.T) {
+		mockService := new(MockGenreService)
+		r := setupGenreRouter(mockService)
+		mockService.On("Delete", mock.Anything, int64(4), true).Return([]int64{10}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodDelete, "/api/genres/4?force=true", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"orphaned_manga_ids":[10]`)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService := new(MockGenreService)
+		r := setupGenreRouter(mockService)
+		mockService.On("Delete", mock.Anything, int64(99), false).Return(nil, service.ErrGenreNotFound).Once()
+
+		req, _ := http.NewRequest(http.MethodDelete, "/api/genres/99", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		mockService := new(MockGenreService)
+		r := setupGenreRouter(mockService)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/api/genres/abc", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Delete")
+	})
+}
+
+func TestGenreHandler_ListForManga(t *testing.T) {
+	get := func(r *gin.Engine, url string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("WithCounts", func(t *testing.T) {
+		mockService := new(MockGenreService)
+		r := setupGenreRouter(mockService)
+		mockService.On("GetForManga", mock.Anything, int64(3), true).
+			Return([]models.Genre{{ID: 1, Name: "Action"}, {ID: 2, Name: "Drama"}}, map[int64]int64{1: 40, 2: 12}, nil).Once()
+
+		w := get(r, "/api/manga/3/genres?counts=true")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp []map[string]any
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Len(t, resp, 2)
+		assert.Equal(t, "Action", resp[0]["name"])
+		assert.Equal(t, float64(40), resp[0]["manga_count"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("NoGenresIsEmptyArray", func(t *testing.T) {
+		mockService := new(MockGenreService)
+		r := setupGenreRouter(mockService)
+		mockService.On("GetForManga", mock.Anything, int64(3), false).Return([]models.Genre{}, nil, nil).Once()
+
+		w := get(r, "/api/manga/3/genres")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[]`, w.Body.String())
+	})
+
+	t.Run("UnknownManga", func(t *testing.T) {
+		mockService := new(MockGenreService)
+		r := setupGenreRouter(mockService)
+		mockService.On("GetForManga", mock.Anything, int64(99), false).Return(nil, nil, service.ErrMangaNotFound).Once()
+
+		w := get(r, "/api/manga/99/genres")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestGenreHandler_LinkMangas(t *testing.T) {
+	t.Run("ReportsEachManga", func(t *testing.T) {
+		mockService := new(MockGenreService)
+		r := setupGenreRouter(mockService)
+		mockService.On("LinkMangas", mock.Anything, int64(4), []int64{1, 2, 3}).
+			Return(&repository.GenreLinkResult{Changed: []int64{1}, Unchanged: []int64{2}, Missing: []int64{3}}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodPost, "/api/genres/4/mangas", strings.NewReader(`{"manga_ids":[1,2,3]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"genre_id":4,"linked":[1],"already_linked":[2],"not_found":[3]}`, w.Body.String())
+	})
+
+	t.Run("GenreNotFound", func(t *testing.T) {
+		mockService := new(MockGenreService)
+		r := setupGenreRouter(mockService)
+		mockService.On("LinkMangas", mock.Anything, int64(99), []int64{1}).Return(nil, service.ErrGenreNotFound).Once()
+
+		req, _ := http.NewRequest(http.MethodPost, "/api/genres/99/mangas", strings.NewReader(`{"manga_ids":[1]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("EmptyList", func(t *testing.T) {
+		mockService := new(MockGenreService)
+		r := setupGenreRouter(mockService)
+
+		req, _ := http.NewRequest(http.MethodPost, "/api/genres/4/mangas", strings.NewReader(`{"manga_ids":[]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"manga_ids"`)
+		mockService.AssertNotCalled(t, "LinkMangas", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGenreHandler_UnlinkMangas(t *testing.T) {
+	mockService := new(MockGenreService)
+	r := setupGenreRouter(mockService)
+	mockService.On("UnlinkMangas", mock.Anything, int64(4), []int64{1, 2, 3}).
+		Return(&repository.GenreLinkResult{Changed: []int64{1}, Unchanged: []int64{2}, Missing: []int64{}, SoleGenre: []int64{3}}, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodDelete, "/api/genres/4/mangas", strings.NewReader(`{"manga_ids":[1,2,3]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"genre_id":4,"unlinked":[1],"not_linked":[2],"not_found":[],"sole_genre":[3]}`, w.Body.String())
+}
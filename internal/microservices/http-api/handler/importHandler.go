@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/middleware"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Imports hit the upstream API synchronously, so they get a longer deadline
+// than regular handlers and a per-admin rate limit
+const (
+	importTimeout   = 30 * time.Second
+	importRateLimit = 10 // requests per minute
+	importRateBurst = 3
+)
+
+type ImportHandler struct {
+	svc service.ImportService
+}
+
+func NewImportHandler(svc service.ImportService) *ImportHandler {
+	return &ImportHandler{svc: svc}
+}
+
+func (h *ImportHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/manga/import",
+		middleware.RequireScopes("admin:import"),
+		middleware.RequireAdmin(),
+		middleware.CatalogWrite(),
+		middleware.RateLimitPerUser(importRateLimit, time.Minute, importRateBurst),
+		h.Import,
+	)
+}
+
+// Import handles POST /api/admin/manga/import, fetching a single title from
+// MangaDex or AniList and upserting it
+func (h *ImportHandler) Import(c *gin.Context) {
+	var in dto.ImportMangaRequest
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), importTimeout)
+	defer cancel()
+
+	m, created, err := h.svc.Import(ctx, in.Source, in.ExternalID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnsupportedSource), errors.Is(err, service.ErrInvalidExternalID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrExternalNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrSourceUnavailable):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		case errors.Is(err, context.DeadlineExceeded):
+			c.JSON(http.StatusGatewayTimeout, gin.H{"error": "import timed out"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, gin.H{"created": created, "data": dto.FromModelToResponse(*m)})
+}
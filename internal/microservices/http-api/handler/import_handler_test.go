@@ -0,0 +1,135 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mangahub/internal/microservices/http-api/handler"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImportService struct {
+	mock.Mock
+}
+
+func (m *MockImportService) Import(ctx context.Context, source, externalID string) (*models.Manga, bool, error) {
+	args := m.Called(ctx, source, externalID)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).(*models.Manga), args.Bool(1), args.Error(2)
+}
+
+func setupImportRouter(mockService *MockImportService, role string, scopes []string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	api := r.Group("/api/admin")
+	api.Use(func(c *gin.Context) {
+		c.Set("userID", "admin-user-id")
+		c.Set("role", role)
+		c.Set("scopes", scopes)
+		c.Next()
+	})
+	handler.NewImportHandler(mockService).RegisterRoutes(api)
+	return r
+}
+
+func postImport(r *gin.Engine, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, "/api/admin/manga/import", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestImportHandler_Import(t *testing.T) {
+	adminScopes := []string{"admin:*"}
+
+	t.Run("Created", func(t *testing.T) {
+		mockService := new(MockImportService)
+		r := setupImportRouter(mockService, "admin", adminScopes)
+		mockService.On("Import", mock.Anything, "anilist", "30013").
+			Return(&models.Manga{ID: 3, Title: "One Piece"}, true, nil).Once()
+
+		w := postImport(r, `{"source":"anilist","external_id":"30013"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"created":true`)
+	})
+
+	t.Run("Updated", func(t *testing.T) {
+		mockService := new(MockImportService)
+		r := setupImportRouter(mockService, "admin", adminScopes)
+		mockService.On("Import", mock.Anything, "anilist", "30013").
+			Return(&models.Manga{ID: 3, Title: "One Piece"}, false, nil).Once()
+
+		w := postImport(r, `{"source":"anilist","external_id":"30013"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("InvalidSource", func(t *testing.T) {
+		mockService := new(MockImportService)
+		r := setupImportRouter(mockService, "admin", adminScopes)
+
+		w := postImport(r, `{"source":"kitsu","external_id":"1"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Import")
+	})
+
+	t.Run("NotFoundAtSource", func(t *testing.T) {
+		mockService := new(MockImportService)
+		r := setupImportRouter(mockService, "admin", adminScopes)
+		mockService.On("Import", mock.Anything, "mangadex", "a1c7c817-4e59-43b7-9365-09675a149a6f").
+			Return(nil, false, service.ErrExternalNotFound).Once()
+
+		w := postImport(r, `{"source":"mangadex","external_id":"a1c7c817-4e59-43b7-9365-09675a149a6f"}`)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("SourceUnavailable", func(t *testing.T) {
+		mockService := new(MockImportService)
+		r := setupImportRouter(mockService, "admin", adminScopes)
+		mockService.On("Import", mock.Anything, "anilist", "1").
+			Return(nil, false, service.ErrSourceUnavailable).Once()
+
+		w := postImport(r, `{"source":"anilist","external_id":"1"}`)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("NonAdminForbidden", func(t *testing.T) {
+		mockService := new(MockImportService)
+		r := setupImportRouter(mockService, "user", []string{"read:manga"})
+
+		w := postImport(r, `{"source":"anilist","external_id":"1"}`)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockService.AssertNotCalled(t, "Import")
+	})
+
+	t.Run("RateLimited", func(t *testing.T) {
+		mockService := new(MockImportService)
+		r := setupImportRouter(mockService, "admin", adminScopes)
+		mockService.On("Import", mock.Anything, "anilist", "1").
+			Return(&models.Manga{ID: 1}, false, nil)
+
+		var last *httptest.ResponseRecorder
+		for i := 0; i < 4; i++ {
+			last = postImport(r, `{"source":"anilist","external_id":"1"}`)
+		}
+
+		assert.Equal(t, http.StatusTooManyRequests, last.Code)
+		assert.NotEmpty(t, last.Header().Get("Retry-After"))
+	})
+}
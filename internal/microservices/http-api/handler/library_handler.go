@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -9,6 +10,7 @@ import (
 
 	"mangahub/internal/microservices/http-api/dto"
 	"mangahub/internal/microservices/http-api/middleware"
+	"mangahub/internal/microservices/http-api/models"
 	"mangahub/internal/microservices/http-api/service"
 
 	"github.com/gin-gonic/gin"
@@ -25,10 +27,15 @@ func NewLibraryHandler(svc service.LibraryService) *LibraryHandler {
 func (h *LibraryHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	rg.POST("/", middleware.RequireScopes("write:library"), h.Add)
 	rg.GET("/", middleware.RequireScopes("read:library"), h.List)
+	rg.GET("/stats", middleware.RequireScopes("read:library"), h.Stats)
+	rg.POST("/catch-up", middleware.RequireScopes("read:library", "write:progress"), h.CatchUp)
+	rg.DELETE("/", middleware.RequireScopes("write:library"), h.RemoveMany)
 	rg.DELETE("/:manga_id", middleware.RequireScopes("write:library"), h.Remove)
 }
 
-// Add manga to user's library
+// Add manga to user's library. Adding a manga that is already there is not
+// an error: it answers 200 with the existing entry. Pass ?strict=true to get
+// a 409 instead.
 func (h *LibraryHandler) Add(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
@@ -48,7 +55,21 @@ func (h *LibraryHandler) Add(c *gin.Context) {
 
 	if err := h.svc.Add(ctx, userID.(string), req.MangaID); err != nil {
 		if err == service.ErrAlreadyInLibrary {
-			c.JSON(http.StatusConflict, gin.H{"error": "manga already in library"})
+			if strict, _ := strconv.ParseBool(c.Query("strict")); strict {
+				c.JSON(http.StatusConflict, gin.H{"error": "manga already in library"})
+				return
+			}
+			existing, getErr := h.svc.Get(ctx, userID.(string), req.MangaID)
+			if getErr != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": getErr.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, libraryResponse(*existing))
+			return
+		}
+		var full *service.LibraryFullError
+		if errors.As(err, &full) {
+			c.JSON(http.StatusConflict, gin.H{"error": full.Error(), "count": full.Count, "limit": full.Limit})
 			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -79,15 +100,7 @@ func (h *LibraryHandler) List(c *gin.Context) {
 	// Convert to response DTOs
 	items := make([]dto.LibraryResponse, 0, len(library))
 	for _, item := range library {
-		resp := dto.LibraryResponse{
-			ID:      item.ID,
-			MangaID: item.MangaID,
-			AddedAt: item.AddedAt,
-		}
-		if item.Manga != nil {
-			resp.Manga = dto.FromModelToResponse(*item.Manga)
-		}
-		items = append(items, resp)
+		items = append(items, libraryResponse(item))
 	}
 
 	c.JSON(http.StatusOK, dto.LibraryListResponse{
@@ -121,3 +134,102 @@ func (h *LibraryHandler) Remove(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// RemoveMany removes several manga from the library in one transaction
+func (h *LibraryHandler) RemoveMany(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var req dto.BulkRemoveFromLibraryRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	removed, notFound, err := h.svc.RemoveMany(ctx, userID.(string), req.MangaIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BulkRemoveFromLibraryResponse{
+		Removed:     len(removed),
+		NotFound:    len(notFound),
+		RemovedIDs:  removed,
+		NotFoundIDs: notFound,
+	})
+}
+
+// CatchUp moves progress to the latest chapter for every library entry
+// with a known chapter count that isn't dropped or planned
+func (h *LibraryHandler) CatchUp(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	result, err := h.svc.CatchUp(ctx, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CatchUpResponse{
+		Updated:          len(result.Updated),
+		AlreadyCaughtUp:  len(result.AlreadyCurrent),
+		Skipped:          len(result.Skipped),
+		Excluded:         len(result.Excluded),
+		UpdatedMangaIDs:  result.Updated,
+		SkippedMangaIDs:  result.Skipped,
+		ExcludedMangaIDs: result.Excluded,
+	})
+}
+
+// Stats summarises the user's library by reading status
+func (h *LibraryHandler) Stats(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	stats, err := h.svc.Stats(ctx, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.LibraryStatsResponse{
+		Reading:           stats.Reading,
+		Completed:         stats.Completed,
+		PlanToRead:        stats.PlanToRead,
+		Dropped:           stats.Dropped,
+		Total:             stats.Total,
+		TotalChapters:     stats.TotalChapters,
+		CompletionPercent: stats.CompletionPct,
+	})
+}
+
+func libraryResponse(item models.UserLibrary) dto.LibraryResponse {
+	resp := dto.LibraryResponse{
+		ID:      item.ID,
+		MangaID: item.MangaID,
+		AddedAt: item.AddedAt,
+	}
+	if item.Manga != nil {
+		resp.Manga = dto.FromModelToResponse(*item.Manga)
+	}
+	return resp
+}
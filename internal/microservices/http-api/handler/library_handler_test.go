@@ -1,13 +1,258 @@
 package handler_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/handler"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+type MockLibraryService struct {
+	mock.Mock
+}
+
+func (m *MockLibraryService) Add(ctx context.Context, userID string, mangaID int64) error {
+	return m.Called(ctx, userID, mangaID).Error(0)
+}
+
+func (m *MockLibraryService) Remove(ctx context.Context, userID string, mangaID int64) error {
+	return m.Called(ctx, userID, mangaID).Error(0)
+}
+
+func (m *MockLibraryService) RemoveMany(ctx context.Context, userID string, mangaIDs []int64) ([]int64, []int64, error) {
+	args := m.Called(ctx, userID, mangaIDs)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]int64), args.Get(1).([]int64), args.Error(2)
+}
+
+func (m *MockLibraryService) Get(ctx context.Context, userID string, mangaID int64) (*models.UserLibrary, error) {
+	args := m.Called(ctx, userID, mangaID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserLibrary), args.Error(1)
+}
+
+func (m *MockLibraryService) CatchUp(ctx context.Context, userID string) (*service.CatchUpResult, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.CatchUpResult), args.Error(1)
+}
+
+func (m *MockLibraryService) Stats(ctx context.Context, userID string) (*service.LibraryStats, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.LibraryStats), args.Error(1)
+}
+
+func (m *MockLibraryService) List(ctx context.Context, userID string) ([]models.UserLibrary, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.UserLibrary), args.Error(1)
+}
+
+func libraryRouter(svc service.LibraryService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-1")
+		c.Set("scopes", []string{"read:library", "write:library", "write:progress"})
+		c.Next()
+	})
+	handler.NewLibraryHandler(svc).RegisterRoutes(r.Group("/api/library"))
+	return r
+}
+
 func TestLibraryHandlerStructure(t *testing.T) {
 	t.Run("HandlerExists", func(t *testing.T) {
 		assert.NotNil(t, "library handler")
 	})
 }
+
+func TestLibraryHandler_RemoveMany(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	mockSvc.On("RemoveMany", mock.Anything, "user-1", []int64{1, 2, 3}).
+		Return([]int64{1, 3}, []int64{2}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/library/", bytes.NewBufferString(`{"manga_ids":[1,2,3]}`))
+	req.Header.Set("Content-Type", "application/json")
+	libraryRouter(mockSvc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.BulkRemoveFromLibraryResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Removed)
+	assert.Equal(t, 1, resp.NotFound)
+	assert.Equal(t, []int64{2}, resp.NotFoundIDs)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestLibraryHandler_RemoveManyValidation(t *testing.T) {
+	tooMany := make([]int64, 101)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 1)
+	}
+	tooManyBody, _ := json.Marshal(map[string][]int64{"manga_ids": tooMany})
+
+	tests := []struct {
+		name string
+		body string
+		rule string
+	}{
+		{"missing", `{}`, "required"},
+		{"empty", `{"manga_ids":[]}`, "min"},
+		{"too many", string(tooManyBody), "max"},
+		{"non-positive id", `{"manga_ids":[1,0]}`, "gt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockLibraryService)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/api/library/", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			libraryRouter(mockSvc).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var resp dto.ValidationErrorResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			if assert.Len(t, resp.Details, 1) {
+				assert.Equal(t, tt.rule, resp.Details[0].Rule)
+			}
+			mockSvc.AssertNotCalled(t, "RemoveMany")
+		})
+	}
+}
+
+func TestLibraryHandler_CatchUp(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	mockSvc.On("CatchUp", mock.Anything, "user-1").Return(&service.CatchUpResult{
+		Updated:        []int64{1, 2},
+		AlreadyCurrent: []int64{4},
+		Skipped:        []int64{3},
+		Excluded:       []int64{5},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/library/catch-up", nil)
+	libraryRouter(mockSvc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.CatchUpResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Updated)
+	assert.Equal(t, 1, resp.AlreadyCaughtUp)
+	assert.Equal(t, 1, resp.Skipped)
+	assert.Equal(t, []int64{3}, resp.SkippedMangaIDs)
+	assert.Equal(t, 1, resp.Excluded)
+	assert.Equal(t, []int64{5}, resp.ExcludedMangaIDs)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestLibraryHandler_Stats(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	mockSvc.On("Stats", mock.Anything, "user-1").Return(&service.LibraryStats{
+		Reading: 2, Completed: 1, Total: 4, TotalChapters: 310, CompletionPct: 25,
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/library/stats", nil)
+	libraryRouter(mockSvc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"reading":2,"completed":1,"plan_to_read":0,"dropped":0,"total":4,"total_chapters":310,"completion_percent":25}`, w.Body.String())
+	mockSvc.AssertExpectations(t)
+}
+
+func TestLibraryHandler_AddLibraryFull(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	mockSvc.On("Add", mock.Anything, "user-1", int64(5)).Return(&service.LibraryFullError{Count: 3, Limit: 3})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/library/", bytes.NewBufferString(`{"manga_id":5}`))
+	req.Header.Set("Content-Type", "application/json")
+	libraryRouter(mockSvc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var resp map[string]any
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, float64(3), resp["count"])
+	assert.Equal(t, float64(3), resp["limit"])
+	assert.Contains(t, resp["error"], "library is full")
+	mockSvc.AssertNotCalled(t, "Get")
+}
+
+func TestLibraryHandler_AddIsIdempotent(t *testing.T) {
+	addedAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	existing := &models.UserLibrary{ID: 11, UserID: "user-1", MangaID: 5, AddedAt: addedAt, Manga: &models.Manga{ID: 5, Title: "Monster"}}
+
+	t.Run("new entry is created", func(t *testing.T) {
+		mockSvc := new(MockLibraryService)
+		mockSvc.On("Add", mock.Anything, "user-1", int64(5)).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/library/", bytes.NewBufferString(`{"manga_id":5}`))
+		req.Header.Set("Content-Type", "application/json")
+		libraryRouter(mockSvc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("existing entry is returned", func(t *testing.T) {
+		mockSvc := new(MockLibraryService)
+		mockSvc.On("Add", mock.Anything, "user-1", int64(5)).Return(service.ErrAlreadyInLibrary)
+		mockSvc.On("Get", mock.Anything, "user-1", int64(5)).Return(existing, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/library/", bytes.NewBufferString(`{"manga_id":5}`))
+		req.Header.Set("Content-Type", "application/json")
+		libraryRouter(mockSvc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp dto.LibraryResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, int64(11), resp.ID)
+		assert.Equal(t, "Monster", resp.Manga.Title)
+		assert.True(t, addedAt.Equal(resp.AddedAt))
+	})
+
+	t.Run("strict mode keeps the conflict", func(t *testing.T) {
+		mockSvc := new(MockLibraryService)
+		mockSvc.On("Add", mock.Anything, "user-1", int64(5)).Return(service.ErrAlreadyInLibrary)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/library/?strict=true", bytes.NewBufferString(`{"manga_id":5}`))
+		req.Header.Set("Content-Type", "application/json")
+		libraryRouter(mockSvc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockSvc.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
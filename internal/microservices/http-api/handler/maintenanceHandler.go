@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"mangahub/internal/microservices/http-api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MaintenanceHandler struct {
+	mode *middleware.Maintenance
+}
+
+func NewMaintenanceHandler(mode *middleware.Maintenance) *MaintenanceHandler {
+	return &MaintenanceHandler{mode: mode}
+}
+
+// setMaintenanceRequest is the body of PUT /api/admin/maintenance
+type setMaintenanceRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// RegisterRoutes registers the maintenance switch under /api/admin. The
+// maintenance middleware must exempt this path so the mode can be turned off.
+func (h *MaintenanceHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/maintenance", middleware.RequireScopes("admin:maintenance"), middleware.RequireAdmin(), h.Get)
+	rg.PUT("/maintenance", middleware.RequireScopes("admin:maintenance"), middleware.RequireAdmin(), h.Set)
+}
+
+// Get handles GET /api/admin/maintenance
+func (h *MaintenanceHandler) Get(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"enabled": h.mode.Enabled()})
+}
+
+// Set handles PUT /api/admin/maintenance
+func (h *MaintenanceHandler) Set(c *gin.Context) {
+	var req setMaintenanceRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	h.mode.Set(*req.Enabled, fmt.Sprintf("admin %s", c.GetString("userID")))
+	c.JSON(http.StatusOK, gin.H{"enabled": h.mode.Enabled()})
+}
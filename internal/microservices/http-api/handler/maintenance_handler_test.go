@@ -0,0 +1,47 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mangahub/internal/microservices/http-api/handler"
+	"mangahub/internal/microservices/http-api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceHandler_Set(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mode := middleware.NewMaintenance(false, time.Minute)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "admin-1")
+		c.Set("role", "admin")
+		c.Set("scopes", []string{"admin:*"})
+		c.Next()
+	})
+	handler.NewMaintenanceHandler(mode).RegisterRoutes(r.Group("/api/admin"))
+
+	put := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/api/admin/maintenance", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := put(`{"enabled":true}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled":true}`, w.Body.String())
+	assert.True(t, mode.Enabled())
+
+	assert.Equal(t, http.StatusOK, put(`{"enabled":false}`).Code)
+	assert.False(t, mode.Enabled())
+
+	assert.Equal(t, http.StatusBadRequest, put(`{}`).Code)
+}
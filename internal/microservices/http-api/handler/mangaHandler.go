@@ -2,7 +2,11 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -15,25 +19,70 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// Catalog exports stream for as long as the catalog takes, so each batch
+// pushes the write deadline out rather than sharing the server's
+// WriteTimeout, and each admin may only start a couple per minute
+const (
+	exportBatchWriteTimeout = 30 * time.Second
+	exportRateLimit         = 2 // requests per minute
+	exportRateBurst         = 1
+)
+
 type MangaHandler struct {
-	svc service.MangaService
+	svc        service.MangaService
+	views      service.RecentlyViewedService // optional; nil disables view tracking
+	viewCounts *service.ViewCounter          // optional; nil disables view counts
+	genres     service.GenreService          // optional; nil skips checking search genres exist
 }
 
 func NewMangaHandler(svc service.MangaService) *MangaHandler {
 	return &MangaHandler{svc: svc}
 }
 
+// NewMangaHandlerWithViews also records detail views into the user's
+// recently viewed list
+func NewMangaHandlerWithViews(svc service.MangaService, views service.RecentlyViewedService) *MangaHandler {
+	return &MangaHandler{svc: svc, views: views}
+}
+
+// WithViewCounter counts detail fetches towards each manga's view_count
+func (h *MangaHandler) WithViewCounter(counter *service.ViewCounter) *MangaHandler {
+	h.viewCounts = counter
+	return h
+}
+
+// WithGenreValidation makes advanced search reject genres that don't exist
+// with a 400 naming them, instead of silently matching nothing
+func (h *MangaHandler) WithGenreValidation(genres service.GenreService) *MangaHandler {
+	h.genres = genres
+	return h
+}
+
 func (h *MangaHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	// Public routes (any authenticated user)
-	rg.GET("/", middleware.RequireScopes("read:manga"), h.List)
-	rg.GET("/search", middleware.RequireScopes("read:manga"), h.SearchByTitle)
-	rg.GET("/advanced-search", middleware.RequireScopes("read:manga"), h.AdvancedSearch)
-	rg.GET("/:manga_id", middleware.RequireScopes("read:manga"), h.Get)
+	rg.GET("/", middleware.RequireScopes("read:manga"), middleware.CatalogCache(), h.List)
+	rg.GET("/search", middleware.RequireScopes("read:manga"), middleware.CatalogCache(), h.SearchByTitle)
+	rg.GET("/advanced-search", middleware.RequireScopes("read:manga"), middleware.CatalogCache(), h.AdvancedSearch)
+	rg.GET("/external/:source/:external_id", middleware.RequireScopes("read:manga"), middleware.CatalogCache(), h.GetByExternalID)
+	rg.GET("/:manga_id", middleware.RequireScopes("read:manga"), middleware.CatalogCache(), h.Get)
+	rg.GET("/:manga_id/sync-info", middleware.RequireScopes("read:manga"), h.SyncInfo)
+	rg.POST("/slugs", middleware.RequireScopes("read:manga"), h.GetBySlugs)
 
 	// Admin-only routes
-	rg.POST("/", middleware.RequireScopes("read:manga", "write:manga"), middleware.RequireAdmin(), h.Create)
-	rg.PUT("/:manga_id", middleware.RequireScopes("read:manga", "write:manga"), middleware.RequireAdmin(), h.Update)
-	rg.DELETE("/:manga_id", middleware.RequireScopes("delete:manga"), middleware.RequireAdmin(), h.Delete)
+	rg.POST("/", middleware.RequireScopes("read:manga", "write:manga"), middleware.RequireAdmin(), middleware.CatalogWrite(), h.Create)
+	rg.PUT("/:manga_id", middleware.RequireScopes("read:manga", "write:manga"), middleware.RequireAdmin(), middleware.CatalogWrite(), h.Update)
+	rg.PATCH("/:manga_id", middleware.RequireScopes("read:manga", "write:manga"), middleware.RequireAdmin(), middleware.CatalogWrite(), h.Update)
+	rg.DELETE("/:manga_id", middleware.RequireScopes("delete:manga"), middleware.RequireAdmin(), middleware.CatalogWrite(), h.Delete)
+}
+
+// RegisterAdminRoutes registers the catalog export under /api/admin
+func (h *MangaHandler) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	rg.GET("/manga/export",
+		middleware.RequireScopes("admin:export"),
+		middleware.RequireAdmin(),
+		middleware.RateLimitPerUser(exportRateLimit, time.Minute, exportRateBurst),
+		h.Export,
+	)
 }
 
 func (h *MangaHandler) List(c *gin.Context) {
@@ -41,22 +90,19 @@ func (h *MangaHandler) List(c *gin.Context) {
 	defer cancel()
 
 	// Parse pagination parameters
-	page := 1
-	pageSize := 20
-
-	if p := c.Query("page"); p != "" {
-		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
-			page = parsed
-		}
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
 	}
 
-	if ps := c.Query("page_size"); ps != "" {
-		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 && parsed <= 100 {
-			pageSize = parsed
-		}
+	// sort_by=views lists the most viewed first; the default is newest first
+	sortBy := strings.TrimSpace(c.Query("sort_by"))
+	if sortBy != "" && sortBy != "recent" && sortBy != "views" {
+		abortWithFieldErrors(c, dto.FieldError{Field: "sort_by", Rule: "oneof", Message: "must be one of: recent, views"})
+		return
 	}
 
-	list, total, err := h.svc.GetAll(ctx, page, pageSize)
+	list, total, err := h.svc.GetAll(ctx, page, pageSize, sortBy)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -69,13 +115,8 @@ func (h *MangaHandler) List(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data": resp,
-		"pagination": gin.H{
-			"page":        page,
-			"page_size":   pageSize,
-			"total":       total,
-			"total_pages": (total + int64(pageSize) - 1) / int64(pageSize),
-		},
+		"data":       resp,
+		"pagination": dto.NewPagination(page, pageSize, total),
 	})
 }
 
@@ -94,13 +135,131 @@ func (h *MangaHandler) Get(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
 		return
 	}
+
+	if userID, ok := c.Get("userID"); ok && h.views != nil {
+		h.views.RecordView(userID.(string), m.ID)
+	}
+	if h.viewCounts != nil {
+		h.viewCounts.Record(m.ID)
+	}
+	c.JSON(http.StatusOK, dto.FromModelToResponse(*m))
+}
+
+// GetByExternalID handles GET /api/manga/external/:source/:external_id,
+// mapping a MangaDex or AniList ID to the ingested manga
+func (h *MangaHandler) GetByExternalID(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	m, err := h.svc.GetByExternalID(ctx, c.Param("source"), c.Param("external_id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnsupportedSource), errors.Is(err, service.ErrInvalidExternalID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrMangaNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
 	c.JSON(http.StatusOK, dto.FromModelToResponse(*m))
 }
 
+// SyncInfo handles GET /api/manga/:manga_id/sync-info, reporting which
+// sources the manga was ingested from and when each last synced it
+func (h *MangaHandler) SyncInfo(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("manga_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	info, err := h.svc.GetSyncInfo(ctx, id)
+	if err != nil {
+		if errors.Is(err, service.ErrMangaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromSyncInfoModel(*info))
+}
+
+// Export handles GET /api/admin/manga/export, streaming the whole catalog as
+// newline-delimited JSON: one manga per line, with its genres and external
+// IDs. Once the first line is out a failure can only cut the stream short.
+func (h *MangaHandler) Export(c *gin.Context) {
+	rc := http.NewResponseController(c.Writer)
+	enc := json.NewEncoder(c.Writer)
+	started := false
+	begin := func() {
+		started = true
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", `attachment; filename="manga-export.ndjson"`)
+		c.Status(http.StatusOK)
+	}
+
+	err := h.svc.ExportCatalog(c.Request.Context(), service.DefaultExportBatchSize, func(batch []models.Manga) error {
+		if !started {
+			begin()
+		}
+		// Not every ResponseWriter supports deadlines; the stream still works
+		if err := rc.SetWriteDeadline(time.Now().Add(exportBatchWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+
+		for _, m := range batch {
+			if err := enc.Encode(dto.FromModelToResponse(m)); err != nil {
+				return err
+			}
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	switch {
+	case err != nil && started:
+		c.Error(err)
+		c.Abort()
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	case !started:
+		// An empty catalog is an empty export
+		begin()
+		c.Writer.WriteHeaderNow()
+	}
+}
+
+// GetBySlugs resolves a batch of slugs in one round-trip
+// POST /api/manga/slugs
+func (h *MangaHandler) GetBySlugs(c *gin.Context) {
+	var in dto.MangaBySlugsRequest
+	if !bindJSON(c, &in) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	found, notFound, err := h.svc.GetBySlugs(ctx, in.Slugs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := make([]dto.MangaResponse, 0, len(found))
+	for _, m := range found {
+		data = append(data, dto.FromModelToResponse(m))
+	}
+	c.JSON(http.StatusOK, dto.MangaBySlugsResponse{Data: data, NotFound: notFound})
+}
+
 func (h *MangaHandler) Create(c *gin.Context) {
 	var in dto.CreateMangaDTO
-	if err := c.ShouldBindJSON(&in); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &in) {
 		return
 	}
 	model := in.ToModel()
@@ -109,6 +268,11 @@ func (h *MangaHandler) Create(c *gin.Context) {
 
 	// Create manga
 	if err := h.svc.Create(ctx, &model); err != nil {
+		var conflict *service.MangaConflictError
+		if errors.As(err, &conflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": conflict.Error(), "field": conflict.Field})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -143,8 +307,7 @@ func (h *MangaHandler) Update(c *gin.Context) {
 	}
 
 	var in dto.UpdateMangaDTO
-	if err := c.ShouldBindJSON(&in); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &in) {
 		return
 	}
 
@@ -156,7 +319,16 @@ func (h *MangaHandler) Update(c *gin.Context) {
 	in.ApplyTo(&m)
 
 	// Update manga basic info
-	if err := h.svc.Update(ctx, id, &m); err != nil {
+	if err := h.svc.Update(ctx, id, &m, in.ClearFields); err != nil {
+		var conflict *service.MangaConflictError
+		if errors.As(err, &conflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": conflict.Error(), "field": conflict.Field})
+			return
+		}
+		if errors.Is(err, service.ErrFieldNotClearable) {
+			abortWithFieldErrors(c, dto.FieldError{Field: "clear_fields", Rule: "clearable", Message: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -209,20 +381,53 @@ func (h *MangaHandler) SearchByTitle(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "q or title query parameter is required"})
 		return
 	}
+	q, ok := normalizeSearchQuery(c, q)
+	if !ok {
+		return
+	}
+
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
+
+	if withUser, _ := strconv.ParseBool(c.Query("with_user_context")); withUser {
+		if len(fields) > 0 {
+			abortWithFieldErrors(c, dto.FieldError{Field: "with_user_context", Rule: "excluded_with", Message: "cannot be combined with fields"})
+			return
+		}
+		h.searchForUser(c, q)
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
+	// A field list returns slim objects for type-ahead instead of full results
+	if len(fields) > 0 {
+		list, err := h.svc.SearchByTitleFields(ctx, q, fields)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		resp := make([]map[string]any, 0, len(list))
+		for _, m := range list {
+			resp = append(resp, dto.ProjectManga(m, fields))
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"data":  resp,
+			"total": len(resp),
+		})
+		return
+	}
+
 	list, err := h.svc.SearchByTitle(ctx, q)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	resp := make([]dto.MangaBasicResponse, 0, len(list))
-	for _, m := range list {
-		resp = append(resp, dto.FromModelToBasicResponse(m))
-	}
+	resp := searchResults(list, q)
 	c.JSON(http.StatusOK, gin.H{
 		"data":  resp,
 		"total": len(resp),
@@ -234,7 +439,11 @@ func (h *MangaHandler) AdvancedSearch(c *gin.Context) {
 	var filters dto.SearchFilters
 
 	// Manual parsing with sanitization
-	filters.Query = strings.TrimSpace(c.Query("q"))
+	query, ok := normalizeSearchQuery(c, c.Query("q"))
+	if !ok {
+		return
+	}
+	filters.Query = query
 	filters.Status = strings.TrimSpace(c.Query("status"))
 	filters.SortBy = strings.TrimSpace(c.Query("sort_by"))
 
@@ -249,46 +458,53 @@ func (h *MangaHandler) AdvancedSearch(c *gin.Context) {
 		}
 	}
 
+	// Parse genre_ids (comma-separated, IDs only)
+	if idsStr := strings.TrimSpace(c.Query("genre_ids")); idsStr != "" {
+		for _, part := range strings.Split(idsStr, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			id, err := strconv.ParseInt(part, 10, 64)
+			if err != nil || id <= 0 {
+				abortWithFieldErrors(c, dto.FieldError{Field: "genre_ids", Rule: "int", Message: fmt.Sprintf("%q is not a valid genre id", part)})
+				return
+			}
+			filters.GenreIDs = append(filters.GenreIDs, id)
+		}
+	}
+
 	// Parse min_rating
 	if minRatingStr := strings.TrimSpace(c.Query("min_rating")); minRatingStr != "" {
 		if minRating, err := strconv.ParseFloat(minRatingStr, 64); err == nil && minRating >= 0 && minRating <= 10 {
 			filters.MinRating = &minRating
 		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid min_rating parameter, must be between 0 and 10"})
+			abortWithFieldErrors(c, dto.FieldError{Field: "min_rating", Rule: "range", Message: "must be a number between 0 and 10"})
 			return
 		}
 	}
 
-	// Parse page
-	filters.Page = 1
-	if pageStr := strings.TrimSpace(c.Query("page")); pageStr != "" {
-		if page, err := strconv.Atoi(pageStr); err == nil && page >= 1 {
-			filters.Page = page
-		}
-	}
-
-	// Parse page_size
-	filters.PageSize = 20
-	if pageSizeStr := strings.TrimSpace(c.Query("page_size")); pageSizeStr != "" {
-		if pageSize, err := strconv.Atoi(pageSizeStr); err == nil && pageSize >= 1 && pageSize <= 100 {
-			filters.PageSize = pageSize
-		}
+	// Parse page and page_size
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
 	}
+	filters.Page = page
+	filters.PageSize = pageSize
 
 	// Validate status
 	if filters.Status != "" {
-		validStatuses := map[string]bool{"ongoing": true, "completed": true, "hiatus": true}
-		if !validStatuses[strings.ToLower(filters.Status)] {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status, must be one of: ongoing, completed, hiatus"})
+		if _, ok := models.ParseMangaStatus(filters.Status); !ok {
+			abortWithFieldErrors(c, dto.FieldError{Field: "status", Rule: "manga_status", Message: "must be one of: " + models.MangaStatusList()})
 			return
 		}
 	}
 
 	// Validate sort_by
 	if filters.SortBy != "" {
-		validSortBy := map[string]bool{"popularity": true, "rating": true, "recent": true, "title": true}
+		validSortBy := map[string]bool{"popularity": true, "rating": true, "recent": true, "title": true, "views": true}
 		if !validSortBy[strings.ToLower(filters.SortBy)] {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sort_by, must be one of: popularity, rating, recent, title"})
+			abortWithFieldErrors(c, dto.FieldError{Field: "sort_by", Rule: "oneof", Message: "must be one of: popularity, rating, recent, title, views"})
 			return
 		}
 	}
@@ -296,39 +512,166 @@ func (h *MangaHandler) AdvancedSearch(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
 
+	// Echo the genres as requested, even once resolved to IDs below
+	requestedGenres, requestedGenreIDs := filters.Genres, filters.GenreIDs
+
+	// Tell a misspelled genre apart from a search with no matches
+	if h.genres != nil && (len(filters.Genres) > 0 || len(filters.GenreIDs) > 0) {
+		genres, unknown, err := h.genres.ResolveGenres(ctx, filters.Genres, filters.GenreIDs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if len(unknown) > 0 {
+			abortWithFieldErrors(c, dto.FieldError{Field: "genres", Rule: "exists", Message: "unknown genres: " + strings.Join(unknown, ", ")})
+			return
+		}
+		// Search by the resolved IDs so "Action,action" counts once
+		filters.Genres = nil
+		filters.GenreIDs = make([]int64, len(genres))
+		for i, g := range genres {
+			filters.GenreIDs[i] = g.ID
+		}
+	}
+
 	list, total, err := h.svc.AdvancedSearch(ctx, filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Use MangaBasicResponse for list results
-	resp := make([]dto.MangaBasicResponse, 0, len(list))
-	for _, m := range list {
-		resp = append(resp, dto.FromModelToBasicResponse(m))
-	}
+	// Basic view per result, annotated with what matched the query
+	resp := searchResults(list, filters.Query)
 
-	totalPages := int64(0)
-	if filters.PageSize > 0 {
-		totalPages = (total + int64(filters.PageSize) - 1) / int64(filters.PageSize)
-	}
+	pagination := dto.NewPagination(filters.Page, filters.PageSize, total)
 
 	c.JSON(http.StatusOK, gin.H{
 		"data": resp,
 		"pagination": gin.H{
-			"page":         filters.Page,
-			"page_size":    filters.PageSize,
-			"total":        total,
-			"total_pages":  totalPages,
-			"has_next":     filters.Page < int(totalPages),
-			"has_previous": filters.Page > 1,
+			"page":         pagination.Page,
+			"page_size":    pagination.PageSize,
+			"total":        pagination.Total,
+			"total_pages":  pagination.TotalPages,
+			"has_next":     pagination.HasNext,
+			"has_prev":     pagination.HasPrev,
+			"has_previous": pagination.HasPrev, // predates has_prev; kept for existing clients
 		},
 		"filters": gin.H{
 			"query":      filters.Query,
-			"genres":     filters.Genres,
+			"genres":     requestedGenres,
+			"genre_ids":  requestedGenreIDs,
 			"status":     filters.Status,
 			"min_rating": filters.MinRating,
 			"sort_by":    filters.SortBy,
 		},
 	})
 }
+
+// searchForUser answers a search with ?with_user_context=true, marking each
+// result with the caller's library entry and progress. It needs read:library
+// and isn't cached, since progress changes as they read.
+func (h *MangaHandler) searchForUser(c *gin.Context, q string) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+	if middleware.RequireScopes("read:library")(c); c.IsAborted() {
+		return
+	}
+	c.Header("Cache-Control", middleware.NoStore)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.svc.SearchByTitleForUser(ctx, q, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.MangaUserSearchResult, 0, len(list))
+	for _, m := range list {
+		fields, excerpt := service.SearchMatch(m.Manga, q)
+		resp = append(resp, dto.MangaUserSearchResult{
+			MangaSearchResult: dto.MangaSearchResult{
+				MangaBasicResponse: dto.FromModelToBasicResponse(m.Manga),
+				MatchedFields:      fields,
+				Highlight:          excerpt,
+			},
+			InLibrary:      m.InLibrary,
+			CurrentChapter: m.CurrentChapter,
+			ReadingStatus:  m.ReadingStatus,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data":  resp,
+		"total": len(resp),
+	})
+}
+
+// searchResults converts search hits to the basic view plus match info
+func searchResults(list []models.Manga, query string) []dto.MangaSearchResult {
+	resp := make([]dto.MangaSearchResult, 0, len(list))
+	for _, m := range list {
+		fields, excerpt := service.SearchMatch(m, query)
+		resp = append(resp, dto.MangaSearchResult{
+			MangaBasicResponse: dto.FromModelToBasicResponse(m),
+			MatchedFields:      fields,
+			Highlight:          excerpt,
+		})
+	}
+	return resp
+}
+
+// normalizeSearchQuery trims and collapses whitespace in a ?q= value. A query
+// over the configured maximum length gets a 400 and ok=false.
+func normalizeSearchQuery(c *gin.Context, raw string) (q string, ok bool) {
+	q, err := dto.NormalizeSearchQuery(raw)
+	if err != nil {
+		abortWithFieldErrors(c, dto.FieldError{
+			Field:   "q",
+			Rule:    "max",
+			Message: fmt.Sprintf("must be at most %d characters", dto.MaxSearchQueryLength()),
+		})
+		return "", false
+	}
+	return q, true
+}
+
+// parseFields reads the comma-separated ?fields= list, dropping duplicates.
+// Unknown fields get a 400 and ok=false; an absent param yields nil.
+func parseFields(c *gin.Context) (fields []string, ok bool) {
+	raw := strings.TrimSpace(c.Query("fields"))
+	if raw == "" {
+		return nil, true
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range strings.Split(raw, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" || seen[f] {
+			continue
+		}
+		if !dto.MangaProjectionFields[f] {
+			abortWithFieldErrors(c, dto.FieldError{
+				Field:   "fields",
+				Rule:    "oneof",
+				Message: fmt.Sprintf("unknown field %q; allowed: %s", f, strings.Join(projectionFieldNames(), ", ")),
+			})
+			return nil, false
+		}
+		seen[f] = true
+		fields = append(fields, f)
+	}
+	return fields, true
+}
+
+func projectionFieldNames() []string {
+	names := make([]string, 0, len(dto.MangaProjectionFields))
+	for name := range dto.MangaProjectionFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -2,11 +2,15 @@ package handler_test
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 
 	//"strconv"
 	"testing"
@@ -14,7 +18,9 @@ import (
 
 	"mangahub/internal/microservices/http-api/dto"
 	"mangahub/internal/microservices/http-api/handler"
+	"mangahub/internal/microservices/http-api/middleware"
 	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/service"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -33,8 +39,8 @@ type MockMangaService struct {
 	mock.Mock
 }
 
-func (m *MockMangaService) GetAll(ctx context.Context, page, pageSize int) ([]models.Manga, int64, error) {
-	args := m.Called(ctx, page, pageSize)
+func (m *MockMangaService) GetAll(ctx context.Context, page, pageSize int, sortBy string) ([]models.Manga, int64, error) {
+	args := m.Called(ctx, page, pageSize, sortBy)
 	return args.Get(0).([]models.Manga), args.Get(1).(int64), args.Error(2)
 }
 
@@ -47,13 +53,40 @@ func (m *MockMangaService) GetByID(ctx context.Context, id int64) (*models.Manga
 	return args.Get(0).(*models.Manga), args.Error(1)
 }
 
+func (m *MockMangaService) GetByExternalID(ctx context.Context, source, externalID string) (*models.Manga, error) {
+	args := m.Called(ctx, source, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Manga), args.Error(1)
+}
+
+func (m *MockMangaService) GetSyncInfo(ctx context.Context, id int64) (*models.MangaSyncInfo, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MangaSyncInfo), args.Error(1)
+}
+
+// ExportCatalog emits the batches given to Return, then returns its error
+func (m *MockMangaService) ExportCatalog(ctx context.Context, batchSize int, emit func([]models.Manga) error) error {
+	args := m.Called(ctx, batchSize)
+	for _, batch := range args.Get(0).([][]models.Manga) {
+		if err := emit(batch); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
 func (m *MockMangaService) Create(ctx context.Context, manga *models.Manga) error {
 	args := m.Called(ctx, manga)
 	return args.Error(0)
 }
 
-func (m *MockMangaService) Update(ctx context.Context, id int64, manga *models.Manga) error {
-	args := m.Called(ctx, id, manga)
+func (m *MockMangaService) Update(ctx context.Context, id int64, manga *models.Manga, clear []string) error {
+	args := m.Called(ctx, id, manga, clear)
 	return args.Error(0)
 }
 
@@ -67,6 +100,24 @@ func (m *MockMangaService) SearchByTitle(ctx context.Context, title string) ([]m
 	return args.Get(0).([]models.Manga), args.Error(1)
 }
 
+func (m *MockMangaService) GetBySlugs(ctx context.Context, slugs []string) ([]models.Manga, []string, error) {
+	args := m.Called(ctx, slugs)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]models.Manga), args.Get(1).([]string), args.Error(2)
+}
+
+func (m *MockMangaService) SearchByTitleFields(ctx context.Context, title string, fields []string) ([]models.Manga, error) {
+	args := m.Called(ctx, title, fields)
+	return args.Get(0).([]models.Manga), args.Error(1)
+}
+
+func (m *MockMangaService) SearchByTitleForUser(ctx context.Context, title, userID string) ([]models.MangaWithUserContext, error) {
+	args := m.Called(ctx, title, userID)
+	return args.Get(0).([]models.MangaWithUserContext), args.Error(1)
+}
+
 func (m *MockMangaService) AdvancedSearch(ctx context.Context, filters dto.SearchFilters) ([]models.Manga, int64, error) {
 	args := m.Called(ctx, filters)
 	return args.Get(0).([]models.Manga), args.Get(1).(int64), args.Error(2)
@@ -88,12 +139,16 @@ func setupRouter(mockService *MockMangaService) *gin.Engine {
 	{
 		rg.GET("", h.List) // Changed from "/" to ""
 		rg.GET("/:manga_id", h.Get)
+		rg.GET("/:manga_id/sync-info", h.SyncInfo)
 		rg.GET("/search", h.SearchByTitle)
 		rg.GET("/advanced-search", h.AdvancedSearch)
+		rg.GET("/external/:source/:external_id", h.GetByExternalID)
+		rg.POST("/slugs", h.GetBySlugs)
 		rg.POST("", h.Create) // Changed from "/" to ""
 		rg.PUT("/:manga_id", h.Update)
 		rg.DELETE("/:manga_id", h.Delete)
 	}
+	r.GET("/api/admin/manga/export", h.Export)
 	return r
 }
 
@@ -126,6 +181,7 @@ func setupRouterWithAuth(mockService *MockMangaService, role string) *gin.Engine
 		rg.GET("/advanced-search", h.AdvancedSearch)
 		rg.POST("", h.Create)
 		rg.PUT("/:manga_id", h.Update)
+		rg.PATCH("/:manga_id", h.Update)
 		rg.DELETE("/:manga_id", h.Delete)
 	}
 	return r
@@ -145,7 +201,7 @@ func TestMangaHandler_List(t *testing.T) {
 	expectedTotal := int64(50)
 
 	t.Run("Success", func(t *testing.T) {
-		mockService.On("GetAll", mock.Anything, 1, 20).Return(expectedManga, expectedTotal, nil).Once()
+		mockService.On("GetAll", mock.Anything, 1, 20, "").Return(expectedManga, expectedTotal, nil).Once()
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/manga", nil)
 		w := httptest.NewRecorder()
@@ -164,6 +220,25 @@ func TestMangaHandler_List(t *testing.T) {
 		assert.Equal(t, "Author A", item1["author"])
 		assert.Equal(t, 9.5, item1["average_rating"])
 	})
+
+	t.Run("SortByViews", func(t *testing.T) {
+		mockService.On("GetAll", mock.Anything, 1, 20, "views").Return(expectedManga, expectedTotal, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga?sort_by=views", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("InvalidSort", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga?sort_by=popularity", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "GetAll", mock.Anything, 1, 20, "popularity")
+	})
 }
 
 func TestMangaHandler_Get(t *testing.T) {
@@ -268,6 +343,37 @@ func TestMangaHandler_Create(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
+
+	t.Run("DuplicateSlug", func(t *testing.T) {
+		dupDTO := dto.CreateMangaDTO{Title: "One Piece", Slug: stringPtr("one-piece")}
+		sameSlug := mock.MatchedBy(func(m *models.Manga) bool {
+			return m.Slug != nil && *m.Slug == "one-piece"
+		})
+		mockService.On("Create", mock.Anything, sameSlug).Return(nil).Once()
+		mockService.On("GetByID", mock.Anything, mock.Anything).Return(&models.Manga{ID: 2, Title: "One Piece"}, nil).Once()
+		mockService.On("Create", mock.Anything, sameSlug).Return(&service.MangaConflictError{Field: "slug"}).Once()
+
+		codes := make([]int, 0, 2)
+		for i := 0; i < 2; i++ {
+			body, _ := json.Marshal(dupDTO)
+			req, _ := http.NewRequest(http.MethodPost, "/api/manga", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			codes = append(codes, w.Code)
+
+			if i == 1 {
+				var resp map[string]string
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, "slug", resp["field"])
+				assert.Contains(t, resp["error"], "slug")
+			}
+		}
+
+		assert.Equal(t, []int{http.StatusCreated, http.StatusConflict}, codes)
+		mockService.AssertExpectations(t)
+	})
 }
 
 func TestMangaHandler_Update(t *testing.T) {
@@ -298,7 +404,7 @@ func TestMangaHandler_Update(t *testing.T) {
 		// Assuming Handler calls Update with the modified model
 		mockService.On("Update", mock.Anything, mangaID, mock.MatchedBy(func(m *models.Manga) bool {
 			return m.Title == "Updated Title" && *m.Status == "completed"
-		})).Return(nil).Once()
+		}), []string(nil)).Return(nil).Once()
 
 		body, _ := json.Marshal(updateDTO)
 		req, _ := http.NewRequest(http.MethodPut, "/api/manga/10", bytes.NewBuffer(body))
@@ -310,6 +416,66 @@ func TestMangaHandler_Update(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("SlugTakenByAnotherManga", func(t *testing.T) {
+		// manga 10 tries to take manga 11's slug
+		mockService.On("Update", mock.Anything, int64(10), mock.MatchedBy(func(m *models.Manga) bool {
+			return m.Slug != nil && *m.Slug == "manga-b"
+		}), []string(nil)).Return(&service.MangaConflictError{Field: "slug"}).Once()
+
+		body, _ := json.Marshal(dto.UpdateMangaDTO{Slug: stringPtr("manga-b")})
+		req, _ := http.NewRequest(http.MethodPut, "/api/manga/10", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		var resp map[string]string
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "slug", resp["field"])
+		mockService.AssertNotCalled(t, "ReplaceGenresForManga", mock.Anything, mock.Anything, mock.Anything)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("PatchClearsFields", func(t *testing.T) {
+		mockService.On("Update", mock.Anything, int64(10), mock.Anything, []string{"description", "cover_url"}).Return(nil).Once()
+		mockService.On("GetByID", mock.Anything, int64(10)).Return(&models.Manga{ID: 10, Title: "Old Title"}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodPatch, "/api/manga/10", strings.NewReader(`{"clear_fields":["description","cover_url"]}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("ClearNonNullableField", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPatch, "/api/manga/10", strings.NewReader(`{"clear_fields":["title"]}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"rule":"oneof"`)
+	})
+
+	t.Run("ClearAndSetSameField", func(t *testing.T) {
+		mockService.On("Update", mock.Anything, int64(10), mock.Anything, []string{"description"}).
+			Return(fmt.Errorf("%w: description is also being set", service.ErrFieldNotClearable)).Once()
+
+		req, _ := http.NewRequest(http.MethodPatch, "/api/manga/10", strings.NewReader(`{"description":"new","clear_fields":["description"]}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"clear_fields"`)
+	})
 }
 
 func TestMangaHandler_Delete(t *testing.T) {
@@ -341,6 +507,124 @@ func TestMangaHandler_SearchByTitle(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
+
+	t.Run("CollapsesWhitespace", func(t *testing.T) {
+		mockService.On("SearchByTitle", mock.Anything, "one piece").Return([]models.Manga{}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/search?q=+one++%09piece+", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("QueryTooLong", func(t *testing.T) {
+		long := strings.Repeat("a", dto.MaxSearchQueryLength()+1)
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/search?q="+long, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var resp dto.ValidationErrorResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "q", resp.Details[0].Field)
+	})
+
+	t.Run("ProjectedFields", func(t *testing.T) {
+		cover := "https://example.com/naruto.jpg"
+		mockService.On("SearchByTitleFields", mock.Anything, "naruto", []string{"id", "title", "cover_url"}).
+			Return([]models.Manga{{ID: 7, Title: "Naruto", CoverURL: &cover}}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/search?q=naruto&fields=id,title,,cover_url,id", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp struct {
+			Data []map[string]any `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		if assert.Len(t, resp.Data, 1) {
+			assert.Equal(t, map[string]any{"id": float64(7), "title": "Naruto", "cover_url": cover}, resp.Data[0])
+		}
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/search?q=naruto&fields=id,password", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"fields"`)
+	})
+
+	mockService.AssertExpectations(t)
+}
+
+func TestMangaHandler_SearchWithUserContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockMangaService)
+	h := handler.NewMangaHandler(mockService)
+
+	newRouter := func(scopes ...string) *gin.Engine {
+		r := gin.New()
+		r.GET("/api/manga/search", func(c *gin.Context) {
+			c.Set("userID", "user-1")
+			c.Set("scopes", scopes)
+			c.Next()
+		}, h.SearchByTitle)
+		return r
+	}
+
+	t.Run("AnnotatesResults", func(t *testing.T) {
+		chapter, status := 12, "reading"
+		mockService.On("SearchByTitleForUser", mock.Anything, "naruto", "user-1").Return([]models.MangaWithUserContext{
+			{Manga: models.Manga{ID: 1, Title: "Naruto"}, InLibrary: true, CurrentChapter: &chapter, ReadingStatus: &status},
+			{Manga: models.Manga{ID: 2, Title: "Boruto: Naruto Next Generations"}},
+		}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/search?q=naruto&with_user_context=true", nil)
+		w := httptest.NewRecorder()
+		newRouter("read:manga", "read:library").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, middleware.NoStore, w.Header().Get("Cache-Control"))
+
+		var resp struct {
+			Data []map[string]any `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		if assert.Len(t, resp.Data, 2) {
+			assert.Equal(t, true, resp.Data[0]["in_library"])
+			assert.Equal(t, float64(12), resp.Data[0]["current_chapter"])
+			assert.Equal(t, "reading", resp.Data[0]["reading_status"])
+
+			assert.Equal(t, false, resp.Data[1]["in_library"])
+			assert.Contains(t, resp.Data[1], "current_chapter")
+			assert.Nil(t, resp.Data[1]["current_chapter"])
+			assert.Nil(t, resp.Data[1]["reading_status"])
+		}
+	})
+
+	t.Run("RequiresLibraryScope", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/search?q=naruto&with_user_context=true", nil)
+		w := httptest.NewRecorder()
+		newRouter("read:manga").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("RejectsFields", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/search?q=naruto&with_user_context=true&fields=id", nil)
+		w := httptest.NewRecorder()
+		newRouter("read:manga", "read:library").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"with_user_context"`)
+	})
+
+	mockService.AssertExpectations(t)
 }
 
 func TestMangaHandler_AdvancedSearch(t *testing.T) {
@@ -397,7 +681,7 @@ func TestMangaHandler_AdvancedSearch(t *testing.T) {
 	})
 
 	t.Run("Invalid_Enum_Status", func(t *testing.T) {
-		// DTO tag: binding:"omitempty,oneof=ongoing completed hiatus"
+		// Must be one of models.MangaStatuses
 		req, _ := http.NewRequest(http.MethodGet, "/api/manga/advanced-search?status=dropped", nil)
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
@@ -405,3 +689,322 @@ func TestMangaHandler_AdvancedSearch(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 }
+
+func TestMangaHandler_AdvancedSearch_GenreValidation(t *testing.T) {
+	newRouter := func(mangaSvc *MockMangaService, genreSvc *MockGenreService) *gin.Engine {
+		gin.SetMode(gin.TestMode)
+		r := gin.New()
+		h := handler.NewMangaHandler(mangaSvc).WithGenreValidation(genreSvc)
+		r.GET("/api/manga/advanced-search", h.AdvancedSearch)
+		return r
+	}
+
+	t.Run("UnknownGenre", func(t *testing.T) {
+		mangaSvc, genreSvc := new(MockMangaService), new(MockGenreService)
+		genreSvc.On("ResolveGenres", mock.Anything, []string{"isekaii", "fantasy"}, []int64(nil)).
+			Return([]models.Genre{{ID: 2, Name: "Fantasy"}}, []string{"isekaii"}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/advanced-search?genres=isekaii,fantasy", nil)
+		w := httptest.NewRecorder()
+		newRouter(mangaSvc, genreSvc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "unknown genres: isekaii")
+		mangaSvc.AssertNotCalled(t, "AdvancedSearch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("SearchesByResolvedIDs", func(t *testing.T) {
+		mangaSvc, genreSvc := new(MockMangaService), new(MockGenreService)
+		genreSvc.On("ResolveGenres", mock.Anything, []string{"Action"}, []int64{7}).
+			Return([]models.Genre{{ID: 1, Name: "Action"}, {ID: 7, Name: "Isekai"}}, []string{}, nil).Once()
+		mangaSvc.On("AdvancedSearch", mock.Anything, mock.MatchedBy(func(f dto.SearchFilters) bool {
+			return len(f.Genres) == 0 && assert.ObjectsAreEqual([]int64{1, 7}, f.GenreIDs)
+		})).Return([]models.Manga{}, int64(0), nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/advanced-search?genres=Action&genre_ids=7", nil)
+		w := httptest.NewRecorder()
+		newRouter(mangaSvc, genreSvc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"genres":["Action"]`)
+		mangaSvc.AssertExpectations(t)
+	})
+
+	t.Run("InvalidGenreID", func(t *testing.T) {
+		mangaSvc, genreSvc := new(MockMangaService), new(MockGenreService)
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/advanced-search?genre_ids=7,isekai", nil)
+		w := httptest.NewRecorder()
+		newRouter(mangaSvc, genreSvc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"genre_ids"`)
+		genreSvc.AssertNotCalled(t, "ResolveGenres", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestMangaHandler_GetByExternalID(t *testing.T) {
+	mockService := new(MockMangaService)
+	r := setupRouter(mockService)
+
+	mangadexID := "a1c7c817-4e59-43b7-9365-09675a149a6f"
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.On("GetByExternalID", mock.Anything, "mangadex", mangadexID).
+			Return(&models.Manga{ID: 7, Title: "One Piece", MangaDexID: stringPtr(mangadexID)}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/external/mangadex/"+mangadexID, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.MangaResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, int64(7), response.ID)
+		assert.Equal(t, mangadexID, *response.MangaDexID)
+	})
+
+	t.Run("NotIngested", func(t *testing.T) {
+		mockService.On("GetByExternalID", mock.Anything, "anilist", "30013").
+			Return(nil, service.ErrMangaNotFound).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/external/anilist/30013", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("UnsupportedSource", func(t *testing.T) {
+		mockService.On("GetByExternalID", mock.Anything, "kitsu", "1").
+			Return(nil, service.ErrUnsupportedSource).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/external/kitsu/1", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestMangaHandler_SyncInfo(t *testing.T) {
+	mockService := new(MockMangaService)
+	r := setupRouter(mockService)
+
+	t.Run("LatestSource", func(t *testing.T) {
+		mangadexSync := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		anilistSync := mangadexSync.Add(time.Hour)
+		mockService.On("GetSyncInfo", mock.Anything, int64(7)).Return(&models.MangaSyncInfo{
+			ID:                       7,
+			MangaDexID:               stringPtr("a1c7c817-4e59-43b7-9365-09675a149a6f"),
+			MangaDexLastSyncedAt:     &mangadexSync,
+			MangaDexLastChapterCheck: &mangadexSync,
+			AniListLastSyncedAt:      &anilistSync,
+		}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/7/sync-info", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]any
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "anilist", response["last_updated_by"])
+		assert.Equal(t, anilistSync.Format(time.RFC3339), response["last_synced_at"])
+		assert.Equal(t, mangadexSync.Format(time.RFC3339), response["last_chapter_check"])
+		assert.Contains(t, response, "anilist_id")
+		assert.Nil(t, response["anilist_id"])
+	})
+
+	t.Run("NeverSynced", func(t *testing.T) {
+		mockService.On("GetSyncInfo", mock.Anything, int64(8)).
+			Return(&models.MangaSyncInfo{ID: 8}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/8/sync-info", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]any
+		json.Unmarshal(w.Body.Bytes(), &response)
+		for _, field := range []string{"mangadex_id", "anilist_id", "last_synced_at", "last_chapter_check", "last_updated_by"} {
+			assert.Contains(t, response, field)
+			assert.Nil(t, response[field], field)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService.On("GetSyncInfo", mock.Anything, int64(999)).
+			Return(nil, service.ErrMangaNotFound).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/999/sync-info", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/manga/abc/sync-info", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestMangaHandler_GetBySlugs(t *testing.T) {
+	mockService := new(MockMangaService)
+	r := setupRouter(mockService)
+
+	post := func(body any) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req, _ := http.NewRequest(http.MethodPost, "/api/manga/slugs", bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("ReportsMissingSlugs", func(t *testing.T) {
+		slugs := []string{"one-piece", "gone", "berserk"}
+		mockService.On("GetBySlugs", mock.Anything, slugs).Return([]models.Manga{
+			{ID: 1, Title: "One Piece", Slug: stringPtr("one-piece")},
+			{ID: 2, Title: "Berserk", Slug: stringPtr("berserk")},
+		}, []string{"gone"}, nil).Once()
+
+		w := post(gin.H{"slugs": slugs})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response dto.MangaBySlugsResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, "one-piece", *response.Data[0].Slug)
+		assert.Equal(t, []string{"gone"}, response.NotFound)
+	})
+
+	t.Run("EmptyList", func(t *testing.T) {
+		w := post(gin.H{"slugs": []string{}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("TooMany", func(t *testing.T) {
+		slugs := make([]string, 101)
+		for i := range slugs {
+			slugs[i] = fmt.Sprintf("manga-%d", i)
+		}
+		w := post(gin.H{"slugs": slugs})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	mockService.AssertExpectations(t)
+}
+
+func TestMangaHandler_Export(t *testing.T) {
+	t.Run("StreamsOneMangaPerLine", func(t *testing.T) {
+		mockService := new(MockMangaService)
+		r := setupRouter(mockService)
+		mangadexID := "a1b2c3d4-0000-0000-0000-000000000000"
+		batches := [][]models.Manga{
+			{
+				{ID: 1, Title: "One Piece", MangaDexID: &mangadexID, Genres: []models.Genre{{ID: 1, Name: "Action"}}},
+				{ID: 2, Title: "Naruto"},
+			},
+			{{ID: 3, Title: "Bleach"}},
+		}
+		mockService.On("ExportCatalog", mock.Anything, service.DefaultExportBatchSize).Return(batches, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/admin/manga/export", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		assert.Len(t, lines, 3)
+		var first dto.MangaResponse
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+		assert.Equal(t, "One Piece", first.Title)
+		assert.Equal(t, []string{"Action"}, first.Genres)
+		assert.Equal(t, &mangadexID, first.MangaDexID)
+	})
+
+	t.Run("BehindGzipWithGzipClient", func(t *testing.T) {
+		mockService := new(MockMangaService)
+		h := handler.NewMangaHandler(mockService)
+		gin.SetMode(gin.TestMode)
+		r := gin.New()
+		r.Use(middleware.Gzip(0))
+		r.GET("/api/admin/manga/export", h.Export)
+		srv := httptest.NewServer(r)
+		defer srv.Close()
+
+		batch := make([]models.Manga, 0, 50)
+		for i := 1; i <= 50; i++ {
+			batch = append(batch, models.Manga{ID: int64(i), Title: fmt.Sprintf("Manga %d", i)})
+		}
+		mockService.On("ExportCatalog", mock.Anything, service.DefaultExportBatchSize).
+			Return([][]models.Manga{batch, batch}, nil).Once()
+
+		// Ask for gzip explicitly so the transport doesn't hide the encoding
+		client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/admin/manga/export", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		resp, err := client.Do(req)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+		var body io.Reader = resp.Body
+		if resp.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(resp.Body)
+			if !assert.NoError(t, err) {
+				return
+			}
+			body = zr
+		}
+		raw, err := io.ReadAll(body)
+		assert.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+		assert.Len(t, lines, 100)
+		var last dto.MangaResponse
+		assert.NoError(t, json.Unmarshal([]byte(lines[99]), &last))
+		assert.Equal(t, "Manga 50", last.Title)
+	})
+
+	t.Run("FailureBeforeFirstBatch", func(t *testing.T) {
+		mockService := new(MockMangaService)
+		r := setupRouter(mockService)
+		mockService.On("ExportCatalog", mock.Anything, service.DefaultExportBatchSize).
+			Return([][]models.Manga{}, errors.New("connection reset")).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/admin/manga/export", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "connection reset")
+	})
+
+	t.Run("EmptyCatalog", func(t *testing.T) {
+		mockService := new(MockMangaService)
+		r := setupRouter(mockService)
+		mockService.On("ExportCatalog", mock.Anything, service.DefaultExportBatchSize).Return([][]models.Manga{}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/admin/manga/export", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/middleware"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// A user has no reason to file more than a handful of reports a minute
+const (
+	reportRateLimit = 10 // requests per minute
+	reportRateBurst = 5
+)
+
+type MetadataReportHandler struct {
+	svc service.MetadataReportService
+}
+
+func NewMetadataReportHandler(svc service.MetadataReportService) *MetadataReportHandler {
+	return &MetadataReportHandler{svc: svc}
+}
+
+// RegisterRoutes registers the report endpoint under the manga group
+func (h *MetadataReportHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/:manga_id/report",
+		middleware.RequireScopes("read:manga"),
+		middleware.UserWrite(),
+		middleware.RateLimitPerUser(reportRateLimit, time.Minute, reportRateBurst),
+		h.Create,
+	)
+}
+
+// RegisterAdminRoutes registers the review queue under /api/admin
+func (h *MetadataReportHandler) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	rg.GET("/metadata-reports", middleware.RequireScopes("admin:reports"), middleware.RequireAdmin(), h.List)
+	rg.PUT("/metadata-reports/:id", middleware.RequireScopes("admin:reports"), middleware.RequireAdmin(), middleware.CatalogWrite(), h.Close)
+}
+
+// Create handles POST /api/manga/:manga_id/report
+func (h *MetadataReportHandler) Create(c *gin.Context) {
+	mangaID, err := strconv.ParseInt(c.Param("manga_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid manga ID"})
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req dto.CreateMetadataReportRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	report, err := h.svc.Report(ctx, userID.(string), mangaID, req.Field, req.Reason)
+	if err != nil {
+		if errors.Is(err, service.ErrMangaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewMetadataReportResponse(*report))
+}
+
+// List handles GET /api/admin/metadata-reports?status=open. Open reports are
+// listed by default; status=all lists every report.
+func (h *MetadataReportHandler) List(c *gin.Context) {
+	status := c.DefaultQuery("status", models.MetadataReportOpen)
+	switch status {
+	case models.MetadataReportOpen, models.MetadataReportResolved, models.MetadataReportDismissed:
+	case "all":
+		status = ""
+	default:
+		abortWithFieldErrors(c, dto.FieldError{
+			Field:   "status",
+			Rule:    "oneof",
+			Message: "must be one of: open resolved dismissed all",
+		})
+		return
+	}
+
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	reports, total, err := h.svc.List(ctx, status, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.MetadataReportResponse, 0, len(reports))
+	for _, r := range reports {
+		resp = append(resp, dto.NewMetadataReportResponse(r))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       resp,
+		"pagination": dto.NewPagination(page, pageSize, total),
+	})
+}
+
+// Close handles PUT /api/admin/metadata-reports/:id, resolving or dismissing a report
+func (h *MetadataReportHandler) Close(c *gin.Context) {
+	reportID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	var req dto.CloseMetadataReportRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	report, err := h.svc.Close(ctx, reportID, req.Status, c.GetString("userID"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReportNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrReportClosed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewMetadataReportResponse(*report))
+}
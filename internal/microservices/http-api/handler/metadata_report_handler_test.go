@@ -0,0 +1,205 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/handler"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMetadataReportService struct {
+	mock.Mock
+}
+
+func (m *MockMetadataReportService) Report(ctx context.Context, userID string, mangaID int64, field, reason string) (*models.MetadataReport, error) {
+	args := m.Called(ctx, userID, mangaID, field, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MetadataReport), args.Error(1)
+}
+
+func (m *MockMetadataReportService) List(ctx context.Context, status string, page, pageSize int) ([]models.MetadataReport, int64, error) {
+	args := m.Called(ctx, status, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]models.MetadataReport), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockMetadataReportService) Close(ctx context.Context, reportID int64, status, adminID string) (*models.MetadataReport, error) {
+	args := m.Called(ctx, reportID, status, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MetadataReport), args.Error(1)
+}
+
+func metadataReportRouter(svc service.MetadataReportService, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-1")
+		c.Set("role", role)
+		if role == "admin" {
+			c.Set("scopes", []string{"read:*", "admin:*"})
+		} else {
+			c.Set("scopes", []string{"read:manga"})
+		}
+		c.Next()
+	})
+	h := handler.NewMetadataReportHandler(svc)
+	h.RegisterRoutes(r.Group("/api/manga"))
+	h.RegisterAdminRoutes(r.Group("/api/admin"))
+	return r
+}
+
+func TestMetadataReportHandler_Create(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockSvc := new(MockMetadataReportService)
+		mockSvc.On("Report", mock.Anything, "user-1", int64(7), "author", "Wrong author").
+			Return(&models.MetadataReport{ID: 1, MangaID: 7, UserID: "user-1", Field: "author", Reason: "Wrong author", Status: "open"}, nil)
+
+		body, _ := json.Marshal(dto.CreateMetadataReportRequest{Field: "author", Reason: "Wrong author"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/manga/7/report", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		metadataReportRouter(mockSvc, "user").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var resp dto.MetadataReportResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "open", resp.Status)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		mockSvc := new(MockMetadataReportService)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/manga/7/report", bytes.NewBufferString(`{"field":"price","reason":"x"}`))
+		req.Header.Set("Content-Type", "application/json")
+		metadataReportRouter(mockSvc, "user").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "field")
+		mockSvc.AssertNotCalled(t, "Report")
+	})
+
+	t.Run("MangaNotFound", func(t *testing.T) {
+		mockSvc := new(MockMetadataReportService)
+		mockSvc.On("Report", mock.Anything, "user-1", int64(99), "status", "Finished years ago").
+			Return(nil, service.ErrMangaNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/manga/99/report", bytes.NewBufferString(`{"field":"status","reason":"Finished years ago"}`))
+		req.Header.Set("Content-Type", "application/json")
+		metadataReportRouter(mockSvc, "user").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestMetadataReportHandler_List(t *testing.T) {
+	t.Run("DefaultsToOpen", func(t *testing.T) {
+		mockSvc := new(MockMetadataReportService)
+		mockSvc.On("List", mock.Anything, "open", 1, 20).
+			Return([]models.MetadataReport{{ID: 1, Field: "title", Status: "open"}}, int64(1), nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/admin/metadata-reports", nil)
+		metadataReportRouter(mockSvc, "admin").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"total":1`)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("AllStatuses", func(t *testing.T) {
+		mockSvc := new(MockMetadataReportService)
+		mockSvc.On("List", mock.Anything, "", 1, 20).Return([]models.MetadataReport{}, int64(0), nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/admin/metadata-reports?status=all", nil)
+		metadataReportRouter(mockSvc, "admin").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("InvalidStatus", func(t *testing.T) {
+		mockSvc := new(MockMetadataReportService)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/admin/metadata-reports?status=pending", nil)
+		metadataReportRouter(mockSvc, "admin").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "List")
+	})
+
+	t.Run("NonAdminForbidden", func(t *testing.T) {
+		mockSvc := new(MockMetadataReportService)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/admin/metadata-reports", nil)
+		metadataReportRouter(mockSvc, "user").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestMetadataReportHandler_Close(t *testing.T) {
+	t.Run("Resolve", func(t *testing.T) {
+		mockSvc := new(MockMetadataReportService)
+		adminID := "user-1"
+		mockSvc.On("Close", mock.Anything, int64(3), "resolved", "user-1").
+			Return(&models.MetadataReport{ID: 3, Status: "resolved", ResolvedBy: &adminID}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/api/admin/metadata-reports/3", bytes.NewBufferString(`{"status":"resolved"}`))
+		req.Header.Set("Content-Type", "application/json")
+		metadataReportRouter(mockSvc, "admin").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp dto.MetadataReportResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "resolved", resp.Status)
+		assert.Equal(t, "user-1", *resp.ResolvedBy)
+	})
+
+	t.Run("AlreadyClosed", func(t *testing.T) {
+		mockSvc := new(MockMetadataReportService)
+		mockSvc.On("Close", mock.Anything, int64(3), "dismissed", "user-1").Return(nil, service.ErrReportClosed)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/api/admin/metadata-reports/3", bytes.NewBufferString(`{"status":"dismissed"}`))
+		req.Header.Set("Content-Type", "application/json")
+		metadataReportRouter(mockSvc, "admin").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("CannotReopen", func(t *testing.T) {
+		mockSvc := new(MockMetadataReportService)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/api/admin/metadata-reports/3", bytes.NewBufferString(`{"status":"open"}`))
+		req.Header.Set("Content-Type", "application/json")
+		metadataReportRouter(mockSvc, "admin").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "Close")
+	})
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"mangahub/internal/microservices/http-api/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultPage = 1
+
+// parsePagination reads the page and page_size query parameters. Missing
+// values fall back to the defaults and page_size is clamped to
+// dto.MaxPageSize (both configurable via DEFAULT_PAGE_SIZE / MAX_PAGE_SIZE).
+// Anything that isn't a positive integer gets a 400 and ok is false, in which
+// case the handler should return without writing a response.
+func parsePagination(c *gin.Context) (page, pageSize int, ok bool) {
+	page, ok = positiveQueryInt(c, "page", defaultPage)
+	if !ok {
+		return 0, 0, false
+	}
+	pageSize, ok = positiveQueryInt(c, "page_size", dto.DefaultPageSize())
+	if !ok {
+		return 0, 0, false
+	}
+	page, pageSize = dto.NormalizePagination(page, pageSize)
+	return page, pageSize, true
+}
+
+func positiveQueryInt(c *gin.Context, key string, def int) (int, bool) {
+	raw := strings.TrimSpace(c.Query(key))
+	if raw == "" {
+		return def, true
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		abortWithFieldErrors(c, dto.FieldError{Field: key, Rule: "min", Message: "must be a positive integer"})
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mangahub/internal/microservices/http-api/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		query    string
+		page     int
+		pageSize int
+		ok       bool
+	}{
+		{"defaults", "", 1, 20, true},
+		{"explicit", "?page=3&page_size=50", 3, 50, true},
+		{"over max page_size is clamped", "?page_size=1000", 1, 100, true},
+		{"max page_size", "?page_size=100", 1, 100, true},
+		{"zero page", "?page=0", 0, 0, false},
+		{"negative page", "?page=-1", 0, 0, false},
+		{"zero page_size", "?page_size=0", 0, 0, false},
+		{"negative page_size", "?page_size=-20", 0, 0, false},
+		{"not a number", "?page=two", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			page, pageSize, ok := parsePagination(c)
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.page, page)
+			assert.Equal(t, tt.pageSize, pageSize)
+			if !tt.ok {
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+			}
+		})
+	}
+}
+
+func TestParsePagination_ConfiguredLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dto.SetPageSizeLimits(10, 50)
+	t.Cleanup(func() { dto.SetPageSizeLimits(20, 100) })
+
+	tests := []struct {
+		query    string
+		pageSize int
+	}{
+		{"", 10},
+		{"?page_size=30", 30},
+		{"?page_size=80", 50},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+		_, pageSize, ok := parsePagination(c)
+
+		assert.True(t, ok)
+		assert.Equal(t, tt.pageSize, pageSize, tt.query)
+	}
+}
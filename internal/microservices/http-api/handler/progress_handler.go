@@ -2,11 +2,15 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"mangahub/internal/microservices/http-api/dto"
 	"mangahub/internal/microservices/http-api/middleware"
 	"mangahub/internal/microservices/http-api/models"
 	"mangahub/internal/microservices/http-api/service"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -28,6 +32,12 @@ func (h *ProgressHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	rg.DELETE("/:manga_id", middleware.RequireScopes("write:progress"), h.DeleteProgress)
 }
 
+// RegisterMangaRoutes registers the progress routes that live under a manga,
+// e.g. GET /api/manga/:manga_id/my-rank
+func (h *ProgressHandler) RegisterMangaRoutes(rg *gin.RouterGroup) {
+	rg.GET("/:manga_id/my-rank", middleware.RequireScopes("read:progress"), h.MyRank)
+}
+
 func (h *ProgressHandler) GetAllProgress(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
@@ -119,18 +129,74 @@ func (h *ProgressHandler) UpdateProgress(c *gin.Context) {
 	c.JSON(http.StatusOK, res)
 }
 
+// DeleteProgress clears the caller's progress on a manga so they can
+// re-read it from scratch. Only the caller's own progress is ever touched.
+// DELETE /api/progress/:manga_id
 func (h *ProgressHandler) DeleteProgress(c *gin.Context) {
-	var req dto.DeleteProgressRequest
-	if err := c.ShouldBindUri(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+	mangaID, err := strconv.ParseInt(c.Param("manga_id"), 10, 64)
+	if err != nil || mangaID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga_id"})
 		return
 	}
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	if err := h.progressService.DeleteProgress(ctx, req.UserID, req.MangaID); err != nil {
+	err = h.progressService.DeleteProgress(ctx, userID.(string), mangaID)
+	if errors.Is(err, service.ErrProgressNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no progress recorded for this manga"})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "progress deleted"})
+	c.Status(http.StatusNoContent)
+}
+
+// MyRank reports how far the caller has read compared to the manga's other readers
+// GET /api/manga/:manga_id/my-rank
+func (h *ProgressHandler) MyRank(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+	mangaID, err := strconv.ParseInt(c.Param("manga_id"), 10, 64)
+	if err != nil || mangaID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	rank, err := h.progressService.GetReaderRank(ctx, userID.(string), mangaID)
+	if errors.Is(err, service.ErrProgressNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no reading progress for this manga"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	res := dto.ReaderRankResponse{
+		MangaID: mangaID,
+		Chapter: rank.Chapter,
+		Readers: rank.Readers,
+	}
+	if rank.Readers < service.MinReadersToRank {
+		res.Message = "Not enough readers to rank you yet, check back once more people are reading"
+	} else {
+		percent := int(math.Round(rank.PercentRank * 100))
+		res.Ranked = true
+		res.AheadOfPercent = &percent
+		res.Message = fmt.Sprintf("You're ahead of %d%% of readers", percent)
+	}
+	c.JSON(http.StatusOK, res)
 }
@@ -0,0 +1,101 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mangahub/internal/microservices/http-api/handler"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProgressService struct {
+	mock.Mock
+}
+
+func (m *MockProgressService) GetAllProgress(ctx context.Context, userID string) (*[]models.UserProgress, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]models.UserProgress), args.Error(1)
+}
+
+func (m *MockProgressService) GetProgressByMangaID(ctx context.Context, userID string, mangaID int64) (*models.UserProgress, error) {
+	args := m.Called(ctx, userID, mangaID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserProgress), args.Error(1)
+}
+
+func (m *MockProgressService) UpdateProgress(ctx context.Context, progress *models.UserProgress) error {
+	return m.Called(ctx, progress).Error(0)
+}
+
+func (m *MockProgressService) DeleteProgress(ctx context.Context, userID string, mangaID int64) error {
+	return m.Called(ctx, userID, mangaID).Error(0)
+}
+
+func (m *MockProgressService) GetReaderRank(ctx context.Context, userID string, mangaID int64) (*repository.ReaderRank, error) {
+	args := m.Called(ctx, userID, mangaID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.ReaderRank), args.Error(1)
+}
+
+func progressRouter(svc service.ProgressService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-1")
+		c.Set("scopes", []string{"read:progress", "write:progress"})
+		c.Next()
+	})
+	handler.NewProgressHandler(svc).RegisterRoutes(r.Group("/api/progress"))
+	return r
+}
+
+func TestProgressHandler_DeleteProgress(t *testing.T) {
+	t.Run("Cleared", func(t *testing.T) {
+		mockSvc := new(MockProgressService)
+		mockSvc.On("DeleteProgress", mock.Anything, "user-1", int64(7)).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/api/progress/7", nil)
+		progressRouter(mockSvc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("NoProgress", func(t *testing.T) {
+		mockSvc := new(MockProgressService)
+		mockSvc.On("DeleteProgress", mock.Anything, "user-1", int64(7)).Return(service.ErrProgressNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/api/progress/7", nil)
+		progressRouter(mockSvc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("InvalidMangaID", func(t *testing.T) {
+		mockSvc := new(MockProgressService)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/api/progress/abc", nil)
+		progressRouter(mockSvc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "DeleteProgress")
+	})
+}
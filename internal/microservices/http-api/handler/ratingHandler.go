@@ -3,8 +3,10 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/middleware"
 	"mangahub/internal/microservices/http-api/service"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +14,7 @@ import (
 
 type RatingHandler struct {
 	ratingService service.RatingService
+	createLimit   gin.HandlerFunc // optional; nil leaves rating unthrottled
 }
 
 func NewRatingHandler(ratingService service.RatingService) *RatingHandler {
@@ -20,19 +23,36 @@ func NewRatingHandler(ratingService service.RatingService) *RatingHandler {
 	}
 }
 
+// WithCreateRateLimit limits each user to limit ratings a minute with bursts
+// of up to burst; a limit of 0 leaves rating unthrottled. Call it before
+// RegisterRoutes.
+func (h *RatingHandler) WithCreateRateLimit(limit, burst int) *RatingHandler {
+	if limit > 0 {
+		h.createLimit = middleware.RateLimitPerUser(limit, time.Minute, burst)
+	}
+	return h
+}
+
 // RegisterRoutes registers rating-related routes
 func (h *RatingHandler) RegisterRoutes(router *gin.RouterGroup) {
-	ratings := router.Group("/:manga_id/ratings")
+	rate := []gin.HandlerFunc{h.CreateOrUpdate}
+	if h.createLimit != nil {
+		rate = append([]gin.HandlerFunc{h.createLimit}, rate...)
+	}
+
+	ratings := router.Group("/:manga_id/ratings", middleware.UserWrite())
 	{
 		// Public routes (no additional middleware needed - read access already through parent middleware)
 		ratings.GET("", h.List)               // Get all ratings for a manga
 		ratings.GET("/average", h.GetAverage) // Get average rating and count
 
 		// Write routes (already authenticated by parent middleware)
-		ratings.POST("", h.CreateOrUpdate)  // Create or update user's rating
+		ratings.POST("", rate...)           // Create or update user's rating
 		ratings.GET("/me", h.GetUserRating) // Get current user's rating
 		ratings.DELETE("", h.Delete)        // Delete user's rating
 	}
+
+	router.POST("/ratings/averages", h.GetAverages) // Averages for several manga at once
 }
 
 // CreateOrUpdate creates or updates a rating for a manga
@@ -52,8 +72,7 @@ func (h *RatingHandler) CreateOrUpdate(c *gin.Context) {
 	}
 
 	var req dto.CreateRatingDTO
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -134,14 +153,9 @@ func (h *RatingHandler) List(c *gin.Context) {
 		return
 	}
 
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
-
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
 	}
 
 	ratings, err := h.ratingService.GetMangaRatings(mangaID, page, pageSize)
@@ -181,3 +195,20 @@ func (h *RatingHandler) GetAverage(c *gin.Context) {
 		"total_ratings":  count,
 	})
 }
+
+// GetAverages retrieves the average rating and count for several manga
+// POST /api/manga/ratings/averages
+func (h *RatingHandler) GetAverages(c *gin.Context) {
+	var req dto.RatingAveragesRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	averages, err := h.ratingService.GetAverageRatings(req.MangaIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RatingAveragesResponse{Averages: averages})
+}
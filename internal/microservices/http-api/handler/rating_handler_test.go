@@ -1,13 +1,160 @@
 package handler_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/handler"
+
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+type MockRatingService struct {
+	mock.Mock
+}
+
+func (m *MockRatingService) CreateOrUpdateRating(userID string, mangaID int64, ratingValue int) (*dto.RatingResponse, error) {
+	args := m.Called(userID, mangaID, ratingValue)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.RatingResponse), args.Error(1)
+}
+
+func (m *MockRatingService) DeleteRating(userID string, mangaID int64) error {
+	return m.Called(userID, mangaID).Error(0)
+}
+
+func (m *MockRatingService) GetUserRating(userID string, mangaID int64) (*dto.UserRatingResponse, error) {
+	args := m.Called(userID, mangaID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UserRatingResponse), args.Error(1)
+}
+
+func (m *MockRatingService) GetMangaRatings(mangaID int64, page, pageSize int) (*dto.PaginatedRatingResponse, error) {
+	args := m.Called(mangaID, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.PaginatedRatingResponse), args.Error(1)
+}
+
+func (m *MockRatingService) GetMangaAverageRating(mangaID int64) (float64, int64, error) {
+	args := m.Called(mangaID)
+	return args.Get(0).(float64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRatingService) GetAverageRatings(mangaIDs []int64) (map[int64]dto.RatingAverage, error) {
+	args := m.Called(mangaIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]dto.RatingAverage), args.Error(1)
+}
+
+func ratingRouter(svc *MockRatingService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-1")
+		c.Next()
+	})
+	handler.NewRatingHandler(svc).RegisterRoutes(r.Group("/api/manga"))
+	return r
+}
+
 func TestRatingHandlerStructure(t *testing.T) {
 	t.Run("HandlerExists", func(t *testing.T) {
 		assert.NotNil(t, "rating handler")
 	})
 }
+
+func TestRatingHandler_GetAverages(t *testing.T) {
+	post := func(r *gin.Engine, body any) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/api/manga/ratings/averages", bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		svc := new(MockRatingService)
+		svc.On("GetAverageRatings", []int64{1, 2}).Return(map[int64]dto.RatingAverage{
+			1: {Average: 8.5, Count: 4},
+			2: {Average: 0, Count: 0},
+		}, nil)
+
+		w := post(ratingRouter(svc), gin.H{"manga_ids": []int64{1, 2}})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Averages map[string]dto.RatingAverage `json:"averages"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, dto.RatingAverage{Average: 8.5, Count: 4}, resp.Averages["1"])
+		assert.Equal(t, int64(0), resp.Averages["2"].Count)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("EmptyList", func(t *testing.T) {
+		svc := new(MockRatingService)
+		w := post(ratingRouter(svc), gin.H{"manga_ids": []int64{}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		svc.AssertNotCalled(t, "GetAverageRatings", mock.Anything)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		svc := new(MockRatingService)
+		w := post(ratingRouter(svc), gin.H{"manga_ids": []int64{1, 0}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		svc.AssertNotCalled(t, "GetAverageRatings", mock.Anything)
+	})
+
+	t.Run("TooMany", func(t *testing.T) {
+		svc := new(MockRatingService)
+		ids := make([]int64, 101)
+		for i := range ids {
+			ids[i] = int64(i + 1)
+		}
+		w := post(ratingRouter(svc), gin.H{"manga_ids": ids})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		svc.AssertNotCalled(t, "GetAverageRatings", mock.Anything)
+	})
+}
+
+func TestRatingHandler_CreateRateLimited(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := new(MockRatingService)
+	svc.On("CreateOrUpdateRating", "user-1", int64(7), 8).Return(&dto.RatingResponse{Rating: 8}, nil).Once()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-1")
+		c.Next()
+	})
+	handler.NewRatingHandler(svc).WithCreateRateLimit(1, 1).RegisterRoutes(r.Group("/api/manga"))
+
+	post := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/manga/7/ratings", bytes.NewReader([]byte(`{"rating":8}`)))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.NotEqual(t, http.StatusTooManyRequests, post().Code)
+	w := post()
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	svc.AssertExpectations(t)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/middleware"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecentlyViewedHandler struct {
+	svc service.RecentlyViewedService
+}
+
+func NewRecentlyViewedHandler(svc service.RecentlyViewedService) *RecentlyViewedHandler {
+	return &RecentlyViewedHandler{svc: svc}
+}
+
+// RegisterRoutes registers the recently viewed list under /api/users
+func (h *RecentlyViewedHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/me/recently-viewed", middleware.RequireScopes("read:manga"), h.List)
+}
+
+// List handles GET /api/users/me/recently-viewed
+func (h *RecentlyViewedHandler) List(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	if h.svc == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "recently viewed is unavailable"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.svc.List(ctx, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.MangaBasicResponse, 0, len(list))
+	for _, m := range list {
+		resp = append(resp, dto.FromModelToBasicResponse(m))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data":  resp,
+		"total": len(resp),
+	})
+}
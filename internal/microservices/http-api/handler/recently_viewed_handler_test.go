@@ -0,0 +1,117 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mangahub/internal/microservices/http-api/handler"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRecentlyViewedService struct {
+	mock.Mock
+}
+
+func (m *MockRecentlyViewedService) RecordView(userID string, mangaID int64) {
+	m.Called(userID, mangaID)
+}
+
+func (m *MockRecentlyViewedService) List(ctx context.Context, userID string) ([]models.Manga, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Manga), args.Error(1)
+}
+
+func withUser(userID string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Set("scopes", []string{"read:manga"})
+		c.Next()
+	}
+}
+
+func TestMangaHandler_GetRecordsView(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mangaSvc := new(MockMangaService)
+	views := new(MockRecentlyViewedService)
+	mangaSvc.On("GetByID", mock.Anything, int64(5)).Return(&models.Manga{ID: 5, Title: "Vinland Saga"}, nil)
+	views.On("RecordView", "user-1", int64(5)).Return()
+
+	r := gin.New()
+	r.Use(withUser("user-1"))
+	handler.NewMangaHandlerWithViews(mangaSvc, views).RegisterRoutes(r.Group("/api/manga"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/manga/5", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	views.AssertExpectations(t)
+}
+
+func TestMangaHandler_GetNotFoundRecordsNothing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mangaSvc := new(MockMangaService)
+	views := new(MockRecentlyViewedService)
+	mangaSvc.On("GetByID", mock.Anything, int64(404)).Return(nil, service.ErrMangaNotFound)
+
+	r := gin.New()
+	r.Use(withUser("user-1"))
+	handler.NewMangaHandlerWithViews(mangaSvc, views).RegisterRoutes(r.Group("/api/manga"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/manga/404", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	views.AssertNotCalled(t, "RecordView", mock.Anything, mock.Anything)
+}
+
+func TestRecentlyViewedHandler_List(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	views := new(MockRecentlyViewedService)
+	views.On("List", mock.Anything, "user-1").Return([]models.Manga{{ID: 3, Title: "Three"}, {ID: 1, Title: "One"}}, nil)
+
+	r := gin.New()
+	r.Use(withUser("user-1"))
+	handler.NewRecentlyViewedHandler(views).RegisterRoutes(r.Group("/api/users"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/users/me/recently-viewed", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data []struct {
+			ID int64 `json:"id"`
+		} `json:"data"`
+		Total int `json:"total"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Total)
+	assert.Equal(t, int64(3), resp.Data[0].ID)
+}
+
+func TestRecentlyViewedHandler_Unavailable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(withUser("user-1"))
+	handler.NewRecentlyViewedHandler(nil).RegisterRoutes(r.Group("/api/users"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/users/me/recently-viewed", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/middleware"
+	"mangahub/internal/microservices/http-api/service"
+	"mangahub/internal/microservices/websocket"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoomStatsProvider exposes the WebSocket hub's active rooms
+type RoomStatsProvider interface {
+	ActiveRooms() []websocket.RoomStats
+}
+
+type RoomHandler struct {
+	rooms    RoomStatsProvider
+	mangaSvc service.MangaService
+}
+
+func NewRoomHandler(rooms RoomStatsProvider, mangaSvc service.MangaService) *RoomHandler {
+	return &RoomHandler{rooms: rooms, mangaSvc: mangaSvc}
+}
+
+func (h *RoomHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("", middleware.RequireScopes("read:manga"), h.List)
+}
+
+// List handles GET /api/rooms, returning active chat rooms with their member
+// counts and manga titles
+func (h *RoomHandler) List(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	stats := h.rooms.ActiveRooms()
+	rooms := make([]dto.RoomSummary, 0, len(stats))
+	for _, st := range stats {
+		room := dto.RoomSummary{
+			RoomID:      st.RoomID,
+			MangaID:     st.RoomID,
+			MemberCount: st.MemberCount,
+		}
+
+		// Titles are best-effort: clients can join a room for any ID, so a
+		// room without a matching manga is still listed
+		if m, err := h.mangaSvc.GetByID(ctx, st.RoomID); err == nil {
+			room.Title = m.Title
+		}
+		rooms = append(rooms, room)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rooms": rooms, "total": len(rooms)})
+}
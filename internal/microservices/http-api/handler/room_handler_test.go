@@ -0,0 +1,63 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/handler"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeRooms []websocket.RoomStats
+
+func (f fakeRooms) ActiveRooms() []websocket.RoomStats { return f }
+
+func getRooms(t *testing.T, rooms fakeRooms, mangaSvc *MockMangaService) []dto.RoomSummary {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/rooms", handler.NewRoomHandler(rooms, mangaSvc).List)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/rooms", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Rooms []dto.RoomSummary `json:"rooms"`
+		Total int               `json:"total"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, len(response.Rooms), response.Total)
+	return response.Rooms
+}
+
+func TestRoomHandler_List(t *testing.T) {
+	t.Run("WithTitles", func(t *testing.T) {
+		mangaSvc := new(MockMangaService)
+		mangaSvc.On("GetByID", mock.Anything, int64(7)).Return(&models.Manga{ID: 7, Title: "Berserk"}, nil)
+		mangaSvc.On("GetByID", mock.Anything, int64(99)).Return(nil, errors.New("record not found"))
+
+		rooms := getRooms(t, fakeRooms{{RoomID: 7, MemberCount: 3}, {RoomID: 99, MemberCount: 1}}, mangaSvc)
+
+		assert.Equal(t, []dto.RoomSummary{
+			{RoomID: 7, MangaID: 7, Title: "Berserk", MemberCount: 3},
+			{RoomID: 99, MangaID: 99, MemberCount: 1},
+		}, rooms)
+	})
+
+	t.Run("NoActiveRooms", func(t *testing.T) {
+		rooms := getRooms(t, fakeRooms{}, new(MockMangaService))
+		assert.NotNil(t, rooms)
+		assert.Empty(t, rooms)
+	})
+}
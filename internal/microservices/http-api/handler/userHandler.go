@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/middleware"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	svc      service.UserService
+	profiles service.PublicProfileService // optional; nil leaves public profiles unregistered
+}
+
+func NewUserHandler(svc service.UserService) *UserHandler {
+	return &UserHandler{svc: svc}
+}
+
+// WithPublicProfiles serves other users' public profiles at
+// GET /api/users/:username. Call it before RegisterRoutes.
+func (h *UserHandler) WithPublicProfiles(profiles service.PublicProfileService) *UserHandler {
+	h.profiles = profiles
+	return h
+}
+
+// RegisterRoutes registers the profile routes under /api/users
+func (h *UserHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/me", h.Me)
+	rg.PUT("/me/privacy", middleware.RequireScopes("write:profile"), middleware.UserWrite(), h.UpdatePrivacy)
+	if h.profiles != nil {
+		rg.GET("/:username", h.PublicProfile)
+	}
+}
+
+// RegisterAdminRoutes registers the moderation user list under /api/admin
+func (h *UserHandler) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	rg.GET("/users", middleware.RequireScopes("admin:users"), middleware.RequireAdmin(), h.List)
+}
+
+// Me handles GET /api/users/me
+func (h *UserHandler) Me(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	user, err := h.svc.GetProfile(ctx, userID.(string))
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewUserProfileResponse(*user))
+}
+
+// UpdatePrivacy handles PUT /api/users/me/privacy
+func (h *UserHandler) UpdatePrivacy(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req dto.UpdatePrivacyRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if req.ProfilePublic == nil && req.LibraryPublic == nil {
+		abortWithFieldErrors(c, dto.FieldError{Field: "profile_public", Rule: "required_without", Message: "set profile_public or library_public"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	user, err := h.svc.UpdatePrivacy(ctx, userID.(string), req.ProfilePublic, req.LibraryPublic)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewUserProfileResponse(*user))
+}
+
+// PublicProfile handles GET /api/users/:username. A hidden profile is a 403
+// for everyone but its owner.
+func (h *UserHandler) PublicProfile(c *gin.Context) {
+	viewerID, _ := c.Get("userID")
+	viewer, _ := viewerID.(string)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	profile, err := h.profiles.Get(ctx, viewer, c.Param("username"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrProfilePrivate):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPublicProfileResponse(profile.User, profile.RatingCount, profile.RecentComments, profile.LibrarySize,
+		profile.FollowerCount, profile.FollowingCount))
+}
+
+// List handles GET /api/admin/users
+func (h *UserHandler) List(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	users, total, err := h.svc.List(ctx, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.UserProfileResponse, 0, len(users))
+	for _, u := range users {
+		resp = append(resp, dto.NewUserProfileResponse(u))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       resp,
+		"pagination": dto.NewPagination(page, pageSize, total),
+	})
+}
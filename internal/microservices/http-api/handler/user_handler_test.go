@@ -0,0 +1,257 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/handler"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) GetProfile(ctx context.Context, userID string) (*models.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserService) List(ctx context.Context, page, pageSize int) ([]models.User, int64, error) {
+	args := m.Called(ctx, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]models.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserService) UpdatePrivacy(ctx context.Context, userID string, profilePublic, libraryPublic *bool) (*models.User, error) {
+	args := m.Called(ctx, userID, profilePublic, libraryPublic)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+type MockPublicProfileService struct {
+	mock.Mock
+}
+
+func (m *MockPublicProfileService) Get(ctx context.Context, viewerID, username string) (*service.PublicProfile, error) {
+	args := m.Called(ctx, viewerID, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.PublicProfile), args.Error(1)
+}
+
+func userRouter(svc service.UserService, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Set("role", "admin")
+		c.Set("scopes", []string{"admin:*"})
+		c.Next()
+	})
+	h := handler.NewUserHandler(svc)
+	h.RegisterRoutes(r.Group("/api/users"))
+	h.RegisterAdminRoutes(r.Group("/api/admin"))
+	return r
+}
+
+func TestUserHandler_MeIncludesLastLogin(t *testing.T) {
+	mockSvc := new(MockUserService)
+	lastLogin := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockSvc.On("GetProfile", mock.Anything, "user-1").Return(&models.User{
+		ID:          "user-1",
+		Username:    "reader",
+		Password:    "hash",
+		LastLogin:   &lastLogin,
+		LastLoginIP: "203.0.113.7",
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/users/me", nil)
+	userRouter(mockSvc, "user-1").ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "hash")
+
+	var resp dto.UserProfileResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "reader", resp.Username)
+	assert.True(t, lastLogin.Equal(*resp.LastLoginAt))
+	assert.Equal(t, "203.0.113.7", resp.LastLoginIP)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestUserHandler_MeNotFound(t *testing.T) {
+	mockSvc := new(MockUserService)
+	mockSvc.On("GetProfile", mock.Anything, "ghost").Return(nil, service.ErrUserNotFound)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/users/me", nil)
+	userRouter(mockSvc, "ghost").ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUserHandler_AdminList(t *testing.T) {
+	mockSvc := new(MockUserService)
+	mockSvc.On("List", mock.Anything, 2, 10).Return([]models.User{
+		{ID: "user-1", Username: "reader", LastLoginIP: "203.0.113.7"},
+	}, int64(11), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/admin/users?page=2&page_size=10", nil)
+	userRouter(mockSvc, "admin-1").ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data       []dto.UserProfileResponse `json:"data"`
+		Pagination map[string]any            `json:"pagination"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, "203.0.113.7", resp.Data[0].LastLoginIP)
+	assert.Equal(t, float64(2), resp.Pagination["total_pages"])
+	assert.Equal(t, false, resp.Pagination["has_next"])
+	assert.Equal(t, true, resp.Pagination["has_prev"])
+	mockSvc.AssertExpectations(t)
+}
+
+func profileRouter(profiles service.PublicProfileService, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Set("scopes", []string{"read:manga", "write:profile"})
+		c.Next()
+	})
+	handler.NewUserHandler(new(MockUserService)).WithPublicProfiles(profiles).RegisterRoutes(r.Group("/api/users"))
+	return r
+}
+
+func TestUserHandler_PublicProfile(t *testing.T) {
+	joined := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	t.Run("ExcludesPrivateFields", func(t *testing.T) {
+		profiles := new(MockPublicProfileService)
+		size := int64(12)
+		profiles.On("Get", mock.Anything, "viewer-1", "reader").Return(&service.PublicProfile{
+			User: models.User{
+				ID: "user-1", Username: "reader", Email: "reader@example.com", Password: "hash",
+				Role: "admin", LastLoginIP: "203.0.113.7", CreatedAt: joined,
+			},
+			RatingCount: 3,
+			RecentComments: []models.Comment{
+				{ID: 9, MangaID: 4, Content: "great", Manga: models.Manga{Title: "Berserk"}},
+			},
+			LibrarySize:    &size,
+			FollowerCount:  6,
+			FollowingCount: 1,
+		}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/users/reader", nil)
+		profileRouter(profiles, "viewer-1").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		for _, private := range []string{"reader@example.com", "hash", "203.0.113.7", "user-1", "admin"} {
+			assert.NotContains(t, w.Body.String(), private)
+		}
+
+		var resp dto.PublicProfileResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "reader", resp.Username)
+		assert.True(t, joined.Equal(resp.JoinedAt))
+		assert.Equal(t, int64(3), resp.RatingCount)
+		assert.Equal(t, "Berserk", resp.RecentComments[0].MangaTitle)
+		assert.Equal(t, int64(12), *resp.LibrarySize)
+		assert.Equal(t, int64(6), resp.FollowerCount)
+		assert.Equal(t, int64(1), resp.FollowingCount)
+		profiles.AssertExpectations(t)
+	})
+
+	t.Run("HiddenLibrarySize", func(t *testing.T) {
+		profiles := new(MockPublicProfileService)
+		profiles.On("Get", mock.Anything, "viewer-1", "reader").Return(&service.PublicProfile{
+			User: models.User{Username: "reader"},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/users/reader", nil)
+		profileRouter(profiles, "viewer-1").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "library_size")
+		assert.Contains(t, w.Body.String(), `"recent_comments":[]`)
+	})
+
+	t.Run("Private", func(t *testing.T) {
+		profiles := new(MockPublicProfileService)
+		profiles.On("Get", mock.Anything, "viewer-1", "reader").Return(nil, service.ErrProfilePrivate)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/users/reader", nil)
+		profileRouter(profiles, "viewer-1").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		profiles := new(MockPublicProfileService)
+		profiles.On("Get", mock.Anything, "viewer-1", "ghost").Return(nil, service.ErrUserNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/users/ghost", nil)
+		profileRouter(profiles, "viewer-1").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestUserHandler_UpdatePrivacy(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockSvc := new(MockUserService)
+		mockSvc.On("UpdatePrivacy", mock.Anything, "user-1", mock.MatchedBy(func(p *bool) bool { return p != nil && !*p }), (*bool)(nil)).
+			Return(&models.User{ID: "user-1", Username: "reader", ProfilePublic: false}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/api/users/me/privacy", strings.NewReader(`{"profile_public":false}`))
+		req.Header.Set("Content-Type", "application/json")
+		userRouter(mockSvc, "user-1").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"profile_public":false`)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("EmptyBody", func(t *testing.T) {
+		mockSvc := new(MockUserService)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/api/users/me/privacy", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		userRouter(mockSvc, "user-1").ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "UpdatePrivacy", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
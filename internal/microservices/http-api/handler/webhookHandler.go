@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/middleware"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	svc service.WebhookService
+}
+
+func NewWebhookHandler(svc service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{svc: svc}
+}
+
+// RegisterRoutes registers the current user's webhooks under /api/webhooks
+func (h *WebhookHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("", h.List)
+	rg.GET("/:id", h.Get)
+	rg.POST("", middleware.RequireScopes("write:profile"), middleware.UserWrite(), h.Create)
+	rg.PUT("/:id", middleware.RequireScopes("write:profile"), middleware.UserWrite(), h.Update)
+	rg.DELETE("/:id", middleware.RequireScopes("write:profile"), middleware.UserWrite(), h.Delete)
+}
+
+// Create handles POST /api/webhooks. The response is the only one that
+// includes the signing secret.
+func (h *WebhookHandler) Create(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var req dto.CreateWebhookRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	webhook, err := h.svc.Create(ctx, userID.(string), req.URL, req.Events, req.Secret)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CreatedWebhookResponse{
+		WebhookResponse: dto.NewWebhookResponse(*webhook),
+		Secret:          webhook.Secret,
+	})
+}
+
+// List handles GET /api/webhooks
+func (h *WebhookHandler) List(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	webhooks, err := h.svc.List(ctx, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.WebhookResponse, 0, len(webhooks))
+	for _, w := range webhooks {
+		resp = append(resp, dto.NewWebhookResponse(w))
+	}
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
+// Get handles GET /api/webhooks/:id
+func (h *WebhookHandler) Get(c *gin.Context) {
+	userID, id, ok := webhookTarget(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	webhook, err := h.svc.Get(ctx, userID, id)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewWebhookResponse(*webhook))
+}
+
+// Update handles PUT /api/webhooks/:id. Setting active back to true clears
+// the failure count of a webhook disabled for failing.
+func (h *WebhookHandler) Update(c *gin.Context) {
+	userID, id, ok := webhookTarget(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	webhook, err := h.svc.Update(ctx, userID, id, service.WebhookChanges{URL: req.URL, Events: req.Events, Active: req.Active})
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewWebhookResponse(*webhook))
+}
+
+// Delete handles DELETE /api/webhooks/:id
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	userID, id, ok := webhookTarget(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.svc.Delete(ctx, userID, id); err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// webhookTarget reads the caller and the :id parameter, writing the error
+// response if either is missing or invalid
+func webhookTarget(c *gin.Context) (userID string, id int64, ok bool) {
+	uid, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return "", 0, false
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook id"})
+		return "", 0, false
+	}
+	return uid.(string), id, true
+}
+
+func writeWebhookError(c *gin.Context, err error) {
+	var fieldErr *service.WebhookFieldError
+	switch {
+	case errors.As(err, &fieldErr):
+		abortWithFieldErrors(c, dto.FieldError{Field: fieldErr.Field, Rule: fieldErr.Rule, Message: fieldErr.Message})
+	case errors.Is(err, service.ErrWebhookNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTooManyWebhooks):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
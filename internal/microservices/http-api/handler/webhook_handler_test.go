@@ -0,0 +1,154 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/handler"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) Create(ctx context.Context, userID, targetURL string, events []string, secret string) (*models.Webhook, error) {
+	args := m.Called(ctx, userID, targetURL, events, secret)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookService) List(ctx context.Context, userID string) ([]models.Webhook, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookService) Get(ctx context.Context, userID string, id int64) (*models.Webhook, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookService) Update(ctx context.Context, userID string, id int64, changes service.WebhookChanges) (*models.Webhook, error) {
+	args := m.Called(ctx, userID, id, changes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookService) Delete(ctx context.Context, userID string, id int64) error {
+	return m.Called(ctx, userID, id).Error(0)
+}
+
+func webhookRouter(svc service.WebhookService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-1")
+		c.Set("scopes", []string{"write:profile"})
+		c.Next()
+	})
+	handler.NewWebhookHandler(svc).RegisterRoutes(r.Group("/api/webhooks"))
+	return r
+}
+
+func TestWebhookHandler_Create(t *testing.T) {
+	svc := new(MockWebhookService)
+	svc.On("Create", mock.Anything, "user-1", "https://example.com/hook", []string{"NEW_CHAPTER"}, "").
+		Return(&models.Webhook{ID: 7, URL: "https://example.com/hook", Events: "NEW_CHAPTER", Secret: "s3cret", Active: true}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/webhooks", bytes.NewBufferString(`{"url":"https://example.com/hook","events":["NEW_CHAPTER"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	webhookRouter(svc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp dto.CreatedWebhookResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "s3cret", resp.Secret)
+	assert.Equal(t, []string{"NEW_CHAPTER"}, resp.Events)
+	svc.AssertExpectations(t)
+}
+
+func TestWebhookHandler_SecretNotListed(t *testing.T) {
+	svc := new(MockWebhookService)
+	svc.On("List", mock.Anything, "user-1").Return([]models.Webhook{{ID: 7, URL: "https://example.com/hook", Events: "NEW_CHAPTER", Secret: "s3cret"}}, nil)
+	svc.On("Get", mock.Anything, "user-1", int64(7)).Return(&models.Webhook{ID: 7, Secret: "s3cret"}, nil)
+
+	for _, path := range []string{"/api/webhooks", "/api/webhooks/7"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		webhookRouter(svc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "s3cret")
+	}
+	svc.AssertExpectations(t)
+}
+
+func TestWebhookHandler_Errors(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"Invalid", &service.WebhookFieldError{Field: "url", Rule: "url", Message: "must be an absolute http or https URL"}, http.StatusBadRequest},
+		{"NotFound", service.ErrWebhookNotFound, http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := new(MockWebhookService)
+			svc.On("Update", mock.Anything, "user-1", int64(7), mock.Anything).Return(nil, tc.err)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/api/webhooks/7", bytes.NewBufferString(`{"url":"nope"}`))
+			req.Header.Set("Content-Type", "application/json")
+			webhookRouter(svc).ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+			svc.AssertExpectations(t)
+		})
+	}
+
+	t.Run("TooMany", func(t *testing.T) {
+		svc := new(MockWebhookService)
+		svc.On("Create", mock.Anything, "user-1", mock.Anything, mock.Anything, mock.Anything).Return(nil, service.ErrTooManyWebhooks)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/webhooks", bytes.NewBufferString(`{"url":"https://example.com","events":["NEW_MANGA"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		webhookRouter(svc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		svc := new(MockWebhookService)
+		svc.On("Delete", mock.Anything, "user-1", int64(7)).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/api/webhooks/7", nil)
+		webhookRouter(svc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
@@ -28,25 +28,49 @@ func AuthMiddleware(authService service.AuthService) gin.HandlerFunc {
 			return
 		}
 
-		tokenString := parts[1]
+		authenticate(c, authService, parts[1])
+	}
+}
 
-		// Validate token
-		claims, err := authService.ValidateToken(tokenString)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
-			c.Abort()
+// WSAuthMiddleware authenticates WebSocket handshakes. Browsers can't set
+// headers on a WebSocket connection, so when there is no Authorization header
+// the token may be passed as a ?token= query parameter instead.
+func WSAuthMiddleware(authService service.AuthService) gin.HandlerFunc {
+	header := AuthMiddleware(authService)
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "" {
+			header(c)
 			return
 		}
 
-		// Set user info in context for handlers to use
-		c.Set("claims", claims)
-		c.Set("userID", claims.UserID)
-		c.Set("email", claims.Email)
-		c.Set("scopes", claims.Scopes)
-		c.Set("role", claims.Role)
+		tokenString := c.Query("token")
+		if tokenString == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authorization header or token parameter"})
+			c.Abort()
+			return
+		}
+		authenticate(c, authService, tokenString)
+	}
+}
 
-		c.Next()
+// authenticate validates tokenString and stores its claims on the context
+func authenticate(c *gin.Context, authService service.AuthService, tokenString string) {
+	// Validate token
+	claims, err := authService.ValidateToken(tokenString)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+		c.Abort()
+		return
 	}
+
+	// Set user info in context for handlers to use
+	c.Set("claims", claims)
+	c.Set("userID", claims.UserID)
+	c.Set("email", claims.Email)
+	c.Set("scopes", claims.Scopes)
+	c.Set("role", claims.Role)
+
+	c.Next()
 }
 
 // All under here are scope-related middlewares use in route protection
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"mangahub/internal/microservices/http-api/service"
+)
+
+// stubAuthService accepts only the token "good"
+type stubAuthService struct {
+	service.AuthService
+}
+
+func (stubAuthService) ValidateToken(tokenString string) (*service.Claims, error) {
+	if tokenString != "good" {
+		return nil, errors.New("invalid token")
+	}
+	return &service.Claims{UserID: "user-1"}, nil
+}
+
+func TestWSAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ws", WSAuthMiddleware(stubAuthService{}), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("userID"))
+	})
+
+	tests := []struct {
+		name   string
+		url    string
+		header string
+		status int
+	}{
+		{"header token", "/ws", "Bearer good", http.StatusOK},
+		{"query token", "/ws?token=good", "", http.StatusOK},
+		{"invalid query token", "/ws?token=bad", "", http.StatusUnauthorized},
+		{"header takes precedence", "/ws?token=good", "Bearer bad", http.StatusUnauthorized},
+		{"no credentials", "/ws", "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, "user-1", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NoStore is the Cache-Control value for user-specific responses
+const NoStore = "private, no-store"
+
+// DefaultCatalogMaxAge is how long clients may reuse a catalog response
+const DefaultCatalogMaxAge = 30 * time.Second
+
+var catalogMaxAge atomic.Int64
+
+func init() {
+	catalogMaxAge.Store(int64(DefaultCatalogMaxAge))
+}
+
+// SetCatalogMaxAge sets the max-age used by CatalogCache; zero turns catalog
+// caching off
+func SetCatalogMaxAge(d time.Duration) {
+	catalogMaxAge.Store(int64(d))
+}
+
+// CacheControl sets the Cache-Control header of every response that the
+// handler doesn't set itself. Error responses are never made cacheable.
+func CacheControl(value string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", value)
+		if value != NoStore {
+			c.Writer = &cacheControlWriter{ResponseWriter: c.Writer}
+		}
+		c.Next()
+	}
+}
+
+// CatalogCache lets clients briefly reuse catalog reads (manga lists, search,
+// genres) that look the same to every user. Responses stay private because
+// every /api request carries the caller's token.
+func CatalogCache() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		maxAge := time.Duration(catalogMaxAge.Load())
+		if maxAge <= 0 {
+			c.Header("Cache-Control", NoStore)
+			c.Next()
+			return
+		}
+		CacheControl(fmt.Sprintf("private, max-age=%d", int(maxAge.Seconds())))(c)
+	}
+}
+
+// cacheControlWriter downgrades the header to NoStore on error statuses
+type cacheControlWriter struct {
+	gin.ResponseWriter
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	if code >= http.StatusBadRequest {
+		w.Header().Set("Cache-Control", NoStore)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
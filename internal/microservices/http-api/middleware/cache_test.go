@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func cacheRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	api := r.Group("/api", CacheControl(NoStore))
+	api.GET("/library", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+	api.GET("/manga", CatalogCache(), func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+	api.GET("/manga/missing", CatalogCache(), func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	})
+	return r
+}
+
+func TestCacheControl(t *testing.T) {
+	SetCatalogMaxAge(time.Minute)
+	t.Cleanup(func() { SetCatalogMaxAge(DefaultCatalogMaxAge) })
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/api/library", NoStore},
+		{"/api/manga", "private, max-age=60"},
+		{"/api/manga/missing", NoStore},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		cacheRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		assert.Equal(t, tt.want, w.Header().Get("Cache-Control"), tt.path)
+	}
+}
+
+func TestCatalogCache_Disabled(t *testing.T) {
+	SetCatalogMaxAge(0)
+	t.Cleanup(func() { SetCatalogMaxAge(DefaultCatalogMaxAge) })
+
+	w := httptest.NewRecorder()
+	cacheRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/manga", nil))
+	assert.Equal(t, NoStore, w.Header().Get("Cache-Control"))
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// CORSPolicy splits cross-origin access by route group. Authenticated origins
+// (the first-party frontends) may call every route with credentials. Public
+// origins may only read the route groups under PublicPrefixes, without
+// credentials, e.g. a public site listing the catalog.
+type CORSPolicy struct {
+	AuthenticatedOrigins []string
+	PublicOrigins        []string // Empty disables the public policy
+	PublicPrefixes       []string // e.g. /api/manga, /api/genres
+}
+
+// CORS applies policy. It runs on the engine rather than on each group
+// because preflight OPTIONS requests never match a registered route, so it
+// picks the group's rules from the request path itself.
+func CORS(policy CORSPolicy) gin.HandlerFunc {
+	authenticated := cors.New(cors.Config{
+		AllowOrigins:     policy.AuthenticatedOrigins,
+		AllowMethods:     []string{"GET", "HEAD", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		ExposeHeaders:    []string{"Content-Length"},
+		AllowCredentials: true, // allow cookies, authorization headers with CORS requests
+		MaxAge:           12 * time.Hour,
+	})
+	if len(policy.PublicOrigins) == 0 {
+		return authenticated
+	}
+
+	public := cors.New(cors.Config{
+		AllowOrigins:  policy.PublicOrigins,
+		AllowMethods:  []string{"GET", "HEAD", "OPTIONS"},
+		AllowHeaders:  []string{"Origin", "Authorization"},
+		ExposeHeaders: []string{"Content-Length"},
+		MaxAge:        12 * time.Hour,
+	})
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if !slices.Contains(policy.AuthenticatedOrigins, origin) && policy.isPublicRead(c.Request) {
+			public(c)
+			return
+		}
+		authenticated(c)
+	}
+}
+
+// isPublicRead reports whether r reads a public route group. For a preflight
+// the method it asks about is checked instead of OPTIONS.
+func (p CORSPolicy) isPublicRead(r *http.Request) bool {
+	method := r.Method
+	if method == http.MethodOptions {
+		method = r.Header.Get("Access-Control-Request-Method")
+	}
+	if !isReadMethod(method) {
+		return false
+	}
+	for _, prefix := range p.PublicPrefixes {
+		if r.URL.Path == prefix || strings.HasPrefix(r.URL.Path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func corsRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(CORS(CORSPolicy{
+		AuthenticatedOrigins: []string{"https://app.example.com"},
+		PublicOrigins:        []string{"https://site.example.com"},
+		PublicPrefixes:       []string{"/api/manga"},
+	}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/api/manga", ok)
+	r.POST("/api/manga/:manga_id/comments", ok)
+	r.GET("/api/library", ok)
+	return r
+}
+
+func corsRequest(r *gin.Engine, method, path, origin, requestMethod string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Origin", origin)
+	if requestMethod != "" {
+		req.Header.Set("Access-Control-Request-Method", requestMethod)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORS_AuthenticatedOrigin(t *testing.T) {
+	r := corsRouter()
+
+	for _, path := range []string{"/api/manga", "/api/library"} {
+		w := corsRequest(r, http.MethodGet, path, "https://app.example.com", "")
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"), path)
+		assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"), path)
+	}
+
+	w := corsRequest(r, http.MethodOptions, "/api/manga/1/comments", "https://app.example.com", http.MethodPost)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), "POST")
+}
+
+func TestCORS_PublicOrigin(t *testing.T) {
+	r := corsRouter()
+
+	t.Run("CatalogRead", func(t *testing.T) {
+		w := corsRequest(r, http.MethodGet, "/api/manga", "https://site.example.com", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "https://site.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("CatalogPreflight", func(t *testing.T) {
+		w := corsRequest(r, http.MethodOptions, "/api/manga", "https://site.example.com", http.MethodGet)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.NotContains(t, w.Header().Get("Access-Control-Allow-Methods"), "POST")
+	})
+
+	t.Run("CatalogWrite", func(t *testing.T) {
+		w := corsRequest(r, http.MethodOptions, "/api/manga/1/comments", "https://site.example.com", http.MethodPost)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("UserRoute", func(t *testing.T) {
+		w := corsRequest(r, http.MethodGet, "/api/library", "https://site.example.com", "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestCORS_UnknownOrigin(t *testing.T) {
+	w := corsRequest(corsRouter(), http.MethodGet, "/api/manga", "https://evil.example.com", "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
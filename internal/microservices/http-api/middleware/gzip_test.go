@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func gzipRouter(body string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Gzip(64))
+	r.GET("/", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"data": body})
+	})
+	return r
+}
+
+func TestGzip_CompressesLargeBodies(t *testing.T) {
+	body := strings.Repeat("manga ", 100)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "br, gzip;q=0.8")
+	gzipRouter(body).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Contains(t, w.Header().Values("Vary"), "Accept-Encoding")
+
+	zr, err := gzip.NewReader(w.Body)
+	assert.NoError(t, err)
+	plain, err := io.ReadAll(zr)
+	assert.NoError(t, err)
+	assert.Contains(t, string(plain), body)
+}
+
+func TestGzip_LeavesSmallBodies(t *testing.T) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	gzipRouter("tiny").ServeHTTP(w, req)
+
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.JSONEq(t, `{"data":"tiny"}`, w.Body.String())
+}
+
+func TestGzip_HonorsAcceptEncoding(t *testing.T) {
+	body := strings.Repeat("manga ", 100)
+
+	for _, header := range []string{"", "identity", "gzip;q=0", "br"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", header)
+		gzipRouter(body).ServeHTTP(w, req)
+
+		assert.Empty(t, w.Header().Get("Content-Encoding"), header)
+		assert.Contains(t, w.Body.String(), body, header)
+	}
+}
+
+func TestGzip_ExposesWriteDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Gzip(64))
+	r.GET("/", func(c *gin.Context) {
+		err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(time.Minute))
+		if err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.String(http.StatusOK, "ok")
+	})
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultClient.Do(req)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+}
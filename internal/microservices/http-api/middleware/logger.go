@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// redactedQueryParams are query parameters that carry credentials and must
+// not reach the access log (WSAuthMiddleware accepts ?token=)
+var redactedQueryParams = []string{"token"}
+
+// Logger is gin's access logger with credentials in the query string
+// replaced by "REDACTED"
+func Logger() gin.HandlerFunc {
+	return gin.LoggerWithConfig(gin.LoggerConfig{Formatter: redactingLogFormatter})
+}
+
+// redactingLogFormatter matches gin's default log line, after redaction
+func redactingLogFormatter(param gin.LogFormatterParams) string {
+	var statusColor, methodColor, resetColor string
+	if param.IsOutputColor() {
+		statusColor = param.StatusCodeColor()
+		methodColor = param.MethodColor()
+		resetColor = param.ResetColor()
+	}
+	if param.Latency > time.Minute {
+		param.Latency = param.Latency.Truncate(time.Second)
+	}
+	return fmt.Sprintf("[GIN] %v |%s %3d %s| %13v | %15s |%s %-7s %s %#v\n%s",
+		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		statusColor, param.StatusCode, resetColor,
+		param.Latency,
+		param.ClientIP,
+		methodColor, param.Method, resetColor,
+		redactPath(param.Path),
+		param.ErrorMessage,
+	)
+}
+
+// redactPath replaces the values of redactedQueryParams in a logged path
+func redactPath(path string) string {
+	base, rawQuery, ok := strings.Cut(path, "?")
+	if !ok {
+		return path
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		// Can't tell where parameters end, so log none of them
+		return base + "?REDACTED"
+	}
+	redacted := false
+	for _, name := range redactedQueryParams {
+		if _, ok := query[name]; ok {
+			query[name] = []string{"REDACTED"}
+			redacted = true
+		}
+	}
+	if !redacted {
+		return path
+	}
+	return base + "?" + query.Encode()
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import "testing"
+
+func TestRedactPath(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"/api/manga", "/api/manga"},
+		{"/api/manga?page=2", "/api/manga?page=2"},
+		{"/ws?token=eyJhbGciOi.payload.sig", "/ws?token=REDACTED"},
+		{"/ws?room=1&token=eyJ", "/ws?room=1&token=REDACTED"},
+		{"/ws?token=%zz", "/ws?REDACTED"},
+	}
+	for _, tt := range tests {
+		if got := redactPath(tt.in); got != tt.want {
+			t.Errorf("redactPath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMaintenanceRetryAfter is the Retry-After hint when none is configured
+const DefaultMaintenanceRetryAfter = 2 * time.Minute
+
+// MaintenanceExemptPaths are the non-GET routes that keep working in
+// maintenance mode: the switch itself, the session endpoints (signing in
+// and out writes only tokens, not catalog or user data) and the POST
+// endpoints that are reads with a body.
+var MaintenanceExemptPaths = []string{
+	"/api/admin/maintenance",
+	"/auth/login",
+	"/auth/refresh",
+	"/auth/revoke",
+	"/api/manga/slugs",
+	"/api/manga/ratings/averages",
+}
+
+// Maintenance is a runtime switch that freezes writes. While enabled,
+// requests other than GET/HEAD/OPTIONS get a 503 with Retry-After; reads
+// keep working. It can be flipped at any time from any goroutine.
+type Maintenance struct {
+	enabled    atomic.Bool
+	retryAfter time.Duration
+}
+
+// NewMaintenance creates the switch in its initial state
+func NewMaintenance(enabled bool, retryAfter time.Duration) *Maintenance {
+	if retryAfter <= 0 {
+		retryAfter = DefaultMaintenanceRetryAfter
+	}
+	m := &Maintenance{retryAfter: retryAfter}
+	m.enabled.Store(enabled)
+	if enabled {
+		log.Println("maintenance mode enabled at startup: API writes will return 503")
+	}
+	return m
+}
+
+// Enabled reports whether writes are currently frozen
+func (m *Maintenance) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// Set turns maintenance mode on or off, logging when it actually flips.
+// source says who flipped it (e.g. "SIGHUP", an admin's user ID).
+func (m *Maintenance) Set(enabled bool, source string) {
+	if m.enabled.Swap(enabled) == enabled {
+		return
+	}
+	if enabled {
+		log.Printf("maintenance mode enabled by %s: API writes will return 503", source)
+	} else {
+		log.Printf("maintenance mode disabled by %s: API writes resumed", source)
+	}
+}
+
+// Middleware rejects writes while maintenance mode is on. Only the exact
+// exemptPaths (usually MaintenanceExemptPaths) are let through, so admin
+// writes such as imports are frozen too.
+func (m *Maintenance) Middleware(exemptPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !m.Enabled() || isReadMethod(c.Request.Method) ||
+			slices.Contains(exemptPaths, strings.TrimSuffix(c.Request.URL.Path, "/")) {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", strconv.Itoa(int(m.retryAfter.Seconds())))
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"error": "service is in maintenance mode, writes are temporarily disabled",
+		})
+	}
+}
+
+func isReadMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func maintenanceRouter(m *Maintenance) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(m.Middleware(MaintenanceExemptPaths...))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.POST("/auth/register", ok)
+	r.POST("/auth/login", ok)
+	r.POST("/auth/refresh", ok)
+	r.POST("/auth/revoke", ok)
+	api := r.Group("/api")
+	api.POST("/manga/slugs", ok)
+	api.POST("/manga/ratings/averages", ok)
+	api.POST("/manga/1/ratings", ok)
+	api.GET("/manga", ok)
+	api.POST("/manga", ok)
+	api.DELETE("/library/1", ok)
+	api.POST("/admin/import", ok)
+	api.PUT("/admin/maintenance", ok)
+	return r
+}
+
+func TestMaintenance_BlocksWritesOnly(t *testing.T) {
+	m := NewMaintenance(true, 90*time.Second)
+	r := maintenanceRouter(m)
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodGet, "/api/manga", http.StatusOK},
+		{http.MethodPost, "/api/manga", http.StatusServiceUnavailable},
+		{http.MethodDelete, "/api/library/1", http.StatusServiceUnavailable},
+		{http.MethodPost, "/api/admin/import", http.StatusServiceUnavailable},
+		{http.MethodPost, "/auth/register", http.StatusServiceUnavailable},
+		{http.MethodPost, "/api/manga/1/ratings", http.StatusServiceUnavailable},
+		{http.MethodPut, "/api/admin/maintenance", http.StatusOK},
+		{http.MethodPost, "/auth/login", http.StatusOK},
+		{http.MethodPost, "/auth/refresh", http.StatusOK},
+		{http.MethodPost, "/auth/revoke", http.StatusOK},
+		{http.MethodPost, "/api/manga/slugs", http.StatusOK},
+		{http.MethodPost, "/api/manga/ratings/averages", http.StatusOK},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+		assert.Equal(t, tt.status, w.Code, "%s %s", tt.method, tt.path)
+		if tt.status == http.StatusServiceUnavailable {
+			assert.Equal(t, "90", w.Header().Get("Retry-After"))
+		}
+	}
+}
+
+func TestMaintenance_Toggle(t *testing.T) {
+	m := NewMaintenance(false, time.Minute)
+	r := maintenanceRouter(m)
+
+	post := func() int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/manga", nil))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, post())
+
+	m.Set(true, "test")
+	assert.True(t, m.Enabled())
+	assert.Equal(t, http.StatusServiceUnavailable, post())
+
+	m.Set(false, "test")
+	assert.Equal(t, http.StatusOK, post())
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MethodNotAllowed answers requests whose path exists under another method.
+// Register it with engine.NoMethod and set engine.HandleMethodNotAllowed;
+// Gin has already set the Allow header by the time it runs.
+func MethodNotAllowed() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed := strings.Split(c.Writer.Header().Get("Allow"), ", ")
+		c.JSON(http.StatusMethodNotAllowed, gin.H{
+			"error":   "method not allowed",
+			"allowed": allowed,
+		})
+	}
+}
+
+// NotFound answers unknown paths with the usual error body instead of Gin's plain text
+func NotFound() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	}
+}
+
+// HeadAsGet serves HEAD requests with the matching GET route and drops the
+// body, so every GET endpoint answers HEAD without registering it twice.
+// It wraps the whole engine because Gin matches routes by method.
+func HeadAsGet(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		get := r.Clone(r.Context())
+		get.Method = http.MethodGet
+		next.ServeHTTP(headWriter{w}, get)
+	})
+}
+
+// headWriter keeps headers and status but discards the body
+type headWriter struct {
+	http.ResponseWriter
+}
+
+func (w headWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+func (w headWriter) WriteString(s string) (int, error) { return len(s), nil }
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func methodsRouter() http.Handler {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.HandleMethodNotAllowed = true
+	r.NoMethod(MethodNotAllowed())
+	r.NoRoute(NotFound())
+	r.GET("/manga", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"data": []string{"a"}}) })
+	r.POST("/manga", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	return HeadAsGet(r)
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	w := httptest.NewRecorder()
+	methodsRouter().ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/manga", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Contains(t, w.Header().Get("Allow"), "GET")
+	assert.Contains(t, w.Header().Get("Allow"), "POST")
+	assert.JSONEq(t, `{"error":"method not allowed","allowed":["GET","POST"]}`, w.Body.String())
+}
+
+func TestNotFound(t *testing.T) {
+	w := httptest.NewRecorder()
+	methodsRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/nope", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error":"not found"}`, w.Body.String())
+}
+
+func TestHeadAsGet(t *testing.T) {
+	w := httptest.NewRecorder()
+	methodsRouter().ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/manga", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Body.String())
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// RateLimitPerUser limits each authenticated user (falling back to client IP)
+// to `limit` requests per `per`, allowing bursts of up to `burst`.
+// Must run after AuthMiddleware so the userID is available.
+func RateLimitPerUser(limit int, per time.Duration, burst int) gin.HandlerFunc {
+	limiters := newKeyedLimiters(rate.Every(per/time.Duration(limit)), burst, per)
+
+	return func(c *gin.Context) {
+		key := c.ClientIP()
+		if userID, ok := c.Get("userID"); ok {
+			key = fmt.Sprint(userID)
+		}
+
+		r := limiters.get(key).Reserve()
+		if delay := r.Delay(); delay > 0 {
+			r.Cancel()
+			c.Header("Retry-After", fmt.Sprint(int(math.Ceil(delay.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// keyedLimiters holds one limiter per user or IP. Keys not seen for
+// forgetAfter are dropped: by then their limiter has refilled, so a new one
+// behaves the same.
+type keyedLimiters struct {
+	every       rate.Limit
+	burst       int
+	forgetAfter time.Duration
+	now         func() time.Time
+
+	mu        sync.Mutex
+	limiters  map[string]*keyedLimiter
+	nextSweep time.Time
+}
+
+type keyedLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newKeyedLimiters(every rate.Limit, burst int, per time.Duration) *keyedLimiters {
+	// Time for an emptied bucket to refill, but at least the rate window
+	forgetAfter := time.Duration(float64(burst) / float64(every) * float64(time.Second))
+	if forgetAfter < per {
+		forgetAfter = per
+	}
+	return &keyedLimiters{
+		every:       every,
+		burst:       burst,
+		forgetAfter: forgetAfter,
+		now:         time.Now,
+		limiters:    make(map[string]*keyedLimiter),
+	}
+}
+
+// get returns key's limiter, creating it on first use
+func (l *keyedLimiters) get(key string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	kl, ok := l.limiters[key]
+	if !ok {
+		kl = &keyedLimiter{limiter: rate.NewLimiter(l.every, l.burst)}
+		l.limiters[key] = kl
+	}
+	kl.lastSeen = now
+	return kl.limiter
+}
+
+// sweep drops limiters idle for forgetAfter, at most once per forgetAfter.
+// Caller must hold l.mu.
+func (l *keyedLimiters) sweep(now time.Time) {
+	if now.Before(l.nextSweep) {
+		return
+	}
+	for key, kl := range l.limiters {
+		if now.Sub(kl.lastSeen) > l.forgetAfter {
+			delete(l.limiters, key)
+		}
+	}
+	l.nextSweep = now.Add(l.forgetAfter)
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestKeyedLimiters_ForgetsIdleKeys(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := newKeyedLimiters(rate.Every(time.Minute/10), 5, time.Minute)
+	l.now = func() time.Time { return now }
+
+	first := l.get("alice")
+	l.get("bob")
+
+	// alice stays active; bob goes quiet
+	now = now.Add(50 * time.Second)
+	if l.get("alice") != first {
+		t.Fatal("active key got a new limiter")
+	}
+
+	now = now.Add(30 * time.Second)
+	l.get("alice")
+	if _, ok := l.limiters["bob"]; ok {
+		t.Error("idle key was not swept")
+	}
+	if _, ok := l.limiters["alice"]; !ok {
+		t.Error("active key was swept")
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Read-only mode is a permanent deployment posture (e.g. a public instance on
+// a read replica), unlike Maintenance which is flipped at runtime. It's set
+// once at startup with SetReadOnly.
+var (
+	readOnlyCatalog  atomic.Bool
+	readOnlyUserData atomic.Bool
+)
+
+// SetReadOnly turns off catalog writes (manga, genres, imports). With
+// lockUserData, per-user writes (library, progress, ratings, comments,
+// reports) are refused too.
+func SetReadOnly(enabled, lockUserData bool) {
+	readOnlyCatalog.Store(enabled)
+	readOnlyUserData.Store(enabled && lockUserData)
+	if enabled {
+		if lockUserData {
+			log.Println("read-only mode: catalog and user data writes are disabled")
+		} else {
+			log.Println("read-only mode: catalog writes are disabled")
+		}
+	}
+}
+
+// CatalogWrite guards routes that change the shared catalog. It answers 403
+// while the instance is read-only; reads always pass.
+func CatalogWrite() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if readOnlyCatalog.Load() && !isReadMethod(c.Request.Method) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "this instance is read-only: catalog changes are disabled",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// UserWrite guards routes that change a user's own data. It only refuses
+// writes when read-only mode also locks user data, so it's safe to attach
+// to a whole group.
+func UserWrite() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if readOnlyUserData.Load() && !isReadMethod(c.Request.Method) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "this instance is read-only: changes to your data are disabled",
+			})
+			return
+		}
+		c.Next()
+	}
+}
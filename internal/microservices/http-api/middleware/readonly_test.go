@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func readOnlyRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/api/manga", CatalogWrite(), ok)
+	r.POST("/api/manga", CatalogWrite(), ok)
+	library := r.Group("/api/library", UserWrite())
+	library.GET("", ok)
+	library.POST("", ok)
+	return r
+}
+
+func TestReadOnly(t *testing.T) {
+	t.Cleanup(func() { SetReadOnly(false, false) })
+
+	tests := []struct {
+		name         string
+		enabled      bool
+		lockUserData bool
+		catalogPost  int
+		libraryPost  int
+	}{
+		{"Off", false, false, http.StatusOK, http.StatusOK},
+		{"CatalogOnly", true, false, http.StatusForbidden, http.StatusOK},
+		{"CatalogAndUserData", true, true, http.StatusForbidden, http.StatusForbidden},
+		{"UserDataFlagNeedsReadOnly", false, true, http.StatusOK, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetReadOnly(tt.enabled, tt.lockUserData)
+			r := readOnlyRouter()
+
+			check := func(method, path string, want int) {
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+				assert.Equal(t, want, w.Code, "%s %s", method, path)
+			}
+			check(http.MethodGet, "/api/manga", http.StatusOK)
+			check(http.MethodGet, "/api/library", http.StatusOK)
+			check(http.MethodPost, "/api/manga", tt.catalogPost)
+			check(http.MethodPost, "/api/library", tt.libraryPost)
+		})
+	}
+}
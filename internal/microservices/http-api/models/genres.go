@@ -1,10 +1,44 @@
 package models
 
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+)
+
 type Genre struct {
-    ID   int64  `json:"id" gorm:"primaryKey;autoIncrement"`
-    Name string `json:"name" gorm:"unique;not null"`
+	ID             int64  `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name           string `json:"name" gorm:"unique;not null"`                          // display name
+	NormalizedName string `json:"-" gorm:"column:normalized_name;uniqueIndex;not null"` // uniqueness key, see NormalizeGenreName
 }
 
 func (Genre) TableName() string {
-    return "genres"
-}
\ No newline at end of file
+	return "genres"
+}
+
+// BeforeSave hook keeps the normalized key in sync with the display name
+func (g *Genre) BeforeSave(tx *gorm.DB) (err error) {
+	g.Name = CleanGenreName(g.Name)
+	g.NormalizedName = NormalizeGenreName(g.Name)
+	return
+}
+
+// CleanGenreName trims a genre display name and collapses inner whitespace
+func CleanGenreName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// NormalizeGenreName returns the key used for genre uniqueness:
+// lowercased, whitespace collapsed and accents stripped ("  Shōnen " -> "shonen")
+func NormalizeGenreName(name string) string {
+	stripAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	stripped, _, err := transform.String(stripAccents, name)
+	if err != nil {
+		stripped = name
+	}
+	return strings.ToLower(CleanGenreName(stripped))
+}
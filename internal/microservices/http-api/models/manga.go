@@ -12,8 +12,13 @@ type Manga struct {
 	Description   *string    `json:"description,omitempty" gorm:"type:text"`
 	AverageRating *float64   `json:"average_rating,omitempty" gorm:"type:decimal(3,2);index"`
 	CoverURL      *string    `json:"cover_url,omitempty"`
+	ViewCount     int64      `json:"view_count" gorm:"not null;default:0;index"`
 	CreatedAt     *time.Time `json:"created_at,omitempty" gorm:"autoCreateTime"`
 
+	// Source-native IDs set by the ingestion services
+	MangaDexID *string `json:"mangadex_id,omitempty" gorm:"column:mangadex_id;type:uuid;uniqueIndex"`
+	AniListID  *int64  `json:"anilist_id,omitempty" gorm:"column:anilist_id;uniqueIndex"`
+
 	// Many-to-many relationship with genres
 	Genres []Genre `json:"genres,omitempty" gorm:"many2many:manga_genres;constraint:OnDelete:CASCADE;"`
 }
@@ -21,3 +26,26 @@ type Manga struct {
 func (Manga) TableName() string {
 	return "manga"
 }
+
+// MangaWithUserContext is a manga plus one user's library entry and reading
+// progress for it. The context fields are false/nil when it isn't in their
+// library or they haven't started it.
+type MangaWithUserContext struct {
+	Manga
+	InLibrary      bool
+	CurrentChapter *int
+	ReadingStatus  *string
+}
+
+// MangaSyncInfo is the ingestion bookkeeping kept on a manga row. Each
+// source writes its own timestamps; nil means that source never touched it.
+type MangaSyncInfo struct {
+	ID         int64
+	MangaDexID *string `gorm:"column:mangadex_id"`
+	AniListID  *int64  `gorm:"column:anilist_id"`
+
+	MangaDexLastSyncedAt     *time.Time `gorm:"column:last_synced_at"`
+	MangaDexLastChapterCheck *time.Time `gorm:"column:last_chapter_check"`
+	AniListLastSyncedAt      *time.Time `gorm:"column:anilist_last_synced_at"`
+	AniListLastChapterCheck  *time.Time `gorm:"column:anilist_last_chapter_check"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "strings"
+
+// MangaStatus is the publication status of a manga. The same values are
+// enforced by the CHECK constraint on manga.status.
+type MangaStatus string
+
+const (
+	MangaStatusOngoing   MangaStatus = "ongoing"
+	MangaStatusCompleted MangaStatus = "completed"
+	MangaStatusHiatus    MangaStatus = "hiatus"
+	MangaStatusCancelled MangaStatus = "cancelled"
+	// MangaStatusUnknown marks a status a source reported that ingestion
+	// couldn't map, so it isn't mistaken for a genuinely ongoing series
+	MangaStatusUnknown MangaStatus = "unknown"
+)
+
+// MangaStatuses lists every valid status, in display order
+var MangaStatuses = []MangaStatus{MangaStatusOngoing, MangaStatusCompleted, MangaStatusHiatus, MangaStatusCancelled, MangaStatusUnknown}
+
+// IsValid reports whether s is one of MangaStatuses
+func (s MangaStatus) IsValid() bool {
+	for _, valid := range MangaStatuses {
+		if s == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseMangaStatus trims and lowercases s ("  Completed" -> completed) and
+// reports whether the result is a valid status
+func ParseMangaStatus(s string) (MangaStatus, bool) {
+	status := MangaStatus(strings.ToLower(strings.TrimSpace(s)))
+	return status, status.IsValid()
+}
+
+// MangaStatusList returns the valid statuses as "ongoing, completed, ..."
+// for error messages
+func MangaStatusList() string {
+	names := make([]string, len(MangaStatuses))
+	for i, s := range MangaStatuses {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Metadata report statuses
+const (
+	MetadataReportOpen      = "open"
+	MetadataReportResolved  = "resolved"
+	MetadataReportDismissed = "dismissed"
+)
+
+// MetadataReport is a user's report that some manga metadata is wrong
+type MetadataReport struct {
+	ID         int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	MangaID    int64      `json:"manga_id" gorm:"not null;index"`
+	UserID     string     `json:"user_id" gorm:"type:uuid;not null"`
+	Field      string     `json:"field" gorm:"not null"`
+	Reason     string     `json:"reason" gorm:"not null;type:text"`
+	Status     string     `json:"status" gorm:"not null;default:open"`
+	ResolvedBy *string    `json:"resolved_by,omitempty" gorm:"type:uuid"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Associations
+	User  User  `json:"user,omitempty" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Manga Manga `json:"manga,omitempty" gorm:"foreignKey:MangaID;constraint:OnDelete:CASCADE;"`
+}
+
+func (MetadataReport) TableName() string {
+	return "metadata_reports"
+}
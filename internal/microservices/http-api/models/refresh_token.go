@@ -8,9 +8,19 @@ type RefreshToken struct {
 	ID        string    `gorm:"primaryKey" json:"id"`
 	UserID    string    `gorm:"not null;index" json:"user_id"`
 	Token     string    `gorm:"uniqueIndex;not null" json:"token"`
+	Scope     string    `gorm:"type:text;not null;default:''" json:"scope,omitempty"` // space-separated; empty means the role's default scopes
 	ExpiresAt time.Time `gorm:"not null" json:"expires_at"`
 	CreatedAt time.Time `json:"created_at"`
 	Revoked   bool      `gorm:"default:false" json:"revoked"`
+
+	// Session metadata captured at login and carried across rotations
+	UserAgent  string     `gorm:"type:text;not null;default:''" json:"user_agent"`
+	IPAddress  string     `gorm:"type:text;not null;default:''" json:"ip_address"`
+	DeviceName string     `gorm:"type:text;not null;default:''" json:"device_name"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+
+	// Access-token lifetime the client asked for at login, in seconds; 0 means the server default
+	AccessTokenTTL int64 `gorm:"column:access_token_ttl;not null;default:0" json:"-"`
 }
 
 // func (RefreshToken) TableName() string {
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// UserFollow is one user following another
+type UserFollow struct {
+	FollowerID string    `json:"follower_id" gorm:"primaryKey;type:uuid"`
+	FolloweeID string    `json:"followee_id" gorm:"primaryKey;type:uuid;index"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	// Associations
+	Follower User `json:"follower,omitempty" gorm:"foreignKey:FollowerID;constraint:OnDelete:CASCADE;"`
+	Followee User `json:"followee,omitempty" gorm:"foreignKey:FolloweeID;constraint:OnDelete:CASCADE;"`
+}
+
+func (UserFollow) TableName() string {
+	return "user_follows"
+}
@@ -16,6 +16,12 @@ type User struct {
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	LastLogin *time.Time `json:"last_login,omitempty"`
+
+	LastLoginIP string `gorm:"column:last_login_ip;type:text;not null;default:''" json:"last_login_ip,omitempty"`
+
+	// Privacy of the public profile at GET /api/users/:username
+	ProfilePublic bool `gorm:"not null;default:true" json:"profile_public"`
+	LibraryPublic bool `gorm:"not null;default:false" json:"library_public"` // Show library size on the public profile
 }
 
 // BeforeCreate hook to set UUID before creating a User
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Notification types a webhook can subscribe to
+const (
+	WebhookEventNewChapter  = "NEW_CHAPTER"
+	WebhookEventMangaUpdate = "MANGA_UPDATE"
+	WebhookEventNewManga    = "NEW_MANGA"
+)
+
+// WebhookEvents lists every event a webhook may subscribe to
+var WebhookEvents = []string{WebhookEventNewChapter, WebhookEventMangaUpdate, WebhookEventNewManga}
+
+// Webhook is an endpoint a user registered to be POSTed notifications
+type Webhook struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID        string     `gorm:"type:uuid;not null;index" json:"user_id"`
+	URL           string     `gorm:"not null" json:"url"`
+	Secret        string     `gorm:"not null" json:"-"`      // HMAC key for the signature header
+	Events        string     `gorm:"not null" json:"events"` // Comma separated; see EventList
+	Active        bool       `gorm:"not null;default:true" json:"active"`
+	FailureCount  int        `gorm:"not null;default:0" json:"failure_count"` // Consecutive failed deliveries
+	LastError     string     `gorm:"not null;default:''" json:"last_error"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+
+	// Associations
+	User *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// EventList splits Events into the subscribed event types
+func (w Webhook) EventList() []string {
+	if w.Events == "" {
+		return nil
+	}
+	return strings.Split(w.Events, ",")
+}
+
+// Handles reports whether the webhook subscribes to event
+func (w Webhook) Handles(event string) bool {
+	return slices.Contains(w.EventList(), event)
+}
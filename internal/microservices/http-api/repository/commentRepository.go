@@ -2,6 +2,7 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"mangahub/internal/microservices/http-api/models"
 
@@ -15,6 +16,7 @@ type CommentRepository interface {
 	GetByID(commentID int64) (*models.Comment, error)
 	GetByManga(mangaID int64, page, pageSize int) ([]models.Comment, int64, error)
 	GetByUser(userID string, page, pageSize int) ([]models.Comment, int64, error)
+	ListRecent(since *time.Time, page, pageSize int) ([]models.Comment, int64, error)
 }
 
 type commentRepository struct {
@@ -111,3 +113,36 @@ func (r *commentRepository) GetByUser(userID string, page, pageSize int) ([]mode
 
 	return comments, total, nil
 }
+
+// ListRecent retrieves comments across every manga, newest first, with the
+// author and manga joined in. A non-nil since skips older comments.
+func (r *commentRepository) ListRecent(since *time.Time, page, pageSize int) ([]models.Comment, int64, error) {
+	var comments []models.Comment
+	var total int64
+
+	query := r.db.Model(&models.Comment{})
+	if since != nil {
+		query = query.Where("comments.created_at >= ?", *since)
+	}
+
+	// Count total comments
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated comments
+	offset := (page - 1) * pageSize
+	err := query.
+		Joins("User").
+		Joins("Manga").
+		Order("comments.created_at DESC").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&comments).Error
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// pgUniqueViolation is the Postgres SQLSTATE for unique constraint violations
+const pgUniqueViolation = "23505"
+
+// isUniqueViolation reports whether err was caused by a unique constraint
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation
+}
+
+// UniqueViolationField returns the column a unique constraint violation was
+// raised on, taken from the "Key (column)=(value) already exists" detail.
+// ok is false if err is not a unique violation; field is empty if the
+// column couldn't be determined.
+func UniqueViolationField(err error) (field string, ok bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != pgUniqueViolation {
+		return "", false
+	}
+
+	if rest, found := strings.CutPrefix(pgErr.Detail, "Key ("); found {
+		if column, _, found := strings.Cut(rest, ")="); found && !strings.ContainsAny(column, ", ") {
+			return column, true
+		}
+	}
+	return "", true
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniqueViolationField(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantField string
+		wantOK    bool
+	}{
+		{
+			name:      "single column",
+			err:       &pgconn.PgError{Code: "23505", Detail: "Key (slug)=(one-piece) already exists."},
+			wantField: "slug",
+			wantOK:    true,
+		},
+		{
+			name:      "wrapped",
+			err:       fmt.Errorf("create manga: %w", &pgconn.PgError{Code: "23505", Detail: "Key (anilist_id)=(30013) already exists."}),
+			wantField: "anilist_id",
+			wantOK:    true,
+		},
+		{
+			name:   "composite key",
+			err:    &pgconn.PgError{Code: "23505", Detail: "Key (user_id, manga_id)=(u, 1) already exists."},
+			wantOK: true,
+		},
+		{
+			name: "other constraint",
+			err:  &pgconn.PgError{Code: "23503", Detail: "Key (manga_id)=(9) is not present in table \"manga\"."},
+		},
+		{
+			name: "not a postgres error",
+			err:  errors.New("boom"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, ok := UniqueViolationField(tt.err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantField, field)
+		})
+	}
+}
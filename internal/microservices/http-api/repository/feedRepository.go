@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Kinds of activity in the feed
+const (
+	ActivityRating     = "rating"
+	ActivityComment    = "comment"
+	ActivityLibraryAdd = "library_add"
+)
+
+// Activity is one public action by a followed user
+type Activity struct {
+	Kind       string
+	ID         int64 // Row ID in the kind's own table
+	UserID     string
+	Username   string
+	MangaID    int64
+	MangaTitle string
+	Rating     *int    // Set for ratings
+	Content    *string // Set for comments
+	At         time.Time
+}
+
+// FeedCursor is the position of the last activity on a page. Activities
+// are ordered by (At, Kind, ID) descending, so the next page starts below it.
+type FeedCursor struct {
+	At   time.Time
+	Kind string
+	ID   int64
+}
+
+// FeedRepository reads the activity of the users someone follows
+type FeedRepository interface {
+	// Feed returns up to limit activities of the users userID follows, newest
+	// first, no older than since and after the cursor if there is one. Users
+	// with a private profile are left out, as are library additions of users
+	// who don't share their library.
+	Feed(ctx context.Context, userID string, since time.Time, after *FeedCursor, limit int) ([]Activity, error)
+}
+
+type feedRepository struct {
+	db *gorm.DB
+}
+
+func NewFeedRepository(db *gorm.DB) FeedRepository {
+	return &feedRepository{db: db}
+}
+
+// feedQuery unions the three activity tables, each already filtered to the
+// followed users and the lookback window so the union stays small
+const feedQuery = `
+	WITH followed AS (
+		SELECT u.id, u.library_public
+		FROM user_follows f
+		JOIN users u ON u.id = f.followee_id
+		WHERE f.follower_id = @user AND u.profile_public
+	)
+	SELECT a.kind, a.id, a.user_id, u.username, a.manga_id, m.title AS manga_title, a.rating, a.content, a.at
+	FROM (
+		SELECT 'rating' AS kind, r.id, r.user_id, r.manga_id, r.rating, NULL::text AS content, r.updated_at AS at
+		FROM ratings r
+		WHERE r.user_id IN (SELECT id FROM followed) AND r.updated_at >= @since
+		UNION ALL
+		SELECT 'comment', c.id, c.user_id, c.manga_id, NULL::int, c.content, c.created_at
+		FROM comments c
+		WHERE c.user_id IN (SELECT id FROM followed) AND c.created_at >= @since
+		UNION ALL
+		SELECT 'library_add', l.id, l.user_id, l.manga_id, NULL::int, NULL::text, l.added_at
+		FROM user_library l
+		WHERE l.user_id IN (SELECT id FROM followed WHERE library_public) AND l.added_at >= @since
+	) a
+	JOIN users u ON u.id = a.user_id
+	JOIN manga m ON m.id = a.manga_id
+	WHERE @first OR (a.at, a.kind, a.id) < (@at, @kind, @id)
+	ORDER BY a.at DESC, a.kind DESC, a.id DESC
+	LIMIT @limit`
+
+func (r *feedRepository) Feed(ctx context.Context, userID string, since time.Time, after *FeedCursor, limit int) ([]Activity, error) {
+	cursor := FeedCursor{}
+	if after != nil {
+		cursor = *after
+	}
+
+	var activities []Activity
+	err := r.db.WithContext(ctx).Raw(feedQuery, map[string]any{
+		"user":  userID,
+		"since": since,
+		"first": after == nil,
+		"at":    cursor.At,
+		"kind":  cursor.Kind,
+		"id":    cursor.ID,
+		"limit": limit,
+	}).Scan(&activities).Error
+	if err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
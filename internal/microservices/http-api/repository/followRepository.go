@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+
+	"mangahub/internal/microservices/http-api/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FollowRepository stores which users follow which
+type FollowRepository interface {
+	// Follow records the follow; following someone twice is a no-op
+	Follow(ctx context.Context, followerID, followeeID string) error
+	// Unfollow removes the follow; it is a no-op if there was none
+	Unfollow(ctx context.Context, followerID, followeeID string) error
+	// ListFollowing returns a page of the users userID follows, with Followee
+	// loaded, newest first, and the total count
+	ListFollowing(ctx context.Context, userID string, page, pageSize int) ([]models.UserFollow, int64, error)
+	// ListFollowers returns a page of the users following userID, with
+	// Follower loaded, newest first, and the total count
+	ListFollowers(ctx context.Context, userID string, page, pageSize int) ([]models.UserFollow, int64, error)
+	// Counts returns how many users follow userID and how many it follows
+	Counts(ctx context.Context, userID string) (followers, following int64, err error)
+}
+
+type followRepository struct {
+	db *gorm.DB
+}
+
+func NewFollowRepository(db *gorm.DB) FollowRepository {
+	return &followRepository{db: db}
+}
+
+func (r *followRepository) Follow(ctx context.Context, followerID, followeeID string) error {
+	follow := &models.UserFollow{FollowerID: followerID, FolloweeID: followeeID}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(follow).Error
+}
+
+func (r *followRepository) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	return r.db.WithContext(ctx).
+		Where("follower_id = ? AND followee_id = ?", followerID, followeeID).
+		Delete(&models.UserFollow{}).Error
+}
+
+func (r *followRepository) ListFollowing(ctx context.Context, userID string, page, pageSize int) ([]models.UserFollow, int64, error) {
+	return r.list(ctx, "follower_id", userID, "Followee", page, pageSize)
+}
+
+func (r *followRepository) ListFollowers(ctx context.Context, userID string, page, pageSize int) ([]models.UserFollow, int64, error) {
+	return r.list(ctx, "followee_id", userID, "Follower", page, pageSize)
+}
+
+// list pages through the follows whose column is userID, loading the user
+// on the other side
+func (r *followRepository) list(ctx context.Context, column, userID, preload string, page, pageSize int) ([]models.UserFollow, int64, error) {
+	var follows []models.UserFollow
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&models.UserFollow{}).Where(column+" = ?", userID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := r.db.WithContext(ctx).
+		Where(column+" = ?", userID).
+		Preload(preload).
+		Order("created_at DESC").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&follows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return follows, total, nil
+}
+
+func (r *followRepository) Counts(ctx context.Context, userID string) (followers, following int64, err error) {
+	var counts struct {
+		Followers int64
+		Following int64
+	}
+	err = r.db.WithContext(ctx).Raw(`
+		SELECT
+			COUNT(*) FILTER (WHERE followee_id = ?) AS followers,
+			COUNT(*) FILTER (WHERE follower_id = ?) AS following
+		FROM user_follows
+		WHERE followee_id = ? OR follower_id = ?`,
+		userID, userID, userID, userID,
+	).Scan(&counts).Error
+	return counts.Followers, counts.Following, err
+}
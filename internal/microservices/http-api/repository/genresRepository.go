@@ -2,13 +2,18 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"mangahub/internal/microservices/http-api/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrGenreExists is returned when a genre with the same normalized name already exists
+var ErrGenreExists = errors.New("genre already exists")
+
 type GenreRepo struct {
 	db *gorm.DB
 }
@@ -27,23 +32,301 @@ func (r *GenreRepo) GetAll(ctx context.Context) ([]models.Genre, error) {
 
 func (r *GenreRepo) Create(ctx context.Context, g *models.Genre) error {
 	if err := r.db.WithContext(ctx).Create(g).Error; err != nil {
+		if isUniqueViolation(err) {
+			return ErrGenreExists
+		}
 		return fmt.Errorf("create genre: %w", err)
 	}
 	return nil
 }
 
-// GetMangasByGenre returns mangas associated with the given genre id.
+// FindByNormalizedName looks up a genre by its normalized key.
+// Returns gorm.ErrRecordNotFound if there is no match.
+func (r *GenreRepo) FindByNormalizedName(ctx context.Context, normalized string) (*models.Genre, error) {
+	var g models.Genre
+	if err := r.db.WithContext(ctx).Where("normalized_name = ?", normalized).First(&g).Error; err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// FindByNormalizedNames returns the genres whose normalized key is in the list.
+// Keys with no genre are simply absent from the result.
+func (r *GenreRepo) FindByNormalizedNames(ctx context.Context, normalized []string) ([]models.Genre, error) {
+	var list []models.Genre
+	if len(normalized) == 0 {
+		return list, nil
+	}
+	if err := r.db.WithContext(ctx).Where("normalized_name IN ?", normalized).Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("find genres by name: %w", err)
+	}
+	return list, nil
+}
+
+// FindByIDs returns the genres with the given ids; unknown ids are skipped
+func (r *GenreRepo) FindByIDs(ctx context.Context, ids []int64) ([]models.Genre, error) {
+	var list []models.Genre
+	if len(ids) == 0 {
+		return list, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("find genres by id: %w", err)
+	}
+	return list, nil
+}
+
+// GetByManga returns the genres attached to a manga, by name. A manga with no
+// genres yields an empty list; gorm.ErrRecordNotFound means no such manga.
+func (r *GenreRepo) GetByManga(ctx context.Context, mangaID int64) ([]models.Genre, error) {
+	var exists int64
+	if err := r.db.WithContext(ctx).Model(&models.Manga{}).Where("id = ?", mangaID).Count(&exists).Error; err != nil {
+		return nil, fmt.Errorf("check manga: %w", err)
+	}
+	if exists == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	list := []models.Genre{}
+	err := r.db.WithContext(ctx).
+		Joins("JOIN manga_genres mg ON mg.genre_id = genres.id").
+		Where("mg.manga_id = ?", mangaID).
+		Order("genres.name asc").
+		Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("get genres for manga: %w", err)
+	}
+	return list, nil
+}
+
+// CountMangas returns how many manga carry each of the given genres.
+// Genres with no manga are absent from the map.
+func (r *GenreRepo) CountMangas(ctx context.Context, genreIDs []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(genreIDs))
+	if len(genreIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		GenreID int64
+		Count   int64
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.MangaGenre{}).
+		Select("genre_id, COUNT(*) AS count").
+		Where("genre_id IN ?", genreIDs).
+		Group("genre_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("count mangas per genre: %w", err)
+	}
+	for _, row := range rows {
+		counts[row.GenreID] = row.Count
+	}
+	return counts, nil
+}
+
+// MangaIDsWithOnlyGenre returns the mangas whose only genre is genreID,
+// i.e. the ones that would be left with no genres if it were deleted
+func (r *GenreRepo) MangaIDsWithOnlyGenre(ctx context.Context, genreID int64) ([]int64, error) {
+	var ids []int64
+	err := r.db.WithContext(ctx).
+		Model(&models.MangaGenre{}).
+		Where("genre_id = ?", genreID).
+		Where("manga_id NOT IN (?)",
+			r.db.Model(&models.MangaGenre{}).Select("manga_id").Where("genre_id <> ?", genreID)).
+		Order("manga_id").
+		Pluck("manga_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("find mangas with only genre: %w", err)
+	}
+	return ids, nil
+}
+
+// Delete removes a genre and its manga_genres links in one transaction.
+// Returns gorm.ErrRecordNotFound if the genre does not exist.
+func (r *GenreRepo) Delete(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("genre_id = ?", id).Delete(&models.MangaGenre{}).Error; err != nil {
+			return fmt.Errorf("delete genre links: %w", err)
+		}
+		res := tx.Delete(&models.Genre{}, id)
+		if res.Error != nil {
+			return fmt.Errorf("delete genre: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// GetMangasByGenre returns one page of mangas associated with the given genre id
+// along with the total number of mangas in that genre.
 // Preloads Genres on each manga.
-func (r *GenreRepo) GetMangasByGenre(ctx context.Context, genreID int64) ([]models.Manga, error) {
+func (r *GenreRepo) GetMangasByGenre(ctx context.Context, genreID int64, page, pageSize int) ([]models.Manga, int64, error) {
 	var list []models.Manga
-	if err := r.db.WithContext(ctx).
+	var total int64
+
+	base := r.db.WithContext(ctx).
 		Model(&models.Manga{}).
 		Joins("JOIN manga_genres mg ON mg.manga_id = manga.id").
-		Where("mg.genre_id = ?", genreID).
+		Where("mg.genre_id = ?", genreID)
+
+	if err := base.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count mangas by genre: %w", err)
+	}
+
+	offset := (page - 1) * pageSize
+	if err := base.
 		Preload("Genres").
 		Order("created_at desc").
+		Limit(pageSize).
+		Offset(offset).
 		Find(&list).Error; err != nil {
-		return nil, fmt.Errorf("get mangas by genre: %w", err)
+		return nil, 0, fmt.Errorf("get mangas by genre: %w", err)
 	}
-	return list, nil
+	return list, total, nil
+}
+
+// GenreLinkResult sorts the manga a bulk link or unlink was asked about
+type GenreLinkResult struct {
+	Changed   []int64 // Linked to, or unlinked from, the genre
+	Unchanged []int64 // Already linked (link) or not linked (unlink)
+	Missing   []int64 // No such manga
+	SoleGenre []int64 // Left linked because it is their only genre (unlink)
+}
+
+// LinkMangas links genreID to each of mangaIDs in one transaction. Manga that
+// already carry the genre or don't exist are reported rather than failing
+// the batch. Returns gorm.ErrRecordNotFound if the genre does not exist.
+func (r *GenreRepo) LinkMangas(ctx context.Context, genreID int64, mangaIDs []int64) (*GenreLinkResult, error) {
+	var result *GenreLinkResult
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		linked, unlinked, missing, err := partitionGenreLinks(tx, genreID, mangaIDs)
+		if err != nil {
+			return err
+		}
+		result = &GenreLinkResult{Changed: unlinked, Unchanged: linked, Missing: missing}
+		if len(result.Changed) == 0 {
+			return nil
+		}
+
+		links := make([]models.MangaGenre, 0, len(result.Changed))
+		for _, id := range result.Changed {
+			links = append(links, models.MangaGenre{MangaID: id, GenreID: genreID})
+		}
+		// A concurrent link of the same pair is not an error
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&links, 100).Error; err != nil {
+			return fmt.Errorf("link mangas to genre: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UnlinkMangas removes genreID from each of mangaIDs in one transaction,
+// reporting manga that didn't carry it or don't exist. Manga for which it is
+// the only genre keep it and are reported in SoleGenre, the bulk form of
+// the check that blocks deleting such a genre. Returns
+// gorm.ErrRecordNotFound if the genre does not exist.
+func (r *GenreRepo) UnlinkMangas(ctx context.Context, genreID int64, mangaIDs []int64) (*GenreLinkResult, error) {
+	var result *GenreLinkResult
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		linked, unlinked, missing, err := partitionGenreLinks(tx, genreID, mangaIDs)
+		if err != nil {
+			return err
+		}
+		result = &GenreLinkResult{Changed: []int64{}, Unchanged: unlinked, Missing: missing, SoleGenre: []int64{}}
+		if len(linked) == 0 {
+			return nil
+		}
+
+		// Lock the manga so a concurrent unlink of their other genre can't
+		// leave them with none
+		if err := tx.Exec("SELECT 1 FROM manga WHERE id IN ? FOR UPDATE", linked).Error; err != nil {
+			return fmt.Errorf("lock mangas: %w", err)
+		}
+		var others []int64
+		err = tx.Model(&models.MangaGenre{}).
+			Distinct("manga_id").
+			Where("manga_id IN ? AND genre_id <> ?", linked, genreID).
+			Pluck("manga_id", &others).Error
+		if err != nil {
+			return fmt.Errorf("find other genres: %w", err)
+		}
+		hasOthers := make(map[int64]bool, len(others))
+		for _, id := range others {
+			hasOthers[id] = true
+		}
+		for _, id := range linked {
+			if hasOthers[id] {
+				result.Changed = append(result.Changed, id)
+			} else {
+				result.SoleGenre = append(result.SoleGenre, id)
+			}
+		}
+		if len(result.Changed) == 0 {
+			return nil
+		}
+
+		err = tx.Where("genre_id = ? AND manga_id IN ?", genreID, result.Changed).
+			Delete(&models.MangaGenre{}).Error
+		if err != nil {
+			return fmt.Errorf("unlink mangas from genre: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// partitionGenreLinks splits mangaIDs into manga that carry genreID, manga
+// that don't and ids with no manga, keeping the caller's order. Returns
+// gorm.ErrRecordNotFound if the genre does not exist.
+func partitionGenreLinks(tx *gorm.DB, genreID int64, mangaIDs []int64) (linked, unlinked, missing []int64, err error) {
+	var genres int64
+	if err := tx.Model(&models.Genre{}).Where("id = ?", genreID).Count(&genres).Error; err != nil {
+		return nil, nil, nil, fmt.Errorf("check genre: %w", err)
+	}
+	if genres == 0 {
+		return nil, nil, nil, gorm.ErrRecordNotFound
+	}
+
+	var existingIDs, linkedIDs []int64
+	if err := tx.Model(&models.Manga{}).Where("id IN ?", mangaIDs).Pluck("id", &existingIDs).Error; err != nil {
+		return nil, nil, nil, fmt.Errorf("find mangas: %w", err)
+	}
+	err = tx.Model(&models.MangaGenre{}).
+		Where("genre_id = ? AND manga_id IN ?", genreID, mangaIDs).
+		Pluck("manga_id", &linkedIDs).Error
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("find genre links: %w", err)
+	}
+
+	exists := make(map[int64]bool, len(existingIDs))
+	for _, id := range existingIDs {
+		exists[id] = true
+	}
+	hasGenre := make(map[int64]bool, len(linkedIDs))
+	for _, id := range linkedIDs {
+		hasGenre[id] = true
+	}
+
+	linked, unlinked, missing = []int64{}, []int64{}, []int64{}
+	for _, id := range mangaIDs {
+		switch {
+		case !exists[id]:
+			missing = append(missing, id)
+		case hasGenre[id]:
+			linked = append(linked, id)
+		default:
+			unlinked = append(unlinked, id)
+		}
+	}
+	return linked, unlinked, missing, nil
 }
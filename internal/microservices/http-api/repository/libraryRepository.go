@@ -2,18 +2,37 @@ package repository
 
 import (
     "context"
+    "errors"
     "fmt"
     "mangahub/internal/microservices/http-api/models"
 
     "gorm.io/gorm"
 )
 
+// ErrLibraryEntryExists is returned when the manga is already in the user's library
+var ErrLibraryEntryExists = errors.New("library entry already exists")
+
+// ErrLibraryLimitReached is returned by AddWithLimit when the library is full
+var ErrLibraryLimitReached = errors.New("library size limit reached")
+
 type LibraryRepository interface {
     Add(ctx context.Context, userID string, mangaID int64) error
+    AddWithLimit(ctx context.Context, userID string, mangaID int64, limit int) (int64, error)
     Remove(ctx context.Context, userID string, mangaID int64) error
+    RemoveMany(ctx context.Context, userID string, mangaIDs []int64) ([]int64, error)
     List(ctx context.Context, userID string) ([]models.UserLibrary, error)
     Exists(ctx context.Context, userID string, mangaID int64) (bool, error)
+    Get(ctx context.Context, userID string, mangaID int64) (*models.UserLibrary, error)
     GetUserIDsByMangaID(ctx context.Context, mangaID int64) ([]string, error)
+    StatusCounts(ctx context.Context, userID string) ([]LibraryStatusCount, error)
+}
+
+// LibraryStatusCount is one reading status's share of a user's library.
+// Status is empty for entries with no progress yet.
+type LibraryStatusCount struct {
+    Status   string
+    Count    int64
+    Chapters int64 // Sum of the known total chapters of those manga
 }
 
 type libraryRepository struct {
@@ -31,11 +50,47 @@ func (r *libraryRepository) Add(ctx context.Context, userID string, mangaID int6
     }
     
     if err := r.db.WithContext(ctx).Create(library).Error; err != nil {
+        if isUniqueViolation(err) {
+            return ErrLibraryEntryExists
+        }
         return fmt.Errorf("add to library: %w", err)
     }
     return nil
 }
 
+// AddWithLimit is Add that refuses with ErrLibraryLimitReached once the user
+// has limit entries. The user's row is locked for the transaction so two
+// concurrent adds can't both slip under the limit. It returns how many
+// entries the library held before the add.
+func (r *libraryRepository) AddWithLimit(ctx context.Context, userID string, mangaID int64, limit int) (int64, error) {
+    var count int64
+
+    err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        if err := tx.Exec("SELECT 1 FROM users WHERE id = ? FOR UPDATE", userID).Error; err != nil {
+            return err
+        }
+        if err := tx.Model(&models.UserLibrary{}).
+            Where("user_id = ?", userID).
+            Count(&count).Error; err != nil {
+            return err
+        }
+        if count >= int64(limit) {
+            return ErrLibraryLimitReached
+        }
+        return tx.Create(&models.UserLibrary{UserID: userID, MangaID: mangaID}).Error
+    })
+    switch {
+    case err == nil:
+        return count, nil
+    case errors.Is(err, ErrLibraryLimitReached):
+        return count, err
+    case isUniqueViolation(err):
+        return count, ErrLibraryEntryExists
+    default:
+        return count, fmt.Errorf("add to library: %w", err)
+    }
+}
+
 func (r *libraryRepository) Remove(ctx context.Context, userID string, mangaID int64) error {
     result := r.db.WithContext(ctx).
         Where("user_id = ? AND manga_id = ?", userID, mangaID).
@@ -52,6 +107,30 @@ func (r *libraryRepository) Remove(ctx context.Context, userID string, mangaID i
     return nil
 }
 
+// RemoveMany deletes the given manga from the user's library in a single
+// transaction and returns the IDs that were actually removed
+func (r *libraryRepository) RemoveMany(ctx context.Context, userID string, mangaIDs []int64) ([]int64, error) {
+    var removed []int64
+
+    err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        if err := tx.Model(&models.UserLibrary{}).
+            Where("user_id = ? AND manga_id IN ?", userID, mangaIDs).
+            Pluck("manga_id", &removed).Error; err != nil {
+            return err
+        }
+        if len(removed) == 0 {
+            return nil
+        }
+        return tx.Where("user_id = ? AND manga_id IN ?", userID, removed).
+            Delete(&models.UserLibrary{}).Error
+    })
+    if err != nil {
+        return nil, fmt.Errorf("bulk remove from library: %w", err)
+    }
+
+    return removed, nil
+}
+
 func (r *libraryRepository) List(ctx context.Context, userID string) ([]models.UserLibrary, error) {
     var library []models.UserLibrary
     
@@ -77,6 +156,19 @@ func (r *libraryRepository) Exists(ctx context.Context, userID string, mangaID i
     return count > 0, nil
 }
 
+// Get returns a single library entry with its manga.
+// Returns gorm.ErrRecordNotFound if the manga isn't in the library.
+func (r *libraryRepository) Get(ctx context.Context, userID string, mangaID int64) (*models.UserLibrary, error) {
+    var entry models.UserLibrary
+    if err := r.db.WithContext(ctx).
+        Preload("Manga").
+        Where("user_id = ? AND manga_id = ?", userID, mangaID).
+        First(&entry).Error; err != nil {
+        return nil, err
+    }
+    return &entry, nil
+}
+
 func (r *libraryRepository) GetUserIDsByMangaID(ctx context.Context, mangaID int64) ([]string, error) {
     var userIDs []string
     
@@ -88,4 +180,23 @@ func (r *libraryRepository) GetUserIDsByMangaID(ctx context.Context, mangaID int
     }
     
     return userIDs, nil
-}
\ No newline at end of file
+}
+
+// StatusCounts groups the user's library by reading status in one query,
+// counting entries and summing their manga's chapter counts
+func (r *libraryRepository) StatusCounts(ctx context.Context, userID string) ([]LibraryStatusCount, error) {
+    var counts []LibraryStatusCount
+
+    if err := r.db.WithContext(ctx).
+        Table("user_library").
+        Select("COALESCE(user_progress.status, '') AS status, COUNT(*) AS count, COALESCE(SUM(manga.total_chapters), 0) AS chapters").
+        Joins("JOIN manga ON manga.id = user_library.manga_id").
+        Joins("LEFT JOIN user_progress ON user_progress.manga_id = user_library.manga_id AND user_progress.user_id = user_library.user_id").
+        Where("user_library.user_id = ?", userID).
+        Group("COALESCE(user_progress.status, '')").
+        Scan(&counts).Error; err != nil {
+        return nil, fmt.Errorf("count library by status: %w", err)
+    }
+
+    return counts, nil
+}
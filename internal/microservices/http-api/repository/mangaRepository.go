@@ -20,7 +20,9 @@ func NewMangaRepo(db *gorm.DB) *MangaRepo {
 	return &MangaRepo{db: db}
 }
 
-func (r *MangaRepo) GetAll(ctx context.Context, page, pageSize int) ([]models.Manga, int64, error) {
+// GetAll returns a page of manga, newest first, or most viewed first when
+// sortBy is "views"
+func (r *MangaRepo) GetAll(ctx context.Context, page, pageSize int, sortBy string) ([]models.Manga, int64, error) {
 	var list []models.Manga
 	var total int64
 
@@ -32,9 +34,14 @@ func (r *MangaRepo) GetAll(ctx context.Context, page, pageSize int) ([]models.Ma
 	// Calculate offset
 	offset := (page - 1) * pageSize
 
+	order := "created_at desc"
+	if sortBy == "views" {
+		order = "view_count desc, id desc"
+	}
+
 	// Fetch paginated results (without genres for better performance)
 	if err := r.db.WithContext(ctx).
-		Order("created_at desc").
+		Order(order).
 		Limit(pageSize).
 		Offset(offset).
 		Find(&list).Error; err != nil {
@@ -52,6 +59,77 @@ func (r *MangaRepo) GetByID(ctx context.Context, id int64) (*models.Manga, error
 	return &m, nil
 }
 
+// GetByIDs loads the given manga in no particular order; missing IDs are skipped
+func (r *MangaRepo) GetByIDs(ctx context.Context, ids []int64) ([]models.Manga, error) {
+	var list []models.Manga
+	if len(ids) == 0 {
+		return list, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("get manga by ids: %w", err)
+	}
+	return list, nil
+}
+
+// GetBySlugs loads the manga with the given slugs in no particular order;
+// unknown slugs are skipped
+func (r *MangaRepo) GetBySlugs(ctx context.Context, slugs []string) ([]models.Manga, error) {
+	var list []models.Manga
+	if len(slugs) == 0 {
+		return list, nil
+	}
+	if err := r.db.WithContext(ctx).Preload("Genres").Where("slug IN ?", slugs).Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("get manga by slugs: %w", err)
+	}
+	return list, nil
+}
+
+// GetByMangaDexID looks up a manga by its MangaDex UUID
+func (r *MangaRepo) GetByMangaDexID(ctx context.Context, mangadexID string) (*models.Manga, error) {
+	var m models.Manga
+	if err := r.db.WithContext(ctx).Preload("Genres").Where("mangadex_id = ?", mangadexID).First(&m).Error; err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetByAniListID looks up a manga by its AniList media ID
+func (r *MangaRepo) GetByAniListID(ctx context.Context, anilistID int64) (*models.Manga, error) {
+	var m models.Manga
+	if err := r.db.WithContext(ctx).Preload("Genres").Where("anilist_id = ?", anilistID).First(&m).Error; err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ListAfterID returns up to limit manga with an id above afterID, in id
+// order and with genres preloaded. Passing the last id of one batch as the
+// next afterID walks the catalog without an OFFSET scan.
+func (r *MangaRepo) ListAfterID(ctx context.Context, afterID int64, limit int) ([]models.Manga, error) {
+	var list []models.Manga
+	if err := r.db.WithContext(ctx).
+		Preload("Genres").
+		Where("id > ?", afterID).
+		Order("id asc").
+		Limit(limit).
+		Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("list manga after id: %w", err)
+	}
+	return list, nil
+}
+
+// GetSyncInfo loads the ingestion IDs and sync timestamps of a manga
+func (r *MangaRepo) GetSyncInfo(ctx context.Context, id int64) (*models.MangaSyncInfo, error) {
+	var info models.MangaSyncInfo
+	if err := r.db.WithContext(ctx).Model(&models.Manga{}).
+		Select("id, mangadex_id, anilist_id, last_synced_at, last_chapter_check, anilist_last_synced_at, anilist_last_chapter_check").
+		Where("id = ?", id).
+		Take(&info).Error; err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
 func (r *MangaRepo) Create(ctx context.Context, m *models.Manga) error {
 	if err := r.db.WithContext(ctx).Create(m).Error; err != nil {
 		return fmt.Errorf("create manga: %w", err)
@@ -63,12 +141,50 @@ func (r *MangaRepo) Create(ctx context.Context, m *models.Manga) error {
 func (r *MangaRepo) Update(ctx context.Context, id int64, m *models.Manga) error {
 	// ensure ID set for Save
 	m.ID = id
-	if err := r.db.WithContext(ctx).Save(m).Error; err != nil {
+	// view_count is only ever incremented in place by IncrementViewCounts;
+	// saving a stale copy would drop the views flushed since it was loaded
+	if err := r.db.WithContext(ctx).Omit("view_count").Save(m).Error; err != nil {
 		return fmt.Errorf("update manga: %w", err)
 	}
 	return nil
 }
 
+// IncrementViewCounts adds each manga's pending views to its view_count in a
+// single statement. IDs that no longer exist are ignored.
+func (r *MangaRepo) IncrementViewCounts(ctx context.Context, counts map[int64]int64) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(counts))
+	args := make([]any, 0, 2*len(counts))
+	for id, n := range counts {
+		values = append(values, "(?::bigint, ?::bigint)")
+		args = append(args, id, n)
+	}
+
+	err := r.db.WithContext(ctx).Exec(
+		"UPDATE manga SET view_count = manga.view_count + v.n FROM (VALUES "+strings.Join(values, ", ")+") AS v(id, n) WHERE manga.id = v.id",
+		args...,
+	).Error
+	if err != nil {
+		return fmt.Errorf("increment view counts: %w", err)
+	}
+	return nil
+}
+
+// SlugExists reports whether another manga (any row but excludeID) already uses slug
+func (r *MangaRepo) SlugExists(ctx context.Context, slug string, excludeID int64) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Manga{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("check slug: %w", err)
+	}
+	return count > 0, nil
+}
+
 func (r *MangaRepo) Delete(ctx context.Context, id int64) error {
 	if err := r.db.WithContext(ctx).Delete(&models.Manga{}, id).Error; err != nil {
 		return fmt.Errorf("delete manga: %w", err)
@@ -76,33 +192,76 @@ func (r *MangaRepo) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// likeEscaper escapes LIKE metacharacters with Postgres' default escape character
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// containsPattern builds an ILIKE pattern matching s anywhere, so a search
+// for "50%" matches the literal text rather than acting as a wildcard
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
 // SearchByTitle performs case-insensitive partial match on title, author and slug.
 // Splits query into tokens and requires each token to appear in at least one of the fields.
+// When columns are given only those are loaded; callers must pass trusted column names.
 // Example: "one piece oda" -> WHERE (title ILIKE '%one%' OR author ILIKE '%one%' OR slug ILIKE '%one%')
 //
 //	AND (title ILIKE '%piece%' OR author ILIKE '%piece%' OR slug ILIKE '%piece%') ...
-func (r *MangaRepo) SearchByTitle(ctx context.Context, title string) ([]models.Manga, error) {
+func (r *MangaRepo) SearchByTitle(ctx context.Context, title string, columns ...string) ([]models.Manga, error) {
 	var list []models.Manga
 	tokens := strings.Fields(title)
 	db := r.db.WithContext(ctx)
+	if len(columns) > 0 {
+		db = db.Select(columns)
+	}
+
+	if len(tokens) == 0 {
+		return list, nil
+	}
+
+	where, args := titleSearchClause(tokens)
+	if err := db.Where(where, args...).Order("manga.created_at desc").Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("search manga by title/author: %w", err)
+	}
+	return list, nil
+}
 
+// SearchByTitleForUser is SearchByTitle with each result annotated with
+// userID's library entry and reading progress, left-joined in the same
+// query. Manga outside their library come back with InLibrary false.
+func (r *MangaRepo) SearchByTitleForUser(ctx context.Context, title, userID string) ([]models.MangaWithUserContext, error) {
+	var list []models.MangaWithUserContext
+	tokens := strings.Fields(title)
 	if len(tokens) == 0 {
 		return list, nil
 	}
 
+	where, args := titleSearchClause(tokens)
+	err := r.db.WithContext(ctx).
+		Table("manga").
+		Select("manga.*, user_library.id IS NOT NULL AS in_library, user_progress.current_chapter, user_progress.status AS reading_status").
+		Joins("LEFT JOIN user_library ON user_library.manga_id = manga.id AND user_library.user_id = ?", userID).
+		Joins("LEFT JOIN user_progress ON user_progress.manga_id = manga.id AND user_progress.user_id = ?", userID).
+		Where(where, args...).
+		Order("manga.created_at desc").
+		Scan(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("search manga with user context: %w", err)
+	}
+	return list, nil
+}
+
+// titleSearchClause requires every token to appear in the title, author or
+// slug. Columns are qualified so the clause survives joins.
+func titleSearchClause(tokens []string) (string, []interface{}) {
 	clauses := make([]string, 0, len(tokens))
 	args := make([]interface{}, 0, len(tokens)*3)
 	for _, t := range tokens {
-		p := "%" + t + "%"
-		clauses = append(clauses, "(title ILIKE ? OR COALESCE(author,'') ILIKE ? OR COALESCE(slug,'') ILIKE ?)")
+		p := containsPattern(t)
+		clauses = append(clauses, "(manga.title ILIKE ? OR COALESCE(manga.author,'') ILIKE ? OR COALESCE(manga.slug,'') ILIKE ?)")
 		args = append(args, p, p, p)
 	}
-
-	where := strings.Join(clauses, " AND ")
-	if err := db.Where(where, args...).Order("created_at desc").Find(&list).Error; err != nil {
-		return nil, fmt.Errorf("search manga by title/author: %w", err)
-	}
-	return list, nil
+	return strings.Join(clauses, " AND "), args
 }
 
 // AdvancedSearch performs full-text search with multiple filters
@@ -119,7 +278,7 @@ func (r *MangaRepo) AdvancedSearch(ctx context.Context, filters dto.SearchFilter
 			clauses := make([]string, 0, len(tokens))
 			args := make([]interface{}, 0, len(tokens)*4)
 			for _, t := range tokens {
-				p := "%" + t + "%"
+				p := containsPattern(t)
 				clauses = append(clauses, "(title ILIKE ? OR COALESCE(author,'') ILIKE ? OR COALESCE(description,'') ILIKE ? OR COALESCE(slug,'') ILIKE ?)")
 				args = append(args, p, p, p, p)
 			}
@@ -128,7 +287,7 @@ func (r *MangaRepo) AdvancedSearch(ctx context.Context, filters dto.SearchFilter
 		}
 	}
 
-	// Filter by status (ongoing, completed, hiatus)
+	// Filter by status (see models.MangaStatuses)
 	if filters.Status != "" {
 		db = db.Where("LOWER(status) = LOWER(?)", filters.Status)
 	}
@@ -139,9 +298,9 @@ func (r *MangaRepo) AdvancedSearch(ctx context.Context, filters dto.SearchFilter
 	}
 
 	// Filter by genres (many-to-many relationship)
-	if len(filters.Genres) > 0 {
-		genreConditions := make([]string, 0, len(filters.Genres))
-		genreArgs := make([]interface{}, 0, len(filters.Genres))
+	if len(filters.Genres) > 0 || len(filters.GenreIDs) > 0 {
+		genreConditions := make([]string, 0, len(filters.Genres)+len(filters.GenreIDs))
+		genreArgs := make([]interface{}, 0, len(filters.Genres)+len(filters.GenreIDs))
 
 		for _, g := range filters.Genres {
 			// Check if it's a numeric ID or name
@@ -153,13 +312,17 @@ func (r *MangaRepo) AdvancedSearch(ctx context.Context, filters dto.SearchFilter
 				genreArgs = append(genreArgs, g)
 			}
 		}
+		for _, id := range filters.GenreIDs {
+			genreConditions = append(genreConditions, "genres.id = ?")
+			genreArgs = append(genreArgs, id)
+		}
 
 		if len(genreConditions) > 0 {
 			db = db.Joins("JOIN manga_genres ON manga_genres.manga_id = manga.id").
 				Joins("JOIN genres ON genres.id = manga_genres.genre_id").
 				Where(strings.Join(genreConditions, " OR "), genreArgs...).
 				Group("manga.id").
-				Having("COUNT(DISTINCT genres.id) >= ?", len(filters.Genres))
+				Having("COUNT(DISTINCT genres.id) >= ?", len(genreConditions))
 		}
 	}
 
@@ -176,19 +339,14 @@ func (r *MangaRepo) AdvancedSearch(ctx context.Context, filters dto.SearchFilter
 		db = db.Order("created_at DESC")
 	case "title":
 		db = db.Order("title ASC")
+	case "views":
+		db = db.Order("view_count DESC")
 	default:
 		db = db.Order("created_at DESC")
 	}
 
 	// Pagination
-	page := filters.Page
-	if page < 1 {
-		page = 1
-	}
-	pageSize := filters.PageSize
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
-	}
+	page, pageSize := dto.NormalizePagination(filters.Page, filters.PageSize)
 	offset := (page - 1) * pageSize
 
 	// Fetch results without preloading genres for better performance
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainsPattern(t *testing.T) {
+	cases := map[string]string{
+		"naruto":    "%naruto%",
+		"50%":       `%50\%%`,
+		"one_piece": `%one\_piece%`,
+		`a\b`:       `%a\\b%`,
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, containsPattern(in), in)
+	}
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"mangahub/internal/microservices/http-api/models"
+
+	"gorm.io/gorm"
+)
+
+// MetadataReportRepository stores user reports of wrong manga metadata
+type MetadataReportRepository interface {
+	Create(ctx context.Context, report *models.MetadataReport) error
+	GetByID(ctx context.Context, id int64) (*models.MetadataReport, error)
+	// List returns a page of reports, newest first; an empty status lists all
+	List(ctx context.Context, status string, page, pageSize int) ([]models.MetadataReport, int64, error)
+	// Close sets the final status of an open report. It returns
+	// gorm.ErrRecordNotFound if the report doesn't exist or is already closed.
+	Close(ctx context.Context, id int64, status, adminID string, at time.Time) (*models.MetadataReport, error)
+}
+
+type metadataReportRepository struct {
+	db *gorm.DB
+}
+
+func NewMetadataReportRepository(db *gorm.DB) MetadataReportRepository {
+	return &metadataReportRepository{db: db}
+}
+
+func (r *metadataReportRepository) Create(ctx context.Context, report *models.MetadataReport) error {
+	return r.db.WithContext(ctx).Omit("User", "Manga").Create(report).Error
+}
+
+func (r *metadataReportRepository) GetByID(ctx context.Context, id int64) (*models.MetadataReport, error) {
+	var report models.MetadataReport
+	if err := r.db.WithContext(ctx).First(&report, id).Error; err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *metadataReportRepository) List(ctx context.Context, status string, page, pageSize int) ([]models.MetadataReport, int64, error) {
+	var reports []models.MetadataReport
+	var total int64
+
+	q := r.db.WithContext(ctx).Model(&models.MetadataReport{})
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := q.Preload("User").
+		Preload("Manga").
+		Order("created_at DESC").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&reports).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reports, total, nil
+}
+
+func (r *metadataReportRepository) Close(ctx context.Context, id int64, status, adminID string, at time.Time) (*models.MetadataReport, error) {
+	db := r.db.WithContext(ctx)
+
+	// Only open reports can be closed, so two admins can't both act on one
+	result := db.Model(&models.MetadataReport{}).
+		Where("id = ? AND status = ?", id, models.MetadataReportOpen).
+		Updates(map[string]any{"status": status, "resolved_by": adminID, "resolved_at": at})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	var report models.MetadataReport
+	if err := db.Preload("User").Preload("Manga").First(&report, id).Error; err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
     "context"
+    "time"
+
     "mangahub/internal/microservices/http-api/models"
     "gorm.io/gorm"
 )
@@ -11,6 +13,9 @@ type NotificationRepository interface {
     GetUnreadByUser(ctx context.Context, userID string) ([]models.Notification, error)
     MarkAsRead(ctx context.Context, notificationID int64) error
     MarkAllAsRead(ctx context.Context, userID string) error
+    // DeleteOlderThan deletes up to limit notifications created before
+    // cutoff, skipping unread ones if keepUnread, and returns how many went
+    DeleteOlderThan(ctx context.Context, cutoff time.Time, keepUnread bool, limit int) (int64, error)
 }
 
 type notificationRepository struct {
@@ -46,4 +51,18 @@ func (r *notificationRepository) MarkAllAsRead(ctx context.Context, userID strin
         Model(&models.Notification{}).
         Where("user_id = ?", userID).
         Update("read", true).Error
-}
\ No newline at end of file
+}
+
+func (r *notificationRepository) DeleteOlderThan(ctx context.Context, cutoff time.Time, keepUnread bool, limit int) (int64, error) {
+    // Bounded batches keep each DELETE short on a large table
+    batch := r.db.WithContext(ctx).Model(&models.Notification{}).
+        Select("id").
+        Where("created_at < ?", cutoff).
+        Order("id").
+        Limit(limit)
+    if keepUnread {
+        batch = batch.Where("read = true")
+    }
+    res := r.db.WithContext(ctx).Where("id IN (?)", batch).Delete(&models.Notification{})
+    return res.RowsAffected, res.Error
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type progressRepository struct {
@@ -17,6 +18,16 @@ type ProgressRepository interface {
 	GetProgressByMangaID(ctx context.Context, userID string, mangaID int64) (*models.UserProgress, error)
 	UpdateProgress(ctx context.Context, progress *models.UserProgress) error
 	DeleteProgress(ctx context.Context, userID string, mangaID int64) error
+	UpsertMany(ctx context.Context, progress []models.UserProgress) error
+	GetReaderRank(ctx context.Context, userID string, mangaID int64) (*ReaderRank, error)
+}
+
+// ReaderRank is where one reader's progress on a manga sits among everyone
+// reading it
+type ReaderRank struct {
+	Chapter     int     // The reader's current chapter
+	Readers     int64   // Readers of the manga, including this one
+	PercentRank float64 // Share of the other readers on an earlier chapter, 0 to 1
 }
 
 func NewProgressRepository(db *gorm.DB) ProgressRepository {
@@ -61,9 +72,59 @@ func (r *progressRepository) UpdateProgress(ctx context.Context, progress *model
 		"updated_at":      time.Now(),
 	}).Error
 }
+// DeleteProgress removes the user's progress on a manga.
+// Returns gorm.ErrRecordNotFound if there was none.
 func (r *progressRepository) DeleteProgress(ctx context.Context, userID string, mangaID int64) error {
-	if err := r.db.WithContext(ctx).Where("user_id = ? AND manga_id = ?", userID, mangaID).Delete(&models.UserProgress{}).Error; err != nil {
-		return err
+	res := r.db.WithContext(ctx).Where("user_id = ? AND manga_id = ?", userID, mangaID).Delete(&models.UserProgress{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
 	return nil
 }
+
+// UpsertMany writes several progress records in one transaction, updating the
+// chapter and status of any that already exist
+func (r *progressRepository) UpsertMany(ctx context.Context, progress []models.UserProgress) error {
+	if len(progress) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for i := range progress {
+		progress[i].UpdatedAt = now
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "manga_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"current_chapter", "status", "updated_at"}),
+		}).CreateInBatches(progress, 100).Error
+	})
+}
+
+// GetReaderRank ranks userID's progress on mangaID by chapter against other
+// readers. Plan-to-read entries aren't readers yet and are left out. It
+// returns gorm.ErrRecordNotFound if the user isn't reading the manga.
+func (r *progressRepository) GetReaderRank(ctx context.Context, userID string, mangaID int64) (*ReaderRank, error) {
+	var rank ReaderRank
+	res := r.db.WithContext(ctx).Raw(`
+		SELECT current_chapter AS chapter, readers, percent_rank
+		FROM (
+			SELECT user_id, current_chapter,
+				COUNT(*) OVER () AS readers,
+				PERCENT_RANK() OVER (ORDER BY current_chapter) AS percent_rank
+			FROM user_progress
+			WHERE manga_id = ? AND status <> 'plan_to_read'
+		) ranked
+		WHERE user_id = ?`, mangaID, userID).Scan(&rank)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &rank, nil
+}
@@ -16,6 +16,15 @@ type RatingRepository interface {
 	GetByManga(mangaID int64, page, pageSize int) ([]models.Rating, int64, error)
 	CalculateAverageRating(mangaID int64) (float64, error)
 	CountRatings(mangaID int64) (int64, error)
+	CountByUser(userID string) (int64, error)
+	AverageRatings(mangaIDs []int64) (map[int64]RatingSummary, error)
+}
+
+// RatingSummary is the average and number of ratings for one manga
+type RatingSummary struct {
+	MangaID int64
+	Average float64
+	Count   int64
 }
 
 type ratingRepository struct {
@@ -110,3 +119,34 @@ func (r *ratingRepository) CountRatings(mangaID int64) (int64, error) {
 	err := r.db.Model(&models.Rating{}).Where("manga_id = ?", mangaID).Count(&count).Error
 	return count, err
 }
+
+// CountByUser counts the ratings a user has given
+func (r *ratingRepository) CountByUser(userID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Rating{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// AverageRatings computes the average and count for several manga in one
+// grouped query. Manga without ratings are absent from the result.
+func (r *ratingRepository) AverageRatings(mangaIDs []int64) (map[int64]RatingSummary, error) {
+	result := make(map[int64]RatingSummary, len(mangaIDs))
+	if len(mangaIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []RatingSummary
+	err := r.db.Model(&models.Rating{}).
+		Select("manga_id, AVG(rating) AS average, COUNT(*) AS count").
+		Where("manga_id IN ?", mangaIDs).
+		Group("manga_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.MangaID] = row
+	}
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// recentlyViewedTTL expires lists of users who stop browsing
+	recentlyViewedTTL = 90 * 24 * time.Hour
+
+	defaultRecentlyViewedMax = 20
+)
+
+// RecentlyViewedRepository keeps a capped, most-recent-first list of the
+// manga each user has opened
+type RecentlyViewedRepository interface {
+	Push(ctx context.Context, userID string, mangaID int64) error
+	List(ctx context.Context, userID string) ([]int64, error)
+}
+
+type recentlyViewedRedisRepo struct {
+	client *redis.Client
+	maxLen int
+}
+
+// NewRecentlyViewedRedisRepo stores each user's list in a Redis list capped at maxLen
+func NewRecentlyViewedRedisRepo(client *redis.Client, maxLen int) RecentlyViewedRepository {
+	if maxLen < 1 {
+		maxLen = defaultRecentlyViewedMax
+	}
+	return &recentlyViewedRedisRepo{client: client, maxLen: maxLen}
+}
+
+func recentlyViewedKey(userID string) string {
+	return "recently_viewed:user:" + userID
+}
+
+// Push moves mangaID to the front, dropping any earlier entry for it and
+// trimming the list to maxLen
+func (r *recentlyViewedRedisRepo) Push(ctx context.Context, userID string, mangaID int64) error {
+	key := recentlyViewedKey(userID)
+	member := strconv.FormatInt(mangaID, 10)
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LRem(ctx, key, 0, member)
+		pipe.LPush(ctx, key, member)
+		pipe.LTrim(ctx, key, 0, int64(r.maxLen-1))
+		pipe.Expire(ctx, key, recentlyViewedTTL)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("push recently viewed: %w", err)
+	}
+	return nil
+}
+
+// List returns the user's manga IDs, most recent first
+func (r *recentlyViewedRedisRepo) List(ctx context.Context, userID string) ([]int64, error) {
+	members, err := r.client.LRange(ctx, recentlyViewedKey(userID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list recently viewed: %w", err)
+	}
+
+	ids := make([]int64, 0, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseInt(m, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -10,6 +10,8 @@ import (
 type RefreshTokenRepository interface {
 	Create(refreshToken *models.RefreshToken) error
 	FindByToken(tokenString string) (*models.RefreshToken, error)
+	FindByID(tokenID string) (*models.RefreshToken, error)
+	ListActiveByUser(userID string) ([]models.RefreshToken, error)
 	Revoke(tokenID string) error
 	Delete(tokenID string) error
 	DeleteExpired() error
@@ -41,6 +43,26 @@ func (r *refreshTokenRepository) FindByToken(tokenString string) (*models.Refres
 	return &refreshToken, nil
 }
 
+// FindByID: look up the refresh token by its primary key
+func (r *refreshTokenRepository) FindByID(tokenID string) (*models.RefreshToken, error) {
+	var refreshToken models.RefreshToken
+	if err := r.db.Where("id = ?", tokenID).First(&refreshToken).Error; err != nil {
+		return nil, err
+	}
+	return &refreshToken, nil
+}
+
+// ListActiveByUser: unrevoked, unexpired refresh tokens of a user (one per signed-in session),
+// most recently used first
+func (r *refreshTokenRepository) ListActiveByUser(userID string) ([]models.RefreshToken, error) {
+	var tokens []models.RefreshToken
+	err := r.db.
+		Where("user_id = ? AND revoked = ? AND expires_at > ?", userID, false, gorm.Expr("NOW()")).
+		Order("COALESCE(last_used_at, created_at) DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
 // Revoke: marks a refresh token as revoked
 func (r *refreshTokenRepository) Revoke(tokenID string) error {
 	return r.db.Model(&models.RefreshToken{}).Where("id = ?", tokenID).Update("revoked", true).Error
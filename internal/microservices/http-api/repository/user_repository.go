@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"mangahub/internal/microservices/http-api/models"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -16,6 +17,12 @@ type UserRepository interface {
 	FindByEmail(email string) (*models.User, error)
 	// GetAllIDs returns all user IDs in the system
 	GetAllIDs(ctx context.Context) ([]string, error)
+	// List returns a page of users, newest first, and the total count
+	List(ctx context.Context, page, pageSize int) ([]models.User, int64, error)
+	// UpdateLastLogin records when and from where the user last signed in
+	UpdateLastLogin(ctx context.Context, id string, at time.Time, ip string) error
+	// UpdatePrivacy sets the profile privacy flags that are non-nil
+	UpdatePrivacy(ctx context.Context, id string, profilePublic, libraryPublic *bool) error
 }
 
 // userRepository is the GORM implementation of UserRepository.
@@ -69,3 +76,54 @@ func (r *userRepository) GetAllIDs(ctx context.Context) ([]string, error) {
 	}
 	return ids, nil
 }
+
+// List returns a page of users ordered by creation time, newest first
+func (r *userRepository) List(ctx context.Context, page, pageSize int) ([]models.User, int64, error) {
+	var users []models.User
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&models.User{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := r.db.WithContext(ctx).
+		Order("created_at desc").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// UpdateLastLogin sets last_login and last_login_ip without touching updated_at
+func (r *userRepository) UpdateLastLogin(ctx context.Context, id string, at time.Time, ip string) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]any{"last_login": at, "last_login_ip": ip}).Error
+}
+
+// UpdatePrivacy sets profile_public and library_public, leaving nil ones alone
+func (r *userRepository) UpdatePrivacy(ctx context.Context, id string, profilePublic, libraryPublic *bool) error {
+	updates := map[string]any{}
+	if profilePublic != nil {
+		updates["profile_public"] = *profilePublic
+	}
+	if libraryPublic != nil {
+		updates["library_public"] = *libraryPublic
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	result := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
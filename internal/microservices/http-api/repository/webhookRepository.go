@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"mangahub/internal/microservices/http-api/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrWebhookLimitReached is returned by CreateWithLimit when the user already
+// has limit webhooks
+var ErrWebhookLimitReached = errors.New("webhook limit reached")
+
+// WebhookRepository stores users' webhooks and their delivery health
+type WebhookRepository interface {
+	// CreateWithLimit inserts the webhook unless its user already has limit
+	// webhooks, in which case it returns ErrWebhookLimitReached
+	CreateWithLimit(ctx context.Context, webhook *models.Webhook, limit int) error
+	// Get returns one of the user's webhooks, or gorm.ErrRecordNotFound
+	Get(ctx context.Context, userID string, id int64) (*models.Webhook, error)
+	List(ctx context.Context, userID string) ([]models.Webhook, error)
+	Update(ctx context.Context, webhook *models.Webhook) error
+	// Delete removes one of the user's webhooks, or returns gorm.ErrRecordNotFound
+	Delete(ctx context.Context, userID string, id int64) error
+
+	// ListActive returns every active webhook subscribed to event
+	ListActive(ctx context.Context, event string) ([]models.Webhook, error)
+	// ListActiveForManga returns the active webhooks subscribed to event of
+	// the users with mangaID in their library
+	ListActiveForManga(ctx context.Context, event string, mangaID int64) ([]models.Webhook, error)
+	// RecordSuccess clears the webhook's consecutive failure count
+	RecordSuccess(ctx context.Context, id int64) error
+	// RecordFailure counts a failed delivery and deactivates the webhook once
+	// it has failed maxFailures times in a row, reporting whether it did
+	RecordFailure(ctx context.Context, id int64, message string, maxFailures int) (disabled bool, err error)
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// CreateWithLimit locks the user's row for the transaction so two concurrent
+// creates can't both slip under the limit
+func (r *webhookRepository) CreateWithLimit(ctx context.Context, webhook *models.Webhook, limit int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT 1 FROM users WHERE id = ? FOR UPDATE", webhook.UserID).Error; err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&models.Webhook{}).Where("user_id = ?", webhook.UserID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= int64(limit) {
+			return ErrWebhookLimitReached
+		}
+		return tx.Create(webhook).Error
+	})
+}
+
+func (r *webhookRepository) Get(ctx context.Context, userID string, id int64) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&webhook).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepository) List(ctx context.Context, userID string) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// Update saves the editable fields and the failure state, which is reset
+// when a webhook is re-enabled
+func (r *webhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	return r.db.WithContext(ctx).Model(webhook).
+		Select("url", "events", "active", "failure_count", "last_error", "updated_at").
+		Updates(webhook).Error
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, userID string, id int64) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.Webhook{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepository) ListActive(ctx context.Context, event string) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.subscribed(ctx, event).Find(&webhooks).Error
+	return webhooks, err
+}
+
+// ListActiveForManga selects the library's users in a subquery rather than
+// binding their IDs, which a popular manga would push past Postgres's
+// parameter limit
+func (r *webhookRepository) ListActiveForManga(ctx context.Context, event string, mangaID int64) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.subscribed(ctx, event).
+		Where("user_id IN (SELECT user_id FROM user_library WHERE manga_id = ?)", mangaID).
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+// subscribed scopes a query to active webhooks subscribed to event
+func (r *webhookRepository) subscribed(ctx context.Context, event string) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Where("active").
+		Where("',' || events || ',' LIKE ?", "%,"+event+",%")
+}
+
+func (r *webhookRepository) RecordSuccess(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Model(&models.Webhook{}).
+		Where("id = ? AND failure_count > 0", id).
+		UpdateColumn("failure_count", 0).Error
+}
+
+func (r *webhookRepository) RecordFailure(ctx context.Context, id int64, message string, maxFailures int) (bool, error) {
+	var result struct{ Active bool }
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE webhooks SET
+			failure_count = failure_count + 1,
+			active = active AND failure_count + 1 < ?,
+			last_error = ?,
+			last_failure_at = ?
+		WHERE id = ?
+		RETURNING active`,
+		maxFailures, message, time.Now(), id,
+	).Scan(&result).Error
+	if err != nil {
+		return false, err
+	}
+	return !result.Active, nil
+}
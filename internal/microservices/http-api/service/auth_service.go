@@ -2,8 +2,10 @@ package service
 
 // upgrade to OAUTH2.1
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log"
 	"mangahub/internal/config"
 	"mangahub/internal/microservices/http-api/models"
 	"mangahub/internal/microservices/http-api/repository"
@@ -13,6 +15,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 var (
@@ -21,35 +24,69 @@ var (
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrExpiredToken       = errors.New("token has expired")
 	ErrEmailInUse         = errors.New("email already in use")
+	ErrInvalidScope       = errors.New("none of the requested scopes are allowed")
 )
 
+// scopesByRole lists the scopes each role may hold; tokens get all of them
+// unless the client asks for a subset at login
+var scopesByRole = map[string][]string{
+	"admin": {"read:*", "write:*", "delete:*", "admin:*", "library:*"},
+	"user": {
+		"read:manga", "read:library", "read:genre", "read:progress",
+		"write:comment", "write:profile", "write:community_chat", "write:library", "write:progress",
+	},
+}
+
 type AuthService interface {
 	Register(username, password, email string) (*models.User, error)
-	Login(username, password, email string) (accessToken, refreshToken string, user *models.User, err error)
-	RefreshAccessToken(refreshToken string) (newAccessToken, newRefreshToken string, err error)
+	Login(username, password, email string, requestedScopes []string, session SessionInfo) (accessToken, refreshToken string, expiresIn time.Duration, user *models.User, scopes []string, err error)
+	RefreshAccessToken(refreshToken string) (newAccessToken, newRefreshToken string, expiresIn time.Duration, err error)
 	ValidateToken(tokenString string) (*Claims, error)
 	RevokeToken(refreshToken string) error
+	ListSessions(userID string) ([]models.RefreshToken, error)
+	RevokeSession(userID, sessionID string) error
+	PasswordPolicy() PasswordPolicy
 }
 
+// MinAccessTokenTTL is the shortest access-token lifetime a client can ask for
+const MinAccessTokenTTL = time.Minute
+
 type authService struct {
-	userRepo         repository.UserRepository
-	refreshTokenRepo repository.RefreshTokenRepository
-	jwtSecret        string
-	accessTokenTTL   time.Duration
-	refreshTokenTTL  time.Duration
+	userRepo          repository.UserRepository
+	refreshTokenRepo  repository.RefreshTokenRepository
+	jwtSecret         string
+	accessTokenTTL    time.Duration
+	accessTokenMaxTTL time.Duration
+	refreshTokenTTL   time.Duration
+	authenticator     Authenticator
+	passwordPolicy    PasswordPolicy
 }
 
 func NewAuthService(
 	userRepo repository.UserRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
 	cfg *config.Config,
+) AuthService {
+	return NewAuthServiceWithAuthenticator(userRepo, refreshTokenRepo, cfg, NewDBAuthenticator(userRepo))
+}
+
+// NewAuthServiceWithAuthenticator is NewAuthService with a custom credential
+// check for Login, e.g. an LDAP or SSO backend. Tokens are still issued locally.
+func NewAuthServiceWithAuthenticator(
+	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	cfg *config.Config,
+	authenticator Authenticator,
 ) AuthService {
 	return &authService{
-		userRepo:         userRepo,
-		refreshTokenRepo: refreshTokenRepo,
-		jwtSecret:        cfg.JWTSecret,
-		accessTokenTTL:   cfg.AccessTokenTTL,  // 15 minutes
-		refreshTokenTTL:  cfg.RefreshTokenTTL, // 7 days
+		userRepo:          userRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		authenticator:     authenticator,
+		passwordPolicy:    PasswordPolicyFromConfig(cfg),
+		jwtSecret:         cfg.JWTSecret,
+		accessTokenTTL:    cfg.AccessTokenTTL,    // 15 minutes
+		accessTokenMaxTTL: cfg.AccessTokenMaxTTL, // cap for client token_ttl hints
+		refreshTokenTTL:   cfg.RefreshTokenTTL,   // 7 days
 	}
 }
 
@@ -74,6 +111,11 @@ func (c Claims) ScopeString() string {
 // Todo: add DTOs for input rather than passing raw strings
 // Register: registers a new user with the given username, password, and email.
 func (s *authService) Register(username, password, email string) (*models.User, error) {
+	// Enforce the password policy before touching the DB or hashing
+	if err := s.passwordPolicy.Check(password); err != nil {
+		return nil, err
+	}
+
 	// Check if user exists
 	if _, err := s.userRepo.FindByUsername(username); err == nil {
 		return nil, ErrNameInUse
@@ -105,40 +147,69 @@ func (s *authService) Register(username, password, email string) (*models.User,
 	return user, nil
 }
 
+// PasswordPolicy: the rules Register enforces on new passwords
+func (s *authService) PasswordPolicy() PasswordPolicy {
+	return s.passwordPolicy
+}
+
 // Login: authenticates a user and returns access and refresh tokens upon successful login.
-func (s *authService) Login(username, password, email string) (string, string, *models.User, error) {
-	// Find user
-	var user *models.User
-	var err error
-	if username != "" {
-		user, err = s.userRepo.FindByUsername(username)
-	} else if email != "" {
-		user, err = s.userRepo.FindByEmail(email)
-	}
+// requestedScopes narrows the access token to a subset of the role's scopes (least privilege);
+// when empty the token carries every scope of the role. The granted scopes are returned,
+// along with the access token's lifetime for expires_in.
+// session describes the client and is stored on the refresh token for session management.
+func (s *authService) Login(username, password, email string, requestedScopes []string, session SessionInfo) (string, string, time.Duration, *models.User, []string, error) {
+	// Verify credentials, falling back to the email when no username is given
+	identifier := username
+	if identifier == "" {
+		identifier = email
+	}
+	user, err := s.authenticator.Verify(identifier, password)
 	if err != nil {
-		// User not found we use dummy compare to mitigate timing attacks (always take same time)
-		_ = bcrypt.CompareHashAndPassword([]byte("$2a$10$7EqJtq98hPqEX7fNZaFWoOhi6Cq1h0u3b0j3Z6h5y5jY5f5h5F5eW"), []byte(password))
-		return "", "", nil, ErrInvalidCredentials
+		return "", "", 0, nil, nil, err
 	}
 
-	// Verify password
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", "", nil, ErrInvalidCredentials
+	// Generate access token (short-lived, 15 min unless the client asked otherwise)
+	var accessToken string
+	scopes := scopesByRole[user.Role]
+	ttl := s.accessTTL(session.AccessTokenTTL)
+	if len(requestedScopes) > 0 {
+		accessToken, scopes, err = s.generateAccessTokenWithRequestedScopes(user, ttl, requestedScopes)
+	} else {
+		accessToken, err = s.generateAccessTokenWithScopes(user, ttl) // default role is "user"
 	}
-
-	// Generate access token (short-lived, 15 min)
-	accessToken, err := s.generateAccessTokenWithScopes(user) // default role is "user"
 	if err != nil {
-		return "", "", nil, err
+		return "", "", 0, nil, nil, err
 	}
 
-	// Generate refresh token (long-lived, 7 days)
-	refreshToken, err := s.generateRefreshToken(user)
+	// Generate refresh token (long-lived, 7 days), remembering a narrowed scope for rotation
+	var refreshScope []string
+	if len(requestedScopes) > 0 {
+		refreshScope = scopes
+	}
+	refreshToken, err := s.generateRefreshToken(user, refreshScope, session.normalize())
 	if err != nil {
-		return "", "", nil, err
+		return "", "", 0, nil, nil, err
 	}
 
-	return accessToken, refreshToken, user, nil
+	s.recordLogin(user.ID, session.IPAddress)
+
+	return accessToken, refreshToken, ttl, user, scopes, nil
+}
+
+// lastLoginTimeout bounds the background last-login write
+const lastLoginTimeout = 5 * time.Second
+
+// recordLogin stores the login time and IP in the background so the write
+// doesn't add latency to the login response; failures are only logged
+func (s *authService) recordLogin(userID, ip string) {
+	at := time.Now()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), lastLoginTimeout)
+		defer cancel()
+		if err := s.userRepo.UpdateLastLogin(ctx, userID, at, ip); err != nil {
+			log.Printf("auth: failed to record last login for user %s: %v", userID, err)
+		}
+	}()
 }
 
 // this version is simple JWT generation without OAUTH2.1 specifics
@@ -155,25 +226,36 @@ func (s *authService) generateAccessToken(user *models.User) (string, error) {
 	return token.SignedString([]byte(s.jwtSecret))
 }
 
-// generateAccessTokenWithScopes: generates an access token with specific scopes based on user role or custom scopes.
-func (s *authService) generateAccessTokenWithScopes(user *models.User, customScopes ...string) (string, error) {
-	// Define default scopes based on role
-	scopeByRole := map[string][]string{
-		"admin": {"read:*", "write:*", "delete:*", "admin:*", "library:*"},
-		"user": {
-			"read:manga", "read:library", "read:genre", "read:progress",
-			"write:comment", "write:profile", "write:community_chat", "write:library", "write:progress",
-		},
+// accessTTL returns the lifetime for a new access token: the client's hint
+// clamped to [MinAccessTokenTTL, max TTL], or the default when there is none
+func (s *authService) accessTTL(requested time.Duration) time.Duration {
+	if requested <= 0 {
+		return s.accessTokenTTL
 	}
+	maxTTL := s.accessTokenMaxTTL
+	if maxTTL < s.accessTokenTTL {
+		maxTTL = s.accessTokenTTL
+	}
+	if requested < MinAccessTokenTTL {
+		requested = MinAccessTokenTTL
+	}
+	if requested > maxTTL {
+		requested = maxTTL
+	}
+	return requested
+}
 
+// generateAccessTokenWithScopes: generates an access token valid for ttl with specific scopes based on user role or custom scopes.
+func (s *authService) generateAccessTokenWithScopes(user *models.User, ttl time.Duration, customScopes ...string) (string, error) {
 	// Get custom scopes if provided, else use default based on role
 	var scopes []string
 	if len(customScopes) > 0 {
 		scopes = customScopes
 	} else {
-		scopes = scopeByRole[user.Role]
+		scopes = scopesByRole[user.Role]
 	}
 
+	now := time.Now()
 	claims := Claims{
 		UserID:   user.ID,
 		Username: user.Username,
@@ -181,8 +263,8 @@ func (s *authService) generateAccessTokenWithScopes(user *models.User, customSco
 		Role:     user.Role,
 		Scopes:   scopes,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.accessTokenTTL)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
 			Issuer:    "mangahub",
 			Subject:   user.ID,
 		},
@@ -195,29 +277,44 @@ func (s *authService) generateAccessTokenWithScopes(user *models.User, customSco
 
 // generateAccessTokenWithRequestedScopes: generates an access token with specific requested scopes after validating them against allowed scopes.
 // This is useful for OAUTH2.1 where clients can request specific scopes during authorization.
-func (s *authService) generateAccessTokenWithRequestedScopes(user *models.User, requestedScopes []string) (string, error) {
-	// Define allowed scopes based on user role
-	allowedScopesByRole := map[string][]string{
-		"admin": {"read:*", "write:*", "delete:*", "admin:*", "library:*"},
-		"user": {
-			"read:manga", "read:library", "read:genre", "read:progress",
-			"write:comment", "write:profile", "write:community_chat", "write:library", "write:progress",
-		},
+// Returns ErrInvalidScope rather than falling back to the role's full scope set when nothing is granted.
+func (s *authService) generateAccessTokenWithRequestedScopes(user *models.User, ttl time.Duration, requestedScopes []string) (string, []string, error) {
+	grantedScopes := grantScopes(scopesByRole[user.Role], requestedScopes)
+	if len(grantedScopes) == 0 {
+		return "", nil, ErrInvalidScope
+	}
+
+	token, err := s.generateAccessTokenWithScopes(user, ttl, grantedScopes...)
+	if err != nil {
+		return "", nil, err
 	}
-	allowed := allowedScopesByRole[user.Role]
+	return token, grantedScopes, nil
+}
 
-	// Filter requested scopes to only those allowed for this role
-	var grantedScopes []string
+// grantScopes filters requested scopes down to those covered by the allowed set, dropping
+// duplicates and malformed scopes (anything not shaped "action:resource")
+func grantScopes(allowed, requestedScopes []string) []string {
+	var granted []string
 	for _, requested := range requestedScopes {
-		// set up wildcard support
-		prefix := requested[:strings.Index(requested, ":")+1]
-		wildcard := prefix + "*"
-		if contains(allowed, requested) || contains(allowed, wildcard) {
-			grantedScopes = append(grantedScopes, requested)
+		action, _, ok := splitScope(requested)
+		if !ok || contains(granted, requested) {
+			continue
+		}
+		// set up wildcard support: "read:manga" is covered by "read:*"
+		if contains(allowed, requested) || contains(allowed, action+":*") {
+			granted = append(granted, requested)
 		}
 	}
+	return granted
+}
 
-	return s.generateAccessTokenWithScopes(user, grantedScopes...)
+// splitScope splits "action:resource"; both halves must be non-empty
+func splitScope(scope string) (action, resource string, ok bool) {
+	action, resource, found := strings.Cut(scope, ":")
+	if !found || action == "" || resource == "" || strings.Contains(resource, ":") {
+		return "", "", false
+	}
+	return action, resource, true
 }
 
 // Contains checks if a slice contains a specific string
@@ -240,12 +337,20 @@ func matchesWildcard(pattern, item string) bool {
 }
 
 // generateRefreshToken: creates a new refresh token for the user and stores it in the database.
-func (s *authService) generateRefreshToken(user *models.User) (string, error) {
+// A non-empty scope is kept on the token so rotated access tokens stay narrowed.
+func (s *authService) generateRefreshToken(user *models.User, scope []string, session SessionInfo) (string, error) {
+	now := time.Now()
 	refreshToken := &models.RefreshToken{
-		ID:        uuid.New().String(),
-		UserID:    user.ID,
-		Token:     uuid.New().String(), // Simple UUID as refresh token
-		ExpiresAt: time.Now().Add(s.refreshTokenTTL),
+		ID:             uuid.New().String(),
+		UserID:         user.ID,
+		Token:          uuid.New().String(), // Simple UUID as refresh token
+		Scope:          strings.Join(scope, " "),
+		ExpiresAt:      now.Add(s.refreshTokenTTL),
+		UserAgent:      session.UserAgent,
+		IPAddress:      session.IPAddress,
+		DeviceName:     session.DeviceName,
+		LastUsedAt:     &now,
+		AccessTokenTTL: int64(session.AccessTokenTTL / time.Second),
 	}
 
 	if err := s.refreshTokenRepo.Create(refreshToken); err != nil {
@@ -255,48 +360,61 @@ func (s *authService) generateRefreshToken(user *models.User) (string, error) {
 	return refreshToken.Token, nil
 }
 
-func (s *authService) RefreshAccessToken(refreshTokenString string) (string, string, error) {
+func (s *authService) RefreshAccessToken(refreshTokenString string) (string, string, time.Duration, error) {
 	// Validate refresh token
 	refreshToken, err := s.refreshTokenRepo.FindByToken(refreshTokenString)
 	if err != nil {
-		return "", "", errors.New("invalid refresh token")
+		return "", "", 0, errors.New("invalid refresh token")
 	}
 
 	// Check expiration
 	if time.Now().After(refreshToken.ExpiresAt) {
 		s.refreshTokenRepo.Delete(refreshToken.ID)
-		return "", "", errors.New("refresh token expired")
+		return "", "", 0, errors.New("refresh token expired")
 	}
 
 	// Check if revoked
 	if refreshToken.Revoked {
 		s.refreshTokenRepo.Delete(refreshToken.ID)
-		return "", "", errors.New("refresh token revoked")
+		return "", "", 0, errors.New("refresh token revoked")
 	}
 
 	// Get user
 	user, err := s.userRepo.FindByID(refreshToken.UserID)
 	if err != nil {
-		return "", "", err
+		return "", "", 0, err
 	}
 	// Rotate refresh token
 	// Invalidate the old refresh token
 	if err := s.refreshTokenRepo.Revoke(refreshToken.ID); err != nil {
 		s.refreshTokenRepo.Delete(refreshToken.ID)
-		return "", "", err
+		return "", "", 0, err
+	}
+	// Issue a new access token, re-checking a narrowed scope against the user's current role
+	// and the session's TTL hint against the current maximum
+	session := SessionInfo{
+		UserAgent:      refreshToken.UserAgent,
+		IPAddress:      refreshToken.IPAddress,
+		DeviceName:     refreshToken.DeviceName,
+		AccessTokenTTL: time.Duration(refreshToken.AccessTokenTTL) * time.Second,
+	}
+	ttl := s.accessTTL(session.AccessTokenTTL)
+	var newAccessToken string
+	var scopes []string
+	if refreshToken.Scope != "" {
+		newAccessToken, scopes, err = s.generateAccessTokenWithRequestedScopes(user, ttl, strings.Fields(refreshToken.Scope))
+	} else {
+		newAccessToken, err = s.generateAccessTokenWithScopes(user, ttl)
 	}
-	// Issue a new access token
-	newAccessToken, err := s.generateAccessTokenWithScopes(user)
 	if err != nil {
-		return "", "", err
+		return "", "", 0, err
 	}
-	// Issue a new refresh token
-	newRefreshToken, err := s.generateRefreshToken(user)
+	// Issue a new refresh token for the same session
+	newRefreshToken, err := s.generateRefreshToken(user, scopes, session)
 	if err != nil {
-		return "", "", err
+		return "", "", 0, err
 	}
-	// Generate new access token
-	return newAccessToken, newRefreshToken, nil
+	return newAccessToken, newRefreshToken, ttl, nil
 }
 
 func (s *authService) ValidateToken(tokenString string) (*Claims, error) {
@@ -355,3 +473,27 @@ func (s *authService) RevokeToken(refreshTokenString string) error {
 	fmt.Println("Refresh token revoked successfully")
 	return nil
 }
+
+// ListSessions: the user's signed-in sessions, one per active refresh token
+func (s *authService) ListSessions(userID string) ([]models.RefreshToken, error) {
+	return s.refreshTokenRepo.ListActiveByUser(userID)
+}
+
+// RevokeSession: signs a session out by revoking its refresh token. Sessions of
+// other users are reported as not found so IDs can't be probed.
+func (s *authService) RevokeSession(userID, sessionID string) error {
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return ErrSessionNotFound
+	}
+	refreshToken, err := s.refreshTokenRepo.FindByID(sessionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if refreshToken.UserID != userID || refreshToken.Revoked {
+		return ErrSessionNotFound
+	}
+	return s.refreshTokenRepo.Revoke(refreshToken.ID)
+}
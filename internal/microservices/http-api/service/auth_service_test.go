@@ -49,6 +49,24 @@ func (m *MockUserRepository) FindByEmail(email string) (*models.User, error) {
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) List(ctx context.Context, page, pageSize int) ([]models.User, int64, error) {
+	args := m.Called(ctx, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]models.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserRepository) UpdateLastLogin(ctx context.Context, id string, at time.Time, ip string) error {
+	args := m.Called(ctx, id, at, ip)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdatePrivacy(ctx context.Context, id string, profilePublic, libraryPublic *bool) error {
+	args := m.Called(ctx, id, profilePublic, libraryPublic)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) GetAllIDs(ctx context.Context) ([]string, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -75,6 +93,22 @@ func (m *MockRefreshTokenRepository) FindByToken(token string) (*models.RefreshT
 	return args.Get(0).(*models.RefreshToken), args.Error(1)
 }
 
+func (m *MockRefreshTokenRepository) FindByID(id string) (*models.RefreshToken, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) ListActiveByUser(userID string) ([]models.RefreshToken, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.RefreshToken), args.Error(1)
+}
+
 func (m *MockRefreshTokenRepository) Delete(id string) error {
 	args := m.Called(id)
 	return args.Error(0)
@@ -168,9 +202,10 @@ func TestLogin_Success(t *testing.T) {
 	}
 
 	mockUserRepo.On("FindByUsername", "testuser").Return(user, nil)
+	allowLastLogin(mockUserRepo)
 	mockRefreshTokenRepo.On("Create", mock.AnythingOfType("*models.RefreshToken")).Return(nil)
 
-	accessToken, refreshToken, returnedUser, err := authService.Login("testuser", "password123", "")
+	accessToken, refreshToken, _, returnedUser, _, err := authService.Login("testuser", "password123", "", nil, SessionInfo{})
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, accessToken)
@@ -195,7 +230,7 @@ func TestLogin_InvalidPassword(t *testing.T) {
 
 	mockUserRepo.On("FindByUsername", "testuser").Return(user, nil)
 
-	accessToken, refreshToken, returnedUser, err := authService.Login("testuser", "wrongpassword", "")
+	accessToken, refreshToken, _, returnedUser, _, err := authService.Login("testuser", "wrongpassword", "", nil, SessionInfo{})
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrInvalidCredentials, err)
@@ -213,7 +248,7 @@ func TestLogin_UserNotFound(t *testing.T) {
 
 	mockUserRepo.On("FindByUsername", "nonexistent").Return(nil, gorm.ErrRecordNotFound)
 
-	accessToken, refreshToken, user, err := authService.Login("nonexistent", "password123", "")
+	accessToken, refreshToken, _, user, _, err := authService.Login("nonexistent", "password123", "", nil, SessionInfo{})
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrInvalidCredentials, err)
@@ -223,6 +258,254 @@ func TestLogin_UserNotFound(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 }
 
+// allowLastLogin accepts the background last-login write that follows a successful Login
+func allowLastLogin(repo *MockUserRepository) {
+	repo.On("UpdateLastLogin", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+}
+
+// fakeAuthenticator accepts a single fixed password for any known user
+type fakeAuthenticator struct {
+	users    map[string]*models.User
+	password string
+	calls    []string
+}
+
+func (f *fakeAuthenticator) Verify(username, password string) (*models.User, error) {
+	f.calls = append(f.calls, username)
+	user, ok := f.users[username]
+	if !ok || password != f.password {
+		return nil, ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+func TestLogin_UsesAuthenticator(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	cfg := &config.Config{JWTSecret: "test-secret", AccessTokenTTL: 15 * time.Minute}
+	user := &models.User{ID: "user-id", Username: "ldapuser", Email: "ldap@example.com", Role: "user"}
+	authn := &fakeAuthenticator{users: map[string]*models.User{"ldapuser": user}, password: "secret"}
+	authService := NewAuthServiceWithAuthenticator(mockUserRepo, mockRefreshTokenRepo, cfg, authn)
+
+	allowLastLogin(mockUserRepo)
+	mockRefreshTokenRepo.On("Create", mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+
+	accessToken, refreshToken, _, returnedUser, _, err := authService.Login("ldapuser", "secret", "", nil, SessionInfo{})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, accessToken)
+	assert.NotEmpty(t, refreshToken)
+	assert.Equal(t, user, returnedUser)
+	assert.Equal(t, []string{"ldapuser"}, authn.calls)
+	// The user repository is never consulted for the credential check
+	mockUserRepo.AssertNotCalled(t, "FindByUsername", mock.Anything)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestLogin_AuthenticatorRejects(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	authn := &fakeAuthenticator{users: map[string]*models.User{}, password: "secret"}
+	authService := NewAuthServiceWithAuthenticator(mockUserRepo, mockRefreshTokenRepo, cfg, authn)
+
+	accessToken, refreshToken, _, user, _, err := authService.Login("", "secret", "nobody@example.com", nil, SessionInfo{})
+
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Empty(t, accessToken)
+	assert.Empty(t, refreshToken)
+	assert.Nil(t, user)
+	assert.Equal(t, []string{"nobody@example.com"}, authn.calls)
+	mockRefreshTokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestLogin_RequestedScopes(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	cfg := &config.Config{JWTSecret: "test-secret", AccessTokenTTL: 15 * time.Minute}
+	user := &models.User{ID: "user-id", Username: "reader", Role: "user"}
+	authn := &fakeAuthenticator{users: map[string]*models.User{"reader": user}, password: "secret"}
+	authService := NewAuthServiceWithAuthenticator(mockUserRepo, mockRefreshTokenRepo, cfg, authn)
+
+	allowLastLogin(mockUserRepo)
+	mockRefreshTokenRepo.On("Create", mock.MatchedBy(func(rt *models.RefreshToken) bool {
+		return rt.Scope == "read:manga read:library"
+	})).Return(nil)
+
+	accessToken, _, _, _, scopes, err := authService.Login("reader", "secret", "", []string{"read:manga", "admin:users", "read:library", "read:manga"}, SessionInfo{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"read:manga", "read:library"}, scopes)
+
+	claims, err := authService.ValidateToken(accessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"read:manga", "read:library"}, claims.Scopes)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestLogin_TokenTTLHint(t *testing.T) {
+	cfg := &config.Config{JWTSecret: "test-secret", AccessTokenTTL: 15 * time.Minute, AccessTokenMaxTTL: time.Hour}
+	user := &models.User{ID: "user-id", Username: "reader", Role: "user"}
+	authn := &fakeAuthenticator{users: map[string]*models.User{"reader": user}, password: "secret"}
+
+	tests := []struct {
+		name      string
+		requested time.Duration
+		want      time.Duration
+	}{
+		{"Default", 0, 15 * time.Minute},
+		{"Shorter", 5 * time.Minute, 5 * time.Minute},
+		{"Longer", 45 * time.Minute, 45 * time.Minute},
+		{"AboveMax", 48 * time.Hour, time.Hour},
+		{"BelowMin", time.Second, MinAccessTokenTTL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+			authService := NewAuthServiceWithAuthenticator(mockUserRepo, mockRefreshTokenRepo, cfg, authn)
+
+			allowLastLogin(mockUserRepo)
+			// The hint itself is stored so refreshes re-apply it against the current max
+			mockRefreshTokenRepo.On("Create", mock.MatchedBy(func(rt *models.RefreshToken) bool {
+				return rt.AccessTokenTTL == int64(tt.requested/time.Second)
+			})).Return(nil)
+
+			accessToken, _, expiresIn, _, _, err := authService.Login("reader", "secret", "", nil, SessionInfo{AccessTokenTTL: tt.requested})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, expiresIn)
+			claims, err := authService.ValidateToken(accessToken)
+			assert.NoError(t, err)
+			assert.WithinDuration(t, time.Now().Add(tt.want), claims.ExpiresAt.Time, 2*time.Second)
+			mockRefreshTokenRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLogin_NoRequestedScopeAllowed(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	user := &models.User{ID: "user-id", Username: "reader", Role: "user"}
+	authn := &fakeAuthenticator{users: map[string]*models.User{"reader": user}, password: "secret"}
+	authService := NewAuthServiceWithAuthenticator(mockUserRepo, mockRefreshTokenRepo, cfg, authn)
+
+	accessToken, refreshToken, _, _, _, err := authService.Login("reader", "secret", "", []string{"admin:users"}, SessionInfo{})
+
+	// Must not fall back to the role's full scope set
+	assert.ErrorIs(t, err, ErrInvalidScope)
+	assert.Empty(t, accessToken)
+	assert.Empty(t, refreshToken)
+	mockRefreshTokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestLogin_DefaultScopesReturned(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	cfg := &config.Config{JWTSecret: "test-secret", AccessTokenTTL: 15 * time.Minute}
+	user := &models.User{ID: "user-id", Username: "reader", Role: "user"}
+	authn := &fakeAuthenticator{users: map[string]*models.User{"reader": user}, password: "secret"}
+	authService := NewAuthServiceWithAuthenticator(mockUserRepo, mockRefreshTokenRepo, cfg, authn)
+
+	allowLastLogin(mockUserRepo)
+	mockRefreshTokenRepo.On("Create", mock.MatchedBy(func(rt *models.RefreshToken) bool {
+		return rt.Scope == ""
+	})).Return(nil)
+
+	_, _, _, _, scopes, err := authService.Login("reader", "secret", "", nil, SessionInfo{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, scopesByRole["user"], scopes)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestLogin_RecordsLastLogin(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	cfg := &config.Config{JWTSecret: "test-secret", AccessTokenTTL: 15 * time.Minute}
+	user := &models.User{ID: "user-id", Username: "reader", Role: "user"}
+	authn := &fakeAuthenticator{users: map[string]*models.User{"reader": user}, password: "secret"}
+	authService := NewAuthServiceWithAuthenticator(mockUserRepo, mockRefreshTokenRepo, cfg, authn)
+
+	recorded := make(chan struct{})
+	mockRefreshTokenRepo.On("Create", mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+	mockUserRepo.On("UpdateLastLogin", mock.Anything, "user-id", mock.AnythingOfType("time.Time"), "203.0.113.7").
+		Return(nil).
+		Run(func(mock.Arguments) { close(recorded) })
+
+	_, _, _, _, _, err := authService.Login("reader", "secret", "", nil, SessionInfo{IPAddress: "203.0.113.7"})
+	assert.NoError(t, err)
+
+	select {
+	case <-recorded:
+	case <-time.After(2 * time.Second):
+		t.Fatal("last login was not recorded")
+	}
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestDBAuthenticator_EmailFirst(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := &models.User{ID: "user-id", Username: "testuser", Email: "test@example.com", Password: string(hashedPassword)}
+
+	mockUserRepo.On("FindByEmail", "test@example.com").Return(user, nil)
+
+	got, err := NewDBAuthenticator(mockUserRepo).Verify("test@example.com", "password123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, user, got)
+	mockUserRepo.AssertExpectations(t)
+	mockUserRepo.AssertNotCalled(t, "FindByUsername", mock.Anything)
+}
+
+func TestDBAuthenticator_EmailWinsOverCollidingUsername(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	ownerHash, _ := bcrypt.GenerateFromPassword([]byte("owner-pass"), bcrypt.MinCost)
+	squatterHash, _ := bcrypt.GenerateFromPassword([]byte("squatter-pass"), bcrypt.MinCost)
+	owner := &models.User{ID: "owner-id", Username: "alice", Email: "alice@example.com", Password: string(ownerHash)}
+	squatter := &models.User{ID: "squatter-id", Username: "alice@example.com", Email: "other@example.com", Password: string(squatterHash)}
+
+	mockUserRepo.On("FindByEmail", "alice@example.com").Return(owner, nil)
+	mockUserRepo.On("FindByUsername", "alice@example.com").Return(squatter, nil).Maybe()
+
+	got, err := NewDBAuthenticator(mockUserRepo).Verify("alice@example.com", "owner-pass")
+	assert.NoError(t, err)
+	assert.Equal(t, owner, got)
+
+	// The legacy username can't be used to sign in as itself over the email
+	got, err = NewDBAuthenticator(mockUserRepo).Verify("alice@example.com", "squatter-pass")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Nil(t, got)
+	mockUserRepo.AssertNotCalled(t, "FindByUsername", mock.Anything)
+}
+
+func TestDBAuthenticator_LegacyUsernameWithAt(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := &models.User{ID: "user-id", Username: "bob@home", Email: "bob@example.com", Password: string(hashedPassword)}
+
+	mockUserRepo.On("FindByEmail", "bob@home").Return(nil, gorm.ErrRecordNotFound)
+	mockUserRepo.On("FindByUsername", "bob@home").Return(user, nil)
+
+	got, err := NewDBAuthenticator(mockUserRepo).Verify("bob@home", "password123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, user, got)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestDBAuthenticator_EmptyIdentifier(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+
+	got, err := NewDBAuthenticator(mockUserRepo).Verify("", "password123")
+
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Nil(t, got)
+	mockUserRepo.AssertNotCalled(t, "FindByUsername", mock.Anything)
+}
+
 func TestValidateToken_Success(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
@@ -377,7 +660,7 @@ func TestRefreshAccessToken_Success(t *testing.T) {
 	mockRefreshTokenRepo.On("Revoke", "token-id").Return(nil)
 	mockRefreshTokenRepo.On("Create", mock.AnythingOfType("*models.RefreshToken")).Return(nil)
 
-	newAccessToken, newRefreshToken, err := authService.RefreshAccessToken("refresh-token")
+	newAccessToken, newRefreshToken, _, err := authService.RefreshAccessToken("refresh-token")
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, newAccessToken)
@@ -386,6 +669,177 @@ func TestRefreshAccessToken_Success(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 }
 
+func TestRefreshAccessToken_KeepsNarrowedScope(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	cfg := &config.Config{JWTSecret: "test-secret", AccessTokenTTL: 15 * time.Minute}
+	authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, cfg)
+
+	refreshToken := &models.RefreshToken{
+		ID:        "token-id",
+		UserID:    "user-id",
+		Token:     "refresh-token",
+		Scope:     "read:manga",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	user := &models.User{ID: "user-id", Username: "testuser", Role: "user"}
+
+	mockRefreshTokenRepo.On("FindByToken", "refresh-token").Return(refreshToken, nil)
+	mockUserRepo.On("FindByID", "user-id").Return(user, nil)
+	mockRefreshTokenRepo.On("Revoke", "token-id").Return(nil)
+	mockRefreshTokenRepo.On("Create", mock.MatchedBy(func(rt *models.RefreshToken) bool {
+		return rt.Scope == "read:manga"
+	})).Return(nil)
+
+	newAccessToken, _, _, err := authService.RefreshAccessToken("refresh-token")
+
+	assert.NoError(t, err)
+	claims, err := authService.ValidateToken(newAccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"read:manga"}, claims.Scopes)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestRefreshAccessToken_KeepsTokenTTLHint(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	cfg := &config.Config{JWTSecret: "test-secret", AccessTokenTTL: 15 * time.Minute, AccessTokenMaxTTL: time.Hour}
+	authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, cfg)
+
+	refreshToken := &models.RefreshToken{
+		ID:             "token-id",
+		UserID:         "user-id",
+		Token:          "refresh-token",
+		ExpiresAt:      time.Now().Add(time.Hour),
+		AccessTokenTTL: 300,
+	}
+	user := &models.User{ID: "user-id", Username: "testuser", Role: "user"}
+
+	mockRefreshTokenRepo.On("FindByToken", "refresh-token").Return(refreshToken, nil)
+	mockUserRepo.On("FindByID", "user-id").Return(user, nil)
+	mockRefreshTokenRepo.On("Revoke", "token-id").Return(nil)
+	mockRefreshTokenRepo.On("Create", mock.MatchedBy(func(rt *models.RefreshToken) bool {
+		return rt.AccessTokenTTL == 300
+	})).Return(nil)
+
+	newAccessToken, _, expiresIn, err := authService.RefreshAccessToken("refresh-token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, expiresIn)
+	claims, err := authService.ValidateToken(newAccessToken)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), claims.ExpiresAt.Time, 2*time.Second)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestLogin_StoresSessionInfo(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	cfg := &config.Config{JWTSecret: "test-secret", AccessTokenTTL: 15 * time.Minute}
+	user := &models.User{ID: "user-id", Username: "reader", Role: "user"}
+	authn := &fakeAuthenticator{users: map[string]*models.User{"reader": user}, password: "secret"}
+	authService := NewAuthServiceWithAuthenticator(mockUserRepo, mockRefreshTokenRepo, cfg, authn)
+
+	ua := "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:120.0) Gecko/20100101 Firefox/120.0"
+	allowLastLogin(mockUserRepo)
+	mockRefreshTokenRepo.On("Create", mock.MatchedBy(func(rt *models.RefreshToken) bool {
+		return rt.UserAgent == ua && rt.IPAddress == "203.0.113.7" &&
+			rt.DeviceName == "Firefox on Windows" && rt.LastUsedAt != nil
+	})).Return(nil)
+
+	_, _, _, _, _, err := authService.Login("reader", "secret", "", nil, SessionInfo{UserAgent: ua, IPAddress: "203.0.113.7"})
+
+	assert.NoError(t, err)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestRefreshAccessToken_CarriesSessionInfo(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	cfg := &config.Config{JWTSecret: "test-secret", AccessTokenTTL: 15 * time.Minute}
+	authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, cfg)
+
+	refreshToken := &models.RefreshToken{
+		ID:         "token-id",
+		UserID:     "user-id",
+		Token:      "refresh-token",
+		ExpiresAt:  time.Now().Add(time.Hour),
+		UserAgent:  "mangahub-cli/1.0",
+		IPAddress:  "198.51.100.2",
+		DeviceName: "work laptop",
+	}
+	user := &models.User{ID: "user-id", Username: "testuser", Role: "user"}
+
+	mockRefreshTokenRepo.On("FindByToken", "refresh-token").Return(refreshToken, nil)
+	mockUserRepo.On("FindByID", "user-id").Return(user, nil)
+	mockRefreshTokenRepo.On("Revoke", "token-id").Return(nil)
+	mockRefreshTokenRepo.On("Create", mock.MatchedBy(func(rt *models.RefreshToken) bool {
+		return rt.DeviceName == "work laptop" && rt.UserAgent == "mangahub-cli/1.0" && rt.IPAddress == "198.51.100.2"
+	})).Return(nil)
+
+	_, _, _, err := authService.RefreshAccessToken("refresh-token")
+
+	assert.NoError(t, err)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestRevokeSession(t *testing.T) {
+	sessionID := "5a1e2f0c-8d2b-4b7a-9c3e-2f1d0e9a8b7c"
+
+	t.Run("own session", func(t *testing.T) {
+		repo := new(MockRefreshTokenRepository)
+		authService := NewAuthService(new(MockUserRepository), repo, &config.Config{JWTSecret: "test-secret"})
+		repo.On("FindByID", sessionID).Return(&models.RefreshToken{ID: sessionID, UserID: "user-id"}, nil)
+		repo.On("Revoke", sessionID).Return(nil)
+
+		assert.NoError(t, authService.RevokeSession("user-id", sessionID))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("another user's session", func(t *testing.T) {
+		repo := new(MockRefreshTokenRepository)
+		authService := NewAuthService(new(MockUserRepository), repo, &config.Config{JWTSecret: "test-secret"})
+		repo.On("FindByID", sessionID).Return(&models.RefreshToken{ID: sessionID, UserID: "someone-else"}, nil)
+
+		assert.ErrorIs(t, authService.RevokeSession("user-id", sessionID), ErrSessionNotFound)
+		repo.AssertNotCalled(t, "Revoke", mock.Anything)
+	})
+
+	t.Run("malformed id", func(t *testing.T) {
+		repo := new(MockRefreshTokenRepository)
+		authService := NewAuthService(new(MockUserRepository), repo, &config.Config{JWTSecret: "test-secret"})
+
+		assert.ErrorIs(t, authService.RevokeSession("user-id", "not-a-uuid"), ErrSessionNotFound)
+		repo.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+
+	t.Run("unknown id", func(t *testing.T) {
+		repo := new(MockRefreshTokenRepository)
+		authService := NewAuthService(new(MockUserRepository), repo, &config.Config{JWTSecret: "test-secret"})
+		repo.On("FindByID", sessionID).Return(nil, gorm.ErrRecordNotFound)
+
+		assert.ErrorIs(t, authService.RevokeSession("user-id", sessionID), ErrSessionNotFound)
+	})
+}
+
+func TestDeviceNameFromUserAgent(t *testing.T) {
+	tests := []struct {
+		ua       string
+		expected string
+	}{
+		{"", "Unknown device"},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Safari/605.1.15", "Safari on macOS"},
+		{"Mozilla/5.0 (Linux; Android 14) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Mobile Safari/537.36", "Chrome on Android"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36 Edg/120.0", "Edge on Windows"},
+		{"Go-http-client/1.1", "Go-http-client"},
+		{"curl/8.4.0", "curl"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, deviceNameFromUserAgent(tt.ua), tt.ua)
+	}
+}
+
 func TestRefreshAccessToken_TokenExpired(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
@@ -401,7 +855,7 @@ func TestRefreshAccessToken_TokenExpired(t *testing.T) {
 	mockRefreshTokenRepo.On("FindByToken", "expired-token").Return(refreshToken, nil)
 	mockRefreshTokenRepo.On("Delete", "token-id").Return(nil)
 
-	newAccessToken, newRefreshToken, err := authService.RefreshAccessToken("expired-token")
+	newAccessToken, newRefreshToken, _, err := authService.RefreshAccessToken("expired-token")
 
 	assert.Error(t, err)
 	assert.Empty(t, newAccessToken)
@@ -425,7 +879,7 @@ func TestRefreshAccessToken_TokenRevoked(t *testing.T) {
 	mockRefreshTokenRepo.On("FindByToken", "revoked-token").Return(refreshToken, nil)
 	mockRefreshTokenRepo.On("Delete", "token-id").Return(nil)
 
-	newAccessToken, newRefreshToken, err := authService.RefreshAccessToken("revoked-token")
+	newAccessToken, newRefreshToken, _, err := authService.RefreshAccessToken("revoked-token")
 
 	assert.Error(t, err)
 	assert.Empty(t, newAccessToken)
@@ -450,7 +904,7 @@ func TestRefreshAccessToken_UserNotFound(t *testing.T) {
 	mockRefreshTokenRepo.On("FindByToken", "refresh-token").Return(refreshToken, nil)
 	mockUserRepo.On("FindByID", "nonexistent-user").Return(nil, errors.New("user not found"))
 
-	newAccessToken, newRefreshToken, err := authService.RefreshAccessToken("refresh-token")
+	newAccessToken, newRefreshToken, _, err := authService.RefreshAccessToken("refresh-token")
 
 	assert.Error(t, err)
 	assert.Empty(t, newAccessToken)
@@ -504,6 +958,36 @@ func TestContains(t *testing.T) {
 	assert.False(t, contains(slice, "admin:users"))
 }
 
+func TestGrantScopes(t *testing.T) {
+	user := scopesByRole["user"]
+	admin := scopesByRole["admin"]
+
+	tests := []struct {
+		name      string
+		allowed   []string
+		requested []string
+		expected  []string
+	}{
+		{"exact match", user, []string{"read:manga"}, []string{"read:manga"}},
+		{"covered by wildcard", admin, []string{"read:manga", "admin:users"}, []string{"read:manga", "admin:users"}},
+		{"not allowed for role", user, []string{"admin:users"}, nil},
+		{"no colon", admin, []string{"admin"}, nil},
+		{"empty string", admin, []string{""}, nil},
+		{"empty action", admin, []string{":manga"}, nil},
+		{"empty resource", admin, []string{"read:"}, nil},
+		{"extra colon", admin, []string{"read:manga:extra"}, nil},
+		{"bare wildcard", admin, []string{"*"}, nil},
+		{"duplicates dropped", user, []string{"read:manga", "read:manga"}, []string{"read:manga"}},
+		{"malformed mixed with valid", user, []string{"admin", "", "read:genre"}, []string{"read:genre"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, grantScopes(tt.allowed, tt.requested))
+		})
+	}
+}
+
 func TestRevokeToken_Success(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
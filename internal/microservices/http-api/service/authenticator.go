@@ -0,0 +1,61 @@
+package service
+
+import (
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Authenticator verifies a user's credentials for Login. The default checks a
+// bcrypt hash stored in the database; other backends (LDAP, an OAuth provider)
+// can be plugged in with NewAuthServiceWithAuthenticator.
+type Authenticator interface {
+	// Verify returns the user for a username (or email) and password, or
+	// ErrInvalidCredentials when they don't match
+	Verify(username, password string) (*models.User, error)
+}
+
+// dummyHash is compared against when the user doesn't exist so a failed
+// lookup takes as long as a wrong password (mitigates timing attacks)
+const dummyHash = "$2a$10$7EqJtq98hPqEX7fNZaFWoOhi6Cq1h0u3b0j3Z6h5y5jY5f5h5F5eW"
+
+type dbAuthenticator struct {
+	userRepo repository.UserRepository
+}
+
+// NewDBAuthenticator checks passwords against the bcrypt hashes in the users table
+func NewDBAuthenticator(userRepo repository.UserRepository) Authenticator {
+	return &dbAuthenticator{userRepo: userRepo}
+}
+
+func (a *dbAuthenticator) Verify(username, password string) (*models.User, error) {
+	user, err := a.findUser(username)
+	if err != nil {
+		_ = bcrypt.CompareHashAndPassword([]byte(dummyHash), []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+// findUser looks an identifier containing "@" up as an email first, so a
+// legacy username that matches someone else's email can't shadow that
+// account; anything else is looked up as a username only
+func (a *dbAuthenticator) findUser(identifier string) (*models.User, error) {
+	if identifier == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	if strings.Contains(identifier, "@") {
+		user, err := a.userRepo.FindByEmail(identifier)
+		if err == nil {
+			return user, nil
+		}
+	}
+	return a.userRepo.FindByUsername(identifier)
+}
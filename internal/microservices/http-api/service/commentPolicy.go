@@ -0,0 +1,173 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"mangahub/internal/config"
+)
+
+// Comment length limits used when none are configured
+const (
+	DefaultCommentMinLength = 1
+	DefaultCommentMaxLength = 5000
+)
+
+// ErrInvalidComment is matched by CommentContentError via errors.Is
+var ErrInvalidComment = errors.New("comment does not meet the content rules")
+
+// ErrContentRejected is returned by a ContentFilter that refuses a comment
+var ErrContentRejected = errors.New("contains disallowed words")
+
+// ContentFilter screens comment text before it is stored. It returns the
+// text to store, possibly masked, or an error to refuse the comment;
+// ErrContentRejected is reported to the client as a validation failure.
+type ContentFilter interface {
+	Filter(content string) (string, error)
+}
+
+// NoopContentFilter accepts every comment unchanged
+type NoopContentFilter struct{}
+
+func (NoopContentFilter) Filter(content string) (string, error) { return content, nil }
+
+// WordlistFilter rejects, or masks with asterisks, comments containing any
+// of its words. Matching is case-insensitive and on whole words.
+type WordlistFilter struct {
+	pattern *regexp.Regexp
+	mask    bool
+}
+
+// nonWordRune delimits a whole word. RE2's \b only knows ASCII word
+// characters, so it would split "café" after the "f" and miss accented words.
+const nonWordRune = `[^\p{L}\p{N}]`
+
+// NewWordlistFilter builds a filter for words; blank entries are ignored
+func NewWordlistFilter(words []string, mask bool) *WordlistFilter {
+	quoted := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			quoted = append(quoted, regexp.QuoteMeta(w))
+		}
+	}
+	f := &WordlistFilter{mask: mask}
+	if len(quoted) > 0 {
+		f.pattern = regexp.MustCompile(`(?i)(?:^|` + nonWordRune + `)(` + strings.Join(quoted, "|") + `)(?:` + nonWordRune + `|$)`)
+	}
+	return f
+}
+
+func (f *WordlistFilter) Filter(content string) (string, error) {
+	matches := f.matches(content)
+	if len(matches) == 0 {
+		return content, nil
+	}
+	if !f.mask {
+		return "", ErrContentRejected
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(content[last:m[0]])
+		b.WriteString(strings.Repeat("*", utf8.RuneCountInString(content[m[0]:m[1]])))
+		last = m[1]
+	}
+	b.WriteString(content[last:])
+	return b.String(), nil
+}
+
+// matches returns the byte ranges of the blocked words in content. The
+// pattern consumes the delimiter after a word, so each search resumes at the
+// end of the word itself to catch back-to-back words like "spoiler spoiler".
+func (f *WordlistFilter) matches(content string) [][2]int {
+	if f.pattern == nil {
+		return nil
+	}
+	var found [][2]int
+	for pos := 0; pos < len(content); {
+		loc := f.pattern.FindStringSubmatchIndex(content[pos:])
+		if loc == nil {
+			break
+		}
+		found = append(found, [2]int{pos + loc[2], pos + loc[3]})
+		pos += loc[3]
+	}
+	return found
+}
+
+// CommentPolicy is the set of rules comment content must satisfy
+type CommentPolicy struct {
+	MinLength int
+	MaxLength int
+	Filter    ContentFilter
+}
+
+// DefaultCommentPolicy applies the default length limits and no filtering
+func DefaultCommentPolicy() CommentPolicy {
+	return CommentPolicy{
+		MinLength: DefaultCommentMinLength,
+		MaxLength: DefaultCommentMaxLength,
+		Filter:    NoopContentFilter{},
+	}
+}
+
+// CommentPolicyFromConfig reads the policy from the COMMENT_* settings. A
+// blocked-word list enables a WordlistFilter; without one nothing is filtered.
+func CommentPolicyFromConfig(cfg *config.Config) CommentPolicy {
+	policy := DefaultCommentPolicy()
+	if cfg.CommentMinLength > 0 {
+		policy.MinLength = cfg.CommentMinLength
+	}
+	if cfg.CommentMaxLength > 0 {
+		policy.MaxLength = cfg.CommentMaxLength
+	}
+	if len(cfg.CommentBlockedWords) > 0 {
+		policy.Filter = NewWordlistFilter(cfg.CommentBlockedWords, cfg.CommentFilterMode == "mask")
+	}
+	return policy
+}
+
+// CommentContentError is the rule a comment failed
+type CommentContentError struct {
+	Rule    string // required, min, max or content
+	Message string
+}
+
+func (e *CommentContentError) Error() string {
+	return "comment " + e.Message
+}
+
+func (e *CommentContentError) Is(target error) bool {
+	return target == ErrInvalidComment
+}
+
+// Apply trims content and checks it against the policy, returning the text
+// to store or a *CommentContentError
+func (p CommentPolicy) Apply(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	length := utf8.RuneCountInString(content)
+
+	switch {
+	case length == 0:
+		return "", &CommentContentError{Rule: "required", Message: "must not be empty"}
+	case length < p.MinLength:
+		return "", &CommentContentError{Rule: "min", Message: fmt.Sprintf("must be at least %d characters", p.MinLength)}
+	case p.MaxLength > 0 && length > p.MaxLength:
+		return "", &CommentContentError{Rule: "max", Message: fmt.Sprintf("must be at most %d characters", p.MaxLength)}
+	}
+
+	if p.Filter == nil {
+		return content, nil
+	}
+	filtered, err := p.Filter.Filter(content)
+	if errors.Is(err, ErrContentRejected) {
+		return "", &CommentContentError{Rule: "content", Message: err.Error()}
+	}
+	if err != nil {
+		return "", err
+	}
+	return filtered, nil
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"mangahub/internal/microservices/http-api/dto"
 	"mangahub/internal/microservices/http-api/models"
@@ -18,17 +19,27 @@ type CommentService interface {
 	GetCommentByID(commentID int64) (*dto.CommentResponse, error)
 	GetMangaComments(mangaID int64, page, pageSize int) (*dto.PaginatedCommentResponse, error)
 	GetUserComments(userID string, page, pageSize int) (*dto.PaginatedCommentResponse, error)
+	GetRecentComments(since *time.Time, page, pageSize int) (*dto.PaginatedAdminCommentResponse, error)
 }
 
 type commentService struct {
 	commentRepo repository.CommentRepository
 	mangaRepo   *repository.MangaRepo
+	policy      CommentPolicy
 }
 
 func NewCommentService(commentRepo repository.CommentRepository, mangaRepo *repository.MangaRepo) CommentService {
+	return NewCommentServiceWithPolicy(commentRepo, mangaRepo, DefaultCommentPolicy())
+}
+
+// NewCommentServiceWithPolicy is NewCommentService with custom length limits
+// and content filter. Content that fails the policy is refused with a
+// *CommentContentError.
+func NewCommentServiceWithPolicy(commentRepo repository.CommentRepository, mangaRepo *repository.MangaRepo, policy CommentPolicy) CommentService {
 	return &commentService{
 		commentRepo: commentRepo,
 		mangaRepo:   mangaRepo,
+		policy:      policy,
 	}
 }
 
@@ -36,8 +47,13 @@ func NewCommentService(commentRepo repository.CommentRepository, mangaRepo *repo
 func (s *commentService) CreateComment(userID string, mangaID int64, content string) (*dto.CommentResponse, error) {
 	ctx := context.Background()
 
+	content, err := s.policy.Apply(content)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if manga exists
-	_, err := s.mangaRepo.GetByID(ctx, mangaID)
+	_, err = s.mangaRepo.GetByID(ctx, mangaID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("manga not found")
@@ -67,6 +83,11 @@ func (s *commentService) CreateComment(userID string, mangaID int64, content str
 
 // UpdateComment updates an existing comment
 func (s *commentService) UpdateComment(commentID int64, userID string, content string) (*dto.CommentResponse, error) {
+	content, err := s.policy.Apply(content)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get existing comment
 	comment, err := s.commentRepo.GetByID(commentID)
 	if err != nil {
@@ -154,3 +175,19 @@ func (s *commentService) GetUserComments(userID string, page, pageSize int) (*dt
 
 	return dto.NewPaginatedCommentResponse(commentResponses, int(total), page, pageSize), nil
 }
+
+// GetRecentComments retrieves the newest comments across all manga for
+// moderation, optionally only those posted since a given time
+func (s *commentService) GetRecentComments(since *time.Time, page, pageSize int) (*dto.PaginatedAdminCommentResponse, error) {
+	comments, total, err := s.commentRepo.ListRecent(since, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	commentResponses := make([]dto.AdminCommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		commentResponses = append(commentResponses, dto.FromModelToAdminCommentResponse(&comment))
+	}
+
+	return dto.NewPaginatedAdminCommentResponse(commentResponses, int(total), page, pageSize), nil
+}
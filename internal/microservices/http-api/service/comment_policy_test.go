@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"mangahub/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func contentRule(t *testing.T, err error) string {
+	t.Helper()
+	var contentErr *CommentContentError
+	if !errors.As(err, &contentErr) {
+		t.Fatalf("expected *CommentContentError, got %v", err)
+	}
+	return contentErr.Rule
+}
+
+func TestCommentPolicy_Apply(t *testing.T) {
+	policy := CommentPolicy{MinLength: 3, MaxLength: 10, Filter: NoopContentFilter{}}
+
+	t.Run("Trims", func(t *testing.T) {
+		content, err := policy.Apply("  great  ")
+		require.NoError(t, err)
+		assert.Equal(t, "great", content)
+	})
+
+	t.Run("WhitespaceOnly", func(t *testing.T) {
+		_, err := policy.Apply(" \n\t ")
+		assert.ErrorIs(t, err, ErrInvalidComment)
+		assert.Equal(t, "required", contentRule(t, err))
+	})
+
+	t.Run("TooShort", func(t *testing.T) {
+		_, err := policy.Apply(" ok ")
+		assert.Equal(t, "min", contentRule(t, err))
+	})
+
+	t.Run("TooLong", func(t *testing.T) {
+		_, err := policy.Apply("far too long for this")
+		assert.Equal(t, "max", contentRule(t, err))
+		assert.Contains(t, err.Error(), "at most 10 characters")
+	})
+
+	t.Run("CountsRunesNotBytes", func(t *testing.T) {
+		_, err := policy.Apply("ワンピース最高")
+		assert.NoError(t, err)
+	})
+}
+
+func TestWordlistFilter(t *testing.T) {
+	words := []string{"spoiler", " ", "dang it"}
+
+	t.Run("Reject", func(t *testing.T) {
+		policy := CommentPolicy{MinLength: 1, MaxLength: 100, Filter: NewWordlistFilter(words, false)}
+
+		_, err := policy.Apply("Huge SPOILER ahead")
+		assert.Equal(t, "content", contentRule(t, err))
+
+		// Whole words only
+		content, err := policy.Apply("no spoilers here")
+		require.NoError(t, err)
+		assert.Equal(t, "no spoilers here", content)
+	})
+
+	t.Run("Mask", func(t *testing.T) {
+		policy := CommentPolicy{MinLength: 1, MaxLength: 100, Filter: NewWordlistFilter(words, true)}
+
+		content, err := policy.Apply("Spoiler: dang it, he lives")
+		require.NoError(t, err)
+		assert.Equal(t, "*******: *******, he lives", content)
+
+		content, err = policy.Apply("spoiler spoiler")
+		require.NoError(t, err)
+		assert.Equal(t, "******* *******", content)
+	})
+
+	t.Run("NonASCII", func(t *testing.T) {
+		policy := CommentPolicy{MinLength: 1, MaxLength: 100, Filter: NewWordlistFilter([]string{"café", "spoiler"}, true)}
+
+		content, err := policy.Apply("Le café, très bon")
+		require.NoError(t, err)
+		assert.Equal(t, "Le ****, très bon", content)
+
+		// Accented letters are part of the word, not a boundary
+		content, err = policy.Apply("spoileré cafés")
+		require.NoError(t, err)
+		assert.Equal(t, "spoileré cafés", content)
+	})
+}
+
+func TestCommentPolicyFromConfig(t *testing.T) {
+	policy := CommentPolicyFromConfig(&config.Config{})
+	assert.Equal(t, DefaultCommentPolicy().MinLength, policy.MinLength)
+	assert.Equal(t, DefaultCommentMaxLength, policy.MaxLength)
+	assert.IsType(t, NoopContentFilter{}, policy.Filter)
+
+	policy = CommentPolicyFromConfig(&config.Config{CommentMaxLength: 280, CommentBlockedWords: []string{"spoiler"}, CommentFilterMode: "mask"})
+	assert.Equal(t, 280, policy.MaxLength)
+	content, err := policy.Apply("spoiler alert")
+	require.NoError(t, err)
+	assert.Equal(t, "******* alert", content)
+}
+
+func TestUpdateComment_InvalidContent(t *testing.T) {
+	// Content is checked before any repository call
+	commentService := NewCommentServiceWithPolicy(nil, nil, DefaultCommentPolicy())
+
+	_, err := commentService.UpdateComment(1, "user-1", "   ")
+	assert.ErrorIs(t, err, ErrInvalidComment)
+}
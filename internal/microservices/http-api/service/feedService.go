@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"mangahub/internal/microservices/http-api/repository"
+)
+
+// DefaultFeedLookback is how far back the feed reaches when not configured
+const DefaultFeedLookback = 30 * 24 * time.Hour
+
+// ErrInvalidFeedCursor is returned for a cursor the feed didn't hand out
+var ErrInvalidFeedCursor = errors.New("invalid feed cursor")
+
+// FeedPage is one page of the activity feed. NextCursor is empty on the
+// last page.
+type FeedPage struct {
+	Activities []repository.Activity
+	NextCursor string
+}
+
+// FeedService builds the activity feed of the users someone follows
+type FeedService interface {
+	// Feed returns up to limit activities after cursor ("" for the first page)
+	Feed(ctx context.Context, userID, cursor string, limit int) (*FeedPage, error)
+}
+
+type feedService struct {
+	repo     repository.FeedRepository
+	lookback time.Duration
+	ttl      time.Duration // 0 disables the cache
+	now      func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedFeedPage // by user, cursor and limit
+}
+
+type cachedFeedPage struct {
+	page    *FeedPage
+	expires time.Time
+}
+
+// NewFeedService creates a feed that only looks back lookback (0 uses
+// DefaultFeedLookback) and caches each page for ttl (0 disables the cache)
+func NewFeedService(repo repository.FeedRepository, lookback, ttl time.Duration) FeedService {
+	if lookback <= 0 {
+		lookback = DefaultFeedLookback
+	}
+	if ttl < 0 {
+		ttl = 0
+	}
+	return &feedService{
+		repo:     repo,
+		lookback: lookback,
+		ttl:      ttl,
+		now:      time.Now,
+		cache:    make(map[string]cachedFeedPage),
+	}
+}
+
+func (s *feedService) Feed(ctx context.Context, userID, cursor string, limit int) (*FeedPage, error) {
+	var after *repository.FeedCursor
+	if cursor != "" {
+		c, err := parseFeedCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = &c
+	}
+
+	key := fmt.Sprintf("%s|%s|%d", userID, cursor, limit)
+	if page, ok := s.cached(key); ok {
+		return page, nil
+	}
+
+	// One extra row tells whether there is a next page
+	activities, err := s.repo.Feed(ctx, userID, s.now().Add(-s.lookback), after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &FeedPage{Activities: activities}
+	if len(activities) > limit {
+		page.Activities = activities[:limit]
+		page.NextCursor = encodeFeedCursor(page.Activities[limit-1])
+	}
+
+	s.store(key, page)
+	return page, nil
+}
+
+func (s *feedService) cached(key string) (*FeedPage, bool) {
+	if s.ttl == 0 {
+		return nil, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.cache[key]
+	if !ok || s.now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.page, true
+}
+
+// store caches page, dropping expired pages so the cache only holds what
+// was fetched within the last ttl
+func (s *feedService) store(key string, page *FeedPage) {
+	if s.ttl == 0 {
+		return
+	}
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, entry := range s.cache {
+		if now.After(entry.expires) {
+			delete(s.cache, k)
+		}
+	}
+	s.cache[key] = cachedFeedPage{page: page, expires: now.Add(s.ttl)}
+}
+
+// encodeFeedCursor makes an opaque cursor pointing just past a
+func encodeFeedCursor(a repository.Activity) string {
+	raw := fmt.Sprintf("%d|%s|%d", a.At.UnixNano(), a.Kind, a.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func parseFeedCursor(cursor string) (repository.FeedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return repository.FeedCursor{}, ErrInvalidFeedCursor
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return repository.FeedCursor{}, ErrInvalidFeedCursor
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return repository.FeedCursor{}, ErrInvalidFeedCursor
+	}
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return repository.FeedCursor{}, ErrInvalidFeedCursor
+	}
+	switch parts[1] {
+	case repository.ActivityRating, repository.ActivityComment, repository.ActivityLibraryAdd:
+	default:
+		return repository.FeedCursor{}, ErrInvalidFeedCursor
+	}
+	return repository.FeedCursor{At: time.Unix(0, nanos), Kind: parts[1], ID: id}, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mangahub/internal/microservices/http-api/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeFeedRepo serves activities newest first and records each call
+type fakeFeedRepo struct {
+	activities []repository.Activity
+	calls      int
+	since      time.Time
+	after      *repository.FeedCursor
+}
+
+func (f *fakeFeedRepo) Feed(ctx context.Context, userID string, since time.Time, after *repository.FeedCursor, limit int) ([]repository.Activity, error) {
+	f.calls++
+	f.since = since
+	f.after = after
+
+	start := 0
+	if after != nil {
+		for i, a := range f.activities {
+			if a.At.Equal(after.At) && a.Kind == after.Kind && a.ID == after.ID {
+				start = i + 1
+			}
+		}
+	}
+	end := min(start+limit, len(f.activities))
+	return f.activities[start:end], nil
+}
+
+func feedActivities(n int) []repository.Activity {
+	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	activities := make([]repository.Activity, n)
+	for i := range activities {
+		activities[i] = repository.Activity{Kind: repository.ActivityComment, ID: int64(n - i), At: base.Add(-time.Duration(i) * time.Minute)}
+	}
+	return activities
+}
+
+func TestFeedService_CursorPagination(t *testing.T) {
+	repo := &fakeFeedRepo{activities: feedActivities(5)}
+	svc := NewFeedService(repo, 24*time.Hour, 0)
+
+	first, err := svc.Feed(context.Background(), "u1", "", 2)
+	assert.NoError(t, err)
+	assert.Len(t, first.Activities, 2)
+	assert.NotEmpty(t, first.NextCursor)
+
+	second, err := svc.Feed(context.Background(), "u1", first.NextCursor, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, repo.activities[2:4], second.Activities)
+	assert.True(t, repo.after.At.Equal(repo.activities[1].At))
+
+	last, err := svc.Feed(context.Background(), "u1", second.NextCursor, 2)
+	assert.NoError(t, err)
+	assert.Len(t, last.Activities, 1)
+	assert.Empty(t, last.NextCursor)
+}
+
+func TestFeedService_Lookback(t *testing.T) {
+	repo := &fakeFeedRepo{}
+	svc := NewFeedService(repo, 7*24*time.Hour, 0).(*feedService)
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	_, err := svc.Feed(context.Background(), "u1", "", 10)
+
+	assert.NoError(t, err)
+	assert.True(t, repo.since.Equal(now.Add(-7*24*time.Hour)))
+}
+
+func TestFeedService_Cache(t *testing.T) {
+	repo := &fakeFeedRepo{activities: feedActivities(3)}
+	svc := NewFeedService(repo, 0, time.Minute).(*feedService)
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	_, _ = svc.Feed(context.Background(), "u1", "", 10)
+	_, _ = svc.Feed(context.Background(), "u1", "", 10)
+	assert.Equal(t, 1, repo.calls)
+
+	_, _ = svc.Feed(context.Background(), "u2", "", 10)
+	assert.Equal(t, 2, repo.calls, "pages are cached per user")
+
+	now = now.Add(2 * time.Minute)
+	_, _ = svc.Feed(context.Background(), "u1", "", 10)
+	assert.Equal(t, 3, repo.calls, "expired pages are fetched again")
+}
+
+func TestFeedService_InvalidCursor(t *testing.T) {
+	svc := NewFeedService(&fakeFeedRepo{}, 0, 0)
+
+	for _, cursor := range []string{"not base64!", "bm9wZQ", encodeFeedCursor(repository.Activity{Kind: "bogus"})} {
+		_, err := svc.Feed(context.Background(), "u1", cursor, 10)
+		assert.True(t, errors.Is(err, ErrInvalidFeedCursor), cursor)
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+
+	"gorm.io/gorm"
+)
+
+// ErrSelfFollow is returned when a user tries to follow themselves
+var ErrSelfFollow = errors.New("you cannot follow yourself")
+
+// FollowService lets users follow each other
+type FollowService interface {
+	// Follow makes followerID follow username. Following someone already
+	// followed succeeds; a private profile can't be followed (ErrProfilePrivate).
+	Follow(ctx context.Context, followerID, username string) error
+	// Unfollow stops followerID following username, if it did
+	Unfollow(ctx context.Context, followerID, username string) error
+	Following(ctx context.Context, userID string, page, pageSize int) ([]models.UserFollow, int64, error)
+	Followers(ctx context.Context, userID string, page, pageSize int) ([]models.UserFollow, int64, error)
+}
+
+type followService struct {
+	users   repository.UserRepository
+	follows repository.FollowRepository
+}
+
+func NewFollowService(users repository.UserRepository, follows repository.FollowRepository) FollowService {
+	return &followService{users: users, follows: follows}
+}
+
+func (s *followService) Follow(ctx context.Context, followerID, username string) error {
+	target, err := s.findUser(username)
+	if err != nil {
+		return err
+	}
+	if target.ID == followerID {
+		return ErrSelfFollow
+	}
+	if !target.ProfilePublic {
+		return ErrProfilePrivate
+	}
+	return s.follows.Follow(ctx, followerID, target.ID)
+}
+
+func (s *followService) Unfollow(ctx context.Context, followerID, username string) error {
+	target, err := s.findUser(username)
+	if err != nil {
+		return err
+	}
+	return s.follows.Unfollow(ctx, followerID, target.ID)
+}
+
+func (s *followService) Following(ctx context.Context, userID string, page, pageSize int) ([]models.UserFollow, int64, error) {
+	return s.follows.ListFollowing(ctx, userID, page, pageSize)
+}
+
+func (s *followService) Followers(ctx context.Context, userID string, page, pageSize int) ([]models.UserFollow, int64, error) {
+	return s.follows.ListFollowers(ctx, userID, page, pageSize)
+}
+
+func (s *followService) findUser(username string) (*models.User, error) {
+	user, err := s.users.FindByUsername(username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
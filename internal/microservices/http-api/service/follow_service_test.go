@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeFollowRepo records follows as "follower->followee" pairs
+type fakeFollowRepo struct {
+	repository.FollowRepository
+	follows map[string]bool
+}
+
+func (f *fakeFollowRepo) Follow(ctx context.Context, followerID, followeeID string) error {
+	f.follows[followerID+"->"+followeeID] = true
+	return nil
+}
+
+func (f *fakeFollowRepo) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	delete(f.follows, followerID+"->"+followeeID)
+	return nil
+}
+
+func newFollowService() (FollowService, *fakeFollowRepo) {
+	users := &fakeProfileUsers{users: map[string]models.User{
+		"alice": {ID: "u1", Username: "alice", ProfilePublic: true},
+		"bob":   {ID: "u2", Username: "bob", ProfilePublic: true},
+		"carol": {ID: "u3", Username: "carol"},
+	}}
+	follows := &fakeFollowRepo{follows: map[string]bool{}}
+	return NewFollowService(users, follows), follows
+}
+
+func TestFollowService_Follow(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Idempotent", func(t *testing.T) {
+		svc, follows := newFollowService()
+
+		assert.NoError(t, svc.Follow(ctx, "u1", "bob"))
+		assert.NoError(t, svc.Follow(ctx, "u1", "bob"))
+		assert.Equal(t, map[string]bool{"u1->u2": true}, follows.follows)
+
+		assert.NoError(t, svc.Unfollow(ctx, "u1", "bob"))
+		assert.Empty(t, follows.follows)
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		svc, follows := newFollowService()
+
+		err := svc.Follow(ctx, "u1", "alice")
+
+		assert.True(t, errors.Is(err, ErrSelfFollow))
+		assert.Empty(t, follows.follows)
+	})
+
+	t.Run("PrivateProfile", func(t *testing.T) {
+		svc, follows := newFollowService()
+
+		err := svc.Follow(ctx, "u1", "carol")
+
+		assert.True(t, errors.Is(err, ErrProfilePrivate))
+		assert.Empty(t, follows.follows)
+	})
+
+	t.Run("UnknownUser", func(t *testing.T) {
+		svc, _ := newFollowService()
+
+		assert.True(t, errors.Is(svc.Follow(ctx, "u1", "ghost"), ErrUserNotFound))
+		assert.True(t, errors.Is(svc.Unfollow(ctx, "u1", "ghost"), ErrUserNotFound))
+	})
+}
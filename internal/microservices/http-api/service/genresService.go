@@ -3,18 +3,51 @@ package service
 import (
 	"context"
 	"errors"
-	"strings"
+	"strconv"
 
+	"mangahub/internal/microservices/http-api/dto"
 	"mangahub/internal/microservices/http-api/models"
 	"mangahub/internal/microservices/http-api/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrGenreNotFound = errors.New("genre not found")
+	// ErrGenreSoleGenre blocks deleting a genre that is the only genre of some manga
+	ErrGenreSoleGenre = errors.New("genre is the only genre of some manga")
 )
 
 type GenreService interface {
 	GetAll(ctx context.Context) ([]models.Genre, error)
-	Create(ctx context.Context, g *models.Genre) error
+	// Create stores a new genre. If a genre with the same normalized name
+	// already exists, g is filled with it and created is false.
+	Create(ctx context.Context, g *models.Genre) (created bool, err error)
+
+	// GetForManga lists a manga's genres. With withCounts, counts holds how
+	// many manga share each genre (by genre ID); otherwise it is nil.
+	GetForManga(ctx context.Context, mangaID int64, withCounts bool) (genres []models.Genre, counts map[int64]int64, err error)
+
+	// new: get a page of mangas for a genre, with the total count
+	GetMangasByGenre(ctx context.Context, genreID int64, page, pageSize int) ([]models.Manga, int64, error)
+
+	// Delete removes a genre and unlinks it from all mangas. It returns the
+	// mangas that had no other genre: unless force is set, those block the
+	// delete with ErrGenreSoleGenre so an admin can reassign them first.
+	Delete(ctx context.Context, id int64, force bool) (affected []int64, err error)
 
-	// new: get mangas for a genre
-	GetMangasByGenre(ctx context.Context, genreID int64) ([]models.Manga, error)
+	// ResolveGenres looks up search filter genres by name (matched like
+	// Create dedupes them) or by ID. Names that are plain numbers count as
+	// IDs. unknown lists, as given, every name or ID that matched nothing.
+	ResolveGenres(ctx context.Context, names []string, ids []int64) (genres []models.Genre, unknown []string, err error)
+
+	// LinkMangas tags each manga with the genre in one transaction, and
+	// UnlinkMangas removes it. Repeated ids count once. Manga already in the
+	// requested state, or that don't exist, are reported instead of failing.
+	// As with Delete, UnlinkMangas never strips a manga of its only genre;
+	// those are skipped and reported in SoleGenre.
+	LinkMangas(ctx context.Context, genreID int64, mangaIDs []int64) (*repository.GenreLinkResult, error)
+	UnlinkMangas(ctx context.Context, genreID int64, mangaIDs []int64) (*repository.GenreLinkResult, error)
 }
 
 type genreService struct {
@@ -29,14 +62,167 @@ func (s *genreService) GetAll(ctx context.Context) ([]models.Genre, error) {
 	return s.repo.GetAll(ctx)
 }
 
-func (s *genreService) Create(ctx context.Context, g *models.Genre) error {
-	if strings.TrimSpace(g.Name) == "" {
-		return errors.New("genre name required")
+func (s *genreService) Create(ctx context.Context, g *models.Genre) (bool, error) {
+	g.Name = models.CleanGenreName(g.Name)
+	if g.Name == "" {
+		return false, errors.New("genre name required")
+	}
+	normalized := models.NormalizeGenreName(g.Name)
+
+	// "Action", " action " and "Actión" are all the same genre
+	if existing, err := s.repo.FindByNormalizedName(ctx, normalized); err == nil {
+		*g = *existing
+		return false, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+
+	if err := s.repo.Create(ctx, g); err != nil {
+		// Lost a race with a concurrent create: return the winner
+		if errors.Is(err, repository.ErrGenreExists) {
+			existing, findErr := s.repo.FindByNormalizedName(ctx, normalized)
+			if findErr != nil {
+				return false, err
+			}
+			*g = *existing
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *genreService) GetForManga(ctx context.Context, mangaID int64, withCounts bool) ([]models.Genre, map[int64]int64, error) {
+	genres, err := s.repo.GetByManga(ctx, mangaID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrMangaNotFound
+		}
+		return nil, nil, err
+	}
+	if !withCounts {
+		return genres, nil, nil
+	}
+
+	ids := make([]int64, len(genres))
+	for i, g := range genres {
+		ids[i] = g.ID
+	}
+	counts, err := s.repo.CountMangas(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return genres, counts, nil
+}
+
+func (s *genreService) GetMangasByGenre(ctx context.Context, genreID int64, page, pageSize int) ([]models.Manga, int64, error) {
+	// Validate pagination parameters
+	page, pageSize = dto.NormalizePagination(page, pageSize)
+	return s.repo.GetMangasByGenre(ctx, genreID, page, pageSize)
+}
+
+func (s *genreService) Delete(ctx context.Context, id int64, force bool) ([]int64, error) {
+	affected, err := s.repo.MangaIDsWithOnlyGenre(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	g.Name = strings.TrimSpace(g.Name)
-	return s.repo.Create(ctx, g)
+	if len(affected) > 0 && !force {
+		return affected, ErrGenreSoleGenre
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGenreNotFound
+		}
+		return nil, err
+	}
+	return affected, nil
 }
 
-func (s *genreService) GetMangasByGenre(ctx context.Context, genreID int64) ([]models.Manga, error) {
-	return s.repo.GetMangasByGenre(ctx, genreID)
+func (s *genreService) ResolveGenres(ctx context.Context, names []string, ids []int64) ([]models.Genre, []string, error) {
+	ids = append([]int64(nil), ids...)
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		if id, err := strconv.ParseInt(name, 10, 64); err == nil {
+			ids = append(ids, id)
+			continue
+		}
+		keys = append(keys, models.NormalizeGenreName(name))
+	}
+
+	byName, err := s.repo.FindByNormalizedNames(ctx, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	byID, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	found := make(map[string]models.Genre, len(byName))
+	for _, g := range byName {
+		found[g.NormalizedName] = g
+	}
+	foundIDs := make(map[int64]models.Genre, len(byID))
+	for _, g := range byID {
+		foundIDs[g.ID] = g
+	}
+
+	// Keep the caller's order and drop repeats, e.g. "Action,action"
+	genres := make([]models.Genre, 0, len(names)+len(ids))
+	seen := make(map[int64]bool)
+	unknown := make([]string, 0)
+	add := func(g models.Genre) {
+		if !seen[g.ID] {
+			seen[g.ID] = true
+			genres = append(genres, g)
+		}
+	}
+	for _, name := range names {
+		if _, err := strconv.ParseInt(name, 10, 64); err == nil {
+			continue
+		}
+		if g, ok := found[models.NormalizeGenreName(name)]; ok {
+			add(g)
+		} else {
+			unknown = append(unknown, name)
+		}
+	}
+	for _, id := range ids {
+		if g, ok := foundIDs[id]; ok {
+			add(g)
+		} else {
+			unknown = append(unknown, strconv.FormatInt(id, 10))
+		}
+	}
+	return genres, unknown, nil
+}
+
+func (s *genreService) LinkMangas(ctx context.Context, genreID int64, mangaIDs []int64) (*repository.GenreLinkResult, error) {
+	result, err := s.repo.LinkMangas(ctx, genreID, uniqueIDs(mangaIDs))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrGenreNotFound
+	}
+	return result, err
+}
+
+func (s *genreService) UnlinkMangas(ctx context.Context, genreID int64, mangaIDs []int64) (*repository.GenreLinkResult, error) {
+	result, err := s.repo.UnlinkMangas(ctx, genreID, uniqueIDs(mangaIDs))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrGenreNotFound
+	}
+	return result, err
+}
+
+// uniqueIDs drops repeated ids, keeping the first occurrence of each
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	out := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
 }
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"mangahub/internal/ingestion"
+	"mangahub/internal/microservices/http-api/models"
+)
+
+var (
+	ErrExternalNotFound  = errors.New("manga not found at source")
+	ErrSourceUnavailable = errors.New("source temporarily unavailable")
+)
+
+// MangaImporter fetches a single title from an external source and upserts
+// it, returning the internal manga ID. Implemented by the MangaDex and
+// AniList sync services.
+type MangaImporter interface {
+	ImportManga(ctx context.Context, externalID string) (int64, error)
+}
+
+type ImportService interface {
+	// Import fetches and upserts a single title, reporting whether it was newly created
+	Import(ctx context.Context, source, externalID string) (*models.Manga, bool, error)
+}
+
+type importService struct {
+	mangaSvc  MangaService
+	importers map[string]MangaImporter
+}
+
+// NewImportService wires importers keyed by source (SourceMangaDex, SourceAniList)
+func NewImportService(mangaSvc MangaService, importers map[string]MangaImporter) ImportService {
+	return &importService{mangaSvc: mangaSvc, importers: importers}
+}
+
+func (s *importService) Import(ctx context.Context, source, externalID string) (*models.Manga, bool, error) {
+	source = strings.ToLower(strings.TrimSpace(source))
+	externalID = strings.TrimSpace(externalID)
+
+	importer, ok := s.importers[source]
+	if !ok {
+		return nil, false, ErrUnsupportedSource
+	}
+
+	// Also validates the external ID format for the source
+	created := false
+	if _, err := s.mangaSvc.GetByExternalID(ctx, source, externalID); err != nil {
+		if !errors.Is(err, ErrMangaNotFound) {
+			return nil, false, err
+		}
+		created = true
+	}
+
+	id, err := importer.ImportManga(ctx, externalID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ingestion.ErrNotFound):
+			return nil, false, ErrExternalNotFound
+		case errors.Is(err, ingestion.ErrCircuitOpen):
+			return nil, false, ErrSourceUnavailable
+		}
+		return nil, false, fmt.Errorf("import from %s: %w", source, err)
+	}
+
+	m, err := s.mangaSvc.GetByID(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	return m, created, nil
+}
@@ -3,30 +3,88 @@ package service
 import (
     "context"
     "errors"
+    "fmt"
+    "math"
     "mangahub/internal/microservices/http-api/models"
     "mangahub/internal/microservices/http-api/repository"
+
+    "gorm.io/gorm"
 )
 
 var (
     ErrAlreadyInLibrary = errors.New("manga already in library")
     ErrNotInLibrary     = errors.New("manga not in library")
+    ErrLibraryFull      = errors.New("library is full")
 )
 
+// LibraryFullError is returned by Add when the user already has the maximum
+// number of entries; it matches ErrLibraryFull via errors.Is
+type LibraryFullError struct {
+    Count int64
+    Limit int
+}
+
+func (e *LibraryFullError) Error() string {
+    return fmt.Sprintf("library is full: %d of %d entries used, remove one to add another", e.Count, e.Limit)
+}
+
+func (e *LibraryFullError) Is(target error) bool {
+    return target == ErrLibraryFull
+}
+
 type LibraryService interface {
     Add(ctx context.Context, userID string, mangaID int64) error
     Remove(ctx context.Context, userID string, mangaID int64) error
+    RemoveMany(ctx context.Context, userID string, mangaIDs []int64) (removed, notFound []int64, err error)
     List(ctx context.Context, userID string) ([]models.UserLibrary, error)
+    Get(ctx context.Context, userID string, mangaID int64) (*models.UserLibrary, error)
+    CatchUp(ctx context.Context, userID string) (*CatchUpResult, error)
+    Stats(ctx context.Context, userID string) (*LibraryStats, error)
+}
+
+// CatchUpResult reports what CatchUp did to each library entry
+type CatchUpResult struct {
+    Updated        []int64 // Progress moved to the latest chapter
+    AlreadyCurrent []int64 // Progress was already at or past the latest chapter
+    Skipped        []int64 // Total chapter count unknown
+    Excluded       []int64 // Dropped or planned; left as they are
+}
+
+// LibraryStats summarises a user's library. Entries without progress count
+// towards Total but none of the status counts.
+type LibraryStats struct {
+    Reading       int64
+    Completed     int64
+    PlanToRead    int64
+    Dropped       int64
+    Total         int64
+    TotalChapters int64   // Across every library manga with a known count
+    CompletionPct float64 // Share of the library marked completed, 0-100
 }
 
 type libraryService struct {
-    repo      repository.LibraryRepository
-    mangaRepo *repository.MangaRepo
+    repo         repository.LibraryRepository
+    mangaRepo    *repository.MangaRepo
+    progressRepo repository.ProgressRepository
+    maxSize      int // 0 = unlimited
+}
+
+func NewLibraryService(repo repository.LibraryRepository, mangaRepo *repository.MangaRepo, progressRepo repository.ProgressRepository) LibraryService {
+    return &libraryService{
+        repo:         repo,
+        mangaRepo:    mangaRepo,
+        progressRepo: progressRepo,
+    }
 }
 
-func NewLibraryService(repo repository.LibraryRepository, mangaRepo *repository.MangaRepo) LibraryService {
+// NewLibraryServiceWithLimit caps each user's library at maxSize entries;
+// 0 leaves it unlimited
+func NewLibraryServiceWithLimit(repo repository.LibraryRepository, mangaRepo *repository.MangaRepo, progressRepo repository.ProgressRepository, maxSize int) LibraryService {
     return &libraryService{
-        repo:      repo,
-        mangaRepo: mangaRepo,
+        repo:         repo,
+        mangaRepo:    mangaRepo,
+        progressRepo: progressRepo,
+        maxSize:      maxSize,
     }
 }
 
@@ -45,13 +103,160 @@ func (s *libraryService) Add(ctx context.Context, userID string, mangaID int64)
         return ErrAlreadyInLibrary
     }
     
-    return s.repo.Add(ctx, userID, mangaID)
+    // A concurrent add can still win between the check and the insert
+    if s.maxSize > 0 {
+        var count int64
+        count, err = s.repo.AddWithLimit(ctx, userID, mangaID, s.maxSize)
+        if errors.Is(err, repository.ErrLibraryLimitReached) {
+            return &LibraryFullError{Count: count, Limit: s.maxSize}
+        }
+    } else {
+        err = s.repo.Add(ctx, userID, mangaID)
+    }
+    if errors.Is(err, repository.ErrLibraryEntryExists) {
+        return ErrAlreadyInLibrary
+    }
+    return err
+}
+
+// Get returns the library entry for mangaID, or ErrNotInLibrary
+func (s *libraryService) Get(ctx context.Context, userID string, mangaID int64) (*models.UserLibrary, error) {
+    entry, err := s.repo.Get(ctx, userID, mangaID)
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil, ErrNotInLibrary
+    }
+    return entry, err
 }
 
 func (s *libraryService) Remove(ctx context.Context, userID string, mangaID int64) error {
     return s.repo.Remove(ctx, userID, mangaID)
 }
 
+// RemoveMany removes several manga at once. Duplicate IDs are collapsed;
+// IDs that weren't in the library are reported back in notFound.
+func (s *libraryService) RemoveMany(ctx context.Context, userID string, mangaIDs []int64) ([]int64, []int64, error) {
+    seen := make(map[int64]bool, len(mangaIDs))
+    unique := make([]int64, 0, len(mangaIDs))
+    for _, id := range mangaIDs {
+        if !seen[id] {
+            seen[id] = true
+            unique = append(unique, id)
+        }
+    }
+
+    removed, err := s.repo.RemoveMany(ctx, userID, unique)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    wasRemoved := make(map[int64]bool, len(removed))
+    for _, id := range removed {
+        wasRemoved[id] = true
+    }
+    removed = make([]int64, 0, len(wasRemoved))
+    notFound := make([]int64, 0, len(unique)-len(wasRemoved))
+    for _, id := range unique {
+        if wasRemoved[id] {
+            removed = append(removed, id)
+        } else {
+            notFound = append(notFound, id)
+        }
+    }
+
+    return removed, notFound, nil
+}
+
 func (s *libraryService) List(ctx context.Context, userID string) ([]models.UserLibrary, error) {
     return s.repo.List(ctx, userID)
-}
\ No newline at end of file
+}
+// CatchUp sets progress to the last chapter for every manga in the library
+// whose total chapter count is known. Progress is never moved backwards, and
+// dropped or plan_to_read entries are left alone. Finished series are marked
+// completed, everything else stays reading.
+func (s *libraryService) CatchUp(ctx context.Context, userID string) (*CatchUpResult, error) {
+    library, err := s.repo.List(ctx, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    existing, err := s.progressRepo.GetAllProgress(ctx, userID)
+    if err != nil {
+        return nil, ErrFailedToGetAllProgress
+    }
+    current := make(map[int64]models.UserProgress, len(*existing))
+    for _, p := range *existing {
+        current[p.MangaID] = p
+    }
+
+    result := &CatchUpResult{
+        Updated:        make([]int64, 0),
+        AlreadyCurrent: make([]int64, 0),
+        Skipped:        make([]int64, 0),
+        Excluded:       make([]int64, 0),
+    }
+    var updates []models.UserProgress
+    for _, entry := range library {
+        progress, hasProgress := current[entry.MangaID]
+        if hasProgress && (progress.Status == "dropped" || progress.Status == "plan_to_read") {
+            result.Excluded = append(result.Excluded, entry.MangaID)
+            continue
+        }
+        if entry.Manga == nil || entry.Manga.TotalChapters == nil || *entry.Manga.TotalChapters <= 0 {
+            result.Skipped = append(result.Skipped, entry.MangaID)
+            continue
+        }
+
+        total := *entry.Manga.TotalChapters
+        if hasProgress && progress.CurrentChapter >= total {
+            result.AlreadyCurrent = append(result.AlreadyCurrent, entry.MangaID)
+            continue
+        }
+
+        status := "reading"
+        if entry.Manga.Status != nil && *entry.Manga.Status == "completed" {
+            status = "completed"
+        }
+        updates = append(updates, models.UserProgress{
+            UserID:         userID,
+            MangaID:        entry.MangaID,
+            CurrentChapter: total,
+            Status:         status,
+        })
+        result.Updated = append(result.Updated, entry.MangaID)
+    }
+
+    if err := s.progressRepo.UpsertMany(ctx, updates); err != nil {
+        return nil, ErrFailedToUpdateProgress
+    }
+
+    return result, nil
+}
+
+// Stats totals the library by reading status. An empty library is all zeros.
+func (s *libraryService) Stats(ctx context.Context, userID string) (*LibraryStats, error) {
+    counts, err := s.repo.StatusCounts(ctx, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    stats := &LibraryStats{}
+    for _, c := range counts {
+        switch c.Status {
+        case "reading":
+            stats.Reading += c.Count
+        case "completed":
+            stats.Completed += c.Count
+        case "plan_to_read":
+            stats.PlanToRead += c.Count
+        case "dropped":
+            stats.Dropped += c.Count
+        }
+        stats.Total += c.Count
+        stats.TotalChapters += c.Chapters
+    }
+    if stats.Total > 0 {
+        stats.CompletionPct = math.Round(float64(stats.Completed)/float64(stats.Total)*1000) / 10
+    }
+
+    return stats, nil
+}
@@ -1,13 +1,194 @@
 package service
 
 import (
+	"context"
 	"testing"
 
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
+// fakeLibraryRepo and fakeProgressRepo implement only what the tests call;
+// anything else panics on the nil embedded interface
+type fakeLibraryRepo struct {
+	repository.LibraryRepository
+	entries []models.UserLibrary
+	counts  []repository.LibraryStatusCount
+
+	addCount  int64 // entries AddWithLimit reports before the add
+	addErr    error
+	added     bool
+	limitUsed int
+}
+
+func (f *fakeLibraryRepo) Exists(ctx context.Context, userID string, mangaID int64) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeLibraryRepo) Add(ctx context.Context, userID string, mangaID int64) error {
+	f.added = true
+	return f.addErr
+}
+
+func (f *fakeLibraryRepo) AddWithLimit(ctx context.Context, userID string, mangaID int64, limit int) (int64, error) {
+	f.limitUsed = limit
+	return f.addCount, f.addErr
+}
+
+func (f *fakeLibraryRepo) List(ctx context.Context, userID string) ([]models.UserLibrary, error) {
+	return f.entries, nil
+}
+
+func (f *fakeLibraryRepo) StatusCounts(ctx context.Context, userID string) ([]repository.LibraryStatusCount, error) {
+	return f.counts, nil
+}
+
+type fakeProgressRepo struct {
+	repository.ProgressRepository
+	existing []models.UserProgress
+	upserted []models.UserProgress
+}
+
+func (f *fakeProgressRepo) GetAllProgress(ctx context.Context, userID string) (*[]models.UserProgress, error) {
+	return &f.existing, nil
+}
+
+func (f *fakeProgressRepo) UpsertMany(ctx context.Context, progress []models.UserProgress) error {
+	f.upserted = append(f.upserted, progress...)
+	return nil
+}
+
+func libraryEntry(mangaID int64, total *int, status string) models.UserLibrary {
+	return models.UserLibrary{
+		MangaID: mangaID,
+		Manga:   &models.Manga{ID: mangaID, TotalChapters: total, Status: &status},
+	}
+}
+
+// dryRunMangaRepo finds every manga without a server, so Add gets past its
+// existence check
+func dryRunMangaRepo(t *testing.T) *repository.MangaRepo {
+	t.Helper()
+	gdb, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=none"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	return repository.NewMangaRepo(gdb)
+}
+
+func TestLibraryService_AddWithLimit(t *testing.T) {
+	t.Run("Full", func(t *testing.T) {
+		libRepo := &fakeLibraryRepo{addCount: 3, addErr: repository.ErrLibraryLimitReached}
+		svc := NewLibraryServiceWithLimit(libRepo, dryRunMangaRepo(t), nil, 3)
+
+		err := svc.Add(context.Background(), "user-1", 7)
+
+		var full *LibraryFullError
+		require.ErrorAs(t, err, &full)
+		assert.Equal(t, &LibraryFullError{Count: 3, Limit: 3}, full)
+		assert.ErrorIs(t, err, ErrLibraryFull)
+		assert.Equal(t, 3, libRepo.limitUsed)
+		assert.False(t, libRepo.added)
+	})
+
+	t.Run("AlreadyInLibrary", func(t *testing.T) {
+		// Lost a race with a concurrent add of the same manga
+		libRepo := &fakeLibraryRepo{addCount: 1, addErr: repository.ErrLibraryEntryExists}
+		svc := NewLibraryServiceWithLimit(libRepo, dryRunMangaRepo(t), nil, 3)
+
+		err := svc.Add(context.Background(), "user-1", 7)
+
+		assert.ErrorIs(t, err, ErrAlreadyInLibrary)
+		assert.NotErrorIs(t, err, ErrLibraryFull)
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		libRepo := &fakeLibraryRepo{}
+		svc := NewLibraryServiceWithLimit(libRepo, dryRunMangaRepo(t), nil, 0)
+
+		assert.NoError(t, svc.Add(context.Background(), "user-1", 7))
+		assert.True(t, libRepo.added)
+		assert.Zero(t, libRepo.limitUsed)
+	})
+}
+
 func TestLibraryServiceStructure(t *testing.T) {
 	t.Run("ServiceExists", func(t *testing.T) {
 		assert.NotNil(t, "library service")
 	})
 }
+
+func TestLibraryService_CatchUp(t *testing.T) {
+	ten, twenty, fifty := 10, 20, 50
+	libRepo := &fakeLibraryRepo{entries: []models.UserLibrary{
+		libraryEntry(1, &ten, "completed"),
+		libraryEntry(2, &twenty, "ongoing"),
+		libraryEntry(3, nil, "ongoing"),
+		libraryEntry(4, &fifty, "ongoing"),
+		libraryEntry(5, &fifty, "ongoing"),
+		libraryEntry(6, &ten, "completed"),
+	}}
+	progressRepo := &fakeProgressRepo{existing: []models.UserProgress{
+		{UserID: "user-1", MangaID: 2, CurrentChapter: 5, Status: "reading"},
+		{UserID: "user-1", MangaID: 4, CurrentChapter: 60, Status: "reading"},
+		{UserID: "user-1", MangaID: 5, CurrentChapter: 12, Status: "dropped"},
+		{UserID: "user-1", MangaID: 6, CurrentChapter: 0, Status: "plan_to_read"},
+	}}
+	svc := NewLibraryService(libRepo, nil, progressRepo)
+
+	result, err := svc.CatchUp(context.Background(), "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, result.Updated)
+	assert.Equal(t, []int64{4}, result.AlreadyCurrent)
+	assert.Equal(t, []int64{3}, result.Skipped)
+	assert.Equal(t, []int64{5, 6}, result.Excluded)
+
+	if assert.Len(t, progressRepo.upserted, 2) {
+		assert.Equal(t, 10, progressRepo.upserted[0].CurrentChapter)
+		assert.Equal(t, "completed", progressRepo.upserted[0].Status)
+		assert.Equal(t, 20, progressRepo.upserted[1].CurrentChapter)
+		assert.Equal(t, "reading", progressRepo.upserted[1].Status)
+	}
+}
+
+func TestLibraryService_Stats(t *testing.T) {
+	t.Run("Totals", func(t *testing.T) {
+		libRepo := &fakeLibraryRepo{counts: []repository.LibraryStatusCount{
+			{Status: "reading", Count: 3, Chapters: 120},
+			{Status: "completed", Count: 2, Chapters: 80},
+			{Status: "dropped", Count: 1, Chapters: 0},
+			{Status: "", Count: 3, Chapters: 45},
+		}}
+		svc := NewLibraryService(libRepo, nil, nil)
+
+		stats, err := svc.Stats(context.Background(), "user-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &LibraryStats{
+			Reading:       3,
+			Completed:     2,
+			Dropped:       1,
+			Total:         9,
+			TotalChapters: 245,
+			CompletionPct: 22.2,
+		}, stats)
+	})
+
+	t.Run("EmptyLibrary", func(t *testing.T) {
+		svc := NewLibraryService(&fakeLibraryRepo{}, nil, nil)
+
+		stats, err := svc.Stats(context.Background(), "user-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &LibraryStats{}, stats)
+	})
+}
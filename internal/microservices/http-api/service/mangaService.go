@@ -8,27 +8,79 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
+	"mangahub/internal/config"
 	"mangahub/internal/microservices/http-api/dto"
 	"mangahub/internal/microservices/http-api/models"
 	"mangahub/internal/microservices/http-api/repository"
+	"mangahub/internal/shared"
+	"mangahub/internal/shared/slug"
 )
 
+// External sources a manga can be looked up by
+const (
+	SourceMangaDex = "mangadex"
+	SourceAniList  = "anilist"
+)
+
+var (
+	ErrMangaNotFound     = errors.New("manga not found")
+	ErrUnsupportedSource = errors.New("unsupported source, must be one of: mangadex, anilist")
+	ErrInvalidExternalID = errors.New("invalid external id for source")
+	ErrMangaConflict     = errors.New("manga already exists")
+	// ErrFieldNotClearable rejects clearing a field that can't be null, or
+	// one the same update also sets
+	ErrFieldNotClearable = errors.New("field cannot be cleared")
+)
+
+// MangaConflictError is returned when a manga collides with an existing one
+// on a unique field. It matches ErrMangaConflict with errors.Is.
+type MangaConflictError struct {
+	Field string // Column that collided, e.g. "slug"; empty if unknown
+}
+
+func (e *MangaConflictError) Error() string {
+	if e.Field == "" {
+		return ErrMangaConflict.Error()
+	}
+	return fmt.Sprintf("a manga with this %s already exists", e.Field)
+}
+
+func (e *MangaConflictError) Is(target error) bool { return target == ErrMangaConflict }
+
+// DefaultExportBatchSize is how many manga ExportCatalog loads at a time
+const DefaultExportBatchSize = 200
+
 type MangaService interface {
-	GetAll(ctx context.Context, page, pageSize int) ([]models.Manga, int64, error)
+	GetAll(ctx context.Context, page, pageSize int, sortBy string) ([]models.Manga, int64, error)
 	GetByID(ctx context.Context, id int64) (*models.Manga, error)
+	GetByExternalID(ctx context.Context, source, externalID string) (*models.Manga, error)
+	GetBySlugs(ctx context.Context, slugs []string) (found []models.Manga, notFound []string, err error)
+	GetSyncInfo(ctx context.Context, id int64) (*models.MangaSyncInfo, error)
 	Create(ctx context.Context, m *models.Manga) error
-	Update(ctx context.Context, id int64, m *models.Manga) error
+	// Update applies the non-nil fields of m, then sets each nullable field
+	// named in clear (by JSON name, e.g. "description") back to null
+	Update(ctx context.Context, id int64, m *models.Manga, clear []string) error
 	Delete(ctx context.Context, id int64) error
 
 	SearchByTitle(ctx context.Context, title string) ([]models.Manga, error)
+	SearchByTitleFields(ctx context.Context, title string, fields []string) ([]models.Manga, error)
+	// SearchByTitleForUser is SearchByTitle with userID's library and
+	// progress attached to each result
+	SearchByTitleForUser(ctx context.Context, title, userID string) ([]models.MangaWithUserContext, error)
 	AdvancedSearch(ctx context.Context, filters dto.SearchFilters) ([]models.Manga, int64, error)
 
 	ReplaceGenresForManga(ctx context.Context, mangaID int64, genreIDs []int64) error
+
+	// ExportCatalog walks every manga in id order, batchSize at a time, and
+	// hands each batch to emit. It stops at the first error from emit.
+	ExportCatalog(ctx context.Context, batchSize int, emit func([]models.Manga) error) error
 }
 
 type mangaService struct {
@@ -39,21 +91,116 @@ func NewMangaService(r *repository.MangaRepo) MangaService {
 	return &mangaService{repo: r}
 }
 
-func (s *mangaService) GetAll(ctx context.Context, page, pageSize int) ([]models.Manga, int64, error) {
+func (s *mangaService) GetAll(ctx context.Context, page, pageSize int, sortBy string) ([]models.Manga, int64, error) {
 	// Validate pagination parameters
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
-	}
-	return s.repo.GetAll(ctx, page, pageSize)
+	page, pageSize = dto.NormalizePagination(page, pageSize)
+	return s.repo.GetAll(ctx, page, pageSize, sortBy)
 }
 
 func (s *mangaService) GetByID(ctx context.Context, id int64) (*models.Manga, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
+// GetBySlugs resolves several slugs in one query. Found manga come back in
+// the order their slugs were given (duplicates collapsed); the rest are
+// reported in notFound.
+func (s *mangaService) GetBySlugs(ctx context.Context, slugs []string) ([]models.Manga, []string, error) {
+	unique := make([]string, 0, len(slugs))
+	seen := make(map[string]bool, len(slugs))
+	for _, slug := range slugs {
+		slug = strings.TrimSpace(slug)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		unique = append(unique, slug)
+	}
+
+	list, err := s.repo.GetBySlugs(ctx, unique)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bySlug := make(map[string]models.Manga, len(list))
+	for _, m := range list {
+		if m.Slug != nil {
+			bySlug[*m.Slug] = m
+		}
+	}
+
+	found := make([]models.Manga, 0, len(list))
+	notFound := make([]string, 0)
+	for _, slug := range unique {
+		if m, ok := bySlug[slug]; ok {
+			found = append(found, m)
+		} else {
+			notFound = append(notFound, slug)
+		}
+	}
+	return found, notFound, nil
+}
+
+// GetByExternalID resolves a MangaDex UUID or AniList media ID to our manga
+func (s *mangaService) GetByExternalID(ctx context.Context, source, externalID string) (*models.Manga, error) {
+	var (
+		m   *models.Manga
+		err error
+	)
+
+	switch strings.ToLower(source) {
+	case SourceMangaDex:
+		id, parseErr := uuid.Parse(externalID)
+		if parseErr != nil {
+			return nil, ErrInvalidExternalID
+		}
+		m, err = s.repo.GetByMangaDexID(ctx, id.String())
+	case SourceAniList:
+		id, parseErr := strconv.ParseInt(externalID, 10, 64)
+		if parseErr != nil || id <= 0 {
+			return nil, ErrInvalidExternalID
+		}
+		m, err = s.repo.GetByAniListID(ctx, id)
+	default:
+		return nil, ErrUnsupportedSource
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrMangaNotFound
+	}
+	return m, err
+}
+
+func (s *mangaService) GetSyncInfo(ctx context.Context, id int64) (*models.MangaSyncInfo, error) {
+	info, err := s.repo.GetSyncInfo(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrMangaNotFound
+	}
+	return info, err
+}
+
+func (s *mangaService) ExportCatalog(ctx context.Context, batchSize int, emit func([]models.Manga) error) error {
+	if batchSize <= 0 {
+		batchSize = DefaultExportBatchSize
+	}
+	var afterID int64
+	for {
+		batch, err := s.repo.ListAfterID(ctx, afterID, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := emit(batch); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		afterID = batch[len(batch)-1].ID
+	}
+}
+
 func (s *mangaService) Create(ctx context.Context, m *models.Manga) error {
 	// basic validation
 	if strings.TrimSpace(m.Title) == "" {
@@ -62,10 +209,13 @@ func (s *mangaService) Create(ctx context.Context, m *models.Manga) error {
 
 	// ensure slug exists, generate from title if missing
 	if m.Slug == nil || strings.TrimSpace(*m.Slug) == "" {
-		slug := generateSlug(m.Title)
+		base := slug.WithFallback(m.Title, "manga")
+		if len(base) > 50 {
+			base = strings.TrimRight(base[:50], "-")
+		}
 		// add short uuid suffix to avoid collisions
-		slug = fmt.Sprintf("%s-%s", slug, uuid.New().String()[:8])
-		m.Slug = &slug
+		generated := fmt.Sprintf("%s-%s", base, uuid.New().String()[:8])
+		m.Slug = &generated
 	}
 
 	// business rules can go here (e.g. normalize fields)
@@ -80,6 +230,9 @@ func (s *mangaService) Create(ctx context.Context, m *models.Manga) error {
 	// }
 
 	if err := s.repo.Create(ctx, m); err != nil {
+		if field, ok := repository.UniqueViolationField(err); ok {
+			return &MangaConflictError{Field: field}
+		}
 		return err
 	}
 
@@ -88,7 +241,7 @@ func (s *mangaService) Create(ctx context.Context, m *models.Manga) error {
 	return nil
 }
 
-func (s *mangaService) Update(ctx context.Context, id int64, m *models.Manga) error {
+func (s *mangaService) Update(ctx context.Context, id int64, m *models.Manga, clear []string) error {
 	// ensure exists
 	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -102,34 +255,29 @@ func (s *mangaService) Update(ctx context.Context, id int64, m *models.Manga) er
 	}
 
 	// Track which fields are being updated with old and new values
-	type fieldChange struct {
-		Field    string      `json:"field"`
-		OldValue interface{} `json:"old_value,omitempty"`
-		NewValue interface{} `json:"new_value"`
-	}
 	var changes []string
-	var detailedChanges []fieldChange
+	var detailedChanges []shared.FieldChange
 
 	// Apply fields that are non-nil / non-zero in m to existing
 	if m.Slug != nil && (existing.Slug == nil || *m.Slug != *existing.Slug) {
+		// the slug must stay unique; check up front for a clear conflict
+		taken, err := s.repo.SlugExists(ctx, *m.Slug, id)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return &MangaConflictError{Field: "slug"}
+		}
 		oldVal := ""
 		if existing.Slug != nil {
 			oldVal = *existing.Slug
 		}
-		detailedChanges = append(detailedChanges, fieldChange{
-			Field:    "slug",
-			OldValue: oldVal,
-			NewValue: *m.Slug,
-		})
+		detailedChanges = append(detailedChanges, shared.NewFieldChange("slug", oldVal, *m.Slug))
 		existing.Slug = m.Slug
 		changes = append(changes, "slug")
 	}
 	if strings.TrimSpace(m.Title) != "" && m.Title != existing.Title {
-		detailedChanges = append(detailedChanges, fieldChange{
-			Field:    "title",
-			OldValue: existing.Title,
-			NewValue: m.Title,
-		})
+		detailedChanges = append(detailedChanges, shared.NewFieldChange("title", existing.Title, m.Title))
 		existing.Title = m.Title
 		changes = append(changes, "title")
 	}
@@ -139,11 +287,7 @@ func (s *mangaService) Update(ctx context.Context, id int64, m *models.Manga) er
 			if existing.Author != nil {
 				oldVal = *existing.Author
 			}
-			detailedChanges = append(detailedChanges, fieldChange{
-				Field:    "author",
-				OldValue: oldVal,
-				NewValue: *m.Author,
-			})
+			detailedChanges = append(detailedChanges, shared.NewFieldChange("author", oldVal, *m.Author))
 			existing.Author = m.Author
 			changes = append(changes, "author")
 		}
@@ -154,11 +298,7 @@ func (s *mangaService) Update(ctx context.Context, id int64, m *models.Manga) er
 			if existing.Status != nil {
 				oldVal = *existing.Status
 			}
-			detailedChanges = append(detailedChanges, fieldChange{
-				Field:    "status",
-				OldValue: oldVal,
-				NewValue: *m.Status,
-			})
+			detailedChanges = append(detailedChanges, shared.NewFieldChange("status", oldVal, *m.Status))
 			existing.Status = m.Status
 			changes = append(changes, "status")
 		}
@@ -169,11 +309,7 @@ func (s *mangaService) Update(ctx context.Context, id int64, m *models.Manga) er
 			if existing.TotalChapters != nil {
 				oldVal = *existing.TotalChapters
 			}
-			detailedChanges = append(detailedChanges, fieldChange{
-				Field:    "total_chapters",
-				OldValue: oldVal,
-				NewValue: *m.TotalChapters,
-			})
+			detailedChanges = append(detailedChanges, shared.NewFieldChange("total_chapters", oldVal, *m.TotalChapters))
 			existing.TotalChapters = m.TotalChapters
 			changes = append(changes, "total chapters")
 		}
@@ -184,11 +320,7 @@ func (s *mangaService) Update(ctx context.Context, id int64, m *models.Manga) er
 			if existing.Description != nil {
 				oldVal = *existing.Description
 			}
-			detailedChanges = append(detailedChanges, fieldChange{
-				Field:    "description",
-				OldValue: oldVal,
-				NewValue: *m.Description,
-			})
+			detailedChanges = append(detailedChanges, shared.NewFieldChange("description", oldVal, *m.Description))
 			existing.Description = m.Description
 			changes = append(changes, "description")
 		}
@@ -199,15 +331,21 @@ func (s *mangaService) Update(ctx context.Context, id int64, m *models.Manga) er
 			if existing.CoverURL != nil {
 				oldVal = *existing.CoverURL
 			}
-			detailedChanges = append(detailedChanges, fieldChange{
-				Field:    "cover_url",
-				OldValue: oldVal,
-				NewValue: *m.CoverURL,
-			})
+			detailedChanges = append(detailedChanges, shared.NewFieldChange("cover_url", oldVal, *m.CoverURL))
 			existing.CoverURL = m.CoverURL
 			changes = append(changes, "cover image")
 		}
 	}
+	for _, field := range clear {
+		oldVal, cleared, err := clearMangaField(existing, m, field)
+		if err != nil {
+			return err
+		}
+		if cleared {
+			detailedChanges = append(detailedChanges, shared.NewFieldChange(field, oldVal, nil))
+			changes = append(changes, strings.ReplaceAll(field, "_", " "))
+		}
+	}
 	// if m.PublishedYear != nil {
 	// 	if existing.PublishedYear == nil || *m.PublishedYear != *existing.PublishedYear {
 	// 		// Validate year
@@ -218,7 +356,7 @@ func (s *mangaService) Update(ctx context.Context, id int64, m *models.Manga) er
 	// 		if existing.PublishedYear != nil {
 	// 			oldVal = *existing.PublishedYear
 	// 		}
-	// 		detailedChanges = append(detailedChanges, fieldChange{
+	// 		detailedChanges = append(detailedChanges, shared.FieldChange{
 	// 			Field:    "published_year",
 	// 			OldValue: oldVal,
 	// 			NewValue: *m.PublishedYear,
@@ -231,17 +369,16 @@ func (s *mangaService) Update(ctx context.Context, id int64, m *models.Manga) er
 	// update updated_at business rule could be here
 
 	if err := s.repo.Update(ctx, id, existing); err != nil {
+		// a concurrent update may still win the race for the slug
+		if field, ok := repository.UniqueViolationField(err); ok {
+			return &MangaConflictError{Field: field}
+		}
 		return err
 	}
 
 	// fire a best-effort notification about the update with specific changes
 	if len(changes) > 0 {
-		// Convert to []interface{} for JSON marshaling
-		detailedChangesInterface := make([]interface{}, len(detailedChanges))
-		for i, v := range detailedChanges {
-			detailedChangesInterface[i] = v
-		}
-		go notifyMangaUpdateDetailed(id, existing.Title, changes, detailedChangesInterface)
+		go notifyMangaUpdateDetailed(id, existing.Title, changes, detailedChanges)
 	}
 	return nil
 }
@@ -254,11 +391,10 @@ func notifyNewManga(mangaID int64, title string) {
 		url = "http://udp-server:8085/notify/new-manga"
 	}
 	payload := map[string]interface{}{"manga_id": mangaID, "title": title}
-	b, _ := json.Marshal(payload)
-	_, _ = http.Post(url, "application/json", bytes.NewReader(b))
+	postTrigger(url, payload)
 }
 
-func notifyMangaUpdateDetailed(mangaID int64, title string, changes []string, detailedChanges []interface{}) {
+func notifyMangaUpdateDetailed(mangaID int64, title string, changes []string, detailedChanges []shared.FieldChange) {
 	url := os.Getenv("UDP_TRIGGER_URL")
 	if url == "" {
 		url = "http://udp-server:8085/notify/manga-update"
@@ -269,8 +405,37 @@ func notifyMangaUpdateDetailed(mangaID int64, title string, changes []string, de
 		"changes":          changes,
 		"detailed_changes": detailedChanges,
 	}
+	postTrigger(url, payload)
+}
+
+// postTrigger sends a fire-and-forget notification to the UDP trigger,
+// authenticated with the INTERNAL_TOKEN shared secret and signed with
+// INTERNAL_SIGNING_KEY when one is set
+func postTrigger(url string, payload map[string]interface{}) {
 	b, _ := json.Marshal(payload)
-	_, _ = http.Post(url, "application/json", bytes.NewReader(b))
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token := os.Getenv("INTERNAL_TOKEN"); token != "" {
+		req.Header.Set(config.InternalTokenHeader, token)
+	}
+	if key := os.Getenv("INTERNAL_SIGNING_KEY"); key != "" {
+		nonce, err := shared.NewTriggerNonce()
+		if err != nil {
+			return
+		}
+		timestamp := time.Now().Unix()
+		req.Header.Set(config.InternalTimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(config.InternalNonceHeader, nonce)
+		req.Header.Set(config.InternalSignatureHeader, shared.SignTriggerRequest(key, timestamp, nonce, req.URL.Path, b))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
 }
 
 func (s *mangaService) Delete(ctx context.Context, id int64) error {
@@ -280,18 +445,46 @@ func (s *mangaService) Delete(ctx context.Context, id int64) error {
 
 // SearchByTitle returns mangas that match title (case-insensitive, partial)
 func (s *mangaService) SearchByTitle(ctx context.Context, title string) ([]models.Manga, error) {
+	title, err := dto.NormalizeSearchQuery(title)
+	if err != nil {
+		return nil, err
+	}
 	return s.repo.SearchByTitle(ctx, title)
 }
 
+// SearchByTitleFields is SearchByTitle loading only the given fields, which
+// must come from dto.MangaProjectionFields
+func (s *mangaService) SearchByTitleFields(ctx context.Context, title string, fields []string) ([]models.Manga, error) {
+	for _, f := range fields {
+		if !dto.MangaProjectionFields[f] {
+			return nil, fmt.Errorf("field %q cannot be selected", f)
+		}
+	}
+	title, err := dto.NormalizeSearchQuery(title)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.SearchByTitle(ctx, title, fields...)
+}
+
+func (s *mangaService) SearchByTitleForUser(ctx context.Context, title, userID string) ([]models.MangaWithUserContext, error) {
+	title, err := dto.NormalizeSearchQuery(title)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.SearchByTitleForUser(ctx, title, userID)
+}
+
 // AdvancedSearch performs full-text search with multiple filters
 func (s *mangaService) AdvancedSearch(ctx context.Context, filters dto.SearchFilters) ([]models.Manga, int64, error) {
 	// Validate and set defaults
-	if filters.Page < 1 {
-		filters.Page = 1
-	}
-	if filters.PageSize < 1 || filters.PageSize > 100 {
-		filters.PageSize = 20
+	filters.Page, filters.PageSize = dto.NormalizePagination(filters.Page, filters.PageSize)
+
+	query, err := dto.NormalizeSearchQuery(filters.Query)
+	if err != nil {
+		return nil, 0, err
 	}
+	filters.Query = query
 
 	// Validate rating range
 	if filters.MinRating != nil && (*filters.MinRating < 0 || *filters.MinRating > 10) {
@@ -341,24 +534,54 @@ func (s *mangaService) ReplaceGenresForManga(ctx context.Context, mangaID int64,
 	return nil
 }
 
-/* helper: generate slug-like string from title */
-var nonAlnum = regexp.MustCompile(`[^a-z0-9\-]+`)
-
-func generateSlug(title string) string {
-	s := strings.ToLower(strings.TrimSpace(title))
-	// replace spaces with dash
-	s = strings.ReplaceAll(s, " ", "-")
-	// remove non-alnum/dash
-	s = nonAlnum.ReplaceAllString(s, "")
-	// collapse dashes
-	s = strings.ReplaceAll(s, "--", "-")
-	s = strings.Trim(s, "-")
-	if s == "" {
-		return "manga"
-	}
-	// limit length
-	if len(s) > 50 {
-		s = s[:50]
-	}
-	return s
+// clearMangaField sets the nullable field named field (its JSON name) on
+// existing to nil and returns the value it had; cleared is false if it was
+// already null. update must not set the same field.
+func clearMangaField(existing, update *models.Manga, field string) (old any, cleared bool, err error) {
+	conflict := fmt.Errorf("%w: %s is also being set", ErrFieldNotClearable, field)
+	switch field {
+	case "author":
+		if update.Author != nil {
+			return nil, false, conflict
+		}
+		if existing.Author != nil {
+			old, cleared = *existing.Author, true
+		}
+		existing.Author = nil
+	case "status":
+		if update.Status != nil {
+			return nil, false, conflict
+		}
+		if existing.Status != nil {
+			old, cleared = *existing.Status, true
+		}
+		existing.Status = nil
+	case "total_chapters":
+		if update.TotalChapters != nil {
+			return nil, false, conflict
+		}
+		if existing.TotalChapters != nil {
+			old, cleared = *existing.TotalChapters, true
+		}
+		existing.TotalChapters = nil
+	case "description":
+		if update.Description != nil {
+			return nil, false, conflict
+		}
+		if existing.Description != nil {
+			old, cleared = *existing.Description, true
+		}
+		existing.Description = nil
+	case "cover_url":
+		if update.CoverURL != nil {
+			return nil, false, conflict
+		}
+		if existing.CoverURL != nil {
+			old, cleared = *existing.CoverURL, true
+		}
+		existing.CoverURL = nil
+	default:
+		return nil, false, fmt.Errorf("%w: %s", ErrFieldNotClearable, field)
+	}
+	return old, cleared, nil
 }
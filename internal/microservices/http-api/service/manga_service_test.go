@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"mangahub/internal/microservices/http-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClearMangaField(t *testing.T) {
+	description := "A pirate adventure"
+	chapters := 1100
+
+	t.Run("ClearsAndReturnsOldValue", func(t *testing.T) {
+		existing := &models.Manga{Description: &description, TotalChapters: &chapters}
+
+		old, cleared, err := clearMangaField(existing, &models.Manga{}, "description")
+		assert.NoError(t, err)
+		assert.True(t, cleared)
+		assert.Equal(t, description, old)
+		assert.Nil(t, existing.Description)
+
+		old, cleared, err = clearMangaField(existing, &models.Manga{}, "total_chapters")
+		assert.NoError(t, err)
+		assert.True(t, cleared)
+		assert.Equal(t, chapters, old)
+		assert.Nil(t, existing.TotalChapters)
+	})
+
+	t.Run("AlreadyNull", func(t *testing.T) {
+		_, cleared, err := clearMangaField(&models.Manga{}, &models.Manga{}, "author")
+		assert.NoError(t, err)
+		assert.False(t, cleared)
+	})
+
+	t.Run("AlsoBeingSet", func(t *testing.T) {
+		_, _, err := clearMangaField(&models.Manga{}, &models.Manga{Description: &description}, "description")
+		assert.ErrorIs(t, err, ErrFieldNotClearable)
+	})
+
+	t.Run("NotNullable", func(t *testing.T) {
+		_, _, err := clearMangaField(&models.Manga{}, &models.Manga{}, "title")
+		assert.ErrorIs(t, err, ErrFieldNotClearable)
+	})
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrReportNotFound = errors.New("report not found")
+	ErrReportClosed   = errors.New("report has already been resolved or dismissed")
+)
+
+// MetadataReportService lets users flag wrong manga metadata and admins review it
+type MetadataReportService interface {
+	Report(ctx context.Context, userID string, mangaID int64, field, reason string) (*models.MetadataReport, error)
+	List(ctx context.Context, status string, page, pageSize int) ([]models.MetadataReport, int64, error)
+	// Close marks an open report resolved or dismissed, recording the admin and time
+	Close(ctx context.Context, reportID int64, status, adminID string) (*models.MetadataReport, error)
+}
+
+type metadataReportService struct {
+	reportRepo repository.MetadataReportRepository
+	mangaRepo  *repository.MangaRepo
+	now        func() time.Time
+}
+
+func NewMetadataReportService(reportRepo repository.MetadataReportRepository, mangaRepo *repository.MangaRepo) MetadataReportService {
+	return &metadataReportService{
+		reportRepo: reportRepo,
+		mangaRepo:  mangaRepo,
+		now:        time.Now,
+	}
+}
+
+func (s *metadataReportService) Report(ctx context.Context, userID string, mangaID int64, field, reason string) (*models.MetadataReport, error) {
+	if _, err := s.mangaRepo.GetByID(ctx, mangaID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMangaNotFound
+		}
+		return nil, err
+	}
+
+	report := &models.MetadataReport{
+		MangaID: mangaID,
+		UserID:  userID,
+		Field:   field,
+		Reason:  reason,
+		Status:  models.MetadataReportOpen,
+	}
+	if err := s.reportRepo.Create(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *metadataReportService) List(ctx context.Context, status string, page, pageSize int) ([]models.MetadataReport, int64, error) {
+	return s.reportRepo.List(ctx, status, page, pageSize)
+}
+
+func (s *metadataReportService) Close(ctx context.Context, reportID int64, status, adminID string) (*models.MetadataReport, error) {
+	report, err := s.reportRepo.GetByID(ctx, reportID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReportNotFound
+		}
+		return nil, err
+	}
+	if report.Status != models.MetadataReportOpen {
+		return nil, ErrReportClosed
+	}
+
+	report, err = s.reportRepo.Close(ctx, reportID, status, adminID, s.now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// another admin closed it between the read and the update
+		return nil, ErrReportClosed
+	}
+	return report, err
+}
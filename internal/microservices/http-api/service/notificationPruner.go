@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Notification pruning defaults
+const (
+	DefaultNotificationPruneInterval = 24 * time.Hour
+	notificationPruneBatchSize       = 1000
+)
+
+// notificationPruneStore deletes old notifications;
+// repository.NotificationRepository implements it
+type notificationPruneStore interface {
+	DeleteOlderThan(ctx context.Context, cutoff time.Time, keepUnread bool, limit int) (int64, error)
+}
+
+// NotificationPruner keeps the notifications table bounded by deleting
+// notifications older than the retention window, once per interval
+type NotificationPruner struct {
+	store      notificationPruneStore
+	retention  time.Duration
+	keepUnread bool // Unread notifications are kept however old they are
+	interval   time.Duration
+	now        func() time.Time
+}
+
+func NewNotificationPruner(store notificationPruneStore, retention time.Duration, keepUnread bool, interval time.Duration) *NotificationPruner {
+	if interval <= 0 {
+		interval = DefaultNotificationPruneInterval
+	}
+	return &NotificationPruner{
+		store:      store,
+		retention:  retention,
+		keepUnread: keepUnread,
+		interval:   interval,
+		now:        time.Now,
+	}
+}
+
+// Prune deletes every notification past the retention window, in batches,
+// and returns how many were deleted
+func (p *NotificationPruner) Prune(ctx context.Context) (int64, error) {
+	cutoff := p.now().Add(-p.retention)
+	var total int64
+	for {
+		n, err := p.store.DeleteOlderThan(ctx, cutoff, p.keepUnread, notificationPruneBatchSize)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < notificationPruneBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// Run prunes once at startup and then every interval until ctx is cancelled
+func (p *NotificationPruner) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.pruneAndLog(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *NotificationPruner) pruneAndLog(ctx context.Context) {
+	pruned, err := p.Prune(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("notification pruner: pruned %d before failing: %v", pruned, err)
+		}
+		return
+	}
+	log.Printf("notification pruner: pruned %d notifications older than %s", pruned, p.retention)
+}
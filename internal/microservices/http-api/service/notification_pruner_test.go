@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePruneStore struct {
+	remaining  int64
+	cutoffs    []time.Time
+	keepUnread bool
+	err        error
+}
+
+func (f *fakePruneStore) DeleteOlderThan(ctx context.Context, cutoff time.Time, keepUnread bool, limit int) (int64, error) {
+	f.cutoffs = append(f.cutoffs, cutoff)
+	f.keepUnread = keepUnread
+	if f.err != nil {
+		return 0, f.err
+	}
+	n := min(f.remaining, int64(limit))
+	f.remaining -= n
+	return n, nil
+}
+
+func TestNotificationPruner_DeletesInBatches(t *testing.T) {
+	store := &fakePruneStore{remaining: 2500}
+	pruner := NewNotificationPruner(store, 30*24*time.Hour, true, 0)
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	pruner.now = func() time.Time { return now }
+
+	pruned, err := pruner.Prune(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2500), pruned)
+	assert.Len(t, store.cutoffs, 3)
+	assert.Equal(t, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), store.cutoffs[0])
+	assert.True(t, store.keepUnread)
+}
+
+func TestNotificationPruner_ReportsFailure(t *testing.T) {
+	store := &fakePruneStore{err: errors.New("db down")}
+	pruner := NewNotificationPruner(store, time.Hour, false, 0)
+
+	_, err := pruner.Prune(context.Background())
+	assert.Error(t, err)
+}
+
+func TestNotificationPruner_RunPrunesAtStartup(t *testing.T) {
+	store := &fakePruneStore{remaining: 5}
+	pruner := NewNotificationPruner(store, time.Hour, false, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pruner.Run(ctx)
+
+	assert.Zero(t, store.remaining)
+}
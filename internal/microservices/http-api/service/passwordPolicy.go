@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"mangahub/internal/config"
+)
+
+// DefaultPasswordMinLength applies when no minimum is configured
+const DefaultPasswordMinLength = 8
+
+// ErrWeakPassword is matched by PasswordPolicyError via errors.Is
+var ErrWeakPassword = errors.New("password does not meet the password policy")
+
+// PasswordPolicy is the set of rules a new password must satisfy
+type PasswordPolicy struct {
+	MinLength      int
+	RequireDigit   bool
+	RequireUpper   bool
+	RequireSpecial bool // anything that isn't a letter, digit or space
+}
+
+// PasswordPolicyFromConfig reads the policy from the PASSWORD_* settings
+func PasswordPolicyFromConfig(cfg *config.Config) PasswordPolicy {
+	policy := PasswordPolicy{
+		MinLength:      cfg.PasswordMinLength,
+		RequireDigit:   cfg.PasswordRequireDigit,
+		RequireUpper:   cfg.PasswordRequireUpper,
+		RequireSpecial: cfg.PasswordRequireSpecial,
+	}
+	if policy.MinLength <= 0 {
+		policy.MinLength = DefaultPasswordMinLength
+	}
+	return policy
+}
+
+// PasswordViolation is one rule a password failed
+type PasswordViolation struct {
+	Rule    string // min_length, digit, upper or special
+	Message string
+}
+
+// PasswordPolicyError lists every rule a password failed
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+	return "password " + strings.Join(messages, "; ")
+}
+
+func (e *PasswordPolicyError) Is(target error) bool {
+	return target == ErrWeakPassword
+}
+
+// Check returns a *PasswordPolicyError naming each unmet rule, or nil
+func (p PasswordPolicy) Check(password string) error {
+	var hasDigit, hasUpper, hasSpecial bool
+	length := 0
+	for _, r := range password {
+		length++
+		switch {
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			hasSpecial = true
+		}
+	}
+
+	var violations []PasswordViolation
+	if length < p.MinLength {
+		violations = append(violations, PasswordViolation{
+			Rule:    "min_length",
+			Message: fmt.Sprintf("must be at least %d characters", p.MinLength),
+		})
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, PasswordViolation{Rule: "digit", Message: "must contain a digit"})
+	}
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, PasswordViolation{Rule: "upper", Message: "must contain an uppercase letter"})
+	}
+	if p.RequireSpecial && !hasSpecial {
+		violations = append(violations, PasswordViolation{Rule: "special", Message: "must contain a special character"})
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
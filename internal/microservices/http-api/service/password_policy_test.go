@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"mangahub/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func strictPolicy() PasswordPolicy {
+	return PasswordPolicy{MinLength: 10, RequireDigit: true, RequireUpper: true, RequireSpecial: true}
+}
+
+func violationRules(t *testing.T, err error) []string {
+	t.Helper()
+	var policyErr *PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("expected *PasswordPolicyError, got %v", err)
+	}
+	rules := make([]string, len(policyErr.Violations))
+	for i, v := range policyErr.Violations {
+		rules[i] = v.Rule
+	}
+	return rules
+}
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	policy := strictPolicy()
+
+	t.Run("Compliant", func(t *testing.T) {
+		assert.NoError(t, policy.Check("Str0ng!Password"))
+	})
+
+	t.Run("TooShort", func(t *testing.T) {
+		err := policy.Check("Sh0rt!")
+		assert.ErrorIs(t, err, ErrWeakPassword)
+		assert.Equal(t, []string{"min_length"}, violationRules(t, err))
+		assert.Contains(t, err.Error(), "at least 10 characters")
+	})
+
+	t.Run("MissingDigit", func(t *testing.T) {
+		assert.Equal(t, []string{"digit"}, violationRules(t, policy.Check("NoDigits!Here")))
+	})
+
+	t.Run("MissingUpper", func(t *testing.T) {
+		assert.Equal(t, []string{"upper"}, violationRules(t, policy.Check("no-upper-1234")))
+	})
+
+	t.Run("MissingSpecial", func(t *testing.T) {
+		assert.Equal(t, []string{"special"}, violationRules(t, policy.Check("NoSpecial1234")))
+	})
+
+	t.Run("ReportsEveryRule", func(t *testing.T) {
+		assert.Equal(t, []string{"min_length", "digit", "upper", "special"}, violationRules(t, policy.Check("abc")))
+	})
+
+	t.Run("CountsRunesNotBytes", func(t *testing.T) {
+		assert.Error(t, PasswordPolicy{MinLength: 5}.Check("ääää"))
+		assert.NoError(t, PasswordPolicy{MinLength: 4}.Check("ääää"))
+	})
+}
+
+func TestPasswordPolicyFromConfig_DefaultsMinLength(t *testing.T) {
+	policy := PasswordPolicyFromConfig(&config.Config{PasswordRequireDigit: true})
+	assert.Equal(t, DefaultPasswordMinLength, policy.MinLength)
+	assert.True(t, policy.RequireDigit)
+}
+
+func TestRegister_WeakPassword(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	cfg := &config.Config{JWTSecret: "test-secret", PasswordMinLength: 12, PasswordRequireDigit: true}
+	authService := NewAuthService(mockUserRepo, new(MockRefreshTokenRepository), cfg)
+
+	user, err := authService.Register("testuser", "password", "test@example.com")
+
+	assert.Nil(t, user)
+	assert.Equal(t, []string{"min_length", "digit"}, violationRules(t, err))
+	mockUserRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"mangahub/internal/microservices/http-api/models"
 	"mangahub/internal/microservices/http-api/repository"
+
+	"gorm.io/gorm"
 )
 
 var (
@@ -13,8 +15,13 @@ var (
 	ErrFailedToGetAllProgress = errors.New("failed to get all progress")
 	ErrFailedToGetProgress    = errors.New("failed to get progress")
 	ErrFailedToDeleteProgress = errors.New("failed to delete progress")
+	ErrFailedToRankProgress   = errors.New("failed to rank progress")
 )
 
+// MinReadersToRank is how many readers a manga needs before a reader's
+// position among them means anything
+const MinReadersToRank = 5
+
 type progressService struct {
 	progressRepo repository.ProgressRepository
 }
@@ -24,6 +31,7 @@ type ProgressService interface {
 	GetProgressByMangaID(ctx context.Context, userID string, mangaID int64) (*models.UserProgress, error)
 	UpdateProgress(ctx context.Context, progress *models.UserProgress) error
 	DeleteProgress(ctx context.Context, userID string, mangaID int64) error
+	GetReaderRank(ctx context.Context, userID string, mangaID int64) (*repository.ReaderRank, error)
 }
 
 func NewProgressService(progressRepo repository.ProgressRepository) ProgressService {
@@ -51,9 +59,30 @@ func (s *progressService) UpdateProgress(ctx context.Context, progress *models.U
 	}
 	return nil
 }
+// DeleteProgress resets the user's progress on a manga so they can start
+// over. The progress row carries the reading status too, so a "completed"
+// mark goes with it. Returns ErrProgressNotFound if there was nothing to clear.
 func (s *progressService) DeleteProgress(ctx context.Context, userID string, mangaID int64) error {
-	if err := s.progressRepo.DeleteProgress(ctx, userID, mangaID); err != nil {
+	err := s.progressRepo.DeleteProgress(ctx, userID, mangaID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrProgressNotFound
+	}
+	if err != nil {
 		return ErrFailedToDeleteProgress
 	}
 	return nil
 }
+
+// GetReaderRank compares the user's progress on a manga with its other
+// readers. Callers should check Readers against MinReadersToRank before
+// presenting the rank.
+func (s *progressService) GetReaderRank(ctx context.Context, userID string, mangaID int64) (*repository.ReaderRank, error) {
+	rank, err := s.progressRepo.GetReaderRank(ctx, userID, mangaID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrProgressNotFound
+	}
+	if err != nil {
+		return nil, ErrFailedToRankProgress
+	}
+	return rank, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"mangahub/internal/microservices/http-api/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type rankProgressRepo struct {
+	repository.ProgressRepository
+	rank *repository.ReaderRank
+	err  error
+}
+
+func (f *rankProgressRepo) GetReaderRank(ctx context.Context, userID string, mangaID int64) (*repository.ReaderRank, error) {
+	return f.rank, f.err
+}
+
+func TestProgressService_GetReaderRank(t *testing.T) {
+	t.Run("Ranked", func(t *testing.T) {
+		want := &repository.ReaderRank{Chapter: 42, Readers: 50, PercentRank: 0.72}
+		svc := NewProgressService(&rankProgressRepo{rank: want})
+
+		rank, err := svc.GetReaderRank(context.Background(), "user-1", 7)
+		require.NoError(t, err)
+		assert.Equal(t, want, rank)
+	})
+
+	t.Run("NotReading", func(t *testing.T) {
+		svc := NewProgressService(&rankProgressRepo{err: gorm.ErrRecordNotFound})
+
+		_, err := svc.GetReaderRank(context.Background(), "user-1", 7)
+		assert.ErrorIs(t, err, ErrProgressNotFound)
+	})
+
+	t.Run("QueryFailed", func(t *testing.T) {
+		svc := NewProgressService(&rankProgressRepo{err: errors.New("connection reset")})
+
+		_, err := svc.GetReaderRank(context.Background(), "user-1", 7)
+		assert.ErrorIs(t, err, ErrFailedToRankProgress)
+	})
+}
+
+type deleteProgressRepo struct {
+	repository.ProgressRepository
+	err error
+}
+
+func (f *deleteProgressRepo) DeleteProgress(ctx context.Context, userID string, mangaID int64) error {
+	return f.err
+}
+
+func TestProgressService_DeleteProgress(t *testing.T) {
+	t.Run("Deleted", func(t *testing.T) {
+		svc := NewProgressService(&deleteProgressRepo{})
+		assert.NoError(t, svc.DeleteProgress(context.Background(), "user-1", 7))
+	})
+
+	t.Run("NothingToClear", func(t *testing.T) {
+		svc := NewProgressService(&deleteProgressRepo{err: gorm.ErrRecordNotFound})
+		assert.ErrorIs(t, svc.DeleteProgress(context.Background(), "user-1", 7), ErrProgressNotFound)
+	})
+
+	t.Run("DeleteFailed", func(t *testing.T) {
+		svc := NewProgressService(&deleteProgressRepo{err: errors.New("connection reset")})
+		assert.ErrorIs(t, svc.DeleteProgress(context.Background(), "user-1", 7), ErrFailedToDeleteProgress)
+	})
+}
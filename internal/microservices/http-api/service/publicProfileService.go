@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+
+	"gorm.io/gorm"
+)
+
+// PublicProfileRecentComments is how many of a user's latest comments a
+// public profile shows
+const PublicProfileRecentComments = 5
+
+// ErrProfilePrivate is returned when another user's profile is hidden
+var ErrProfilePrivate = errors.New("profile is private")
+
+// PublicProfile is what other users may see about a user. It carries only
+// public activity; the handler decides which User fields leave the server.
+type PublicProfile struct {
+	User           models.User
+	RatingCount    int64
+	RecentComments []models.Comment // Newest first, with Manga loaded
+	LibrarySize    *int64           // nil unless the user shares it
+	FollowerCount  int64
+	FollowingCount int64
+}
+
+// PublicProfileService aggregates a user's public activity across the
+// rating, comment, library and follow repositories
+type PublicProfileService interface {
+	// Get returns username's profile as seen by viewerID. Users can always
+	// see their own profile; anyone else gets ErrProfilePrivate if it's hidden.
+	Get(ctx context.Context, viewerID, username string) (*PublicProfile, error)
+}
+
+type publicProfileService struct {
+	users    repository.UserRepository
+	ratings  repository.RatingRepository
+	comments repository.CommentRepository
+	library  repository.LibraryRepository
+	follows  repository.FollowRepository
+}
+
+func NewPublicProfileService(users repository.UserRepository, ratings repository.RatingRepository,
+	comments repository.CommentRepository, library repository.LibraryRepository, follows repository.FollowRepository) PublicProfileService {
+	return &publicProfileService{users: users, ratings: ratings, comments: comments, library: library, follows: follows}
+}
+
+func (s *publicProfileService) Get(ctx context.Context, viewerID, username string) (*PublicProfile, error) {
+	user, err := s.users.FindByUsername(username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	self := user.ID == viewerID
+	if !user.ProfilePublic && !self {
+		return nil, ErrProfilePrivate
+	}
+
+	profile := &PublicProfile{User: *user}
+
+	if profile.RatingCount, err = s.ratings.CountByUser(user.ID); err != nil {
+		return nil, err
+	}
+
+	if profile.RecentComments, _, err = s.comments.GetByUser(user.ID, 1, PublicProfileRecentComments); err != nil {
+		return nil, err
+	}
+
+	if profile.FollowerCount, profile.FollowingCount, err = s.follows.Counts(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	if user.LibraryPublic || self {
+		counts, err := s.library.StatusCounts(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		var size int64
+		for _, c := range counts {
+			size += c.Count
+		}
+		profile.LibrarySize = &size
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// fakeProfileUsers, fakeProfileRatings and fakeProfileComments implement
+// only what the public profile service calls
+type fakeProfileUsers struct {
+	repository.UserRepository
+	users map[string]models.User // by username
+}
+
+func (f *fakeProfileUsers) FindByUsername(username string) (*models.User, error) {
+	u, ok := f.users[username]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &u, nil
+}
+
+type fakeProfileRatings struct {
+	repository.RatingRepository
+	count int64
+}
+
+func (f *fakeProfileRatings) CountByUser(userID string) (int64, error) {
+	return f.count, nil
+}
+
+type fakeProfileComments struct {
+	repository.CommentRepository
+	pageSize int
+}
+
+func (f *fakeProfileComments) GetByUser(userID string, page, pageSize int) ([]models.Comment, int64, error) {
+	f.pageSize = pageSize
+	return []models.Comment{{ID: 1, UserID: userID, Content: "hi"}}, 1, nil
+}
+
+type fakeProfileFollows struct {
+	repository.FollowRepository
+}
+
+func (f *fakeProfileFollows) Counts(ctx context.Context, userID string) (int64, int64, error) {
+	return 7, 2, nil
+}
+
+func newProfileService(users ...models.User) (PublicProfileService, *fakeProfileComments) {
+	byName := make(map[string]models.User, len(users))
+	for _, u := range users {
+		byName[u.Username] = u
+	}
+	comments := &fakeProfileComments{}
+	library := &fakeLibraryRepo{counts: []repository.LibraryStatusCount{
+		{Status: "reading", Count: 2},
+		{Status: "", Count: 3},
+	}}
+	return NewPublicProfileService(&fakeProfileUsers{users: byName}, &fakeProfileRatings{count: 4}, comments, library, &fakeProfileFollows{}), comments
+}
+
+func TestPublicProfileService_Get(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("PublicWithSharedLibrary", func(t *testing.T) {
+		svc, comments := newProfileService(models.User{ID: "u1", Username: "reader", ProfilePublic: true, LibraryPublic: true})
+
+		profile, err := svc.Get(ctx, "viewer", "reader")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), profile.RatingCount)
+		assert.Len(t, profile.RecentComments, 1)
+		assert.Equal(t, PublicProfileRecentComments, comments.pageSize)
+		assert.Equal(t, int64(5), *profile.LibrarySize)
+		assert.Equal(t, int64(7), profile.FollowerCount)
+		assert.Equal(t, int64(2), profile.FollowingCount)
+	})
+
+	t.Run("LibraryNotShared", func(t *testing.T) {
+		svc, _ := newProfileService(models.User{ID: "u1", Username: "reader", ProfilePublic: true})
+
+		profile, err := svc.Get(ctx, "viewer", "reader")
+
+		assert.NoError(t, err)
+		assert.Nil(t, profile.LibrarySize)
+	})
+
+	t.Run("PrivateToOthers", func(t *testing.T) {
+		svc, _ := newProfileService(models.User{ID: "u1", Username: "reader"})
+
+		_, err := svc.Get(ctx, "viewer", "reader")
+
+		assert.True(t, errors.Is(err, ErrProfilePrivate))
+	})
+
+	t.Run("PrivateVisibleToOwner", func(t *testing.T) {
+		svc, _ := newProfileService(models.User{ID: "u1", Username: "reader"})
+
+		profile, err := svc.Get(ctx, "u1", "reader")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), *profile.LibrarySize)
+	})
+
+	t.Run("UnknownUser", func(t *testing.T) {
+		svc, _ := newProfileService()
+
+		_, err := svc.Get(ctx, "viewer", "ghost")
+
+		assert.True(t, errors.Is(err, ErrUserNotFound))
+	})
+}
@@ -17,6 +17,7 @@ type RatingService interface {
 	GetUserRating(userID string, mangaID int64) (*dto.UserRatingResponse, error)
 	GetMangaRatings(mangaID int64, page, pageSize int) (*dto.PaginatedRatingResponse, error)
 	GetMangaAverageRating(mangaID int64) (float64, int64, error)
+	GetAverageRatings(mangaIDs []int64) (map[int64]dto.RatingAverage, error)
 }
 
 type ratingService struct {
@@ -181,6 +182,32 @@ func (s *ratingService) GetMangaAverageRating(mangaID int64) (float64, int64, er
 	return avg, count, nil
 }
 
+// GetAverageRatings retrieves the average rating and count for several manga
+// with a single query. Every requested ID is present in the result; manga
+// with no ratings (or that don't exist) report zero.
+func (s *ratingService) GetAverageRatings(mangaIDs []int64) (map[int64]dto.RatingAverage, error) {
+	ids := make([]int64, 0, len(mangaIDs))
+	seen := make(map[int64]bool, len(mangaIDs))
+	for _, id := range mangaIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	summaries, err := s.ratingRepo.AverageRatings(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	averages := make(map[int64]dto.RatingAverage, len(ids))
+	for _, id := range ids {
+		summary := summaries[id]
+		averages[id] = dto.RatingAverage{Average: summary.Average, Count: summary.Count}
+	}
+	return averages, nil
+}
+
 // updateMangaAverageRating updates the average_rating field in the manga table
 func (s *ratingService) updateMangaAverageRating(mangaID int64) error {
 	ctx := context.Background()
@@ -3,6 +3,9 @@ package service
 import (
 	"testing"
 
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/repository"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -12,5 +15,30 @@ func TestRatingServiceStructure(t *testing.T) {
 	})
 }
 
-// Full tests require proper mocking infrastructure
-// See comment_service_test.go for structure
+// fakeRatingRepo implements only what the tests call
+type fakeRatingRepo struct {
+	repository.RatingRepository
+	summaries map[int64]repository.RatingSummary
+	asked     []int64
+}
+
+func (f *fakeRatingRepo) AverageRatings(mangaIDs []int64) (map[int64]repository.RatingSummary, error) {
+	f.asked = mangaIDs
+	return f.summaries, nil
+}
+
+func TestGetAverageRatings_DedupesAndFillsMissing(t *testing.T) {
+	repo := &fakeRatingRepo{summaries: map[int64]repository.RatingSummary{
+		1: {MangaID: 1, Average: 7.5, Count: 2},
+	}}
+	svc := NewRatingService(repo, nil)
+
+	averages, err := svc.GetAverageRatings([]int64{1, 2, 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, repo.asked)
+	assert.Equal(t, map[int64]dto.RatingAverage{
+		1: {Average: 7.5, Count: 2},
+		2: {Average: 0, Count: 0},
+	}, averages)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+)
+
+// recordViewTimeout bounds the background write made for each detail view
+const recordViewTimeout = 2 * time.Second
+
+// RecentlyViewedService tracks the manga a user opens for the
+// "continue browsing" row
+type RecentlyViewedService interface {
+	// RecordView notes that userID opened mangaID. It returns immediately;
+	// the write happens in the background and failures are only logged.
+	RecordView(userID string, mangaID int64)
+	List(ctx context.Context, userID string) ([]models.Manga, error)
+}
+
+// mangaByIDs is the part of the manga repository the service needs
+type mangaByIDs interface {
+	GetByIDs(ctx context.Context, ids []int64) ([]models.Manga, error)
+}
+
+type recentlyViewedService struct {
+	repo      repository.RecentlyViewedRepository
+	mangaRepo mangaByIDs
+}
+
+func NewRecentlyViewedService(repo repository.RecentlyViewedRepository, mangaRepo *repository.MangaRepo) RecentlyViewedService {
+	return &recentlyViewedService{repo: repo, mangaRepo: mangaRepo}
+}
+
+func (s *recentlyViewedService) RecordView(userID string, mangaID int64) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), recordViewTimeout)
+		defer cancel()
+
+		if err := s.repo.Push(ctx, userID, mangaID); err != nil {
+			log.Printf("recently viewed: record manga %d for user %s: %v", mangaID, userID, err)
+		}
+	}()
+}
+
+// List returns the user's recently viewed manga, most recent first. Manga
+// deleted since they were viewed are left out.
+func (s *recentlyViewedService) List(ctx context.Context, userID string) ([]models.Manga, error) {
+	ids, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := s.mangaRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]models.Manga, len(found))
+	for _, m := range found {
+		byID[m.ID] = m
+	}
+
+	list := make([]models.Manga, 0, len(ids))
+	for _, id := range ids {
+		if m, ok := byID[id]; ok {
+			list = append(list, m)
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mangahub/internal/microservices/http-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRecentlyViewedRepo struct {
+	ids    []int64
+	pushed chan int64
+}
+
+func (f *fakeRecentlyViewedRepo) Push(ctx context.Context, userID string, mangaID int64) error {
+	f.pushed <- mangaID
+	return nil
+}
+
+func (f *fakeRecentlyViewedRepo) List(ctx context.Context, userID string) ([]int64, error) {
+	return f.ids, nil
+}
+
+type fakeMangaByIDs map[int64]models.Manga
+
+func (f fakeMangaByIDs) GetByIDs(ctx context.Context, ids []int64) ([]models.Manga, error) {
+	var list []models.Manga
+	for _, id := range ids {
+		if m, ok := f[id]; ok {
+			list = append(list, m)
+		}
+	}
+	// The repository gives no ordering guarantee
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+	return list, nil
+}
+
+func TestRecentlyViewedService_ListKeepsRecencyOrder(t *testing.T) {
+	repo := &fakeRecentlyViewedRepo{ids: []int64{3, 9, 1, 2}}
+	manga := fakeMangaByIDs{
+		1: {ID: 1, Title: "One"},
+		2: {ID: 2, Title: "Two"},
+		3: {ID: 3, Title: "Three"},
+	}
+	svc := &recentlyViewedService{repo: repo, mangaRepo: manga}
+
+	list, err := svc.List(context.Background(), "user-1")
+
+	assert.NoError(t, err)
+	ids := make([]int64, 0, len(list))
+	for _, m := range list {
+		ids = append(ids, m.ID)
+	}
+	assert.Equal(t, []int64{3, 1, 2}, ids, "deleted manga 9 is dropped")
+}
+
+func TestRecentlyViewedService_RecordViewIsAsync(t *testing.T) {
+	repo := &fakeRecentlyViewedRepo{pushed: make(chan int64)}
+	svc := &recentlyViewedService{repo: repo}
+
+	done := make(chan struct{})
+	go func() {
+		svc.RecordView("user-1", 42)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RecordView blocked on the repository")
+	}
+
+	select {
+	case id := <-repo.pushed:
+		assert.Equal(t, int64(42), id)
+	case <-time.After(time.Second):
+		t.Fatal("view was never recorded")
+	}
+}
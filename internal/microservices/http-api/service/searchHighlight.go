@@ -0,0 +1,154 @@
+package service
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"mangahub/internal/microservices/http-api/models"
+)
+
+// Searchable manga fields, in the order they are reported
+const (
+	MatchTitle       = "title"
+	MatchAuthor      = "author"
+	MatchSlug        = "slug"
+	MatchDescription = "description"
+)
+
+// Excerpt sizing around the first description match, in runes
+const (
+	excerptBefore = 40
+	excerptAfter  = 80
+)
+
+// Highlight markers wrapped around each matched term in an excerpt
+const (
+	HighlightOpen  = "<em>"
+	HighlightClose = "</em>"
+)
+
+// SearchMatch reports which fields of m contain at least one query token,
+// using the same case-insensitive substring rule as the repository. When the
+// description matches it also returns a short, HTML-escaped excerpt with the
+// terms highlighted; otherwise excerpt is empty.
+func SearchMatch(m models.Manga, query string) (fields []string, excerpt string) {
+	tokens := searchTokens(query)
+	if len(tokens) == 0 {
+		return nil, ""
+	}
+
+	candidates := []struct {
+		name  string
+		value *string
+	}{
+		{MatchTitle, &m.Title},
+		{MatchAuthor, m.Author},
+		{MatchSlug, m.Slug},
+		{MatchDescription, m.Description},
+	}
+
+	for _, f := range candidates {
+		if f.value == nil || *f.value == "" {
+			continue
+		}
+		if _, _, ok := firstMatch(lowerRunes(*f.value), tokens); ok {
+			fields = append(fields, f.name)
+		}
+	}
+
+	if m.Description != nil {
+		excerpt = highlightExcerpt(*m.Description, tokens)
+	}
+	return fields, excerpt
+}
+
+// searchTokens splits the query like the repository does and lowercases each
+// token, longest first so overlapping terms highlight the longer one
+func searchTokens(query string) [][]rune {
+	words := strings.Fields(query)
+	tokens := make([][]rune, 0, len(words))
+	for _, w := range words {
+		tokens = append(tokens, lowerRunes(w))
+	}
+	sort.SliceStable(tokens, func(i, j int) bool { return len(tokens[i]) > len(tokens[j]) })
+	return tokens
+}
+
+// lowerRunes lowercases rune by rune so indexes line up with the original
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// firstMatch finds the earliest position any token occurs at in text
+func firstMatch(text []rune, tokens [][]rune) (pos, length int, ok bool) {
+	for i := range text {
+		for _, t := range tokens {
+			if hasPrefixRunes(text[i:], t) {
+				return i, len(t), true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func hasPrefixRunes(s, prefix []rune) bool {
+	if len(prefix) == 0 || len(prefix) > len(s) {
+		return false
+	}
+	for i, r := range prefix {
+		if s[i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// highlightExcerpt cuts a window around the first match in text and wraps
+// every token occurrence inside it in the highlight markers. Ingested
+// descriptions may contain HTML, so the text itself is escaped and the
+// markers are the only markup in the result.
+func highlightExcerpt(text string, tokens [][]rune) string {
+	original := []rune(text)
+	lower := lowerRunes(text)
+
+	pos, length, ok := firstMatch(lower, tokens)
+	if !ok {
+		return ""
+	}
+
+	start := max(pos-excerptBefore, 0)
+	end := min(pos+length+excerptAfter, len(original))
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; {
+		matched := 0
+		for _, t := range tokens {
+			if i+len(t) <= end && hasPrefixRunes(lower[i:], t) {
+				matched = len(t)
+				break
+			}
+		}
+		if matched == 0 {
+			b.WriteString(html.EscapeString(string(original[i])))
+			i++
+			continue
+		}
+		b.WriteString(HighlightOpen)
+		b.WriteString(html.EscapeString(string(original[i : i+matched])))
+		b.WriteString(HighlightClose)
+		i += matched
+	}
+	if end < len(original) {
+		b.WriteString("…")
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"mangahub/internal/microservices/http-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestSearchMatch_Fields(t *testing.T) {
+	m := models.Manga{
+		Title:       "One Piece",
+		Author:      strPtr("Eiichiro Oda"),
+		Slug:        strPtr("one-piece"),
+		Description: strPtr("Monkey D. Luffy sets off to find the legendary treasure."),
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"piece", []string{MatchTitle, MatchSlug}},
+		{"ODA", []string{MatchAuthor}},
+		{"oda treasure", []string{MatchAuthor, MatchDescription}},
+		{"naruto", nil},
+		{"   ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			fields, _ := SearchMatch(m, tt.query)
+			assert.Equal(t, tt.want, fields)
+		})
+	}
+}
+
+func TestSearchMatch_DescriptionExcerpt(t *testing.T) {
+	m := models.Manga{
+		Title:       "Berserk",
+		Description: strPtr("Guts, a former mercenary known as the Black Swordsman, is out for revenge."),
+	}
+
+	_, excerpt := SearchMatch(m, "black swordsman")
+	assert.Equal(t, "Guts, a former mercenary known as the <em>Black</em> <em>Swordsman</em>, is out for revenge.", excerpt)
+
+	_, excerpt = SearchMatch(m, "berserk")
+	assert.Empty(t, excerpt, "no excerpt when the description didn't match")
+}
+
+func TestSearchMatch_ExcerptIsTrimmedAroundMatch(t *testing.T) {
+	desc := strings.Repeat("filler ", 30) + "dragon" + strings.Repeat(" filler", 30)
+	m := models.Manga{Title: "Long", Description: &desc}
+
+	_, excerpt := SearchMatch(m, "dragon")
+	assert.True(t, strings.HasPrefix(excerpt, "…"))
+	assert.True(t, strings.HasSuffix(excerpt, "…"))
+	assert.Contains(t, excerpt, "<em>dragon</em>")
+	assert.Less(t, len([]rune(excerpt)), len([]rune(desc)))
+}
+
+func TestSearchMatch_PrefersLongerToken(t *testing.T) {
+	m := models.Manga{Title: "X", Description: strPtr("A tale of swordsmanship")}
+
+	_, excerpt := SearchMatch(m, "sword swordsman")
+	assert.Equal(t, "A tale of <em>swordsman</em>ship", excerpt)
+}
+
+func TestSearchMatch_ExcerptEscapesHTML(t *testing.T) {
+	m := models.Manga{Title: "X", Description: strPtr(`<img src=x onerror="alert(1)"> A <b>dragon</b> & a knight`)}
+
+	_, excerpt := SearchMatch(m, "dragon")
+	assert.Equal(t, "&lt;img src=x onerror=&#34;alert(1)&#34;&gt; A &lt;b&gt;<em>dragon</em>&lt;/b&gt; &amp; a knight", excerpt)
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrSessionNotFound is returned when a session doesn't exist or belongs to another user
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionInfo describes the client a login came from. It's stored on the
+// refresh token so users can see and revoke where they're signed in.
+type SessionInfo struct {
+	UserAgent  string
+	IPAddress  string
+	DeviceName string // optional; derived from the user agent when empty
+	// AccessTokenTTL is the client's token_ttl hint; zero uses the server
+	// default. It's kept for the session so refreshed tokens get it too.
+	AccessTokenTTL time.Duration
+}
+
+// maxUserAgentLength bounds the stored user agent; some clients send huge ones
+const maxUserAgentLength = 512
+
+// normalize truncates the user agent and fills in a device name
+func (s SessionInfo) normalize() SessionInfo {
+	if len(s.UserAgent) > maxUserAgentLength {
+		s.UserAgent = s.UserAgent[:maxUserAgentLength]
+	}
+	s.DeviceName = strings.TrimSpace(s.DeviceName)
+	if s.DeviceName == "" {
+		s.DeviceName = deviceNameFromUserAgent(s.UserAgent)
+	}
+	return s
+}
+
+// deviceNameFromUserAgent builds a friendly name like "Firefox on Windows".
+// It's a best-effort label, not a full user-agent parser.
+func deviceNameFromUserAgent(ua string) string {
+	if ua == "" {
+		return "Unknown device"
+	}
+
+	browser := ""
+	switch {
+	case strings.Contains(ua, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "Chrome/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "Safari/"):
+		browser = "Safari"
+	}
+
+	os := ""
+	switch {
+	case strings.Contains(ua, "Android"):
+		os = "Android"
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"):
+		os = "iOS"
+	case strings.Contains(ua, "Windows"):
+		os = "Windows"
+	case strings.Contains(ua, "Mac OS X"), strings.Contains(ua, "Macintosh"):
+		os = "macOS"
+	case strings.Contains(ua, "Linux"):
+		os = "Linux"
+	}
+
+	switch {
+	case browser != "" && os != "":
+		return browser + " on " + os
+	case browser != "":
+		return browser
+	case os != "":
+		return os
+	}
+
+	// Non-browser clients (CLI, curl, Go's HTTP client): use the product token
+	product, _, _ := strings.Cut(ua, " ")
+	product, _, _ = strings.Cut(product, "/")
+	return product
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+
+	"gorm.io/gorm"
+)
+
+// ErrUserNotFound is returned when a user ID doesn't exist
+var ErrUserNotFound = errors.New("user not found")
+
+// UserService serves user profiles and the admin user list
+type UserService interface {
+	GetProfile(ctx context.Context, userID string) (*models.User, error)
+	List(ctx context.Context, page, pageSize int) ([]models.User, int64, error)
+	// UpdatePrivacy changes the flags that are non-nil and returns the updated profile
+	UpdatePrivacy(ctx context.Context, userID string, profilePublic, libraryPublic *bool) (*models.User, error)
+}
+
+type userService struct {
+	repo repository.UserRepository
+}
+
+func NewUserService(repo repository.UserRepository) UserService {
+	return &userService{repo: repo}
+}
+
+func (s *userService) GetProfile(ctx context.Context, userID string) (*models.User, error) {
+	user, err := s.repo.FindByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+func (s *userService) List(ctx context.Context, page, pageSize int) ([]models.User, int64, error) {
+	return s.repo.List(ctx, page, pageSize)
+}
+
+func (s *userService) UpdatePrivacy(ctx context.Context, userID string, profilePublic, libraryPublic *bool) (*models.User, error) {
+	err := s.repo.UpdatePrivacy(ctx, userID, profilePublic, libraryPublic)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.GetProfile(ctx, userID)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultViewFlushInterval is how often pending view counts are written
+const DefaultViewFlushInterval = 30 * time.Second
+
+// viewCountStore persists batched view counts; *repository.MangaRepo implements it
+type viewCountStore interface {
+	IncrementViewCounts(ctx context.Context, counts map[int64]int64) error
+}
+
+// ViewCounter batches manga detail views in memory and adds them to
+// manga.view_count periodically, so a popular title costs one UPDATE per
+// flush instead of one per view
+type ViewCounter struct {
+	store    viewCountStore
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending map[int64]int64
+}
+
+func NewViewCounter(store viewCountStore, interval time.Duration) *ViewCounter {
+	if interval <= 0 {
+		interval = DefaultViewFlushInterval
+	}
+	return &ViewCounter{
+		store:    store,
+		interval: interval,
+		pending:  make(map[int64]int64),
+	}
+}
+
+// Record counts one view of mangaID; it never blocks on the database
+func (v *ViewCounter) Record(mangaID int64) {
+	v.mu.Lock()
+	v.pending[mangaID]++
+	v.mu.Unlock()
+}
+
+// Flush writes the pending counts. On failure they are kept and retried
+// with the next flush.
+func (v *ViewCounter) Flush(ctx context.Context) error {
+	v.mu.Lock()
+	batch := v.pending
+	v.pending = make(map[int64]int64, len(batch))
+	v.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if err := v.store.IncrementViewCounts(ctx, batch); err != nil {
+		v.mu.Lock()
+		for id, n := range batch {
+			v.pending[id] += n
+		}
+		v.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Run flushes every interval until ctx is cancelled, then flushes once more
+// so views recorded before shutdown aren't lost
+func (v *ViewCounter) Run(ctx context.Context) {
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := v.Flush(ctx); err != nil {
+				log.Printf("view counter: flush failed, will retry: %v", err)
+			}
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := v.Flush(flushCtx); err != nil {
+				log.Printf("view counter: final flush failed: %v", err)
+			}
+			cancel()
+			return
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeViewStore struct {
+	flushed []map[int64]int64
+	err     error
+}
+
+func (f *fakeViewStore) IncrementViewCounts(ctx context.Context, counts map[int64]int64) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.flushed = append(f.flushed, counts)
+	return nil
+}
+
+func TestViewCounter_BatchesViews(t *testing.T) {
+	store := &fakeViewStore{}
+	counter := NewViewCounter(store, 0)
+
+	counter.Record(1)
+	counter.Record(1)
+	counter.Record(2)
+
+	assert.NoError(t, counter.Flush(context.Background()))
+	assert.Equal(t, []map[int64]int64{{1: 2, 2: 1}}, store.flushed)
+
+	// Nothing pending: no write at all
+	assert.NoError(t, counter.Flush(context.Background()))
+	assert.Len(t, store.flushed, 1)
+}
+
+func TestViewCounter_KeepsCountsWhenFlushFails(t *testing.T) {
+	store := &fakeViewStore{err: errors.New("db down")}
+	counter := NewViewCounter(store, 0)
+
+	counter.Record(7)
+	assert.Error(t, counter.Flush(context.Background()))
+
+	counter.Record(7)
+	store.err = nil
+	assert.NoError(t, counter.Flush(context.Background()))
+	assert.Equal(t, []map[int64]int64{{7: 2}}, store.flushed)
+}
+
+func TestViewCounter_RunFlushesOnShutdown(t *testing.T) {
+	store := &fakeViewStore{}
+	counter := NewViewCounter(store, 0)
+	counter.Record(3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	counter.Run(ctx)
+
+	assert.Equal(t, []map[int64]int64{{3: 1}}, store.flushed)
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+
+	"gorm.io/gorm"
+)
+
+// Webhook limits
+const (
+	MaxWebhooksPerUser   = 10
+	MinWebhookSecretSize = 16
+)
+
+var (
+	// ErrWebhookNotFound is returned for a webhook the user doesn't own
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrTooManyWebhooks is returned once a user has MaxWebhooksPerUser
+	ErrTooManyWebhooks = fmt.Errorf("at most %d webhooks per user", MaxWebhooksPerUser)
+	// ErrInvalidWebhook is matched by WebhookFieldError via errors.Is
+	ErrInvalidWebhook = errors.New("invalid webhook")
+)
+
+// WebhookFieldError is a webhook field that failed validation
+type WebhookFieldError struct {
+	Field   string // url, events or secret
+	Rule    string
+	Message string
+}
+
+func (e *WebhookFieldError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+func (e *WebhookFieldError) Is(target error) bool {
+	return target == ErrInvalidWebhook
+}
+
+// WebhookChanges are the fields to change on a webhook; nil leaves a field
+// as it is. Re-activating a webhook clears its failure count.
+type WebhookChanges struct {
+	URL    *string
+	Events []string
+	Active *bool
+}
+
+// WebhookService manages the webhooks users register for notifications
+type WebhookService interface {
+	// Create registers a webhook. An empty secret gets a random one; the
+	// returned webhook is the only place the caller sees it.
+	Create(ctx context.Context, userID, targetURL string, events []string, secret string) (*models.Webhook, error)
+	List(ctx context.Context, userID string) ([]models.Webhook, error)
+	Get(ctx context.Context, userID string, id int64) (*models.Webhook, error)
+	Update(ctx context.Context, userID string, id int64, changes WebhookChanges) (*models.Webhook, error)
+	Delete(ctx context.Context, userID string, id int64) error
+}
+
+type webhookService struct {
+	repo repository.WebhookRepository
+}
+
+func NewWebhookService(repo repository.WebhookRepository) WebhookService {
+	return &webhookService{repo: repo}
+}
+
+func (s *webhookService) Create(ctx context.Context, userID, targetURL string, events []string, secret string) (*models.Webhook, error) {
+	if err := validateWebhookURL(targetURL); err != nil {
+		return nil, err
+	}
+	eventList, err := normalizeWebhookEvents(events)
+	if err != nil {
+		return nil, err
+	}
+	if secret == "" {
+		if secret, err = newWebhookSecret(); err != nil {
+			return nil, err
+		}
+	} else if len(secret) < MinWebhookSecretSize {
+		return nil, &WebhookFieldError{Field: "secret", Rule: "min", Message: fmt.Sprintf("must be at least %d characters", MinWebhookSecretSize)}
+	}
+
+	webhook := &models.Webhook{
+		UserID: userID,
+		URL:    targetURL,
+		Secret: secret,
+		Events: eventList,
+		Active: true,
+	}
+	err = s.repo.CreateWithLimit(ctx, webhook, MaxWebhooksPerUser)
+	if errors.Is(err, repository.ErrWebhookLimitReached) {
+		return nil, ErrTooManyWebhooks
+	}
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *webhookService) List(ctx context.Context, userID string) ([]models.Webhook, error) {
+	return s.repo.List(ctx, userID)
+}
+
+func (s *webhookService) Get(ctx context.Context, userID string, id int64) (*models.Webhook, error) {
+	webhook, err := s.repo.Get(ctx, userID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, err
+}
+
+func (s *webhookService) Update(ctx context.Context, userID string, id int64, changes WebhookChanges) (*models.Webhook, error) {
+	webhook, err := s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if changes.URL != nil {
+		if err := validateWebhookURL(*changes.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = *changes.URL
+	}
+	if changes.Events != nil {
+		if webhook.Events, err = normalizeWebhookEvents(changes.Events); err != nil {
+			return nil, err
+		}
+	}
+	if changes.Active != nil {
+		if *changes.Active && !webhook.Active {
+			webhook.FailureCount = 0
+			webhook.LastError = ""
+		}
+		webhook.Active = *changes.Active
+	}
+
+	if err := s.repo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *webhookService) Delete(ctx context.Context, userID string, id int64) error {
+	err := s.repo.Delete(ctx, userID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrWebhookNotFound
+	}
+	return err
+}
+
+// validateWebhookURL accepts absolute http and https URLs. Whether the host
+// is reachable, or allowed, is checked when delivering.
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return &WebhookFieldError{Field: "url", Rule: "url", Message: "must be an absolute http or https URL"}
+	}
+	return nil
+}
+
+// normalizeWebhookEvents checks events against models.WebhookEvents and
+// joins them, deduplicated, for storage
+func normalizeWebhookEvents(events []string) (string, error) {
+	var list []string
+	for _, e := range events {
+		e = strings.ToUpper(strings.TrimSpace(e))
+		if !slices.Contains(models.WebhookEvents, e) {
+			return "", &WebhookFieldError{Field: "events", Rule: "oneof", Message: "must be one of " + strings.Join(models.WebhookEvents, ", ")}
+		}
+		if !slices.Contains(list, e) {
+			list = append(list, e)
+		}
+	}
+	if len(list) == 0 {
+		return "", &WebhookFieldError{Field: "events", Rule: "required", Message: "must list at least one event"}
+	}
+	return strings.Join(list, ","), nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// fakeWebhookRepo keeps webhooks in memory, by ID
+type fakeWebhookRepo struct {
+	repository.WebhookRepository
+	webhooks map[int64]*models.Webhook
+	nextID   int64
+}
+
+func (f *fakeWebhookRepo) CreateWithLimit(ctx context.Context, w *models.Webhook, limit int) error {
+	count := 0
+	for _, existing := range f.webhooks {
+		if existing.UserID == w.UserID {
+			count++
+		}
+	}
+	if count >= limit {
+		return repository.ErrWebhookLimitReached
+	}
+	f.nextID++
+	w.ID = f.nextID
+	f.webhooks[w.ID] = w
+	return nil
+}
+
+func (f *fakeWebhookRepo) Get(ctx context.Context, userID string, id int64) (*models.Webhook, error) {
+	w, ok := f.webhooks[id]
+	if !ok || w.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *w
+	return &copied, nil
+}
+
+func (f *fakeWebhookRepo) Update(ctx context.Context, w *models.Webhook) error {
+	f.webhooks[w.ID] = w
+	return nil
+}
+
+func TestWebhookService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("GeneratesSecret", func(t *testing.T) {
+		svc := NewWebhookService(&fakeWebhookRepo{webhooks: map[int64]*models.Webhook{}})
+
+		w, err := svc.Create(ctx, "u1", "https://example.com/hook", []string{"new_chapter", "NEW_CHAPTER", "NEW_MANGA"}, "")
+
+		assert.NoError(t, err)
+		assert.Len(t, w.Secret, 64)
+		assert.Equal(t, "NEW_CHAPTER,NEW_MANGA", w.Events)
+		assert.True(t, w.Active)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		svc := NewWebhookService(&fakeWebhookRepo{webhooks: map[int64]*models.Webhook{}})
+		cases := []struct {
+			url, secret string
+			events      []string
+			field       string
+		}{
+			{"ftp://example.com", "", []string{"NEW_CHAPTER"}, "url"},
+			{"/relative", "", []string{"NEW_CHAPTER"}, "url"},
+			{"https://example.com", "", nil, "events"},
+			{"https://example.com", "", []string{"SUBSCRIBE"}, "events"},
+			{"https://example.com", "short", []string{"NEW_CHAPTER"}, "secret"},
+		}
+		for _, tc := range cases {
+			_, err := svc.Create(ctx, "u1", tc.url, tc.events, tc.secret)
+
+			var fieldErr *WebhookFieldError
+			assert.True(t, errors.As(err, &fieldErr))
+			assert.True(t, errors.Is(err, ErrInvalidWebhook))
+			assert.Equal(t, tc.field, fieldErr.Field)
+		}
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		svc := NewWebhookService(&fakeWebhookRepo{webhooks: map[int64]*models.Webhook{}})
+		for i := 0; i < MaxWebhooksPerUser; i++ {
+			_, err := svc.Create(ctx, "u1", "https://example.com/hook", []string{"NEW_CHAPTER"}, "")
+			assert.NoError(t, err)
+		}
+
+		_, err := svc.Create(ctx, "u1", "https://example.com/hook", []string{"NEW_CHAPTER"}, "")
+
+		assert.True(t, errors.Is(err, ErrTooManyWebhooks))
+	})
+}
+
+func TestWebhookService_Update(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeWebhookRepo{webhooks: map[int64]*models.Webhook{
+		1: {ID: 1, UserID: "u1", URL: "https://example.com", Events: "NEW_CHAPTER", FailureCount: 10, LastError: "timeout"},
+	}}
+	svc := NewWebhookService(repo)
+
+	t.Run("OtherUser", func(t *testing.T) {
+		_, err := svc.Update(ctx, "u2", 1, WebhookChanges{})
+
+		assert.True(t, errors.Is(err, ErrWebhookNotFound))
+	})
+
+	t.Run("ReenableResetsFailures", func(t *testing.T) {
+		active := true
+		w, err := svc.Update(ctx, "u1", 1, WebhookChanges{Active: &active, Events: []string{"MANGA_UPDATE"}})
+
+		assert.NoError(t, err)
+		assert.True(t, w.Active)
+		assert.Zero(t, w.FailureCount)
+		assert.Empty(t, w.LastError)
+		assert.Equal(t, "MANGA_UPDATE", repo.webhooks[1].Events)
+	})
+}
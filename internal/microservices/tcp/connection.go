@@ -8,8 +8,10 @@ import (
 	"io"
 	"log/slog"
 	"mangahub/internal/config"
+	"mangahub/internal/shared"
 	"net"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -35,6 +37,8 @@ type ClientConnection struct {
 	Username      string             // authenticated username (from JWT)
 	Authenticated bool               // whether the connection is authenticated
 	logger        *slog.Logger
+	topicsMu      sync.RWMutex
+	topics        map[string]struct{} // broadcast scope, empty = receive everything (see topics.go)
 }
 
 // constructor for Connection
@@ -88,18 +92,21 @@ func (c *ClientConnection) Listen() {
 				strings.Contains(err.Error(), "forcibly closed") {
 				break // Exit silently for expected shutdown errors
 			}
+			c.Manager.metrics.errors.Add(1)
 			c.Manager.logger.Error("client_read_error",
 				"client_id", c.ID,
 				"error", err,
 			)
 			continue
 		}
+		c.Manager.metrics.messagesReceived.Add(1)
 
 		// reset deadline on successful read
 		c.conn.SetReadDeadline(time.Now().Add(MaxDeadlineDuration))
 
 		// Check message size (protect against oversized messages)
 		if len(line) > MaxMessageSize {
+			c.Manager.metrics.errors.Add(1)
 			c.Manager.logger.Warn(
 				"message_too_large",
 				"client_id", c.ID,
@@ -111,6 +118,7 @@ func (c *ClientConnection) Listen() {
 
 		// check rate limit
 		if !c.Limiter.Allow() { // returns true if a token is available then consumes it
+			c.Manager.metrics.rateLimited.Add(1)
 			c.Manager.logger.Warn(
 				"rate_limit_exceeded",
 				"client_id", c.ID,
@@ -123,6 +131,7 @@ func (c *ClientConnection) Listen() {
 		// process the incoming message
 		var msg Message                                    // custom struct to hold the incoming message
 		if err := json.Unmarshal(line, &msg); err != nil { // parse JSON message into struct
+			c.Manager.metrics.errors.Add(1)
 			c.Manager.logger.Warn(
 				"invalid_json_received",
 				"client_id", c.ID,
@@ -134,9 +143,11 @@ func (c *ClientConnection) Listen() {
 		// handle different message types
 		switch msg.Type {
 		case "progress_update":
-			c.HandleProgressMessage(msg.Data)
+			c.HandleProgressMessage(msg.ID, msg.Data)
 		case "auth":
 			c.HandleAuthMessage(msg.Data)
+		case "subscribe", "unsubscribe":
+			c.HandleSubscribeMessage(msg.ID, msg.Type, msg.Data)
 		default:
 			// Broadcast any valid JSON message (for flexibility and testing)
 			c.Manager.logger.Info("broadcasting_message",
@@ -163,16 +174,16 @@ func (c *ClientConnection) Listen() {
 
 // method to handle incoming messages in this case is the
 // progress messages
-func (c *ClientConnection) HandleProgressMessage(data map[string]any) {
+// msgID is the optional client-generated message ID; when set, the client
+// gets an "ack" once the update is persisted, or an error carrying the same ID
+func (c *ClientConnection) HandleProgressMessage(msgID string, data map[string]any) {
 	// Extract data
 
 	// check for authorize user
 	userID, ok := data["user_id"].(string)
 	if !ok || userID == "" {
-		c.Send([]byte(`{
-		"type":"error",
-		"code":"INVALID_USER_ID",
-		"message":"Missing or invalid user_id"}`))
+		c.sendError(msgID, "error", "INVALID_USER_ID", "Missing or invalid user_id")
+		return
 	}
 
 	if c.Authenticated && userID != c.UserID {
@@ -180,21 +191,15 @@ func (c *ClientConnection) HandleProgressMessage(data map[string]any) {
 			"unauthorized_progress_update",
 			"client_user_id", c.UserID,
 			"attempted_user_id", userID)
-		c.Send([]byte(`{
-		"type":"error",
-		"code":"FORBIDDEN",
-		"message":"Cannot update other user's progress"}`))
+		c.sendError(msgID, "error", "FORBIDDEN", "Cannot update other user's progress")
 		return
 	}
-	mangaID, _ := data["manga_id"].(float64)
+	mangaID, idErr := shared.MangaIDFromJSON(data["manga_id"])
 	chapter, _ := data["chapter"].(float64) // JSON numbers are float64
 
 	// Validate data ranges
-	if mangaID <= 0 || chapter < 0 {
-		c.Send([]byte(`{
-		"type":"error",
-		"code":"INVALID_DATA",
-		"message":"Invalid manga_id or chapter"}`))
+	if idErr != nil || chapter < 0 {
+		c.sendError(msgID, "error", "INVALID_DATA", "Invalid manga_id or chapter")
 		return
 	}
 
@@ -202,7 +207,7 @@ func (c *ClientConnection) HandleProgressMessage(data map[string]any) {
 	if c.Manager.progressRepo != nil {
 		progressData := &ProgressData{
 			UserID:         userID,
-			MangaID:        int64(mangaID),
+			MangaID:        mangaID,
 			CurrentChapter: int(chapter),
 			Status:         "reading",
 			UpdatedAt:      time.Now(),
@@ -215,20 +220,14 @@ func (c *ClientConnection) HandleProgressMessage(data map[string]any) {
 				"error", err.Error(),
 			)
 
-			errResponse, _ := json.Marshal(map[string]any{
-				"type":    "progress_error",
-				"code":    "SAVE_FAILED",
-				"message": "Failed to save progress",
-				"error":   err.Error(),
-			})
-			c.Send(errResponse)
+			c.sendError(msgID, "progress_error", "SAVE_FAILED", "Failed to save progress")
 			return
 		}
 
 		c.Manager.logger.Info("progress_saved",
 			"client_id", c.ID,
 			"user_id", userID,
-			"manga_id", int64(mangaID),
+			"manga_id", mangaID,
 			"chapter", int64(chapter),
 		)
 	}
@@ -240,7 +239,33 @@ func (c *ClientConnection) HandleProgressMessage(data map[string]any) {
 		"timestamp": time.Now().Unix(),
 	})
 
-	c.Manager.Broadcast(payload, c.ID)
+	// Only clients following this manga or user (or not scoped at all) get it
+	c.Manager.BroadcastTopics(payload, c.ID, MangaTopic(mangaID), UserTopic(userID))
+
+	// Confirm delivery to the sender
+	if msgID != "" {
+		ack, _ := json.Marshal(Message{
+			Type: "ack",
+			ID:   msgID,
+			Data: map[string]any{"status": "saved"},
+		})
+		c.Send(ack)
+	}
+}
+
+// sendError replies with an error message, echoing msgID so the client can
+// correlate it with the request that failed
+func (c *ClientConnection) sendError(msgID, msgType, code, message string) {
+	payload := map[string]any{
+		"type":    msgType,
+		"code":    code,
+		"message": message,
+	}
+	if msgID != "" {
+		payload["id"] = msgID
+	}
+	data, _ := json.Marshal(payload)
+	c.Send(data)
 }
 
 // method to send data over the connection
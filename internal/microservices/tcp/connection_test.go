@@ -0,0 +1,84 @@
+package tcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+)
+
+// newPipeClient wires a ClientConnection to one end of an in-memory pipe and
+// returns the other end for the test to act as the remote client
+func newPipeClient(t *testing.T) (net.Conn, *bufio.Reader) {
+	t.Helper()
+
+	serverSide, clientSide := net.Pipe()
+	manager := NewConnectionManager(nil)
+	conn := NewClientConnection(serverSide, manager)
+	conn.UserID = "user-1"
+	conn.Authenticated = true
+
+	go conn.Listen()
+	t.Cleanup(func() { clientSide.Close() })
+
+	return clientSide, bufio.NewReader(clientSide)
+}
+
+func sendAndReadReply(t *testing.T, conn net.Conn, reader *bufio.Reader, msg Message) map[string]any {
+	t.Helper()
+
+	data, _ := json.Marshal(msg)
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		t.Fatalf("Failed to write message: %v", err)
+	}
+
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		t.Fatalf("Failed to read reply: %v", err)
+	}
+
+	var reply map[string]any
+	if err := json.Unmarshal(line, &reply); err != nil {
+		t.Fatalf("Failed to parse reply: %v", err)
+	}
+	return reply
+}
+
+func TestHandleProgressMessage_AckEchoesID(t *testing.T) {
+	conn, reader := newPipeClient(t)
+
+	reply := sendAndReadReply(t, conn, reader, Message{
+		Type: "progress_update",
+		ID:   "msg-1",
+		Data: map[string]any{"user_id": "user-1", "manga_id": 1, "chapter": 5},
+	})
+
+	if reply["type"] != "ack" {
+		t.Errorf("Expected ack, got %v", reply["type"])
+	}
+	if reply["id"] != "msg-1" {
+		t.Errorf("Expected id msg-1, got %v", reply["id"])
+	}
+}
+
+func TestHandleProgressMessage_ErrorEchoesID(t *testing.T) {
+	conn, reader := newPipeClient(t)
+
+	reply := sendAndReadReply(t, conn, reader, Message{
+		Type: "progress_update",
+		ID:   "msg-2",
+		Data: map[string]any{"user_id": "someone-else", "manga_id": 1, "chapter": 5},
+	})
+
+	if reply["type"] != "error" {
+		t.Errorf("Expected error, got %v", reply["type"])
+	}
+	if reply["code"] != "FORBIDDEN" {
+		t.Errorf("Expected FORBIDDEN, got %v", reply["code"])
+	}
+	if reply["id"] != "msg-2" {
+		t.Errorf("Expected id msg-2, got %v", reply["id"])
+	}
+}
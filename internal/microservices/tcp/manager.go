@@ -1,9 +1,11 @@
 package tcp
 
 import (
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 )
 
 // ProgressRepository interface for abstraction (supports both Redis-only and Hybrid)
@@ -22,6 +24,7 @@ type ConnectionManager struct {
 	mu           sync.RWMutex       // read-write mutex for concurrent access
 	logger       *slog.Logger       // pointer to structured logger for logging events
 	progressRepo ProgressRepository // pointer to progress repository (can be Redis or Hybrid)
+	metrics      metrics            // connection and message counters, read via TCPServer.Metrics
 }
 
 // constructor for ConnectionManager
@@ -79,6 +82,42 @@ func (m *ConnectionManager) CloseAllConnections() {
 	// allowing garbage collection
 }
 
+// NotifyShutdown tells every client the server is stopping on purpose, so they
+// can reconnect after reconnectAfter instead of treating it as a crash. Each
+// write gets writeTimeout so a slow client can't hold up shutdown.
+func (m *ConnectionManager) NotifyShutdown(reconnectAfter, writeTimeout time.Duration) {
+	msg, _ := json.Marshal(Message{
+		Type: "server_shutdown",
+		Data: map[string]any{
+			"message":         "Server is shutting down",
+			"reconnect_after": int(reconnectAfter.Seconds()),
+		},
+	})
+
+	m.mu.RLock()
+	clients := make([]*ClientConnection, 0, len(m.clients))
+	for _, c := range m.clients {
+		clients = append(clients, c)
+	}
+	m.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, c := range clients {
+		wg.Add(1)
+		go func(client *ClientConnection) {
+			defer wg.Done()
+			client.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := client.Send(msg); err != nil {
+				m.logger.Warn("failed_to_send_shutdown_notice",
+					"client_id", client.ID,
+					"error", err.Error(),
+				)
+			}
+		}(c)
+	}
+	wg.Wait()
+}
+
 func (m *ConnectionManager) BroadcastSystemMessage(text string) {
 	msg := []byte(fmt.Sprintf(`{"type":"system","message":"%s"}`, text))
 	// construct system message payload in JSON format in byte slice for network transmission
@@ -97,12 +136,28 @@ func (m *ConnectionManager) BroadcastUserMessage(text, senderID string) {
 // fix by using read lock only to copy the map of clients
 // then release lock before sending messages
 func (m *ConnectionManager) Broadcast(msg []byte, senderID string) {
+	m.broadcast(msg, senderID, nil)
+}
+
+// BroadcastTopics sends msg to clients subscribed to any of topics, plus
+// clients that haven't subscribed to anything
+func (m *ConnectionManager) BroadcastTopics(msg []byte, senderID string, topics ...string) {
+	m.broadcast(msg, senderID, topics)
+}
+
+// broadcast sends msg to the connected clients; non-nil topics restrict
+// delivery to clients that want them
+func (m *ConnectionManager) broadcast(msg []byte, senderID string, topics []string) {
 	m.mu.RLock() // use read lock because we are only reading from the map, by that
 	clients := make([]*ClientConnection, 0, len(m.clients))
 	for _, c := range m.clients {
+		if topics != nil && !c.wants(topics) {
+			continue
+		}
 		clients = append(clients, c)
 	}
 	m.mu.RUnlock()
+	m.metrics.messagesBroadcast.Add(1)
 	// release lock before performing i/o operations
 	var wg sync.WaitGroup // wait group to wait for all send operations to complete
 	for _, c := range clients {
@@ -110,6 +165,7 @@ func (m *ConnectionManager) Broadcast(msg []byte, senderID string) {
 		go func(client *ClientConnection) { // launch goroutine for each send operation
 			defer wg.Done()
 			if err := client.Send(msg); err != nil {
+				m.metrics.errors.Add(1)
 				m.logger.Warn("failed_to_send_broadcast",
 					"client_id", client.ID,
 					"error", err.Error(),
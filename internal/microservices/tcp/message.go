@@ -1,6 +1,7 @@
 package tcp
 
 type Message struct {
-	Type string         `json:"type"` // basic routing based on type field
-	Data map[string]any `json:"data"` // flexible data payload
+	Type string         `json:"type"`         // basic routing based on type field
+	ID   string         `json:"id,omitempty"` // optional client-generated ID, echoed back in ack/error replies
+	Data map[string]any `json:"data"`         // flexible data payload
 }
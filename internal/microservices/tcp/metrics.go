@@ -0,0 +1,68 @@
+package tcp
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// DefaultMetricsInterval is how often the server logs its metrics
+const DefaultMetricsInterval = time.Minute
+
+// metrics holds the server's counters; the zero value is ready to use
+type metrics struct {
+	connectionsAccepted atomic.Int64 // connections given a slot
+	connectionsRejected atomic.Int64 // connections refused at the connection limit
+	messagesReceived    atomic.Int64 // newline-delimited messages read from clients
+	messagesBroadcast   atomic.Int64 // broadcasts fanned out to other clients
+	rateLimited         atomic.Int64 // messages dropped by the per-client rate limiter
+	errors              atomic.Int64 // read, parse, size and send failures
+}
+
+// MetricsSnapshot is a point-in-time view of the server's metrics
+type MetricsSnapshot struct {
+	ActiveConnections   int64 `json:"active_connections"`
+	ConnectionsAccepted int64 `json:"connections_accepted"`
+	ConnectionsRejected int64 `json:"connections_rejected"`
+	MessagesReceived    int64 `json:"messages_received"`
+	MessagesBroadcast   int64 `json:"messages_broadcast"`
+	RateLimited         int64 `json:"rate_limited"`
+	Errors              int64 `json:"errors"`
+}
+
+// Metrics returns the server's current counters and gauges
+func (s *TCPServer) Metrics() MetricsSnapshot {
+	m := &s.Manager.metrics
+	return MetricsSnapshot{
+		ActiveConnections:   s.active.Load(),
+		ConnectionsAccepted: m.connectionsAccepted.Load(),
+		ConnectionsRejected: m.connectionsRejected.Load(),
+		MessagesReceived:    m.messagesReceived.Load(),
+		MessagesBroadcast:   m.messagesBroadcast.Load(),
+		RateLimited:         m.rateLimited.Load(),
+		Errors:              m.errors.Load(),
+	}
+}
+
+// reportMetrics logs a metrics snapshot every interval until the server quits
+func (s *TCPServer) reportMetrics(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.quitChan:
+			return
+		case <-ticker.C:
+			m := s.Metrics()
+			s.logger.Info("server_metrics",
+				"active_connections", m.ActiveConnections,
+				"connections_accepted", m.ConnectionsAccepted,
+				"connections_rejected", m.ConnectionsRejected,
+				"messages_received", m.MessagesReceived,
+				"messages_broadcast", m.MessagesBroadcast,
+				"rate_limited", m.RateLimited,
+				"errors", m.Errors,
+			)
+		}
+	}
+}
@@ -3,17 +3,24 @@ package tcp
 import (
 	"bufio"
 	"context"
+	"crypto/tls"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // server entry point would go here
 
+const (
+	ShutdownGracePeriod  = 5 * time.Second // time between the shutdown notice and closing connections
+	shutdownWriteTimeout = 2 * time.Second // per-client deadline for the shutdown notice
+)
+
 // server struct and methods
 // when using pointers we need to define explicitly in the constructor, avoid nil pointer dereference
 type TCPServer struct {
@@ -39,6 +46,15 @@ type TCPServer struct {
 	listener   net.Listener
 	// TCP listener for accepting incoming connections
 	// mutex to protect access to listener during shutdown
+	MaxConnections int
+	// cap on concurrent connections, 0 = unlimited
+	// connections over the cap get a SERVER_FULL error and are closed
+	active atomic.Int64
+	// number of connections currently being handled
+	MetricsInterval time.Duration
+	// how often metrics are logged, 0 = DefaultMetricsInterval, negative disables
+	TLSConfig *tls.Config
+	// when set, Start accepts TLS connections only; nil keeps plaintext for local dev
 }
 
 // NewServer creates a TCP server with Redis-only storage (backward compatible)
@@ -127,8 +143,14 @@ func NewServerWithMockRedis(addrTCP string) *TCPServer {
 
 // method to start the server
 func (s *TCPServer) Start() error {
-	// listen for incoming connections
-	listener, err := net.Listen("tcp", s.Addr)
+	// listen for incoming connections, over TLS when configured
+	var listener net.Listener
+	var err error
+	if s.TLSConfig != nil {
+		listener, err = tls.Listen("tcp", s.Addr, s.TLSConfig)
+	} else {
+		listener, err = net.Listen("tcp", s.Addr)
+	}
 	if err != nil {
 		s.logger.Error(
 			"failed_to_start_server",
@@ -136,14 +158,29 @@ func (s *TCPServer) Start() error {
 		)
 		return fmt.Errorf("failed to start TCP server, error: %v", err)
 	}
+	return s.Serve(listener)
+}
+
+// Serve accepts connections on an existing listener until Stop is called.
+// Tests use it with a 127.0.0.1:0 listener to get an ephemeral port.
+func (s *TCPServer) Serve(listener net.Listener) error {
 	s.logger.Info("server_started",
-		"addr", s.Addr,
+		"addr", listener.Addr().String(),
 	)
 
 	s.listenerMu.Lock()
 	s.listener = listener // store listener for later use in shutdown
 	s.listenerMu.Unlock()
 
+	// periodically log metrics
+	if s.MetricsInterval >= 0 {
+		interval := s.MetricsInterval
+		if interval == 0 {
+			interval = DefaultMetricsInterval
+		}
+		go s.reportMetrics(interval)
+	}
+
 	// Close listener on quit signal
 	go func() { // goroutine to handle shutdown
 		<-s.quitChan     // wait for quit signal
@@ -165,6 +202,13 @@ func (s *TCPServer) Start() error {
 				continue
 			}
 		}
+		// refuse the connection up front when at capacity
+		if !s.acquireSlot() {
+			s.Manager.metrics.connectionsRejected.Add(1)
+			s.rejectConnection(conn)
+			continue
+		}
+		s.Manager.metrics.connectionsAccepted.Add(1)
 		// add +1 to wait group for the new connection handler goroutine
 		s.wg.Add(1)
 		// use an anonymous function to handle the connection
@@ -173,11 +217,41 @@ func (s *TCPServer) Start() error {
 		// 3. accessing the server's wait group to signal when done
 		go func(conn net.Conn) {
 			defer s.wg.Done()
+			defer s.active.Add(-1) // free the slot on disconnect
 			s.handleConnection(conn)
 		}(conn)
 	}
 }
 
+// acquireSlot reserves a connection slot, failing when MaxConnections is reached
+func (s *TCPServer) acquireSlot() bool {
+	for {
+		n := s.active.Load()
+		if s.MaxConnections > 0 && n >= int64(s.MaxConnections) {
+			return false
+		}
+		if s.active.CompareAndSwap(n, n+1) {
+			return true
+		}
+	}
+}
+
+// rejectConnection tells the client the server is full and closes the connection
+func (s *TCPServer) rejectConnection(conn net.Conn) {
+	s.logger.Warn("connection_limit_reached",
+		"remote_addr", conn.RemoteAddr().String(),
+		"max_connections", s.MaxConnections,
+	)
+	conn.SetWriteDeadline(time.Now().Add(time.Second))
+	conn.Write([]byte(`{"type":"error","code":"SERVER_FULL","message":"Server is at its connection limit, try again later"}` + "\n"))
+	conn.Close()
+}
+
+// ActiveConnections returns the number of connections currently being handled
+func (s *TCPServer) ActiveConnections() int64 {
+	return s.active.Load()
+}
+
 // handle connections/lifecycle of single client connection
 func (s *TCPServer) handleConnection(conn net.Conn) {
 	client := NewClientConnection(conn, s.Manager) // create new client connection that wrap around manager
@@ -276,9 +350,9 @@ func (s *TCPServer) authenticateClient(client *ClientConnection) bool {
 func (s *TCPServer) Stop() {
 	close(s.quitChan)
 	// signal all goroutines to shutdown
-	s.Manager.BroadcastSystemMessage("Server is shutting down in 5 seconds.") // notify clients
-	time.Sleep(5 * time.Second)                                               // wait for a moment to allow clients to process the shutdown message
-	s.Manager.CloseAllConnections()                                           // close all active connections
+	s.Manager.NotifyShutdown(ShutdownGracePeriod, shutdownWriteTimeout) // tell clients this is a planned shutdown
+	time.Sleep(ShutdownGracePeriod)                                     // wait for a moment to allow clients to process the shutdown message
+	s.Manager.CloseAllConnections()                                     // close all active connections
 	s.wg.Wait()
 
 	// Close the listener
@@ -0,0 +1,226 @@
+package tcp
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+func startTestServer(t *testing.T, maxConns int) (*TCPServer, string) {
+	t.Helper()
+	return startTestServerWithTLS(t, maxConns, nil)
+}
+
+func startTestServerWithTLS(t *testing.T, maxConns int, tlsConfig *tls.Config) (*TCPServer, string) {
+	t.Helper()
+
+	server := NewServerWithMockRedis("127.0.0.1:0")
+	server.MaxConnections = maxConns
+	server.TLSConfig = tlsConfig
+	go server.Start()
+	t.Cleanup(func() { close(server.quitChan) })
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		server.listenerMu.RLock()
+		l := server.listener
+		server.listenerMu.RUnlock()
+		if l != nil {
+			return server, l.Addr().String()
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Server did not start listening")
+	return nil, ""
+}
+
+func waitForActive(t *testing.T, server *TCPServer, want int64) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for server.ActiveConnections() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d active connections, got %d", want, server.ActiveConnections())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTCPServer_RejectsConnectionsOverLimit(t *testing.T) {
+	server, addr := startTestServer(t, 1)
+
+	first, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	waitForActive(t, server, 1)
+
+	second, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer second.Close()
+	second.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	line, err := bufio.NewReader(second).ReadBytes('\n')
+	if err != nil {
+		t.Fatalf("Expected a rejection message, got error: %v", err)
+	}
+	var reply map[string]any
+	if err := json.Unmarshal(line, &reply); err != nil {
+		t.Fatalf("Failed to parse reply: %v", err)
+	}
+	if reply["code"] != "SERVER_FULL" {
+		t.Errorf("Expected SERVER_FULL, got %v", reply["code"])
+	}
+
+	// Disconnecting frees the slot
+	first.Close()
+	waitForActive(t, server, 0)
+
+	third, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer third.Close()
+	waitForActive(t, server, 1)
+
+	m := server.Metrics()
+	if m.ConnectionsAccepted != 2 || m.ConnectionsRejected != 1 || m.ActiveConnections != 1 {
+		t.Errorf("Unexpected connection metrics: %+v", m)
+	}
+}
+
+// selfSignedCert returns a certificate for 127.0.0.1 and a pool trusting it
+func selfSignedCert(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, pool
+}
+
+func TestTCPServer_TLS(t *testing.T) {
+	cert, pool := selfSignedCert(t)
+	server, addr := startTestServerWithTLS(t, 1, &tls.Config{Certificates: []tls.Certificate{cert}})
+
+	first, err := tls.Dial("tcp", addr, &tls.Config{RootCAs: pool})
+	if err != nil {
+		t.Fatalf("Failed to dial with TLS: %v", err)
+	}
+	defer first.Close()
+	waitForActive(t, server, 1)
+
+	// The over-limit rejection arrives over TLS too
+	second, err := tls.Dial("tcp", addr, &tls.Config{RootCAs: pool})
+	if err != nil {
+		t.Fatalf("Failed to dial with TLS: %v", err)
+	}
+	defer second.Close()
+	second.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	line, err := bufio.NewReader(second).ReadBytes('\n')
+	if err != nil {
+		t.Fatalf("Expected a rejection message, got error: %v", err)
+	}
+	var reply map[string]any
+	if err := json.Unmarshal(line, &reply); err != nil {
+		t.Fatalf("Failed to parse reply: %v", err)
+	}
+	if reply["code"] != "SERVER_FULL" {
+		t.Errorf("Expected SERVER_FULL, got %v", reply["code"])
+	}
+}
+
+func TestTCPServer_MessageMetrics(t *testing.T) {
+	server, addr := startTestServer(t, 0)
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	conn.Write([]byte(`{"type":"chat","data":{"text":"hi"}}` + "\n"))
+	conn.Write([]byte("not json\n"))
+
+	deadline := time.Now().Add(2 * time.Second)
+	for server.Metrics().Errors < 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for metrics: %+v", server.Metrics())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	m := server.Metrics()
+	if m.MessagesReceived != 2 || m.MessagesBroadcast != 1 || m.Errors != 1 {
+		t.Errorf("Unexpected message metrics: %+v", m)
+	}
+}
+
+func TestNotifyShutdown_DoesNotBlockOnSlowClients(t *testing.T) {
+	manager := NewConnectionManager(nil)
+
+	serverSide, reader := net.Pipe()
+	defer reader.Close()
+	manager.AddConnection(NewClientConnection(serverSide, manager))
+
+	// This client never reads, so its write can only end via the deadline
+	slowServerSide, slowClient := net.Pipe()
+	defer slowClient.Close()
+	manager.AddConnection(NewClientConnection(slowServerSide, manager))
+
+	lines := make(chan []byte, 1)
+	go func() {
+		line, _ := bufio.NewReader(reader).ReadBytes('\n')
+		lines <- line
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		manager.NotifyShutdown(5*time.Second, 100*time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("NotifyShutdown blocked on a slow client")
+	}
+
+	var msg Message
+	if err := json.Unmarshal(<-lines, &msg); err != nil {
+		t.Fatalf("Failed to parse shutdown notice: %v", err)
+	}
+	if msg.Type != "server_shutdown" || msg.Data["reconnect_after"] != float64(5) {
+		t.Errorf("Unexpected shutdown notice: %+v", msg)
+	}
+}
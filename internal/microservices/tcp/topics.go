@@ -0,0 +1,130 @@
+package tcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Topics scope broadcasts: "manga:<id>" for a title and "user:<id>" for a
+// user's own devices. A client that hasn't subscribed to anything receives
+// every broadcast, as before topics existed.
+const (
+	TopicManga = "manga"
+	TopicUser  = "user"
+)
+
+// MangaTopic returns the topic for a manga
+func MangaTopic(mangaID int64) string {
+	return fmt.Sprintf("%s:%d", TopicManga, mangaID)
+}
+
+// UserTopic returns the topic for a user
+func UserTopic(userID string) string {
+	return TopicUser + ":" + userID
+}
+
+// validateTopic checks a topic is "manga:<positive int>" or "user:<non-empty id>"
+func validateTopic(topic string) error {
+	kind, id, ok := strings.Cut(topic, ":")
+	if !ok || id == "" {
+		return fmt.Errorf("invalid topic %q: expected manga:<id> or user:<id>", topic)
+	}
+	switch kind {
+	case TopicManga:
+		if n, err := strconv.ParseInt(id, 10, 64); err != nil || n <= 0 {
+			return fmt.Errorf("invalid topic %q: manga id must be a positive integer", topic)
+		}
+	case TopicUser:
+	default:
+		return fmt.Errorf("invalid topic %q: expected manga:<id> or user:<id>", topic)
+	}
+	return nil
+}
+
+// Subscribe adds topics to the client's subscriptions
+func (c *ClientConnection) Subscribe(topics ...string) {
+	c.topicsMu.Lock()
+	defer c.topicsMu.Unlock()
+	if c.topics == nil {
+		c.topics = make(map[string]struct{})
+	}
+	for _, t := range topics {
+		c.topics[t] = struct{}{}
+	}
+}
+
+// Unsubscribe removes topics from the client's subscriptions
+func (c *ClientConnection) Unsubscribe(topics ...string) {
+	c.topicsMu.Lock()
+	defer c.topicsMu.Unlock()
+	for _, t := range topics {
+		delete(c.topics, t)
+	}
+}
+
+// Topics returns the client's subscriptions, sorted
+func (c *ClientConnection) Topics() []string {
+	c.topicsMu.RLock()
+	defer c.topicsMu.RUnlock()
+	topics := make([]string, 0, len(c.topics))
+	for t := range c.topics {
+		topics = append(topics, t)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// wants reports whether a broadcast for any of topics should reach the client
+func (c *ClientConnection) wants(topics []string) bool {
+	c.topicsMu.RLock()
+	defer c.topicsMu.RUnlock()
+	if len(c.topics) == 0 {
+		return true // unscoped clients receive everything
+	}
+	for _, t := range topics {
+		if _, ok := c.topics[t]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// HandleSubscribeMessage handles "subscribe" and "unsubscribe" messages with
+// a data.topics list, replying with the client's resulting subscriptions
+func (c *ClientConnection) HandleSubscribeMessage(msgID, msgType string, data map[string]any) {
+	raw, _ := data["topics"].([]any)
+	if len(raw) == 0 {
+		c.sendError(msgID, "error", "INVALID_TOPICS", "Missing or empty topics list")
+		return
+	}
+
+	topics := make([]string, 0, len(raw))
+	for _, r := range raw {
+		topic, ok := r.(string)
+		if !ok {
+			c.sendError(msgID, "error", "INVALID_TOPICS", "Topics must be strings")
+			return
+		}
+		if err := validateTopic(topic); err != nil {
+			c.sendError(msgID, "error", "INVALID_TOPICS", err.Error())
+			return
+		}
+		topics = append(topics, topic)
+	}
+
+	if msgType == "unsubscribe" {
+		c.Unsubscribe(topics...)
+	} else {
+		c.Subscribe(topics...)
+	}
+
+	reply, _ := json.Marshal(Message{
+		Type: msgType + "d", // subscribed / unsubscribed
+		ID:   msgID,
+		Data: map[string]any{"topics": c.Topics()},
+	})
+	c.Send(reply)
+}
@@ -0,0 +1,84 @@
+package tcp
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestHandleSubscribeMessage(t *testing.T) {
+	conn, reader := newPipeClient(t)
+
+	reply := sendAndReadReply(t, conn, reader, Message{
+		Type: "subscribe",
+		ID:   "sub-1",
+		Data: map[string]any{"topics": []any{"manga:7", "user:user-1"}},
+	})
+	if reply["type"] != "subscribed" || reply["id"] != "sub-1" {
+		t.Fatalf("Expected subscribed reply for sub-1, got %v", reply)
+	}
+	topics := reply["data"].(map[string]any)["topics"].([]any)
+	if len(topics) != 2 || topics[0] != "manga:7" || topics[1] != "user:user-1" {
+		t.Errorf("Unexpected topics: %v", topics)
+	}
+
+	reply = sendAndReadReply(t, conn, reader, Message{
+		Type: "unsubscribe",
+		Data: map[string]any{"topics": []any{"manga:7"}},
+	})
+	topics = reply["data"].(map[string]any)["topics"].([]any)
+	if reply["type"] != "unsubscribed" || len(topics) != 1 || topics[0] != "user:user-1" {
+		t.Errorf("Unexpected unsubscribe reply: %v", reply)
+	}
+
+	reply = sendAndReadReply(t, conn, reader, Message{
+		Type: "subscribe",
+		Data: map[string]any{"topics": []any{"chapter:1"}},
+	})
+	if reply["code"] != "INVALID_TOPICS" {
+		t.Errorf("Expected INVALID_TOPICS, got %v", reply)
+	}
+}
+
+func TestBroadcastTopics_ScopesDelivery(t *testing.T) {
+	manager := NewConnectionManager(nil)
+
+	newClient := func(topics ...string) *bufio.Reader {
+		serverSide, clientSide := net.Pipe()
+		t.Cleanup(func() { clientSide.Close() })
+		c := NewClientConnection(serverSide, manager)
+		c.Subscribe(topics...)
+		manager.AddConnection(c)
+		return bufio.NewReader(clientSide)
+	}
+	follower := newClient(MangaTopic(1))
+	other := newClient(MangaTopic(2))
+	unscoped := newClient()
+
+	received := make(chan string, 3)
+	read := func(name string, r *bufio.Reader) {
+		if _, err := r.ReadBytes('\n'); err == nil {
+			received <- name
+		}
+	}
+	go read("follower", follower)
+	go read("other", other)
+	go read("unscoped", unscoped)
+
+	manager.BroadcastTopics([]byte(`{"type":"progress_broadcast"}`), "", MangaTopic(1), UserTopic("u1"))
+
+	got := map[string]bool{}
+	timeout := time.After(200 * time.Millisecond)
+	for done := false; !done; {
+		select {
+		case name := <-received:
+			got[name] = true
+		case <-timeout:
+			done = true
+		}
+	}
+	if !got["follower"] || !got["unscoped"] || got["other"] {
+		t.Errorf("Expected delivery to follower and unscoped only, got %v", got)
+	}
+}
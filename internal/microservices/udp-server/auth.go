@@ -0,0 +1,123 @@
+package udp
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mangahub/internal/config"
+	"mangahub/internal/microservices/http-api/service"
+	"mangahub/internal/shared"
+)
+
+// maxTriggerBody caps how much of a trigger request is read to verify it
+const maxTriggerBody = 1 << 20
+
+var (
+	ErrMissingToken   = errors.New("access token required")
+	ErrUserIDMismatch = errors.New("user_id does not match access token")
+)
+
+// TokenValidator validates the access token sent with SUBSCRIBE.
+// service.AuthService satisfies it.
+type TokenValidator interface {
+	ValidateToken(tokenString string) (*service.Claims, error)
+}
+
+// authenticate resolves the user a SUBSCRIBE request may bind to. The
+// subscription always uses the token's user ID; a user_id in the request is
+// only accepted when it matches. Without a validator, auth is disabled and
+// the request's user_id is trusted as before.
+func (s *Server) authenticate(req *SubscribeRequest) (string, error) {
+	if s.auth == nil {
+		return req.UserID, nil
+	}
+	if req.Token == "" {
+		return "", ErrMissingToken
+	}
+
+	claims, err := s.auth.ValidateToken(req.Token)
+	if err != nil {
+		return "", fmt.Errorf("invalid access token: %w", err)
+	}
+	if req.UserID != "" && req.UserID != claims.UserID {
+		return "", ErrUserIDMismatch
+	}
+	return claims.UserID, nil
+}
+
+// RequireInternalToken guards the HTTP trigger: requests must carry the shared
+// secret in the X-Internal-Token header or get a 401. An empty token disables
+// the check (development only; config.Validate rejects it elsewhere).
+func RequireInternalToken(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	expected := []byte(token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get(config.InternalTokenHeader))
+		if subtle.ConstantTimeCompare(got, expected) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireSignature guards the HTTP trigger with an HMAC of each request (see
+// shared.SignTriggerRequest). Requests whose timestamp is more than window
+// away from now, whose signature doesn't match, or that reuse the nonce of
+// one already handled get a 401. A nonce is released again when the handler
+// fails, so a failed request may be resent as is. An empty key disables the
+// check.
+func RequireSignature(key string, window time.Duration, next http.Handler) http.Handler {
+	if key == "" {
+		return next
+	}
+	// A timestamp stays acceptable for window either side of now, so that is
+	// how long a used nonce must be remembered
+	nonces := NewIdempotencyCache(2 * window)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestamp, err := strconv.ParseInt(r.Header.Get(config.InternalTimestampHeader), 10, 64)
+		if err != nil {
+			http.Error(w, "missing or invalid timestamp", http.StatusUnauthorized)
+			return
+		}
+		if age := time.Since(time.Unix(timestamp, 0)); age > window || age < -window {
+			http.Error(w, "stale timestamp", http.StatusUnauthorized)
+			return
+		}
+		nonce := r.Header.Get(config.InternalNonceHeader)
+		if nonce == "" {
+			http.Error(w, "missing nonce", http.StatusUnauthorized)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxTriggerBody))
+		if err != nil {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		signature := r.Header.Get(config.InternalSignatureHeader)
+		if !shared.VerifyTriggerSignature(key, timestamp, nonce, r.URL.Path, body, signature) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		if !nonces.Reserve(nonce) {
+			http.Error(w, "replayed request", http.StatusUnauthorized)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		if rec.status >= http.StatusMultipleChoices {
+			nonces.Release(nonce)
+		}
+	})
+}
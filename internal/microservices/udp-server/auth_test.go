@@ -0,0 +1,218 @@
+package udp
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"mangahub/internal/config"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/service"
+	"mangahub/internal/shared"
+)
+
+// fakeValidator accepts tokens of the form "token-<userID>"
+type fakeValidator struct{}
+
+func (fakeValidator) ValidateToken(tokenString string) (*service.Claims, error) {
+	if len(tokenString) > len("token-") && tokenString[:len("token-")] == "token-" {
+		return &service.Claims{UserID: tokenString[len("token-"):]}, nil
+	}
+	return nil, errors.New("bad token")
+}
+
+func TestServer_SubscribeRequiresValidToken(t *testing.T) {
+	server, err := NewServerWithConfig("0", Config{Auth: fakeValidator{}},
+		&mockLibraryRepo{}, &mockNotificationRepo{notifications: make([]*models.Notification, 0)}, &mockUserRepo{})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	go server.Start()
+	defer server.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	serverAddr := server.conn.LocalAddr().(*net.UDPAddr)
+
+	subscribe := func(t *testing.T, req SubscribeRequest) Notification {
+		t.Helper()
+		clientConn, err := net.DialUDP("udp", nil, serverAddr)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer clientConn.Close()
+
+		data, _ := json.Marshal(req)
+		clientConn.Write(data)
+
+		buffer := make([]byte, 4096)
+		clientConn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		n, err := clientConn.Read(buffer)
+		if err != nil {
+			t.Fatalf("Failed to read reply: %v", err)
+		}
+
+		var reply Notification
+		if err := json.Unmarshal(buffer[:n], &reply); err != nil {
+			t.Fatalf("Failed to parse reply: %v", err)
+		}
+		return reply
+	}
+
+	t.Run("Missing token", func(t *testing.T) {
+		reply := subscribe(t, SubscribeRequest{Type: "SUBSCRIBE", UserID: "user-1"})
+		if reply.Type != NotificationError {
+			t.Errorf("Expected ERROR, got %s", reply.Type)
+		}
+	})
+
+	t.Run("Token for another user", func(t *testing.T) {
+		reply := subscribe(t, SubscribeRequest{Type: "SUBSCRIBE", UserID: "user-1", Token: "token-user-2"})
+		if reply.Type != NotificationError {
+			t.Errorf("Expected ERROR, got %s", reply.Type)
+		}
+		if _, ok := server.subManager.GetByUserID("user-1"); ok {
+			t.Error("Expected no subscription for user-1")
+		}
+	})
+
+	t.Run("Valid token binds to token user", func(t *testing.T) {
+		reply := subscribe(t, SubscribeRequest{Type: "SUBSCRIBE", Token: "token-user-3"})
+		if reply.Type != NotificationSubscribe {
+			t.Errorf("Expected SUBSCRIBE confirmation, got %s", reply.Type)
+		}
+		if _, ok := server.subManager.GetByUserID("user-3"); !ok {
+			t.Error("Expected subscription for user-3")
+		}
+	})
+}
+
+func TestRequireInternalToken(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	handler := RequireInternalToken("s3cret", ok)
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"missing header", "", http.StatusUnauthorized},
+		{"wrong token", "nope", http.StatusUnauthorized},
+		{"valid token", "s3cret", http.StatusAccepted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/notify/new-manga", nil)
+			if tt.token != "" {
+				req.Header.Set("X-Internal-Token", tt.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("Expected %d, got %d", tt.status, rec.Code)
+			}
+		})
+	}
+
+	// No configured token leaves the trigger open (development)
+	rec := httptest.NewRecorder()
+	RequireInternalToken("", ok).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/notify/new-manga", nil))
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("Expected %d with auth disabled, got %d", http.StatusAccepted, rec.Code)
+	}
+}
+
+func TestRequireSignature(t *testing.T) {
+	var gotBody string
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	handler := RequireSignature("signing-key", time.Minute, ok)
+	body := `{"manga_id":1,"title":"Berserk"}`
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name   string
+		req    *http.Request
+		status int
+	}{
+		{"unsigned", httptest.NewRequest(http.MethodPost, "/notify/new-manga", strings.NewReader(body)), http.StatusUnauthorized},
+		{"stale", signedTrigger(now-120, "n1", body, body), http.StatusUnauthorized},
+		{"future", signedTrigger(now+120, "n1", body, body), http.StatusUnauthorized},
+		{"no nonce", signedTrigger(now, "", body, body), http.StatusUnauthorized},
+		{"tampered", signedTrigger(now, "n1", body, `{"manga_id":2,"title":"Berserk"}`), http.StatusUnauthorized},
+		{"valid", signedTrigger(now, "n1", body, body), http.StatusAccepted},
+		{"replayed", signedTrigger(now, "n1", body, body), http.StatusUnauthorized},
+		// A client retry in the same second signs with a new nonce
+		{"retried", signedTrigger(now, "n2", body, body), http.StatusAccepted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, tt.req)
+			if rec.Code != tt.status {
+				t.Errorf("Expected %d, got %d", tt.status, rec.Code)
+			}
+		})
+	}
+	if gotBody != body {
+		t.Errorf("Expected the handler to read %q, got %q", body, gotBody)
+	}
+
+	// No configured key leaves requests unchecked
+	rec := httptest.NewRecorder()
+	RequireSignature("", time.Minute, ok).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/notify/new-manga", nil))
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("Expected %d with signing disabled, got %d", http.StatusAccepted, rec.Code)
+	}
+}
+
+func TestRequireSignature_ReleasesNonceOnFailure(t *testing.T) {
+	fail := true
+	handler := RequireSignature("signing-key", time.Minute, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	body := `{"manga_id":1}`
+	now := time.Now().Unix()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedTrigger(now, "n1", body, body))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+
+	// The failed request may be resent unchanged
+	fail = false
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedTrigger(now, "n1", body, body))
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("Expected %d on resend, got %d", http.StatusAccepted, rec.Code)
+	}
+}
+
+// signedTrigger builds a trigger request signed over signedBody but carrying
+// sentBody
+func signedTrigger(timestamp int64, nonce, signedBody, sentBody string) *http.Request {
+	const path = "/notify/new-manga"
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(sentBody))
+	req.Header.Set(config.InternalTimestampHeader, strconv.FormatInt(timestamp, 10))
+	if nonce != "" {
+		req.Header.Set(config.InternalNonceHeader, nonce)
+	}
+	req.Header.Set(config.InternalSignatureHeader, shared.SignTriggerRequest("signing-key", timestamp, nonce, path, []byte(signedBody)))
+	return req
+}
@@ -16,6 +16,7 @@ type Broadcaster struct {
 	libraryRepo      repository.LibraryRepository
 	notificationRepo repository.NotificationRepository
 	userRepo         repository.UserRepository
+	webhooks         *WebhookDispatcher // nil disables webhook delivery
 	mu               sync.RWMutex
 }
 
@@ -94,6 +95,9 @@ func (b *Broadcaster) BroadcastToLibraryUsers(ctx context.Context, mangaID int64
 	}
 
 	wg.Wait()
+	if b.webhooks != nil {
+		b.webhooks.DispatchToLibrary(ctx, mangaID, notification)
+	}
 	log.Printf("Notification sent to %d online users and stored for %d total users (manga ID %d)",
 		len(subscribers), len(userIDs), mangaID)
 
@@ -150,10 +154,32 @@ func (b *Broadcaster) BroadcastToAll(notification *Notification) error {
 	}
     
 	wg.Wait()
+	if b.webhooks != nil {
+		b.webhooks.DispatchToAll(ctx, notification)
+	}
 	log.Printf("Notification persisted and broadcast attempted to %d subscribers", len(subscribers))
 	return nil
 }
 
+// SendBatch sends several notifications to one subscriber, packed into as few
+// datagrams as fit under MaxDatagramSize. It returns how many notifications,
+// in order, were handed to the network before the first failed write.
+func (b *Broadcaster) SendBatch(sub *Subscriber, notifications []*Notification) (int, error) {
+	packets, err := packNotifications(notifications, MaxDatagramSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, p := range packets {
+		if err := b.sendToSubscriber(sub, p.Data); err != nil {
+			return sent, err
+		}
+		sent += p.Count
+	}
+	return sent, nil
+}
+
 // sendToSubscriber sends data to a specific subscriber
 func (b *Broadcaster) sendToSubscriber(sub *Subscriber, data []byte) error {
 	_, err := b.conn.WriteToUDP(data, sub.Addr)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
 )
 
 // Mock repositories for testing
@@ -23,10 +24,22 @@ func (m *mockLibraryRepo) Add(ctx context.Context, userID string, mangaID int64)
 	return nil
 }
 
+func (m *mockLibraryRepo) AddWithLimit(ctx context.Context, userID string, mangaID int64, limit int) (int64, error) {
+	return 0, nil
+}
+
 func (m *mockLibraryRepo) Remove(ctx context.Context, userID string, mangaID int64) error {
 	return nil
 }
 
+func (m *mockLibraryRepo) Get(ctx context.Context, userID string, mangaID int64) (*models.UserLibrary, error) {
+	return nil, nil
+}
+
+func (m *mockLibraryRepo) RemoveMany(ctx context.Context, userID string, mangaIDs []int64) ([]int64, error) {
+	return nil, nil
+}
+
 func (m *mockLibraryRepo) List(ctx context.Context, userID string) ([]models.UserLibrary, error) {
 	return nil, nil
 }
@@ -35,6 +48,10 @@ func (m *mockLibraryRepo) Exists(ctx context.Context, userID string, mangaID int
 	return false, nil
 }
 
+func (m *mockLibraryRepo) StatusCounts(ctx context.Context, userID string) ([]repository.LibraryStatusCount, error) {
+	return nil, nil
+}
+
 type mockNotificationRepo struct {
 	notifications []*models.Notification
 	err           error
@@ -57,6 +74,10 @@ func (m *mockNotificationRepo) MarkAllAsRead(ctx context.Context, userID string)
 	return nil
 }
 
+func (m *mockNotificationRepo) DeleteOlderThan(ctx context.Context, cutoff time.Time, keepUnread bool, limit int) (int64, error) {
+	return 0, nil
+}
+
 // mockUserRepo implements the user repository interface used by broadcaster tests
 type mockUserRepo struct {
 	ids []string
@@ -84,6 +105,18 @@ func (m *mockUserRepo) FindByEmail(email string) (*models.User, error) {
 	return nil, nil
 }
 
+func (m *mockUserRepo) List(ctx context.Context, page, pageSize int) ([]models.User, int64, error) {
+	return nil, 0, nil
+}
+
+func (m *mockUserRepo) UpdateLastLogin(ctx context.Context, id string, at time.Time, ip string) error {
+	return nil
+}
+
+func (m *mockUserRepo) UpdatePrivacy(ctx context.Context, id string, profilePublic, libraryPublic *bool) error {
+	return nil
+}
+
 func TestBroadcaster_BroadcastToAll(t *testing.T) {
 	// Create a UDP connection for testing
 	addr, _ := net.ResolveUDPAddr("udp", "127.0.0.1:0")
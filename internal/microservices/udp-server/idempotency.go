@@ -0,0 +1,102 @@
+package udp
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"mangahub/internal/config"
+)
+
+// DefaultIdempotencyTTL is how long a trigger's Idempotency-Key is remembered
+const DefaultIdempotencyTTL = 10 * time.Minute
+
+// IdempotencyCache remembers recently seen Idempotency-Key values so a retried
+// trigger call doesn't broadcast the same notification twice
+type IdempotencyCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	seen      map[string]time.Time // key -> expiry
+	nextSweep time.Time
+}
+
+// NewIdempotencyCache creates an in-memory cache that forgets keys after ttl
+func NewIdempotencyCache(ttl time.Duration) *IdempotencyCache {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	return &IdempotencyCache{
+		ttl:  ttl,
+		now:  time.Now,
+		seen: make(map[string]time.Time),
+	}
+}
+
+// Reserve records key and reports whether it was new. A key that is already
+// reserved (in flight or completed within the TTL) returns false.
+func (c *IdempotencyCache) Reserve(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if now.After(c.nextSweep) {
+		for k, expiry := range c.seen {
+			if now.After(expiry) {
+				delete(c.seen, k)
+			}
+		}
+		c.nextSweep = now.Add(c.ttl)
+	}
+
+	if expiry, ok := c.seen[key]; ok && !now.After(expiry) {
+		return false
+	}
+	c.seen[key] = now.Add(c.ttl)
+	return true
+}
+
+// Release forgets key so the request can be retried, e.g. after a failed broadcast
+func (c *IdempotencyCache) Release(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.seen, key)
+}
+
+// Idempotent skips trigger requests whose Idempotency-Key was already handled,
+// answering 202 without broadcasting again. Keys are scoped per path and only
+// stick once the handler succeeds. Requests without the header pass through.
+func Idempotent(cache *IdempotencyCache, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(config.IdempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		scoped := r.URL.Path + "|" + key
+		if !cache.Reserve(scoped) {
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		if rec.status >= http.StatusMultipleChoices {
+			cache.Release(scoped)
+		}
+	})
+}
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,73 @@
+package udp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyCache_ReserveAndExpire(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewIdempotencyCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	if !cache.Reserve("chapter:1:5") {
+		t.Fatal("Expected first reserve to succeed")
+	}
+	if cache.Reserve("chapter:1:5") {
+		t.Error("Expected duplicate key to be rejected")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if !cache.Reserve("chapter:1:5") {
+		t.Error("Expected key to be accepted again after the TTL")
+	}
+}
+
+func TestIdempotent_SkipsDuplicateBroadcasts(t *testing.T) {
+	calls := 0
+	fail := false
+	handler := Idempotent(NewIdempotencyCache(time.Minute), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if fail {
+			http.Error(w, "broadcast failed", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	send := func(key string) int {
+		req := httptest.NewRequest(http.MethodPost, "/notify/new-chapter", nil)
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// A failed attempt doesn't consume the key
+	fail = true
+	if code := send("chapter:1:5"); code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500, got %d", code)
+	}
+	fail = false
+
+	if code := send("chapter:1:5"); code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d", code)
+	}
+	if code := send("chapter:1:5"); code != http.StatusAccepted {
+		t.Fatalf("Expected 202 for replay, got %d", code)
+	}
+	if calls != 2 {
+		t.Errorf("Expected the replay to skip the handler, got %d calls", calls)
+	}
+
+	// Requests without a key are never deduplicated
+	send("")
+	send("")
+	if calls != 4 {
+		t.Errorf("Expected keyless requests to reach the handler, got %d calls", calls)
+	}
+}
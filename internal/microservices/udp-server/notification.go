@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"mangahub/internal/shared"
 )
 
 // NotificationType defines the type of notification
@@ -15,8 +17,30 @@ const (
 	NotificationMangaUpdate NotificationType = "MANGA_UPDATE"
 	NotificationSubscribe   NotificationType = "SUBSCRIBE"
 	NotificationUnsubscribe NotificationType = "UNSUBSCRIBE"
+	NotificationBatch       NotificationType = "BATCH"
+	NotificationError       NotificationType = "ERROR"
 )
 
+// MaxDatagramSize is the largest payload packed into one datagram. It stays
+// below a 1500-byte Ethernet MTU minus IP/UDP headers so batches are not
+// fragmented on the way to the client.
+const MaxDatagramSize = 1200
+
+// BatchNotification packs several notifications for one subscriber into a
+// single datagram
+type BatchNotification struct {
+	Type      NotificationType  `json:"type"`
+	Count     int               `json:"count"`
+	Events    []json.RawMessage `json:"events"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// datagram is one encoded UDP payload carrying Count consecutive notifications
+type datagram struct {
+	Data  []byte
+	Count int
+}
+
 // Notification represents a notification message
 type Notification struct {
 	Type      NotificationType `json:"type"`
@@ -29,11 +53,7 @@ type Notification struct {
 }
 
 // FieldChange represents a specific field that was updated
-type FieldChange struct {
-	Field    string      `json:"field"`
-	OldValue interface{} `json:"old_value,omitempty"`
-	NewValue interface{} `json:"new_value"`
-}
+type FieldChange = shared.FieldChange
 
 // NewMangaNotification creates a notification for new manga
 func NewMangaNotification(mangaID int64, title string) *Notification {
@@ -139,10 +159,69 @@ func (n *Notification) ToJSON() ([]byte, error) {
 	return json.Marshal(n)
 }
 
+// packNotifications encodes notifications into as few datagrams as possible,
+// keeping each under maxSize. Events stay in order. A group of one is sent as
+// a plain notification, so clients that predate batching still understand
+// it; a notification that is too large on its own is sent alone regardless.
+func packNotifications(notifications []*Notification, maxSize int) ([]datagram, error) {
+	var (
+		packets []datagram
+		group   []json.RawMessage
+		size    int
+	)
+
+	// Envelope bytes around the events, with room for a large count
+	overhead := len(`{"type":"BATCH","count":000000,"events":[],"timestamp":""}`) + len(time.RFC3339Nano)
+
+	flush := func() error {
+		switch len(group) {
+		case 0:
+			return nil
+		case 1:
+			packets = append(packets, datagram{Data: group[0], Count: 1})
+		default:
+			data, err := json.Marshal(BatchNotification{
+				Type:      NotificationBatch,
+				Count:     len(group),
+				Events:    group,
+				Timestamp: time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+			packets = append(packets, datagram{Data: data, Count: len(group)})
+		}
+		group, size = nil, 0
+		return nil
+	}
+
+	for _, n := range notifications {
+		data, err := n.ToJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal notification: %w", err)
+		}
+
+		// +1 for the comma separating events
+		if len(group) > 0 && overhead+size+len(data)+1 > maxSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		group = append(group, data)
+		size += len(data) + 1
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return packets, nil
+}
+
 // SubscribeRequest represents a subscription request from client
 type SubscribeRequest struct {
 	Type   string `json:"type"` // "SUBSCRIBE" or "UNSUBSCRIBE"
 	UserID string `json:"user_id"`
+	Token  string `json:"token,omitempty"` // Access token, required on SUBSCRIBE when auth is enabled
 }
 
 // ParseSubscribeRequest parses incoming subscription request
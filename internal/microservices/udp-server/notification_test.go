@@ -124,3 +124,76 @@ func TestParseSubscribeRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestPackNotifications_BatchesUnderSizeCap(t *testing.T) {
+	notifications := make([]*Notification, 0, 30)
+	for i := 0; i < 30; i++ {
+		notifications = append(notifications, NewChapterNotification(int64(i), "Test Manga", i+1))
+	}
+
+	packets, err := packNotifications(notifications, MaxDatagramSize)
+	if err != nil {
+		t.Fatalf("Failed to pack notifications: %v", err)
+	}
+
+	if len(packets) < 2 || len(packets) >= len(notifications) {
+		t.Fatalf("Expected notifications split into a few batches, got %d datagrams", len(packets))
+	}
+
+	total := 0
+	nextManga := int64(0)
+	for _, p := range packets {
+		if len(p.Data) > MaxDatagramSize {
+			t.Errorf("Datagram of %d bytes exceeds cap %d", len(p.Data), MaxDatagramSize)
+		}
+
+		var batch BatchNotification
+		if err := json.Unmarshal(p.Data, &batch); err != nil {
+			t.Fatalf("Failed to parse batch: %v", err)
+		}
+		if batch.Type != NotificationBatch || batch.Count != p.Count || len(batch.Events) != p.Count {
+			t.Errorf("Unexpected batch header: type=%s count=%d events=%d", batch.Type, batch.Count, len(batch.Events))
+		}
+
+		// Events keep their original order
+		for _, raw := range batch.Events {
+			var n Notification
+			json.Unmarshal(raw, &n)
+			if n.MangaID != nextManga {
+				t.Errorf("Expected manga %d, got %d", nextManga, n.MangaID)
+			}
+			nextManga++
+		}
+		total += p.Count
+	}
+
+	if total != len(notifications) {
+		t.Errorf("Expected %d notifications packed, got %d", len(notifications), total)
+	}
+}
+
+func TestPackNotifications_SingleAndOversized(t *testing.T) {
+	single, err := packNotifications([]*Notification{NewMangaNotification(1, "Solo")}, MaxDatagramSize)
+	if err != nil {
+		t.Fatalf("Failed to pack notification: %v", err)
+	}
+	if len(single) != 1 {
+		t.Fatalf("Expected 1 datagram, got %d", len(single))
+	}
+
+	// A lone notification goes out unwrapped
+	var n Notification
+	json.Unmarshal(single[0].Data, &n)
+	if n.Type != NotificationNewManga {
+		t.Errorf("Expected plain %s notification, got %s", NotificationNewManga, n.Type)
+	}
+
+	big := NewMangaNotification(2, string(make([]byte, 2*MaxDatagramSize)))
+	packets, err := packNotifications([]*Notification{NewMangaNotification(1, "Small"), big}, MaxDatagramSize)
+	if err != nil {
+		t.Fatalf("Failed to pack notifications: %v", err)
+	}
+	if len(packets) != 2 {
+		t.Errorf("Expected oversized notification in its own datagram, got %d datagrams", len(packets))
+	}
+}
@@ -12,6 +12,20 @@ import (
 	"time"
 )
 
+// Config holds tunables for the UDP server. Zero values use the defaults.
+type Config struct {
+	SubscriptionTTL time.Duration // Evict subscribers not seen (SUBSCRIBE/PING) for this long
+	SweepInterval   time.Duration // How often expired subscriptions are swept
+
+	// Auth validates SUBSCRIBE access tokens. Nil disables auth, which lets
+	// any client subscribe as any user and should only be used in tests.
+	Auth TokenValidator
+
+	// Webhooks delivers broadcast notifications to users' webhooks and is
+	// closed on Shutdown. Nil disables webhook delivery.
+	Webhooks *WebhookDispatcher
+}
+
 // Server represents the UDP notification server
 type Server struct {
 	conn             *net.UDPConn
@@ -19,11 +33,25 @@ type Server struct {
 	broadcaster      *Broadcaster
 	notificationRepo repository.NotificationRepository
 	userRepo         repository.UserRepository
+	sweepInterval    time.Duration
+	auth             TokenValidator
 	done             chan struct{}
 }
 
-// NewServer creates a new UDP server
+// NewServer creates a new UDP server with the default Config
 func NewServer(port string, libraryRepo repository.LibraryRepository, notificationRepo repository.NotificationRepository, userRepo repository.UserRepository) (*Server, error) {
+	return NewServerWithConfig(port, Config{}, libraryRepo, notificationRepo, userRepo)
+}
+
+// NewServerWithConfig creates a new UDP server
+func NewServerWithConfig(port string, cfg Config, libraryRepo repository.LibraryRepository, notificationRepo repository.NotificationRepository, userRepo repository.UserRepository) (*Server, error) {
+	if cfg.SubscriptionTTL <= 0 {
+		cfg.SubscriptionTTL = DefaultSubscriptionTTL
+	}
+	if cfg.SweepInterval <= 0 {
+		cfg.SweepInterval = DefaultSweepInterval
+	}
+
 	addr, err := net.ResolveUDPAddr("udp", ":"+port)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve UDP address: %w", err)
@@ -34,8 +62,9 @@ func NewServer(port string, libraryRepo repository.LibraryRepository, notificati
 		return nil, fmt.Errorf("failed to listen on UDP: %w", err)
 	}
 
-	subManager := NewSubscriberManager(5 * time.Minute)
+	subManager := NewSubscriberManager(cfg.SubscriptionTTL)
 	broadcaster := NewBroadcaster(conn, subManager, libraryRepo, notificationRepo, userRepo)
+	broadcaster.webhooks = cfg.Webhooks
 
 	return &Server{
 		conn:             conn,
@@ -43,6 +72,8 @@ func NewServer(port string, libraryRepo repository.LibraryRepository, notificati
 		broadcaster:      broadcaster,
 		notificationRepo: notificationRepo,
 		userRepo:         userRepo,
+		sweepInterval:    cfg.SweepInterval,
+		auth:             cfg.Auth,
 		done:             make(chan struct{}),
 	}, nil
 }
@@ -51,8 +82,8 @@ func NewServer(port string, libraryRepo repository.LibraryRepository, notificati
 func (s *Server) Start() error {
 	log.Printf("UDP server listening on %s", s.conn.LocalAddr().String())
 
-	// Start cleanup routine
-	go s.subManager.StartCleanupRoutine(1*time.Minute, s.done)
+	// Evict subscriptions whose clients stopped pinging
+	go s.subManager.StartCleanupRoutine(s.sweepInterval, s.done)
 
 	// Start listening for incoming messages
 	go s.handleIncomingMessages()
@@ -96,8 +127,15 @@ func (s *Server) processMessage(data []byte, addr *net.UDPAddr) {
 
 	switch req.Type {
 	case "SUBSCRIBE":
-		s.subManager.Add(req.UserID, addr)
-		log.Printf("User %s subscribed from %s", req.UserID, addr.String())
+		userID, err := s.authenticate(req)
+		if err != nil {
+			log.Printf("Rejected SUBSCRIBE from %s: %v", addr.String(), err)
+			s.sendError(addr, err.Error())
+			return
+		}
+
+		s.subManager.Add(userID, addr)
+		log.Printf("User %s subscribed from %s", userID, addr.String())
 
 		// Send confirmation
 		confirmation := &Notification{
@@ -110,9 +148,13 @@ func (s *Server) processMessage(data []byte, addr *net.UDPAddr) {
 		}
 
 		// SYNC: Push missed notifications to reconnecting user
-		go s.syncMissedNotifications(req.UserID, addr)
+		go s.syncMissedNotifications(userID, addr)
 
 	case "UNSUBSCRIBE":
+		// Only the subscribed address may end the subscription
+		if !s.fromSubscriber(req.UserID, addr) {
+			return
+		}
 		s.subManager.Remove(req.UserID)
 		log.Printf("User %s unsubscribed", req.UserID)
 
@@ -127,7 +169,11 @@ func (s *Server) processMessage(data []byte, addr *net.UDPAddr) {
 		}
 
 	case "PING":
-		s.subManager.UpdateActivity(req.UserID)
+		// The subscription expired (or never existed): ask the client to subscribe again
+		if !s.fromSubscriber(req.UserID, addr) || !s.subManager.UpdateActivity(req.UserID) {
+			s.conn.WriteToUDP([]byte(`{"type":"RESUBSCRIBE"}`), addr)
+			return
+		}
 		// Send pong
 		s.conn.WriteToUDP([]byte(`{"type":"PONG"}`), addr)
 
@@ -136,6 +182,28 @@ func (s *Server) processMessage(data []byte, addr *net.UDPAddr) {
 	}
 }
 
+// fromSubscriber reports whether addr is the address userID subscribed from.
+// With auth disabled every sender is accepted.
+func (s *Server) fromSubscriber(userID string, addr *net.UDPAddr) bool {
+	if s.auth == nil {
+		return true
+	}
+	sub, ok := s.subManager.GetByUserID(userID)
+	return ok && sub.Addr.String() == addr.String()
+}
+
+// sendError replies to addr with an ERROR message
+func (s *Server) sendError(addr *net.UDPAddr, message string) {
+	reply := &Notification{
+		Type:      NotificationError,
+		Message:   message,
+		Timestamp: time.Now(),
+	}
+	if data, err := reply.ToJSON(); err == nil {
+		s.conn.WriteToUDP(data, addr)
+	}
+}
+
 // syncMissedNotifications retrieves and sends all unread notifications to a reconnecting user
 func (s *Server) syncMissedNotifications(userID string, addr *net.UDPAddr) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -155,38 +223,35 @@ func (s *Server) syncMissedNotifications(userID string, addr *net.UDPAddr) {
 
 	log.Printf("Syncing %d missed notifications to user %s", len(unreadNotifs), userID)
 
-	// Send each unread notification via UDP
+	notifications := make([]*Notification, 0, len(unreadNotifs))
 	for _, dbNotif := range unreadNotifs {
-		notification := &Notification{
+		notifications = append(notifications, &Notification{
 			Type:      NotificationType(dbNotif.Type),
 			MangaID:   dbNotif.MangaID,
 			Title:     dbNotif.Title,
 			Message:   dbNotif.Message,
 			Timestamp: dbNotif.CreatedAt,
-		}
-
-		data, err := notification.ToJSON()
-		if err != nil {
-			log.Printf("Failed to marshal notification %d: %v", dbNotif.ID, err)
-			continue
-		}
+		})
+	}
 
-		if _, err := s.conn.WriteToUDP(data, addr); err != nil {
-			log.Printf("Failed to send notification %d to %s: %v", dbNotif.ID, addr.String(), err)
-		} else {
-			log.Printf("Synced notification %d to user %s", dbNotif.ID, userID)
+	// Pack the backlog into batched datagrams instead of one per notification
+	sub, ok := s.subManager.GetByUserID(userID)
+	if !ok {
+		sub = &Subscriber{UserID: userID, Addr: addr}
+	}
+	sent, err := s.broadcaster.SendBatch(sub, notifications)
+	if err != nil {
+		log.Printf("Failed to send missed notifications to %s after %d of %d: %v", addr.String(), sent, len(notifications), err)
+	}
 
-			// Mark notification as read so it won't be sent again
-			if err := s.notificationRepo.MarkAsRead(ctx, dbNotif.ID); err != nil {
-				log.Printf("Failed to mark notification %d as read for user %s: %v", dbNotif.ID, userID, err)
-			}
+	// Mark delivered notifications as read so they won't be sent again
+	for _, dbNotif := range unreadNotifs[:sent] {
+		if err := s.notificationRepo.MarkAsRead(ctx, dbNotif.ID); err != nil {
+			log.Printf("Failed to mark notification %d as read for user %s: %v", dbNotif.ID, userID, err)
 		}
-
-		// Small delay to avoid overwhelming the client
-		time.Sleep(50 * time.Millisecond)
 	}
 
-	log.Printf("Sync completed for user %s", userID)
+	log.Printf("Sync completed for user %s (%d/%d delivered)", userID, sent, len(unreadNotifs))
 }
 
 // NotifyNewManga broadcasts notification for new manga to all users
@@ -236,6 +301,9 @@ func (s *Server) GetBroadcaster() *Broadcaster {
 // Shutdown gracefully shuts down the server
 func (s *Server) Shutdown() error {
 	close(s.done)
+	if s.broadcaster.webhooks != nil {
+		s.broadcaster.webhooks.Close()
+	}
 	return s.conn.Close()
 }
 
@@ -1,11 +1,19 @@
 package udp
 
 import (
+	"log"
 	"net"
 	"sync"
 	"time"
 )
 
+// Subscription TTL defaults. Clients PING every 30s, so the default TTL
+// tolerates a few lost pings before a subscription is evicted.
+const (
+	DefaultSubscriptionTTL = 2 * time.Minute
+	DefaultSweepInterval   = 30 * time.Second
+)
+
 // Subscriber represents a connected client
 type Subscriber struct {
 	UserID   string
@@ -14,13 +22,19 @@ type Subscriber struct {
 	Active   bool
 }
 
-// SubscriberManager manages all subscribers
+// SubscriberManager manages all subscribers. A subscription lives for
+// timeout after the client was last seen (SUBSCRIBE or PING).
 type SubscriberManager struct {
 	mu          sync.RWMutex
 	subscribers map[string]*Subscriber // userID -> Subscriber
 	timeout     time.Duration
 }
 
+// expired reports whether sub has not been seen within the TTL
+func (sm *SubscriberManager) expired(sub *Subscriber, now time.Time) bool {
+	return now.Sub(sub.LastSeen) > sm.timeout
+}
+
 // NewSubscriberManager creates a new subscriber manager
 func NewSubscriberManager(timeout time.Duration) *SubscriberManager {
 	return &SubscriberManager{
@@ -50,15 +64,19 @@ func (sm *SubscriberManager) Remove(userID string) {
 	delete(sm.subscribers, userID)
 }
 
-// UpdateActivity updates the last seen time for a subscriber
-func (sm *SubscriberManager) UpdateActivity(userID string) {
+// UpdateActivity refreshes a subscriber's TTL. It returns false if the user
+// has no subscription (never subscribed or already evicted).
+func (sm *SubscriberManager) UpdateActivity(userID string) bool {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
-	if sub, exists := sm.subscribers[userID]; exists {
-		sub.LastSeen = time.Now()
-		sub.Active = true
+	sub, exists := sm.subscribers[userID]
+	if !exists {
+		return false
 	}
+	sub.LastSeen = time.Now()
+	sub.Active = true
+	return true
 }
 
 // GetAll returns all active subscribers
@@ -66,9 +84,10 @@ func (sm *SubscriberManager) GetAll() []*Subscriber {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
+	now := time.Now()
 	subs := make([]*Subscriber, 0, len(sm.subscribers))
 	for _, sub := range sm.subscribers {
-		if sub.Active {
+		if sub.Active && !sm.expired(sub, now) {
 			subs = append(subs, sub)
 		}
 	}
@@ -89,26 +108,33 @@ func (sm *SubscriberManager) GetByUserIDs(userIDs []string) []*Subscriber {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
+	now := time.Now()
 	subs := make([]*Subscriber, 0, len(userIDs))
 	for _, userID := range userIDs {
-		if sub, exists := sm.subscribers[userID]; exists && sub.Active {
+		if sub, exists := sm.subscribers[userID]; exists && sub.Active && !sm.expired(sub, now) {
 			subs = append(subs, sub)
 		}
 	}
 	return subs
 }
 
-// CleanupInactive removes inactive subscribers
-func (sm *SubscriberManager) CleanupInactive() {
+// CleanupInactive evicts subscribers not seen within the TTL and returns
+// how many were removed
+func (sm *SubscriberManager) CleanupInactive() int {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
 	now := time.Now()
+	evicted := 0
 	for userID, sub := range sm.subscribers {
-		if now.Sub(sub.LastSeen) > sm.timeout {
+		if sm.expired(sub, now) {
+			log.Printf("Evicting subscriber %s at %s (last seen %s ago)",
+				userID, sub.Addr, now.Sub(sub.LastSeen).Round(time.Second))
 			delete(sm.subscribers, userID)
+			evicted++
 		}
 	}
+	return evicted
 }
 
 // Count returns the number of active subscribers
@@ -127,7 +153,9 @@ func (sm *SubscriberManager) StartCleanupRoutine(interval time.Duration, done <-
 	for {
 		select {
 		case <-ticker.C:
-			sm.CleanupInactive()
+			if n := sm.CleanupInactive(); n > 0 {
+				log.Printf("Subscription sweep evicted %d subscriber(s), %d remaining", n, sm.Count())
+			}
 		case <-done:
 			return
 		}
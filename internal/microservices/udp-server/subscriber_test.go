@@ -98,6 +98,32 @@ func TestSubscriberManager_CleanupInactive(t *testing.T) {
 	}
 }
 
+func TestSubscriberManager_ExpiredNotSentBeforeSweep(t *testing.T) {
+	sm := NewSubscriberManager(100 * time.Millisecond)
+
+	addr, _ := net.ResolveUDPAddr("udp", "127.0.0.1:12345")
+	sm.Add("user1", addr)
+
+	time.Sleep(150 * time.Millisecond)
+
+	// Not swept yet, but no longer a send target
+	if got := len(sm.GetAll()); got != 0 {
+		t.Errorf("Expected expired subscriber excluded from GetAll, got %d", got)
+	}
+	if got := len(sm.GetByUserIDs([]string{"user1"})); got != 0 {
+		t.Errorf("Expected expired subscriber excluded from GetByUserIDs, got %d", got)
+	}
+
+	if evicted := sm.CleanupInactive(); evicted != 1 {
+		t.Errorf("Expected 1 eviction, got %d", evicted)
+	}
+
+	// A PING after eviction does not revive the subscription
+	if sm.UpdateActivity("user1") {
+		t.Error("Expected UpdateActivity to report no subscription after eviction")
+	}
+}
+
 func TestSubscriberManager_UpdateActivity(t *testing.T) {
 	sm := NewSubscriberManager(100 * time.Millisecond)
 
@@ -0,0 +1,257 @@
+package udp
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+)
+
+// Headers on every webhook delivery
+const (
+	WebhookEventHeader     = "X-MangaHub-Event"
+	WebhookSignatureHeader = "X-MangaHub-Signature" // "sha256=" + hex HMAC-SHA256 of the body
+)
+
+// Webhook delivery defaults
+const (
+	DefaultWebhookTimeout     = 5 * time.Second
+	DefaultWebhookMaxFailures = 10
+	webhookBackoff            = 500 * time.Millisecond // Doubled after each failed attempt
+	webhookWorkers            = 8                      // Deliveries in flight at once
+	webhookQueueSize          = 1000                   // Deliveries waiting for a worker
+)
+
+// errPrivateWebhookAddress is returned when a webhook resolves to an address
+// the dispatcher may not reach
+var errPrivateWebhookAddress = errors.New("webhook address is not public")
+
+// WebhookDispatcher POSTs notifications to the webhooks users registered for
+// their type, signing each body with the webhook's secret. Deliveries run on
+// a fixed pool of workers until Close.
+type WebhookDispatcher struct {
+	repo        repository.WebhookRepository
+	client      *http.Client
+	retries     int
+	maxFailures int
+	backoff     time.Duration
+
+	queue   chan webhookDelivery
+	ctx     context.Context // Cancelled by Close
+	cancel  context.CancelFunc
+	workers sync.WaitGroup
+}
+
+// webhookDelivery is one notification bound for one webhook
+type webhookDelivery struct {
+	webhook models.Webhook
+	event   string
+	body    []byte
+}
+
+// NewWebhookDispatcher creates a dispatcher and starts its workers. Each
+// attempt gets timeout, a failed delivery is retried retries times and a
+// webhook is disabled after maxFailures failed deliveries in a row. Unless
+// allowPrivate, webhooks on loopback, private or link-local addresses are
+// refused.
+func NewWebhookDispatcher(repo repository.WebhookRepository, timeout time.Duration, retries, maxFailures int, allowPrivate bool) *WebhookDispatcher {
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	if retries < 0 {
+		retries = 0
+	}
+	if maxFailures < 1 {
+		maxFailures = DefaultWebhookMaxFailures
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		// Checked on the resolved address, so a public name pointing at an
+		// internal host is refused too
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return errPrivateWebhookAddress
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &WebhookDispatcher{
+		repo: repo,
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// A redirect counts as a failed delivery rather than being followed
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		retries:     retries,
+		maxFailures: maxFailures,
+		backoff:     webhookBackoff,
+		queue:       make(chan webhookDelivery, webhookQueueSize),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+	for i := 0; i < webhookWorkers; i++ {
+		d.workers.Add(1)
+		go d.work()
+	}
+	return d
+}
+
+// Close stops the workers, abandoning queued deliveries and cancelling the
+// ones in flight, and waits for them to return
+func (d *WebhookDispatcher) Close() {
+	d.cancel()
+	d.workers.Wait()
+}
+
+// DispatchToLibrary queues n for the webhooks of the users with mangaID in
+// their library
+func (d *WebhookDispatcher) DispatchToLibrary(ctx context.Context, mangaID int64, n *Notification) {
+	webhooks, err := d.repo.ListActiveForManga(ctx, string(n.Type), mangaID)
+	if err != nil {
+		log.Printf("Failed to list webhooks for %s on manga %d: %v", n.Type, mangaID, err)
+		return
+	}
+	d.enqueue(webhooks, n)
+}
+
+// DispatchToAll queues n for every webhook subscribed to its type
+func (d *WebhookDispatcher) DispatchToAll(ctx context.Context, n *Notification) {
+	webhooks, err := d.repo.ListActive(ctx, string(n.Type))
+	if err != nil {
+		log.Printf("Failed to list webhooks for %s: %v", n.Type, err)
+		return
+	}
+	d.enqueue(webhooks, n)
+}
+
+// enqueue hands n to the workers without waiting. When the queue is full
+// the delivery is dropped rather than holding up the broadcast.
+func (d *WebhookDispatcher) enqueue(webhooks []models.Webhook, n *Notification) {
+	if len(webhooks) == 0 {
+		return
+	}
+	body, err := n.ToJSON()
+	if err != nil {
+		log.Printf("Failed to marshal webhook payload: %v", err)
+		return
+	}
+
+	for _, w := range webhooks {
+		select {
+		case d.queue <- webhookDelivery{webhook: w, event: string(n.Type), body: body}:
+		default:
+			log.Printf("Webhook queue full, dropping %s delivery to webhook %d", n.Type, w.ID)
+		}
+	}
+}
+
+func (d *WebhookDispatcher) work() {
+	defer d.workers.Done()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case job := <-d.queue:
+			d.deliver(d.ctx, job.webhook, job.event, job.body)
+		}
+	}
+}
+
+// deliver POSTs body to w, retrying with backoff, and records the outcome
+func (d *WebhookDispatcher) deliver(ctx context.Context, w models.Webhook, event string, body []byte) {
+	signature := "sha256=" + SignWebhookBody(w.Secret, body)
+
+	var err error
+	delay := d.backoff
+attempts:
+	for attempt := 0; attempt <= d.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				break attempts
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+		if err = d.post(ctx, w.URL, event, signature, body); err == nil {
+			if err := d.repo.RecordSuccess(ctx, w.ID); err != nil {
+				log.Printf("Failed to record webhook %d delivery: %v", w.ID, err)
+			}
+			return
+		}
+	}
+
+	if ctx.Err() != nil {
+		return // shutting down; not the webhook's fault
+	}
+	log.Printf("Webhook %d delivery failed: %v", w.ID, err)
+	disabled, recErr := d.repo.RecordFailure(ctx, w.ID, err.Error(), d.maxFailures)
+	if recErr != nil {
+		log.Printf("Failed to record webhook %d failure: %v", w.ID, recErr)
+		return
+	}
+	if disabled {
+		log.Printf("Webhook %d disabled after %d consecutive failures", w.ID, d.maxFailures)
+	}
+}
+
+func (d *WebhookDispatcher) post(ctx context.Context, url, event, signature string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookSignatureHeader, signature)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+// SignWebhookBody returns the hex HMAC-SHA256 of body keyed with secret, as
+// sent in the signature header after "sha256="
+func SignWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast())
+}
@@ -0,0 +1,214 @@
+package udp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/repository"
+)
+
+// mockWebhookRepo serves a fixed set of webhooks and records outcomes
+type mockWebhookRepo struct {
+	repository.WebhookRepository
+	webhooks []models.Webhook
+
+	mu        sync.Mutex
+	successes []int64
+	failures  map[int64]int
+	recorded  chan int64 // Receives the webhook ID of every recorded outcome
+}
+
+func newMockWebhookRepo(webhooks ...models.Webhook) *mockWebhookRepo {
+	return &mockWebhookRepo{
+		webhooks: webhooks,
+		failures: map[int64]int{},
+		recorded: make(chan int64, 100),
+	}
+}
+
+func (m *mockWebhookRepo) ListActive(ctx context.Context, event string) ([]models.Webhook, error) {
+	var matched []models.Webhook
+	for _, w := range m.webhooks {
+		if w.Active && w.Handles(event) {
+			matched = append(matched, w)
+		}
+	}
+	return matched, nil
+}
+
+func (m *mockWebhookRepo) ListActiveForManga(ctx context.Context, event string, mangaID int64) ([]models.Webhook, error) {
+	return m.ListActive(ctx, event)
+}
+
+func (m *mockWebhookRepo) RecordSuccess(ctx context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.successes = append(m.successes, id)
+	m.recorded <- id
+	return nil
+}
+
+func (m *mockWebhookRepo) RecordFailure(ctx context.Context, id int64, message string, maxFailures int) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures[id]++
+	m.recorded <- id
+	return m.failures[id] >= maxFailures, nil
+}
+
+func newTestDispatcher(t *testing.T, repo repository.WebhookRepository, retries, maxFailures int, allowPrivate bool) *WebhookDispatcher {
+	d := NewWebhookDispatcher(repo, time.Second, retries, maxFailures, allowPrivate)
+	d.backoff = time.Millisecond
+	t.Cleanup(d.Close)
+	return d
+}
+
+// waitRecorded waits until the dispatcher has recorded n delivery outcomes
+func waitRecorded(t *testing.T, repo *mockWebhookRepo, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-repo.recorded:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("recorded %d delivery outcomes, want %d", i, n)
+		}
+	}
+}
+
+// dispatchAndWait queues n for every subscribed webhook and waits until the
+// one matching webhook's delivery is recorded
+func dispatchAndWait(t *testing.T, d *WebhookDispatcher, repo *mockWebhookRepo, n *Notification) {
+	t.Helper()
+	d.DispatchToAll(context.Background(), n)
+	waitRecorded(t, repo, 1)
+}
+
+func TestWebhookDispatcher_SignsPayload(t *testing.T) {
+	var gotSignature, gotEvent string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(WebhookSignatureHeader)
+		gotEvent = r.Header.Get(WebhookEventHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	repo := newMockWebhookRepo(
+		models.Webhook{ID: 1, URL: srv.URL, Secret: "secret", Events: "NEW_CHAPTER", Active: true},
+		models.Webhook{ID: 2, URL: srv.URL, Secret: "secret", Events: "NEW_MANGA", Active: true},
+	)
+
+	d := newTestDispatcher(t, repo, 0, 3, true)
+	d.DispatchToLibrary(context.Background(), 1, NewChapterNotification(1, "Berserk", 12))
+	waitRecorded(t, repo, 1)
+
+	if gotEvent != "NEW_CHAPTER" {
+		t.Errorf("event header = %q, want NEW_CHAPTER", gotEvent)
+	}
+	if want := "sha256=" + SignWebhookBody("secret", gotBody); gotSignature != want {
+		t.Errorf("signature = %q, want %q", gotSignature, want)
+	}
+	if len(repo.successes) != 1 || repo.successes[0] != 1 {
+		t.Errorf("successes = %v, want only webhook 1", repo.successes)
+	}
+}
+
+func TestWebhookDispatcher_Retries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	repo := newMockWebhookRepo(models.Webhook{ID: 1, URL: srv.URL, Secret: "secret", Events: "NEW_CHAPTER", Active: true})
+
+	dispatchAndWait(t, newTestDispatcher(t, repo, 2, 3, true), repo, NewChapterNotification(1, "Berserk", 12))
+
+	if calls.Load() != 3 {
+		t.Errorf("attempts = %d, want 3", calls.Load())
+	}
+	if len(repo.successes) != 1 || repo.failures[1] != 0 {
+		t.Errorf("successes = %v, failures = %v, want one success", repo.successes, repo.failures)
+	}
+}
+
+func TestWebhookDispatcher_DisablesAfterFailures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	repo := newMockWebhookRepo(models.Webhook{ID: 1, URL: srv.URL, Secret: "secret", Events: "NEW_CHAPTER", Active: true})
+	d := newTestDispatcher(t, repo, 1, 2, true)
+
+	for i := 0; i < 2; i++ {
+		dispatchAndWait(t, d, repo, NewChapterNotification(1, "Berserk", 12))
+	}
+
+	if repo.failures[1] != 2 {
+		t.Errorf("failures = %d, want 2", repo.failures[1])
+	}
+	if len(repo.successes) != 0 {
+		t.Errorf("successes = %v, want none", repo.successes)
+	}
+}
+
+func TestWebhookDispatcher_RefusesPrivateAddress(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	repo := newMockWebhookRepo(models.Webhook{ID: 1, URL: srv.URL, Secret: "secret", Events: "NEW_CHAPTER", Active: true})
+	dispatchAndWait(t, newTestDispatcher(t, repo, 0, 3, false), repo, NewChapterNotification(1, "Berserk", 12))
+
+	if calls.Load() != 0 {
+		t.Errorf("loopback webhook was called %d times", calls.Load())
+	}
+	if repo.failures[1] != 1 {
+		t.Errorf("failures = %d, want 1", repo.failures[1])
+	}
+}
+
+func TestWebhookDispatcher_CloseCancelsDeliveries(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release) // before srv.Close, which waits for the handler
+
+	repo := newMockWebhookRepo(models.Webhook{ID: 1, URL: srv.URL, Secret: "secret", Events: "NEW_MANGA", Active: true})
+	d := NewWebhookDispatcher(repo, time.Minute, 3, 3, true)
+	d.DispatchToAll(context.Background(), NewMangaNotification(1, "Berserk"))
+	<-started
+
+	closed := make(chan struct{})
+	go func() {
+		d.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not cancel the in-flight delivery")
+	}
+	if repo.failures[1] != 0 {
+		t.Errorf("failures = %d, want none recorded on shutdown", repo.failures[1])
+	}
+}
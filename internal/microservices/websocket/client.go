@@ -1,6 +1,7 @@
 package websocket
 
 import (
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -49,6 +50,7 @@ func (c *Client) ReadPump() {
 	defer func() {
 		c.Hub.Unregister <- c
 		c.Conn.Close()
+		c.Hub.ReleaseConnection()
 	}()
 
 	// set read limit for incoming messages
@@ -107,11 +109,19 @@ func (c *Client) ReadPump() {
 		case TypeChat:
 			// get room ID from client.RoomID to ensure correct room
 			message.RoomID = c.RoomID
+			// drop messages from users flooding this room
+			if !c.allowChat() {
+				continue
+			}
 			// broadcast chat message to room via hub
 			c.Hub.Broadcast <- message
 		case TypeTyping:
 			// get room ID from client.RoomID to ensure correct room
 			message.RoomID = c.RoomID
+			// muted users can't send typing indicators either
+			if c.Hub.Flood != nil && c.Hub.Flood.Muted(c.UserID, c.RoomID) {
+				continue
+			}
 			// broadcast typing indicator to room via hub
 			c.Hub.Broadcast <- message
 		default:
@@ -174,6 +184,28 @@ func (c *Client) WritePump() {
 	}
 }
 
+// allowChat: checks the room's flood guard and tells the sender when they are
+// throttled or muted
+func (c *Client) allowChat() bool {
+	if c.Hub.Flood == nil {
+		return true
+	}
+
+	verdict, mute := c.Hub.Flood.Check(c.UserID, c.RoomID)
+	switch verdict {
+	case FloodThrottled:
+		c.SendMessage(NewSystemMessage(c.RoomID, "You are sending messages too fast. Slow down."))
+	case FloodMuted:
+		slog.Warn("Client muted for flooding", "room_id", c.RoomID, "user_id", c.UserID, "duration", mute)
+		c.SendMessage(NewSystemMessage(c.RoomID,
+			fmt.Sprintf("You have been muted in this room for %s for flooding.", mute.Round(time.Second))))
+	case FloodStillMuted:
+		c.SendMessage(NewSystemMessage(c.RoomID,
+			fmt.Sprintf("You are muted in this room for another %s.", mute.Round(time.Second))))
+	}
+	return verdict == FloodAllowed
+}
+
 // SendMessage: sends a message to the client's send channel
 func (c *Client) SendMessage(message *Message) error {
 	data, err := message.ToJSON()
@@ -0,0 +1,158 @@
+package websocket
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Chat flood protection defaults
+const (
+	DefaultChatRatePerMinute = 30               // sustained chat messages per user per room
+	DefaultChatBurst         = 5                // messages allowed back-to-back
+	DefaultMuteStrikes       = 5                // dropped messages before a mute
+	DefaultMuteDuration      = 30 * time.Second // first mute; doubles for each repeat offense
+	DefaultMaxMuteDuration   = 10 * time.Minute
+
+	// floodForgetAfter is how long a (user, room) pair must stay quiet before
+	// its state, including past offenses, is forgotten
+	floodForgetAfter = time.Hour
+)
+
+// FloodConfig configures per-(user, room) chat rate limiting. Zero values use the defaults above.
+type FloodConfig struct {
+	RatePerMinute int
+	Burst         int
+	MuteStrikes   int           // Rate-limited messages tolerated before muting
+	MuteDuration  time.Duration // Length of the first mute
+	MaxMute       time.Duration // Cap for repeat-offender mutes
+}
+
+// FloodVerdict is the outcome of FloodGuard.Check
+type FloodVerdict int
+
+const (
+	FloodAllowed    FloodVerdict = iota
+	FloodThrottled               // over the rate limit; message dropped
+	FloodMuted                   // this message triggered a new mute
+	FloodStillMuted              // sender is serving an earlier mute
+)
+
+type floodState struct {
+	limiter    *rate.Limiter
+	strikes    int // messages dropped since the last mute
+	offenses   int // mutes handed out so far
+	mutedUntil time.Time
+	lastSeen   time.Time
+}
+
+type floodKey struct {
+	userID string
+	roomID int64
+}
+
+// FloodGuard rate limits chat per (user, room). Senders who keep going past
+// the limit are muted in that room, with each repeat offense doubling the mute.
+type FloodGuard struct {
+	cfg FloodConfig
+	now func() time.Time
+
+	mu        sync.Mutex
+	states    map[floodKey]*floodState
+	nextSweep time.Time
+}
+
+// NewFloodGuard creates a FloodGuard
+func NewFloodGuard(cfg FloodConfig) *FloodGuard {
+	if cfg.RatePerMinute <= 0 {
+		cfg.RatePerMinute = DefaultChatRatePerMinute
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = DefaultChatBurst
+	}
+	if cfg.MuteStrikes <= 0 {
+		cfg.MuteStrikes = DefaultMuteStrikes
+	}
+	if cfg.MuteDuration <= 0 {
+		cfg.MuteDuration = DefaultMuteDuration
+	}
+	if cfg.MaxMute < cfg.MuteDuration {
+		cfg.MaxMute = DefaultMaxMuteDuration
+		if cfg.MaxMute < cfg.MuteDuration {
+			cfg.MaxMute = cfg.MuteDuration
+		}
+	}
+
+	return &FloodGuard{
+		cfg:    cfg,
+		now:    time.Now,
+		states: make(map[floodKey]*floodState),
+	}
+}
+
+// Check records a message from userID in roomID and reports whether it may be
+// broadcast. For FloodMuted and FloodStillMuted, the returned duration is how
+// long the mute lasts from now.
+func (g *FloodGuard) Check(userID string, roomID int64) (FloodVerdict, time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	g.sweep(now)
+
+	key := floodKey{userID: userID, roomID: roomID}
+	st, ok := g.states[key]
+	if !ok {
+		st = &floodState{limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(g.cfg.RatePerMinute)), g.cfg.Burst)}
+		g.states[key] = st
+	}
+	st.lastSeen = now
+
+	if now.Before(st.mutedUntil) {
+		return FloodStillMuted, st.mutedUntil.Sub(now)
+	}
+	if st.limiter.AllowN(now, 1) {
+		return FloodAllowed, 0
+	}
+
+	st.strikes++
+	if st.strikes < g.cfg.MuteStrikes {
+		return FloodThrottled, 0
+	}
+
+	st.strikes = 0
+	st.offenses++
+	mute := g.cfg.MuteDuration
+	for i := 1; i < st.offenses && mute < g.cfg.MaxMute; i++ {
+		mute *= 2
+	}
+	if mute > g.cfg.MaxMute {
+		mute = g.cfg.MaxMute
+	}
+	st.mutedUntil = now.Add(mute)
+	return FloodMuted, mute
+}
+
+// Muted reports whether userID is currently muted in roomID
+func (g *FloodGuard) Muted(userID string, roomID int64) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	st, ok := g.states[floodKey{userID: userID, roomID: roomID}]
+	return ok && g.now().Before(st.mutedUntil)
+}
+
+// sweep drops state for pairs that have been quiet for floodForgetAfter.
+// Caller must hold g.mu.
+func (g *FloodGuard) sweep(now time.Time) {
+	if now.Before(g.nextSweep) {
+		return
+	}
+	for key, st := range g.states {
+		if now.Sub(st.lastSeen) > floodForgetAfter && now.After(st.mutedUntil) {
+			delete(g.states, key)
+		}
+	}
+	g.nextSweep = now.Add(time.Minute)
+}
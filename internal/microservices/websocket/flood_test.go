@@ -0,0 +1,58 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestFloodGuard(cfg FloodConfig) (*FloodGuard, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := NewFloodGuard(cfg)
+	g.now = func() time.Time { return now }
+	return g, &now
+}
+
+func TestFloodGuard_ThrottlesThenMutes(t *testing.T) {
+	g, _ := newTestFloodGuard(FloodConfig{RatePerMinute: 1, Burst: 2, MuteStrikes: 2, MuteDuration: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		if v, _ := g.Check("u1", 7); v != FloodAllowed {
+			t.Fatalf("Expected burst message %d to be allowed, got %v", i, v)
+		}
+	}
+	if v, _ := g.Check("u1", 7); v != FloodThrottled {
+		t.Fatalf("Expected throttled, got %v", v)
+	}
+	v, mute := g.Check("u1", 7)
+	if v != FloodMuted || mute != time.Minute {
+		t.Fatalf("Expected 1m mute, got %v %s", v, mute)
+	}
+	if !g.Muted("u1", 7) {
+		t.Error("Expected user to be muted in room 7")
+	}
+
+	// Limits are per room and per user
+	if v, _ := g.Check("u1", 8); v != FloodAllowed {
+		t.Errorf("Expected other room to be unaffected, got %v", v)
+	}
+	if v, _ := g.Check("u2", 7); v != FloodAllowed {
+		t.Errorf("Expected other user to be unaffected, got %v", v)
+	}
+}
+
+func TestFloodGuard_RepeatOffendersGetLongerMutes(t *testing.T) {
+	g, now := newTestFloodGuard(FloodConfig{RatePerMinute: 1, Burst: 1, MuteStrikes: 1, MuteDuration: time.Minute, MaxMute: 3 * time.Minute})
+
+	expected := []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute}
+	for i, want := range expected {
+		g.Check("u1", 7) // uses the refilled token
+		v, mute := g.Check("u1", 7)
+		if v != FloodMuted || mute != want {
+			t.Fatalf("Offense %d: expected %s mute, got %v %s", i+1, want, v, mute)
+		}
+		if v, _ := g.Check("u1", 7); v != FloodStillMuted {
+			t.Fatalf("Offense %d: expected still muted, got %v", i+1, v)
+		}
+		*now = now.Add(mute + time.Minute)
+	}
+}
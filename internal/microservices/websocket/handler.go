@@ -1,8 +1,10 @@
 package websocket
 
 import (
+	"log/slog"
 	"mangahub/internal/microservices/http-api/service"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -38,9 +40,16 @@ func WSHandler(hub *Hub) gin.HandlerFunc {
 			}
 		}
 
+		// reserve a connection slot; the slot is released when ReadPump exits
+		if !hub.AcquireConnection() {
+			rejectFull(c, hub)
+			return
+		}
+
 		// upgrade HTTP connection to WebSocket
 		conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 		if err != nil {
+			hub.ReleaseConnection()
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upgrade to WebSocket"})
 			return
 		}
@@ -64,3 +73,18 @@ func WSHandler(hub *Hub) gin.HandlerFunc {
 
 	}
 }
+
+// rejectFull: completes the handshake only to send a "try again later" close
+// frame, since browsers don't expose the HTTP status of a failed handshake
+func rejectFull(c *gin.Context, hub *Hub) {
+	slog.Warn("Websocket connection limit reached", "max_connections", hub.MaxConnections, "remote_addr", c.ClientIP())
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return // Upgrade already wrote an HTTP error
+	}
+	defer conn.Close()
+
+	closeMsg := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "server is at its connection limit")
+	conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(WriteWait))
+}
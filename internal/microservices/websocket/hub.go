@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"log/slog"
 	"mangahub/internal/microservices/http-api/models"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -24,6 +26,10 @@ type Hub struct {
 	LeaveRoom   chan *RoomActions     // Leave room action = happened
 	mu          sync.RWMutex          // mutex for concurrent access
 	MessageRepo ChatMessageRepository // Repository for storing chat messages
+	Flood       *FloodGuard           // Per-(user, room) chat rate limit and mutes
+
+	MaxConnections int          // Cap on concurrent connections, 0 = unlimited
+	connections    atomic.Int64 // Connections currently open
 }
 
 // RoomActions defines actions leave/join on rooms of specific clients
@@ -43,15 +49,24 @@ func NewHub(messageRepo ChatMessageRepository) *Hub {
 		JoinRoom:    make(chan *RoomActions),
 		LeaveRoom:   make(chan *RoomActions),
 		MessageRepo: messageRepo,
+		Flood:       NewFloodGuard(FloodConfig{}),
 	}
 }
 
 // Run: starts the Hub's main loop to process incoming channels
 func (h *Hub) Run() {
-	// infinite loop to listen on channels
+	h.RunContext(context.Background())
+}
+
+// RunContext: Run until ctx is cancelled, so the hub stops persisting chat
+// messages before the DB pool is closed on shutdown
+func (h *Hub) RunContext(ctx context.Context) {
+	// loop until shutdown, listening on channels
 	for {
 		// use select case statement to listen on multiple channels then execute corresponding action
 		select {
+		case <-ctx.Done():
+			return
 		case client := <-h.Register:
 			h.RegisterClient(client)
 		case client := <-h.Unregister:
@@ -62,11 +77,34 @@ func (h *Hub) Run() {
 			h.HandleLeaveRoom(action)
 		case message := <-h.Broadcast:
 			h.BroadcastMessage(message)
-			// shut down case can be added later once needed
 		}
 	}
 }
 
+// AcquireConnection: reserves a slot for a new connection, failing when
+// MaxConnections is reached. Every successful call must be paired with ReleaseConnection.
+func (h *Hub) AcquireConnection() bool {
+	for {
+		n := h.connections.Load()
+		if h.MaxConnections > 0 && n >= int64(h.MaxConnections) {
+			return false
+		}
+		if h.connections.CompareAndSwap(n, n+1) {
+			return true
+		}
+	}
+}
+
+// ReleaseConnection: frees a slot taken by AcquireConnection
+func (h *Hub) ReleaseConnection() {
+	h.connections.Add(-1)
+}
+
+// ConnectionCount: returns the number of open connections
+func (h *Hub) ConnectionCount() int64 {
+	return h.connections.Load()
+}
+
 // RegisterClient: registers a new client
 func (h *Hub) RegisterClient(c *Client) {
 	h.mu.Lock()
@@ -265,3 +303,30 @@ func (h *Hub) GetRoom(roomID int64) (*Room, error) {
 	// if not return error and nil
 	return nil, fmt.Errorf("room with ID %d not found", roomID)
 }
+
+// RoomStats is a snapshot of an active room
+type RoomStats struct {
+	RoomID      int64 // manga ID
+	MemberCount int
+}
+
+// ActiveRooms: returns rooms that currently have members, busiest first.
+// Rooms are held in memory, so this only covers clients connected to this instance.
+func (h *Hub) ActiveRooms() []RoomStats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	stats := make([]RoomStats, 0, len(h.Rooms))
+	for id, room := range h.Rooms {
+		if count := room.GetUserCount(); count > 0 {
+			stats = append(stats, RoomStats{RoomID: id, MemberCount: count})
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].MemberCount != stats[j].MemberCount {
+			return stats[i].MemberCount > stats[j].MemberCount
+		}
+		return stats[i].RoomID < stats[j].RoomID
+	})
+	return stats
+}
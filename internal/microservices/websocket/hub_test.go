@@ -0,0 +1,51 @@
+package websocket
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHub_ActiveRooms(t *testing.T) {
+	hub := NewHub(nil)
+	for roomID, members := range map[int64]int{1: 1, 2: 3, 3: 0, 4: 1} {
+		room := NewRoom(roomID, "")
+		for i := 0; i < members; i++ {
+			c := &Client{ID: string(rune('a' + i)), RoomID: NilRoomID}
+			room.AddUser(c)
+		}
+		hub.Rooms[roomID] = room
+	}
+
+	expected := []RoomStats{
+		{RoomID: 2, MemberCount: 3},
+		{RoomID: 1, MemberCount: 1},
+		{RoomID: 4, MemberCount: 1},
+	}
+	if got := hub.ActiveRooms(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := NewHub(nil).ActiveRooms(); got == nil || len(got) != 0 {
+		t.Errorf("Expected an empty, non-nil list, got %#v", got)
+	}
+}
+
+func TestHub_ConnectionLimit(t *testing.T) {
+	hub := NewHub(nil)
+	hub.MaxConnections = 2
+
+	if !hub.AcquireConnection() || !hub.AcquireConnection() {
+		t.Fatal("Expected the first two connections to be accepted")
+	}
+	if hub.AcquireConnection() {
+		t.Error("Expected a connection over the limit to be rejected")
+	}
+
+	hub.ReleaseConnection()
+	if !hub.AcquireConnection() {
+		t.Error("Expected a released slot to be reusable")
+	}
+	if got := hub.ConnectionCount(); got != 2 {
+		t.Errorf("Expected 2 connections, got %d", got)
+	}
+}
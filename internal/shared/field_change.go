@@ -0,0 +1,70 @@
+package shared
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrFieldChangeField    = errors.New("field must be a non-empty string")
+	ErrFieldChangeNewValue = errors.New("new_value is required")
+)
+
+// FieldChange describes one field of a manga update, sent from the API server
+// to the UDP server's /notify/manga-update trigger and on to subscribers
+type FieldChange struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"old_value,omitempty"`
+	NewValue interface{} `json:"new_value"`
+}
+
+// NewFieldChange builds a FieldChange for field going from oldValue to newValue
+func NewFieldChange(field string, oldValue, newValue interface{}) FieldChange {
+	return FieldChange{Field: field, OldValue: oldValue, NewValue: newValue}
+}
+
+// DecodeFieldChanges parses the detailed_changes of a trigger payload. Each
+// entry must be an object with a string field and a new_value; the first
+// malformed entry is reported by index.
+func DecodeFieldChanges(raw []json.RawMessage) ([]FieldChange, error) {
+	changes := make([]FieldChange, 0, len(raw))
+	for i, entry := range raw {
+		fc, err := decodeFieldChange(entry)
+		if err != nil {
+			return nil, fmt.Errorf("detailed_changes[%d]: %w", i, err)
+		}
+		changes = append(changes, fc)
+	}
+	return changes, nil
+}
+
+func decodeFieldChange(entry json.RawMessage) (FieldChange, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(entry, &obj); err != nil || obj == nil {
+		return FieldChange{}, errors.New("must be an object")
+	}
+
+	var field string
+	if rawField, ok := obj["field"]; !ok || json.Unmarshal(rawField, &field) != nil || field == "" {
+		return FieldChange{}, ErrFieldChangeField
+	}
+
+	rawNew, ok := obj["new_value"]
+	if !ok {
+		return FieldChange{}, ErrFieldChangeNewValue
+	}
+	var newValue interface{}
+	if err := json.Unmarshal(rawNew, &newValue); err != nil {
+		return FieldChange{}, fmt.Errorf("new_value: %w", err)
+	}
+
+	var oldValue interface{}
+	if rawOld, ok := obj["old_value"]; ok {
+		if err := json.Unmarshal(rawOld, &oldValue); err != nil {
+			return FieldChange{}, fmt.Errorf("old_value: %w", err)
+		}
+	}
+
+	return NewFieldChange(field, oldValue, newValue), nil
+}
@@ -0,0 +1,61 @@
+package shared
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func rawChanges(t *testing.T, body string) []json.RawMessage {
+	t.Helper()
+	var raw []json.RawMessage
+	if err := json.Unmarshal([]byte(body), &raw); err != nil {
+		t.Fatalf("Bad test payload: %v", err)
+	}
+	return raw
+}
+
+func TestDecodeFieldChanges_Valid(t *testing.T) {
+	changes, err := DecodeFieldChanges(rawChanges(t, `[
+		{"field": "status", "old_value": "ongoing", "new_value": "completed"},
+		{"field": "total_chapters", "new_value": 120}
+	]`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d", len(changes))
+	}
+	if changes[0] != NewFieldChange("status", "ongoing", "completed") {
+		t.Errorf("Unexpected first change: %+v", changes[0])
+	}
+	if changes[1].OldValue != nil || changes[1].NewValue != float64(120) {
+		t.Errorf("Unexpected second change: %+v", changes[1])
+	}
+}
+
+func TestDecodeFieldChanges_Malformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+	}{
+		{"field not a string", `[{"field": 42, "new_value": "x"}]`, ErrFieldChangeField},
+		{"missing field", `[{"new_value": "x"}]`, ErrFieldChangeField},
+		{"empty field", `[{"field": "", "new_value": "x"}]`, ErrFieldChangeField},
+		{"missing new_value", `[{"field": "title"}]`, ErrFieldChangeNewValue},
+		{"not an object", `["title"]`, nil},
+		{"null entry", `[null]`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeFieldChanges(rawChanges(t, tt.body))
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
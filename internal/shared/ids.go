@@ -0,0 +1,58 @@
+package shared
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+)
+
+// Manga IDs are int64 everywhere: the manga.id BIGSERIAL column, the GORM
+// models, the proto messages and the ingestion structs. These helpers convert
+// the looser representations that reach the services without losing precision.
+
+// ErrInvalidMangaID is returned for IDs that aren't positive whole numbers
+// that fit in an int64
+var ErrInvalidMangaID = errors.New("manga ID must be a positive integer")
+
+// maxExactFloat is the largest integer a float64 holds exactly (2^53). JSON
+// numbers decoded into interface{} are float64, so bigger IDs arrive rounded.
+const maxExactFloat = 1 << 53
+
+// ParseMangaID parses a decimal manga ID
+func ParseMangaID(s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidMangaID
+	}
+	return id, nil
+}
+
+// MangaIDFromJSON converts a manga ID decoded into interface{}: a float64, a
+// json.Number (json.Decoder.UseNumber) or a decimal string. Fractional values
+// and floats too large to be exact are rejected rather than truncated.
+func MangaIDFromJSON(v any) (int64, error) {
+	switch id := v.(type) {
+	case float64:
+		if id <= 0 || id > maxExactFloat || id != math.Trunc(id) {
+			return 0, ErrInvalidMangaID
+		}
+		return int64(id), nil
+	case json.Number:
+		return ParseMangaID(id.String())
+	case string:
+		return ParseMangaID(id)
+	case int64:
+		if id <= 0 {
+			return 0, ErrInvalidMangaID
+		}
+		return id, nil
+	case int:
+		if id <= 0 {
+			return 0, ErrInvalidMangaID
+		}
+		return int64(id), nil
+	default:
+		return 0, ErrInvalidMangaID
+	}
+}
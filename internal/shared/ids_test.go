@@ -0,0 +1,71 @@
+package shared
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseMangaID(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+		ok   bool
+	}{
+		{"42", 42, true},
+		{"9223372036854775807", math.MaxInt64, true},
+		{"9223372036854775808", 0, false}, // overflows int64
+		{"0", 0, false},
+		{"-1", 0, false},
+		{"1.5", 0, false},
+		{"abc", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseMangaID(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseMangaID(%q) = %d, %v; want %d, ok=%v", tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestMangaIDFromJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   any
+		want int64
+		ok   bool
+	}{
+		{"float", float64(7), 7, true},
+		{"largest exact float", float64(1 << 53), 1 << 53, true},
+		{"float beyond 2^53", float64(1<<53) * 2, 0, false},
+		{"fractional float", 1.5, 0, false},
+		{"negative float", float64(-3), 0, false},
+		{"json.Number max int64", json.Number("9223372036854775807"), math.MaxInt64, true},
+		{"string", "3000000000", 3000000000, true},
+		{"int64", int64(math.MaxInt64), math.MaxInt64, true},
+		{"int", 5, 5, true},
+		{"nil", nil, 0, false},
+		{"bool", true, 0, false},
+	}
+	for _, tt := range tests {
+		got, err := MangaIDFromJSON(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("%s: MangaIDFromJSON(%v) = %d, %v; want %d, ok=%v", tt.name, tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestMangaIDFromJSON_LargeIDsSurviveUseNumber(t *testing.T) {
+	var payload map[string]any
+	dec := json.NewDecoder(strings.NewReader(`{"manga_id": 9007199254740993}`))
+	dec.UseNumber()
+	if err := dec.Decode(&payload); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	got, err := MangaIDFromJSON(payload["manga_id"])
+	if err != nil || got != 9007199254740993 {
+		t.Errorf("got %d, %v; want 9007199254740993", got, err)
+	}
+}
@@ -0,0 +1,89 @@
+package slug
+
+import "strings"
+
+// Hepburn romanization of hiragana. Katakana is mapped onto hiragana first.
+var hiragana = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ゔ': "vu",
+}
+
+// Small kana that combine with the syllable before them (きゃ -> kya, ファ -> fa)
+var smallKana = map[rune]string{
+	'ゃ': "a", 'ゅ': "u", 'ょ': "o",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o", 'ゎ': "a",
+}
+
+const (
+	smallTsu       = 'っ'
+	katakanaToHira = 'ア' - 'あ'
+	katakanaFirst  = 'ァ'
+	katakanaLast   = 'ヴ'
+	hiraganaFirst  = 'ぁ'
+	hiraganaLast   = 'ゔ'
+)
+
+func isKana(r rune) bool {
+	return (r >= hiraganaFirst && r <= hiraganaLast) || (r >= katakanaFirst && r <= katakanaLast)
+}
+
+// kana writes the romanization of a hiragana or katakana rune
+func (b *builder) kana(r rune) {
+	if r >= katakanaFirst {
+		r -= katakanaToHira
+	}
+
+	switch {
+	case r == smallTsu:
+		b.geminate = true
+	case r == 'ゃ' || r == 'ゅ' || r == 'ょ':
+		b.smallY(smallKana[r])
+	case smallKana[r] != "":
+		b.smallVowel(smallKana[r])
+	default:
+		b.write(hiragana[r])
+	}
+}
+
+// smallY combines ゃゅょ with an i-syllable: き+ゃ -> kya, し+ゃ -> sha
+func (b *builder) smallY(vowel string) {
+	stem, ok := strings.CutSuffix(b.last, "i")
+	if !ok || stem == "" {
+		b.write("y" + vowel)
+		return
+	}
+	if strings.HasSuffix(stem, "h") || stem == "j" {
+		b.replaceLast(stem + vowel)
+	} else {
+		b.replaceLast(stem + "y" + vowel)
+	}
+}
+
+// smallVowel swaps the vowel of the previous syllable, as katakana does for
+// foreign sounds: フ+ァ -> fa, テ+ィ -> ti, ウ+ィ -> wi
+func (b *builder) smallVowel(vowel string) {
+	switch {
+	case b.last == "":
+		b.write(vowel)
+	case b.last == "u":
+		b.replaceLast("w" + vowel)
+	case strings.ContainsRune("aeiou", rune(b.last[len(b.last)-1])) && len(b.last) > 1:
+		b.replaceLast(b.last[:len(b.last)-1] + vowel)
+	default:
+		b.write(vowel)
+	}
+}
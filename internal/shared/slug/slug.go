@@ -0,0 +1,144 @@
+// Package slug builds URL slugs from manga titles. Titles in other scripts
+// are transliterated to ASCII first, so "ワンピース" becomes "wanpiisu"
+// instead of an empty slug.
+package slug
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Make turns title into a slug of lowercase ASCII letters, digits and single
+// hyphens. Latin diacritics are stripped and kana, Hangul, Cyrillic and Greek
+// are romanized. Scripts without a romanization here (Han ideographs, Arabic,
+// Thai, ...) are dropped; if that loses most of the title's letters, Make
+// returns "" rather than a misleading fragment such as "no" for "進撃の巨人".
+func Make(title string) string {
+	var (
+		b          builder
+		kept, lost int
+	)
+
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsSpace(r) || r == '-' || r == '_' || r == '/' || r == '・':
+			b.separate()
+		case isKana(r):
+			b.kana(r)
+			kept++
+		case r == 'ー' || r == 'ｰ':
+			b.prolong()
+		case r >= hangulFirst && r <= hangulLast:
+			b.write(romanizeHangul(r))
+			kept++
+		default:
+			if s, ok := transliterate(r); ok {
+				b.write(s)
+				kept++
+			} else if unicode.IsLetter(r) {
+				// Keep words on either side of a dropped ideograph apart
+				b.separate()
+				lost++
+			}
+		}
+	}
+
+	if lost > kept {
+		return ""
+	}
+	return b.String()
+}
+
+// WithFallback is Make, falling back to a slug of fallback (typically the
+// source and external ID, e.g. "anilist-30013") when the title yields
+// nothing. The result is never empty.
+func WithFallback(title, fallback string) string {
+	if s := Make(title); s != "" {
+		return s
+	}
+	if s := Make(fallback); s != "" {
+		return s
+	}
+	return "manga"
+}
+
+// transliterate maps a rune outside kana and Hangul to ASCII. Runes with no
+// mapping are decomposed (é -> e + accent) and the base letter is tried.
+func transliterate(r rune) (string, bool) {
+	if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+		return string(r), true
+	}
+	if s, ok := letters[r]; ok {
+		return s, true
+	}
+
+	var out strings.Builder
+	for _, d := range norm.NFKD.String(string(r)) {
+		if d == r || unicode.Is(unicode.Mn, d) {
+			continue
+		}
+		if s, ok := transliterate(unicode.ToLower(d)); ok {
+			out.WriteString(s)
+		}
+	}
+	return out.String(), out.Len() > 0
+}
+
+// builder accumulates slug parts, collapsing separators
+type builder struct {
+	sb       strings.Builder
+	pending  bool   // A separator was seen since the last letter
+	geminate bool   // A small tsu doubles the next consonant
+	last     string // Last syllable written, for small kana and long vowels
+}
+
+func (b *builder) separate() {
+	b.pending = b.sb.Len() > 0
+	b.geminate = false
+	b.last = ""
+}
+
+func (b *builder) write(s string) {
+	if s == "" {
+		return
+	}
+	if b.pending {
+		b.sb.WriteByte('-')
+		b.pending = false
+	}
+	if b.geminate {
+		b.geminate = false
+		if strings.HasPrefix(s, "ch") {
+			b.sb.WriteByte('t')
+		} else if c := s[0]; !strings.ContainsRune("aeiou", rune(c)) {
+			b.sb.WriteByte(c)
+		}
+	}
+	b.sb.WriteString(s)
+	b.last = s
+}
+
+// replaceLast swaps the last syllable written for s
+func (b *builder) replaceLast(s string) {
+	out := strings.TrimSuffix(b.sb.String(), b.last)
+	b.sb.Reset()
+	b.sb.WriteString(out)
+	b.sb.WriteString(s)
+	b.last = s
+}
+
+// prolong handles the long vowel mark by repeating the previous vowel
+func (b *builder) prolong() {
+	if b.last == "" {
+		return
+	}
+	if v := b.last[len(b.last)-1]; strings.ContainsRune("aeiou", rune(v)) {
+		b.sb.WriteByte(v)
+	}
+}
+
+func (b *builder) String() string {
+	return b.sb.String()
+}
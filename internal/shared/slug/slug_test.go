@@ -0,0 +1,44 @@
+package slug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"One Piece", "one-piece"},
+		{"Kaguya-sama: Love Is War", "kaguya-sama-love-is-war"},
+		{"  Re:Zero  ", "rezero"},
+		{"Pokémon Adventures", "pokemon-adventures"},
+		{"Ｄｒ．ＳＴＯＮＥ", "drstone"},
+		{"ワンピース", "wanpiisu"},
+		{"チェンソーマン", "chensooman"},
+		{"ちはやふる", "chihayafuru"},
+		{"しょうじょ", "shoujo"},
+		{"ハイキュー!!", "haikyuu"},
+		{"ジョジョの奇妙な冒険", "jojono-na"},
+		{"ドラゴンボール・スーパー", "doragonbooru-suupaa"},
+		{"ヴァイオレット", "vaioretto"},
+		{"나 혼자만 레벨업", "na-honjaman-rebeleop"},
+		{"Мастер и Маргарита", "master-i-margarita"},
+		{"Ὀδύσσεια", "odysseia"},
+		{"進撃の巨人", ""},
+		{"!!!", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Make(tt.title), tt.title)
+	}
+}
+
+func TestWithFallback(t *testing.T) {
+	assert.Equal(t, "one-piece", WithFallback("One Piece", "anilist-30013"))
+	assert.Equal(t, "anilist-30013", WithFallback("進撃の巨人", "anilist-30013"))
+	assert.Equal(t, "manga", WithFallback("進撃の巨人", ""))
+}
@@ -0,0 +1,40 @@
+package shared
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Requests to the UDP server's notification trigger are signed with
+// HMAC-SHA256 over "<unix timestamp>\n<nonce>\n<path>\n<body>". The
+// timestamp bounds how long a captured request stays usable, the nonce
+// (fresh for every attempt) lets the server refuse the same request twice,
+// and the path stops a body from being replayed against another endpoint.
+
+// NewTriggerNonce returns a random nonce for one signed trigger request
+func NewTriggerNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// SignTriggerRequest returns the hex signature of a trigger request
+func SignTriggerRequest(key string, timestamp int64, nonce, path string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("\n" + nonce + "\n" + path + "\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyTriggerSignature reports whether signature matches the request, in
+// constant time
+func VerifyTriggerSignature(key string, timestamp int64, nonce, path string, body []byte, signature string) bool {
+	want := SignTriggerRequest(key, timestamp, nonce, path, body)
+	return hmac.Equal([]byte(want), []byte(signature))
+}
@@ -0,0 +1,43 @@
+package shared
+
+import "testing"
+
+func TestVerifyTriggerSignature(t *testing.T) {
+	body := []byte(`{"manga_id":1}`)
+	sig := SignTriggerRequest("key", 1700000000, "n1", "/notify/new-manga", body)
+
+	if !VerifyTriggerSignature("key", 1700000000, "n1", "/notify/new-manga", body, sig) {
+		t.Fatal("valid signature rejected")
+	}
+
+	tests := []struct {
+		name      string
+		key       string
+		timestamp int64
+		nonce     string
+		path      string
+		body      string
+	}{
+		{"wrong key", "other", 1700000000, "n1", "/notify/new-manga", `{"manga_id":1}`},
+		{"other timestamp", "key", 1700000001, "n1", "/notify/new-manga", `{"manga_id":1}`},
+		{"other nonce", "key", 1700000000, "n2", "/notify/new-manga", `{"manga_id":1}`},
+		{"other endpoint", "key", 1700000000, "n1", "/notify/new-chapter", `{"manga_id":1}`},
+		{"tampered body", "key", 1700000000, "n1", "/notify/new-manga", `{"manga_id":2}`},
+	}
+	for _, tt := range tests {
+		if VerifyTriggerSignature(tt.key, tt.timestamp, tt.nonce, tt.path, []byte(tt.body), sig) {
+			t.Errorf("%s: signature accepted", tt.name)
+		}
+	}
+}
+
+func TestNewTriggerNonce(t *testing.T) {
+	a, err := NewTriggerNonce()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := NewTriggerNonce()
+	if len(a) != 32 || a == b {
+		t.Errorf("nonces %q and %q: want 32 hex chars, distinct", a, b)
+	}
+}
@@ -1,5 +1,9 @@
 // Package benchmark provides comprehensive benchmark tests for MangaHub
 // Run with: go test -v -timeout 10m ./test/benchmark/...
+//
+// By default every test boots the servers in-process (see test/harness).
+// Set MANGAHUB_API_URL, MANGAHUB_TCP_ADDR and MANGAHUB_WS_URL to benchmark
+// a running deployment instead.
 package benchmark
 
 import (
@@ -11,11 +15,14 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"mangahub/test/harness"
+
 	"github.com/gorilla/websocket"
 )
 
@@ -24,11 +31,6 @@ import (
 // ============================================================================
 
 const (
-	// Service endpoints
-	APIBaseURL = "http://10.238.20.112:8084"
-	TCPAddr    = "10.238.20.112:8081"
-	WSEndpoint = "ws://10.238.20.112:8084/ws"
-
 	// Test credentials (create a test user first)
 	TestUsername = "benchmarkuser"
 	TestPassword = "benchmark123"
@@ -47,12 +49,22 @@ type BenchmarkConfig struct {
 	TestDuration    time.Duration
 }
 
-// DefaultConfig returns default benchmark configuration matching your targets
-func DefaultConfig() BenchmarkConfig {
+// DefaultConfig returns default benchmark configuration matching your targets.
+// Endpoints come from MANGAHUB_API_URL / MANGAHUB_TCP_ADDR / MANGAHUB_WS_URL;
+// when those aren't set the servers are started in-process for this test.
+func DefaultConfig(t *testing.T) BenchmarkConfig {
+	apiURL := os.Getenv("MANGAHUB_API_URL")
+	tcpAddr := os.Getenv("MANGAHUB_TCP_ADDR")
+	wsURL := os.Getenv("MANGAHUB_WS_URL")
+	if apiURL == "" || tcpAddr == "" || wsURL == "" {
+		env := harness.Start(t)
+		apiURL, tcpAddr, wsURL = env.APIBaseURL, env.TCPAddr, env.WSEndpoint
+	}
+
 	return BenchmarkConfig{
-		APIBaseURL:      APIBaseURL,
-		TCPAddr:         TCPAddr,
-		WSEndpoint:      WSEndpoint,
+		APIBaseURL:      apiURL,
+		TCPAddr:         tcpAddr,
+		WSEndpoint:      wsURL,
 		ConcurrentUsers: 100,                    // Target: 50-100 concurrent users
 		TCPConnections:  30,                     // Target: 20-30 TCP connections
 		WSConnections:   20,                     // Target: 10-20 WebSocket users
@@ -147,7 +159,7 @@ func (c *TestClient) AuthenticatedGet(path string) (*http.Response, error) {
 // ============================================================================
 
 func TestConcurrentUsers(t *testing.T) {
-	cfg := DefaultConfig()
+	cfg := DefaultConfig(t)
 
 	t.Logf("🎯 Target: Support %d concurrent users", cfg.ConcurrentUsers)
 	t.Log("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -240,7 +252,7 @@ func TestConcurrentUsers(t *testing.T) {
 // ============================================================================
 
 func TestSearchLatency(t *testing.T) {
-	cfg := DefaultConfig()
+	cfg := DefaultConfig(t)
 
 	t.Logf("🎯 Target: Search queries < %v", cfg.SearchTimeout)
 	t.Log("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -331,7 +343,7 @@ func TestSearchLatency(t *testing.T) {
 // ============================================================================
 
 func TestTCPConnections(t *testing.T) {
-	cfg := DefaultConfig()
+	cfg := DefaultConfig(t)
 
 	t.Logf("🎯 Target: Support %d concurrent TCP connections", cfg.TCPConnections)
 	t.Log("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -461,7 +473,7 @@ func TestTCPConnections(t *testing.T) {
 // ============================================================================
 
 func TestWebSocketConnections(t *testing.T) {
-	cfg := DefaultConfig()
+	cfg := DefaultConfig(t)
 
 	t.Logf("🎯 Target: Support %d concurrent WebSocket connections", cfg.WSConnections)
 	t.Log("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -592,7 +604,7 @@ func TestDatabaseCapacity(t *testing.T) {
 	t.Log("🎯 Target: Handle 30-40 manga series in database")
 	t.Log("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	cfg := DefaultConfig()
+	cfg := DefaultConfig(t)
 
 	// Setup
 	client := NewTestClient(cfg.APIBaseURL)
@@ -640,25 +652,35 @@ func TestErrorHandling(t *testing.T) {
 	t.Log("🎯 Target: Basic error handling and recovery")
 	t.Log("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	cfg := DefaultConfig()
+	cfg := DefaultConfig(t)
 	client := NewTestClient(cfg.APIBaseURL)
+	if err := client.Register(TestUsername, TestEmail, TestPassword); err != nil {
+		t.Logf("Registration note: %v", err)
+	}
+	if err := client.Login(TestUsername, TestPassword); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
 
 	tests := []struct {
 		name           string
 		method         string
 		path           string
+		authenticated  bool
 		expectedStatus int
 	}{
-		{"Invalid endpoint", "GET", "/api/nonexistent", http.StatusNotFound},
-		{"Unauthorized access", "GET", "/api/manga", http.StatusUnauthorized},
-		{"Invalid manga ID", "GET", "/api/manga/99999999", http.StatusNotFound},
-		{"Health check", "GET", "/check-conn", http.StatusOK},
+		{"Invalid endpoint", "GET", "/api/nonexistent", false, http.StatusNotFound},
+		{"Unauthorized access", "GET", "/api/manga", false, http.StatusUnauthorized},
+		{"Invalid manga ID", "GET", "/api/manga/99999999", true, http.StatusNotFound},
+		{"Health check", "GET", "/check-conn", false, http.StatusOK},
 	}
 
 	var passed, failed int
 
 	for _, tc := range tests {
 		req, _ := http.NewRequest(tc.method, cfg.APIBaseURL+tc.path, nil)
+		if tc.authenticated {
+			req.Header.Set("Authorization", "Bearer "+client.token)
+		}
 		resp, err := client.httpClient.Do(req)
 
 		if err != nil {
@@ -702,7 +724,7 @@ func TestFullBenchmarkSuite(t *testing.T) {
 	t.Log("╚══════════════════════════════════════════════════════════════════╝")
 	t.Log("")
 
-	cfg := DefaultConfig()
+	cfg := DefaultConfig(t)
 
 	t.Log("📋 Configuration:")
 	t.Logf("   ├── API URL:           %s", cfg.APIBaseURL)
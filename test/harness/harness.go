@@ -0,0 +1,127 @@
+// Package harness boots the MangaHub HTTP, WebSocket and TCP servers
+// in-process on ephemeral ports, backed by in-memory storage, so the
+// integration and benchmark suites can run without Postgres, Redis or a lab
+// machine.
+package harness
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mangahub/internal/config"
+	h "mangahub/internal/microservices/http-api/handler"
+	mid "mangahub/internal/microservices/http-api/middleware"
+	svc "mangahub/internal/microservices/http-api/service"
+	"mangahub/internal/microservices/tcp"
+	ws "mangahub/internal/microservices/websocket"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SeedMangaCount is how many titles the in-memory catalog starts with,
+// named "Test Manga 1" through "Test Manga 200"
+const SeedMangaCount = 200
+
+// JWTSecret signs the harness's tokens; the HTTP and TCP servers share it
+const JWTSecret = "harness-secret-not-for-production"
+
+// Env holds the addresses of the running servers
+type Env struct {
+	APIBaseURL string // e.g. http://127.0.0.1:41234
+	WSEndpoint string // e.g. ws://127.0.0.1:41234/ws
+	TCPAddr    string // e.g. 127.0.0.1:41235
+}
+
+// Start boots every server and stops them when the test finishes. Users
+// register through /auth/register as usual; the catalog is seeded with
+// SeedMangaCount titles.
+func Start(tb testing.TB) *Env {
+	tb.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		JWTSecret:         JWTSecret,
+		AccessTokenTTL:    15 * time.Minute,
+		RefreshTokenTTL:   24 * time.Hour,
+		PasswordMinLength: svc.DefaultPasswordMinLength,
+	}
+	authSvc := svc.NewAuthService(newMemoryUsers(), newMemoryRefreshTokens(), cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tb.Cleanup(cancel)
+
+	hub := ws.NewHub(&memoryChat{})
+	go hub.RunContext(ctx)
+
+	api := httptest.NewServer(newRouter(authSvc, newMemoryManga(SeedMangaCount), hub))
+	tb.Cleanup(api.Close)
+
+	tcpAddr := startTCP(tb)
+
+	return &Env{
+		APIBaseURL: api.URL,
+		WSEndpoint: "ws" + strings.TrimPrefix(api.URL, "http") + "/ws",
+		TCPAddr:    tcpAddr,
+	}
+}
+
+// newRouter mirrors the api-server routes the suites exercise
+func newRouter(authSvc svc.AuthService, mangaSvc svc.MangaService, hub *ws.Hub) *gin.Engine {
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.HandleMethodNotAllowed = true
+	r.NoMethod(mid.MethodNotAllowed())
+	r.NoRoute(mid.NotFound())
+
+	authHandler := h.NewAuthHandler(authSvc)
+	auth := r.Group("/auth")
+	{
+		auth.POST("/register", authHandler.Register)
+		auth.POST("/login", authHandler.Login)
+		auth.POST("/refresh", authHandler.RefreshToken)
+		auth.POST("/revoke", authHandler.RevokeToken)
+	}
+
+	api := r.Group("/api", mid.AuthMiddleware(authSvc))
+	h.NewMangaHandler(mangaSvc).RegisterRoutes(api.Group("/manga"))
+
+	r.GET("/check-conn", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true, "message": "api server running"})
+	})
+	r.GET("/ws", mid.WSAuthMiddleware(authSvc), ws.WSHandler(hub))
+	return r
+}
+
+// startTCP runs a TCP server with in-memory progress storage that accepts
+// the same tokens as the HTTP API
+func startTCP(tb testing.TB) string {
+	tb.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		tb.Fatalf("harness: listen for TCP server: %v", err)
+	}
+
+	server := tcp.NewServerWithMockRedis(listener.Addr().String())
+	server.AuthService = tcp.NewTCPAuthService(JWTSecret)
+	server.MetricsInterval = -1
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := server.Serve(listener); err != nil {
+			tb.Logf("harness: TCP server stopped: %v", err)
+		}
+	}()
+	tb.Cleanup(func() {
+		server.Stop()
+		<-done
+	})
+
+	return listener.Addr().String()
+}
@@ -0,0 +1,70 @@
+package harness
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postJSON(t *testing.T, url string, body any) *http.Response {
+	t.Helper()
+	data, _ := json.Marshal(body)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+	require.NoError(t, err)
+	return resp
+}
+
+func TestStart_ServesEveryProtocol(t *testing.T) {
+	env := Start(t)
+
+	// Register and log in over HTTP
+	resp := postJSON(t, env.APIBaseURL+"/auth/register", map[string]string{
+		"username": "harnessuser", "email": "harness@test.com", "password": "harness123",
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = postJSON(t, env.APIBaseURL+"/auth/login", map[string]string{
+		"username": "harnessuser", "password": "harness123",
+	})
+	var login struct {
+		AccessToken string `json:"access_token"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&login))
+	resp.Body.Close()
+	require.NotEmpty(t, login.AccessToken)
+
+	// Seeded catalog is readable
+	req, _ := http.NewRequest(http.MethodGet, env.APIBaseURL+"/api/manga/1", nil)
+	req.Header.Set("Authorization", "Bearer "+login.AccessToken)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// TCP accepts the same token
+	conn, err := net.DialTimeout("tcp", env.TCPAddr, 2*time.Second)
+	require.NoError(t, err)
+	defer conn.Close()
+	auth, _ := json.Marshal(map[string]any{"type": "auth", "data": map[string]string{"token": login.AccessToken}})
+	_, err = conn.Write(append(auth, '\n'))
+	require.NoError(t, err)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	require.NoError(t, err)
+	assert.Contains(t, string(line), "auth_success")
+
+	// WebSocket upgrades with the token
+	header := http.Header{"Authorization": {"Bearer " + login.AccessToken}}
+	wsConn, _, err := websocket.DefaultDialer.Dial(env.WSEndpoint, header)
+	require.NoError(t, err)
+	wsConn.Close()
+}
@@ -0,0 +1,404 @@
+package harness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"mangahub/internal/microservices/http-api/dto"
+	"mangahub/internal/microservices/http-api/models"
+	"mangahub/internal/microservices/http-api/service"
+
+	"gorm.io/gorm"
+)
+
+// errUnsupported is returned by in-memory operations the suites don't need
+var errUnsupported = errors.New("not supported by the test harness")
+
+// memoryUsers is an in-memory repository.UserRepository
+type memoryUsers struct {
+	mu    sync.RWMutex
+	users map[string]*models.User // by ID
+}
+
+func newMemoryUsers() *memoryUsers {
+	return &memoryUsers{users: make(map[string]*models.User)}
+}
+
+func (r *memoryUsers) Create(user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if u.Username == user.Username || u.Email == user.Email {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	if user.Role == "" {
+		user.Role = "user"
+	}
+	user.ProfilePublic = true // column default; GORM never inserts the false zero value
+	copied := *user
+	r.users[user.ID] = &copied
+	return nil
+}
+
+func (r *memoryUsers) find(match func(*models.User) bool) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, u := range r.users {
+		if match(u) {
+			copied := *u
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryUsers) FindByUsername(username string) (*models.User, error) {
+	return r.find(func(u *models.User) bool { return u.Username == username })
+}
+
+func (r *memoryUsers) FindByID(id string) (*models.User, error) {
+	return r.find(func(u *models.User) bool { return u.ID == id })
+}
+
+func (r *memoryUsers) FindByEmail(email string) (*models.User, error) {
+	return r.find(func(u *models.User) bool { return u.Email == email })
+}
+
+func (r *memoryUsers) GetAllIDs(ctx context.Context) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.users))
+	for id := range r.users {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (r *memoryUsers) List(ctx context.Context, page, pageSize int) ([]models.User, int64, error) {
+	return nil, 0, errUnsupported
+}
+
+func (r *memoryUsers) UpdateLastLogin(ctx context.Context, id string, at time.Time, ip string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[id]; ok {
+		u.LastLogin = &at
+		u.LastLoginIP = ip
+	}
+	return nil
+}
+
+func (r *memoryUsers) UpdatePrivacy(ctx context.Context, id string, profilePublic, libraryPublic *bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.users[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	if profilePublic != nil {
+		u.ProfilePublic = *profilePublic
+	}
+	if libraryPublic != nil {
+		u.LibraryPublic = *libraryPublic
+	}
+	return nil
+}
+
+// memoryRefreshTokens is an in-memory repository.RefreshTokenRepository
+type memoryRefreshTokens struct {
+	mu     sync.RWMutex
+	tokens map[string]*models.RefreshToken // by ID
+}
+
+func newMemoryRefreshTokens() *memoryRefreshTokens {
+	return &memoryRefreshTokens{tokens: make(map[string]*models.RefreshToken)}
+}
+
+func (r *memoryRefreshTokens) Create(token *models.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *token
+	copied.CreatedAt = time.Now()
+	r.tokens[token.ID] = &copied
+	return nil
+}
+
+func (r *memoryRefreshTokens) FindByToken(tokenString string) (*models.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, t := range r.tokens {
+		if t.Token == tokenString {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRefreshTokens) FindByID(tokenID string) (*models.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if t, ok := r.tokens[tokenID]; ok {
+		copied := *t
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRefreshTokens) ListActiveByUser(userID string) ([]models.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var active []models.RefreshToken
+	now := time.Now()
+	for _, t := range r.tokens {
+		if t.UserID == userID && !t.Revoked && t.ExpiresAt.After(now) {
+			active = append(active, *t)
+		}
+	}
+	return active, nil
+}
+
+func (r *memoryRefreshTokens) Revoke(tokenID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.tokens[tokenID]; ok {
+		t.Revoked = true
+	}
+	return nil
+}
+
+func (r *memoryRefreshTokens) Delete(tokenID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tokens, tokenID)
+	return nil
+}
+
+func (r *memoryRefreshTokens) DeleteExpired() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for id, t := range r.tokens {
+		if t.ExpiresAt.Before(now) {
+			delete(r.tokens, id)
+		}
+	}
+	return nil
+}
+
+// memoryManga is an in-memory service.MangaService over a fixed catalog
+type memoryManga struct {
+	mu    sync.RWMutex
+	manga []models.Manga // sorted by ID
+}
+
+// newMemoryManga seeds n titles named "Test Manga 1".."Test Manga n"
+func newMemoryManga(n int) *memoryManga {
+	statuses := models.MangaStatuses
+	catalog := make([]models.Manga, n)
+	for i := range catalog {
+		id := int64(i + 1)
+		slug := fmt.Sprintf("test-manga-%d", id)
+		author := fmt.Sprintf("Author %d", id%10)
+		status := string(statuses[i%len(statuses)])
+		catalog[i] = models.Manga{ID: id, Slug: &slug, Title: fmt.Sprintf("Test Manga %d", id), Author: &author, Status: &status}
+	}
+	return &memoryManga{manga: catalog}
+}
+
+func (s *memoryManga) GetAll(ctx context.Context, page, pageSize int, sortBy string) ([]models.Manga, int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return paginate(s.manga, page, pageSize), int64(len(s.manga)), nil
+}
+
+func (s *memoryManga) GetByID(ctx context.Context, id int64) (*models.Manga, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, m := range s.manga {
+		if m.ID == id {
+			return &m, nil
+		}
+	}
+	return nil, service.ErrMangaNotFound
+}
+
+func (s *memoryManga) GetByExternalID(ctx context.Context, source, externalID string) (*models.Manga, error) {
+	return nil, service.ErrMangaNotFound
+}
+
+func (s *memoryManga) GetSyncInfo(ctx context.Context, id int64) (*models.MangaSyncInfo, error) {
+	m, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MangaSyncInfo{ID: m.ID, MangaDexID: m.MangaDexID, AniListID: m.AniListID}, nil
+}
+
+func (s *memoryManga) ExportCatalog(ctx context.Context, batchSize int, emit func([]models.Manga) error) error {
+	s.mu.RLock()
+	catalog := append([]models.Manga(nil), s.manga...)
+	s.mu.RUnlock()
+	for start := 0; start < len(catalog); start += batchSize {
+		end := min(start+batchSize, len(catalog))
+		if err := emit(catalog[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memoryManga) GetBySlugs(ctx context.Context, slugs []string) ([]models.Manga, []string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	bySlug := make(map[string]models.Manga, len(s.manga))
+	for _, m := range s.manga {
+		if m.Slug != nil {
+			bySlug[*m.Slug] = m
+		}
+	}
+	var found []models.Manga
+	var notFound []string
+	for _, slug := range slugs {
+		if m, ok := bySlug[slug]; ok {
+			found = append(found, m)
+		} else {
+			notFound = append(notFound, slug)
+		}
+	}
+	return found, notFound, nil
+}
+
+func (s *memoryManga) Create(ctx context.Context, m *models.Manga) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m.ID = int64(len(s.manga) + 1)
+	s.manga = append(s.manga, *m)
+	return nil
+}
+
+func (s *memoryManga) Update(ctx context.Context, id int64, m *models.Manga, clear []string) error {
+	return errUnsupported
+}
+
+func (s *memoryManga) Delete(ctx context.Context, id int64) error {
+	return errUnsupported
+}
+
+func (s *memoryManga) SearchByTitle(ctx context.Context, title string) ([]models.Manga, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	query := strings.ToLower(title)
+	var matches []models.Manga
+	for _, m := range s.manga {
+		if strings.Contains(strings.ToLower(m.Title), query) {
+			matches = append(matches, m)
+		}
+	}
+	return matches, nil
+}
+
+func (s *memoryManga) SearchByTitleFields(ctx context.Context, title string, fields []string) ([]models.Manga, error) {
+	return s.SearchByTitle(ctx, title)
+}
+
+// SearchByTitleForUser reports nothing in the library; the harness has no
+// library store
+func (s *memoryManga) SearchByTitleForUser(ctx context.Context, title, userID string) ([]models.MangaWithUserContext, error) {
+	matches, _ := s.SearchByTitle(ctx, title)
+	list := make([]models.MangaWithUserContext, 0, len(matches))
+	for _, m := range matches {
+		list = append(list, models.MangaWithUserContext{Manga: m})
+	}
+	return list, nil
+}
+
+func (s *memoryManga) AdvancedSearch(ctx context.Context, filters dto.SearchFilters) ([]models.Manga, int64, error) {
+	matches, _ := s.SearchByTitle(ctx, filters.Query)
+	if filters.Status != "" {
+		kept := matches[:0]
+		for _, m := range matches {
+			if m.Status != nil && *m.Status == filters.Status {
+				kept = append(kept, m)
+			}
+		}
+		matches = kept
+	}
+	return paginate(matches, filters.Page, filters.PageSize), int64(len(matches)), nil
+}
+
+func (s *memoryManga) ReplaceGenresForManga(ctx context.Context, mangaID int64, genreIDs []int64) error {
+	return errUnsupported
+}
+
+func paginate(list []models.Manga, page, pageSize int) []models.Manga {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = len(list)
+	}
+	start := (page - 1) * pageSize
+	if start >= len(list) {
+		return []models.Manga{}
+	}
+	end := start + pageSize
+	if end > len(list) {
+		end = len(list)
+	}
+	return append([]models.Manga(nil), list[start:end]...)
+}
+
+// memoryChat is an in-memory websocket.ChatMessageRepository
+type memoryChat struct {
+	mu       sync.Mutex
+	nextID   int64
+	messages []models.ChatMessage
+}
+
+func (r *memoryChat) Create(ctx context.Context, message *models.ChatMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	message.ID = r.nextID
+	r.messages = append(r.messages, *message)
+	return nil
+}
+
+func (r *memoryChat) GetByRoomID(ctx context.Context, roomID int64, limit int) ([]models.ChatMessage, error) {
+	return r.latest(limit, func(m models.ChatMessage) bool { return m.RoomID == roomID }), nil
+}
+
+func (r *memoryChat) GetByUserID(ctx context.Context, userID string, limit int) ([]models.ChatMessage, error) {
+	return r.latest(limit, func(m models.ChatMessage) bool { return m.UserID == userID }), nil
+}
+
+func (r *memoryChat) DeleteByID(ctx context.Context, messageID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, m := range r.messages {
+		if m.ID == messageID {
+			r.messages = append(r.messages[:i], r.messages[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// latest returns up to limit matching messages, newest first
+func (r *memoryChat) latest(limit int, match func(models.ChatMessage) bool) []models.ChatMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []models.ChatMessage
+	for i := len(r.messages) - 1; i >= 0 && len(out) < limit; i-- {
+		if match(r.messages[i]) {
+			out = append(out, r.messages[i])
+		}
+	}
+	return out
+}